@@ -311,3 +311,53 @@ func ParseUint32FromKey(args map[string]interface{}, key string) (uint32, error)
 		return 0, errors.Errorf("Cannot parse uint32 from %v", args[key])
 	}
 }
+
+// ParseUint8FromKey pulls out the uint8 value from a map with provided key,
+// and validates the data type for it
+func ParseUint8FromKey(args map[string]interface{}, key string) (uint8, error) {
+	if val, ok := args[key].(uint8); ok {
+		return val, nil
+	} else {
+		return 0, errors.Errorf("Cannot parse uint8 from %v", args[key])
+	}
+}
+
+// ParseBoolFromKey pulls out the bool value from a map with provided key,
+// and validates the data type for it
+func ParseBoolFromKey(args map[string]interface{}, key string) (bool, error) {
+	if val, ok := args[key].(bool); ok {
+		return val, nil
+	} else {
+		return false, errors.Errorf("Cannot parse bool from %v", args[key])
+	}
+}
+
+// ParseStringFromKey pulls out the string value from a map with provided key,
+// and validates the data type for it
+func ParseStringFromKey(args map[string]interface{}, key string) (string, error) {
+	if val, ok := args[key].(string); ok {
+		return val, nil
+	} else {
+		return "", errors.Errorf("Cannot parse string from %v", args[key])
+	}
+}
+
+// ParseBytesFromKey pulls out the []byte value from a map with provided key,
+// and validates the data type for it
+func ParseBytesFromKey(args map[string]interface{}, key string) ([]byte, error) {
+	if val, ok := args[key].([]byte); ok {
+		return val, nil
+	} else {
+		return nil, errors.Errorf("Cannot parse bytes from %v", args[key])
+	}
+}
+
+// ParseBytesSliceFromKey pulls out the [][]byte value from a map with provided key,
+// and validates the data type for it
+func ParseBytesSliceFromKey(args map[string]interface{}, key string) ([][]byte, error) {
+	if val, ok := args[key].([][]byte); ok {
+		return val, nil
+	} else {
+		return nil, errors.Errorf("Cannot parse bytes slice from %v", args[key])
+	}
+}
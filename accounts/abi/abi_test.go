@@ -1185,3 +1185,63 @@ func TestParseUint32FromKey(t *testing.T) {
 		t.Errorf("Expected error %v, got result", expectedError)
 	}
 }
+
+func TestParseUint8FromKey(t *testing.T) {
+	args := map[string]interface{}{}
+	expectedError := errors.New("Cannot parse uint8 from <nil>")
+	if _, err := ParseUint8FromKey(args, "PotentialUint8"); err != nil {
+		if expectedError.Error() != err.Error() {
+			t.Errorf("Expected error %v, got %v", expectedError, err)
+		}
+	} else {
+		t.Errorf("Expected error %v, got result", expectedError)
+	}
+}
+
+func TestParseBoolFromKey(t *testing.T) {
+	args := map[string]interface{}{}
+	expectedError := errors.New("Cannot parse bool from <nil>")
+	if _, err := ParseBoolFromKey(args, "PotentialBool"); err != nil {
+		if expectedError.Error() != err.Error() {
+			t.Errorf("Expected error %v, got %v", expectedError, err)
+		}
+	} else {
+		t.Errorf("Expected error %v, got result", expectedError)
+	}
+}
+
+func TestParseStringFromKey(t *testing.T) {
+	args := map[string]interface{}{}
+	expectedError := errors.New("Cannot parse string from <nil>")
+	if _, err := ParseStringFromKey(args, "PotentialString"); err != nil {
+		if expectedError.Error() != err.Error() {
+			t.Errorf("Expected error %v, got %v", expectedError, err)
+		}
+	} else {
+		t.Errorf("Expected error %v, got result", expectedError)
+	}
+}
+
+func TestParseBytesFromKey(t *testing.T) {
+	args := map[string]interface{}{}
+	expectedError := errors.New("Cannot parse bytes from <nil>")
+	if _, err := ParseBytesFromKey(args, "PotentialBytes"); err != nil {
+		if expectedError.Error() != err.Error() {
+			t.Errorf("Expected error %v, got %v", expectedError, err)
+		}
+	} else {
+		t.Errorf("Expected error %v, got result", expectedError)
+	}
+}
+
+func TestParseBytesSliceFromKey(t *testing.T) {
+	args := map[string]interface{}{}
+	expectedError := errors.New("Cannot parse bytes slice from <nil>")
+	if _, err := ParseBytesSliceFromKey(args, "PotentialBytesSlice"); err != nil {
+		if expectedError.Error() != err.Error() {
+			t.Errorf("Expected error %v, got %v", expectedError, err)
+		}
+	} else {
+		t.Errorf("Expected error %v, got result", expectedError)
+	}
+}
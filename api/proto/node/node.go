@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"log"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/harmony-one/harmony/api/proto"
 	"github.com/harmony-one/harmony/block"
@@ -35,6 +36,7 @@ type TransactionMessageType int
 const (
 	Send TransactionMessageType = iota
 	Unlock
+	Announce // hashes of the transactions to be fetched from the announcer
 )
 
 // BlockMessageType represents the type of messages used for Node/Block
@@ -56,6 +58,7 @@ var (
 	slashB              = byte(SlashCandidate)
 	txnB                = byte(Transaction)
 	sendB               = byte(Send)
+	announceB           = byte(Announce)
 	stakingB            = byte(Staking)
 	syncB               = byte(Sync)
 	crossLinkB          = byte(CrossLink)
@@ -65,6 +68,8 @@ var (
 	slashH              = []byte{nodeB, blockB, slashB}
 	transactionListH    = []byte{nodeB, txnB, sendB}
 	stakingTxnListH     = []byte{nodeB, stakingB, sendB}
+	transactionAnnH     = []byte{nodeB, txnB, announceB}
+	stakingTxnAnnH      = []byte{nodeB, stakingB, announceB}
 	syncH               = []byte{nodeB, blockB, syncB}
 	crossLinkH          = []byte{nodeB, blockB, crossLinkB}
 	cxReceiptH          = []byte{nodeB, blockB, receiptB}
@@ -97,6 +102,55 @@ func ConstructStakingTransactionListMessageAccount(
 	return byteBuffer.Bytes()
 }
 
+// TxAnnouncement is the announcement of a transaction in the pool of the
+// announcer, its type and size let the receiver check the fetched transaction.
+// The type is the typed transaction envelope type of the plain transactions
+// and the directive of the staking transactions.
+type TxAnnouncement struct {
+	Hash common.Hash
+	Type byte
+	Size uint32
+}
+
+// NewTxAnnouncement returns the announcement of the plain or staking transaction
+func NewTxAnnouncement(tx types.PoolTransaction) TxAnnouncement {
+	ann := TxAnnouncement{
+		Hash: tx.Hash(),
+		Size: uint32(tx.Size()),
+	}
+	switch tx := tx.(type) {
+	case *types.Transaction:
+		ann.Type = tx.Type()
+	case *staking.StakingTransaction:
+		ann.Type = byte(tx.StakingType())
+	}
+	return ann
+}
+
+// ConstructTransactionAnnouncementMessage constructs serialized announcements of transactions
+func ConstructTransactionAnnouncementMessage(anns []TxAnnouncement) []byte {
+	byteBuffer := bytes.NewBuffer(transactionAnnH)
+	data, err := rlp.EncodeToBytes(anns)
+	if err != nil {
+		utils.Logger().Error().Err(err).Msg("[ConstructTransactionAnnouncementMessage] Encode Error")
+		return []byte{}
+	}
+	byteBuffer.Write(data)
+	return byteBuffer.Bytes()
+}
+
+// ConstructStakingTransactionAnnouncementMessage constructs serialized announcements of staking transactions
+func ConstructStakingTransactionAnnouncementMessage(anns []TxAnnouncement) []byte {
+	byteBuffer := bytes.NewBuffer(stakingTxnAnnH)
+	data, err := rlp.EncodeToBytes(anns)
+	if err != nil {
+		utils.Logger().Error().Err(err).Msg("[ConstructStakingTransactionAnnouncementMessage] Encode Error")
+		return []byte{}
+	}
+	byteBuffer.Write(data)
+	return byteBuffer.Bytes()
+}
+
 // ConstructBlocksSyncMessage constructs blocks sync message to send blocks to other nodes
 func ConstructBlocksSyncMessage(blocks []*types.Block) []byte {
 	byteBuffer := bytes.NewBuffer(syncH)
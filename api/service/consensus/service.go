@@ -31,6 +31,7 @@ func (s *Service) Start() error {
 func (s *Service) Stop() error {
 	utils.Logger().Info().Msg("Stopping consensus service.")
 	close(s.stopChan)
+	s.consensus.Close()
 	utils.Logger().Info().Msg("Consensus service stopped.")
 	return nil
 }
@@ -540,6 +540,34 @@ func toFromStakingTx(tx *staking.StakingTransaction, addressBlock *types.Block)
 			return common.Address{}, core2.ErrInvalidSender
 		}
 		return undelegateMsg.ValidatorAddress, nil
+
+	case staking.DirectiveRedelegate:
+		stkMsg, err := staking.RLPDecodeStakeMsg(tx.Data(), staking.DirectiveRedelegate)
+		if err != nil {
+			return common.Address{}, err
+		}
+		if _, ok := stkMsg.(*staking.Redelegate); !ok {
+			return common.Address{}, core2.ErrInvalidMsgForStakingDirective
+		}
+		redelegateMsg := stkMsg.(*staking.Redelegate)
+		if !bytes.Equal(msg.From().Bytes()[:], redelegateMsg.DelegatorAddress.Bytes()[:]) {
+			return common.Address{}, core2.ErrInvalidSender
+		}
+		return redelegateMsg.ToValidatorAddress, nil
+
+	case staking.DirectiveEditDelegation:
+		stkMsg, err := staking.RLPDecodeStakeMsg(tx.Data(), staking.DirectiveEditDelegation)
+		if err != nil {
+			return common.Address{}, err
+		}
+		if _, ok := stkMsg.(*staking.EditDelegation); !ok {
+			return common.Address{}, core2.ErrInvalidMsgForStakingDirective
+		}
+		editDelegationMsg := stkMsg.(*staking.EditDelegation)
+		if !bytes.Equal(msg.From().Bytes()[:], editDelegationMsg.DelegatorAddress.Bytes()[:]) {
+			return common.Address{}, core2.ErrInvalidSender
+		}
+		return editDelegationMsg.ValidatorAddress, nil
 	default:
 		return common.Address{}, nil
 	}
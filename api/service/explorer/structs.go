@@ -230,6 +230,36 @@ func GetStakingTransaction(tx *staking.StakingTransaction, addressBlock *types.B
 		}
 
 		toAddress = &undelegateMsg.ValidatorAddress
+	case staking.DirectiveRedelegate:
+		stkMsg, err := staking.RLPDecodeStakeMsg(tx.Data(), staking.DirectiveRedelegate)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := stkMsg.(*staking.Redelegate); !ok {
+			return nil, core2.ErrInvalidMsgForStakingDirective
+		}
+
+		redelegateMsg := stkMsg.(*staking.Redelegate)
+		if !bytes.Equal(msg.From().Bytes()[:], redelegateMsg.DelegatorAddress.Bytes()[:]) {
+			return nil, core2.ErrInvalidSender
+		}
+
+		toAddress = &redelegateMsg.ToValidatorAddress
+	case staking.DirectiveEditDelegation:
+		stkMsg, err := staking.RLPDecodeStakeMsg(tx.Data(), staking.DirectiveEditDelegation)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := stkMsg.(*staking.EditDelegation); !ok {
+			return nil, core2.ErrInvalidMsgForStakingDirective
+		}
+
+		editDelegationMsg := stkMsg.(*staking.EditDelegation)
+		if !bytes.Equal(msg.From().Bytes()[:], editDelegationMsg.DelegatorAddress.Bytes()[:]) {
+			return nil, core2.ErrInvalidSender
+		}
+
+		toAddress = &editDelegationMsg.ValidatorAddress
 	default:
 		break
 	}
@@ -0,0 +1,339 @@
+package lightnode
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/harmony-one/harmony/block"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/state/snapshot"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/internal/utils"
+	syncproto "github.com/harmony-one/harmony/p2p/stream/protocols/sync"
+	sttypes "github.com/harmony-one/harmony/p2p/stream/types"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// maxRequestAttempts is the number of streams a request is tried with
+	maxRequestAttempts = 3
+)
+
+var (
+	// ErrBlockNotFound is returned when the peers do not have the block
+	ErrBlockNotFound = errors.New("block not found")
+
+	// errInvalidData is returned when the data delivered by a stream does not
+	// match the verified header
+	errInvalidData = errors.New("data not matching the header")
+)
+
+// syncProtocol is the sync protocol the data is requested with
+type syncProtocol interface {
+	GetCurrentBlockNumber(ctx context.Context, opts ...syncproto.Option) (uint64, sttypes.StreamID, error)
+	GetBlocksByNumber(ctx context.Context, bns []uint64, opts ...syncproto.Option) ([]*types.Block, sttypes.StreamID, error)
+	GetReceipts(ctx context.Context, hs []common.Hash, opts ...syncproto.Option) (receipts []types.Receipts, stid sttypes.StreamID, err error)
+	GetAccountProof(ctx context.Context, root, account common.Hash, opts ...syncproto.Option) (body []byte, proof [][]byte, stid sttypes.StreamID, err error)
+	GetStorageProof(ctx context.Context, root, account, storageRoot, slot common.Hash, opts ...syncproto.Option) (value []byte, proof [][]byte, stid sttypes.StreamID, err error)
+
+	RemoveStream(stID sttypes.StreamID) // If a stream delivers invalid data, remove the stream
+	StreamFailed(stID sttypes.StreamID, reason string)
+}
+
+// AccountProof is an account of the state of a block with its merkle proof, and
+// the storage slots of the account with theirs, verified against the state root
+type AccountProof struct {
+	Address       common.Address
+	Nonce         uint64
+	Balance       *big.Int
+	StorageHash   common.Hash
+	CodeHash      common.Hash
+	Proof         [][]byte
+	StorageProofs []StorageProof
+}
+
+// StorageProof is a storage slot with its merkle proof
+type StorageProof struct {
+	Key   common.Hash
+	Value *big.Int
+	Proof [][]byte
+}
+
+// Backend serves the data of the light node. The headers are verified against
+// their commit signatures, the bodies, receipts and states fetched on demand
+// are verified against the headers.
+type Backend struct {
+	hc         *HeaderChain
+	epochChain core.BlockChain
+	protocol   syncProtocol
+	logger     zerolog.Logger
+}
+
+// NewBackend creates the backend of the light node
+func NewBackend(hc *HeaderChain, epochChain core.BlockChain, protocol syncProtocol) *Backend {
+	return &Backend{
+		hc:         hc,
+		epochChain: epochChain,
+		protocol:   protocol,
+		logger: utils.Logger().With().
+			Str("module", "light node").
+			Uint32("ShardID", hc.ShardID()).Logger(),
+	}
+}
+
+// ShardID returns the shard of the light node
+func (b *Backend) ShardID() uint32 {
+	return b.hc.ShardID()
+}
+
+// CurrentHeader returns the highest verified header
+func (b *Backend) CurrentHeader() *block.Header {
+	return b.hc.CurrentHeader()
+}
+
+// HeaderByHash returns the verified header of the hash, nil if it is not known.
+// Only the headers followed or fetched by the node are known by their hash.
+func (b *Backend) HeaderByHash(hash common.Hash) *block.Header {
+	return b.hc.GetHeaderByHash(hash)
+}
+
+// HeaderByNumber returns the verified header of the block number, fetching and
+// verifying it if it is not known yet
+func (b *Backend) HeaderByNumber(ctx context.Context, number uint64) (*block.Header, error) {
+	if header := b.hc.GetHeaderByNumber(number); header != nil {
+		return header, nil
+	}
+	blk, err := b.fetchBlock(ctx, number, nil)
+	if err != nil {
+		return nil, err
+	}
+	return blk.Header(), nil
+}
+
+// BlockByHeader returns the block of the verified header, with its transactions
+// verified against the header
+func (b *Backend) BlockByHeader(ctx context.Context, header *block.Header) (*types.Block, error) {
+	return b.fetchBlock(ctx, header.Number().Uint64(), header)
+}
+
+// GetReceipts returns the receipts of the block, verified against the receipt
+// root of its header
+func (b *Backend) GetReceipts(ctx context.Context, blk *types.Block) (types.Receipts, error) {
+	if blk.Header().ReceiptHash() == types.EmptyRootHash {
+		return types.Receipts{}, nil
+	}
+	var receipts types.Receipts
+	err := b.request(ctx, "getReceipts", func() (sttypes.StreamID, error) {
+		rs, stid, err := b.protocol.GetReceipts(ctx, []common.Hash{blk.Hash()})
+		if err != nil {
+			return stid, err
+		}
+		if len(rs) != 1 {
+			return stid, errors.Wrapf(errInvalidData, "receipts of %v blocks", len(rs))
+		}
+		if hash := types.DeriveSha(rs[0]); hash != blk.Header().ReceiptHash() {
+			return stid, errors.Wrapf(errInvalidData, "receipt root %x, want %x", hash, blk.Header().ReceiptHash())
+		}
+		receipts = rs[0]
+		return stid, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return receipts, nil
+}
+
+// GetProof returns the account and its storage slots of the keys, with their
+// merkle proofs, from the state of the verified header. The proofs are verified
+// against the state root. The peers only serve the states of the recent blocks.
+func (b *Backend) GetProof(ctx context.Context, header *block.Header, address common.Address, keys []common.Hash) (*AccountProof, error) {
+	var (
+		root        = header.Root()
+		accountHash = crypto.Keccak256Hash(address[:])
+		ap          = &AccountProof{
+			Address:     address,
+			Balance:     new(big.Int),
+			StorageHash: types.EmptyRootHash,
+			CodeHash:    crypto.Keccak256Hash(nil),
+		}
+		body []byte
+	)
+	err := b.request(ctx, "getAccountProof", func() (stid sttypes.StreamID, err error) {
+		body, ap.Proof, stid, err = b.protocol.GetAccountProof(ctx, root, accountHash)
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		acc, err := snapshot.FullAccount(body)
+		if err != nil {
+			return nil, errors.Wrap(err, "decode account")
+		}
+		ap.Nonce = acc.Nonce
+		ap.Balance = acc.Balance
+		ap.StorageHash = common.BytesToHash(acc.Root)
+		ap.CodeHash = common.BytesToHash(acc.CodeHash)
+	}
+	for _, key := range keys {
+		sp := StorageProof{
+			Key:   key,
+			Value: new(big.Int),
+		}
+		if body != nil {
+			var value []byte
+			err := b.request(ctx, "getStorageProof", func() (stid sttypes.StreamID, err error) {
+				value, sp.Proof, stid, err = b.protocol.GetStorageProof(ctx, root, accountHash, ap.StorageHash,
+					crypto.Keccak256Hash(key[:]))
+				return
+			})
+			if err != nil {
+				return nil, err
+			}
+			if len(value) != 0 {
+				_, content, _, err := rlp.Split(value)
+				if err != nil {
+					return nil, errors.Wrap(err, "decode storage slot")
+				}
+				sp.Value.SetBytes(content)
+			}
+		}
+		ap.StorageProofs = append(ap.StorageProofs, sp)
+	}
+	return ap, nil
+}
+
+// Leader returns the address of the leader of the block, the validator owning
+// the key the block is proposed with since the staking epoch
+func (b *Backend) Leader(header *block.Header) (common.Address, error) {
+	if !b.epochChain.Config().IsStaking(header.Epoch()) {
+		return header.Coinbase(), nil
+	}
+	ss, err := b.epochChain.ReadShardState(header.Epoch())
+	if err != nil {
+		return common.Address{}, err
+	}
+	committee, err := ss.FindCommitteeByID(header.ShardID())
+	if err != nil {
+		return common.Address{}, err
+	}
+	for _, slot := range committee.Slots {
+		if utils.GetAddressFromBLSPubKeyBytes(slot.BLSPublicKey[:]) == header.Coinbase() {
+			return slot.EcdsaAddress, nil
+		}
+	}
+	return common.Address{}, errors.New("leader not in the committee")
+}
+
+// followHead verifies the blocks the peers have after the current head, up
+// to a request of them, starting from the latest ones
+func (b *Backend) followHead(ctx context.Context) error {
+	var target uint64
+	err := b.request(ctx, "getCurrentBlockNumber", func() (stid sttypes.StreamID, err error) {
+		target, stid, err = b.protocol.GetCurrentBlockNumber(ctx)
+		return
+	})
+	if err != nil {
+		return err
+	}
+	head := b.hc.CurrentHeader().Number().Uint64()
+	if target <= head {
+		return nil
+	}
+	from := head + 1
+	if target-head > syncproto.GetBlocksByNumAmountCap {
+		from = target - syncproto.GetBlocksByNumAmountCap + 1
+	}
+	bns := make([]uint64, 0, target-from+1)
+	for bn := from; bn <= target; bn++ {
+		bns = append(bns, bn)
+	}
+	return b.request(ctx, "getBlocksByNumber", func() (sttypes.StreamID, error) {
+		blocks, stid, err := b.protocol.GetBlocksByNumber(ctx, bns)
+		if err != nil {
+			return stid, err
+		}
+		for _, blk := range blocks {
+			if blk == nil {
+				continue
+			}
+			if err := b.hc.InsertHeader(blk.Header(), blk.GetCurrentCommitSig()); err != nil {
+				if errors.Is(err, ErrEpochChainBehind) {
+					return "", err
+				}
+				return stid, errors.Wrapf(errInvalidData, "%v", err)
+			}
+		}
+		return stid, nil
+	})
+}
+
+// fetchBlock fetches the block of the number and verifies its transactions
+// against its header. The header is verified and written if it is not known,
+// otherwise it is to be the block header.
+func (b *Backend) fetchBlock(ctx context.Context, number uint64, header *block.Header) (*types.Block, error) {
+	var blk *types.Block
+	err := b.request(ctx, "getBlocksByNumber", func() (sttypes.StreamID, error) {
+		blocks, stid, err := b.protocol.GetBlocksByNumber(ctx, []uint64{number})
+		if err != nil {
+			return stid, err
+		}
+		if len(blocks) != 1 || blocks[0] == nil {
+			return "", ErrBlockNotFound
+		}
+		if header == nil {
+			if err := b.hc.InsertHeader(blocks[0].Header(), blocks[0].GetCurrentCommitSig()); err != nil {
+				if errors.Is(err, ErrEpochChainBehind) {
+					return "", err
+				}
+				return stid, errors.Wrapf(errInvalidData, "%v", err)
+			}
+		} else if blocks[0].Hash() != header.Hash() {
+			return stid, errors.Wrapf(errInvalidData, "block hash %x, want %x", blocks[0].Hash(), header.Hash())
+		}
+		txHash := types.DeriveSha(blocks[0].Transactions(), blocks[0].StakingTransactions())
+		if txHash != blocks[0].Header().TxHash() {
+			return stid, errors.Wrapf(errInvalidData, "transaction root %x, want %x", txHash, blocks[0].Header().TxHash())
+		}
+		blk = blocks[0]
+		return stid, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return blk, nil
+}
+
+// request does the request with up to maxRequestAttempts streams. The streams
+// delivering data not matching the headers are removed.
+func (b *Backend) request(ctx context.Context, name string, do func() (sttypes.StreamID, error)) error {
+	var err error
+	for i := 0; i < maxRequestAttempts; i++ {
+		var stid sttypes.StreamID
+		stid, err = do()
+		switch {
+		case err == nil:
+			return nil
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case errors.Is(err, ErrBlockNotFound), errors.Is(err, ErrEpochChainBehind):
+			return err
+		case errors.Is(err, syncproto.ErrStateUnavailable):
+			// the peer pruned the state, try another one
+		case errors.Is(err, errInvalidData):
+			b.logger.Warn().Err(err).Str("stream", string(stid)).Msg(name + " delivered invalid data")
+			if stid != "" {
+				b.protocol.RemoveStream(stid)
+			}
+		default:
+			if stid != "" {
+				b.protocol.StreamFailed(stid, name+" failed")
+			}
+		}
+	}
+	return errors.Wrap(err, name)
+}
@@ -0,0 +1,140 @@
+package lightnode
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/types"
+	syncproto "github.com/harmony-one/harmony/p2p/stream/protocols/sync"
+	sttypes "github.com/harmony-one/harmony/p2p/stream/types"
+)
+
+func TestBackend_BlockByHeader(t *testing.T) {
+	blk, _ := makeTestBlock()
+	tests := []struct {
+		delivered  *types.Block
+		expErr     error
+		expRemoved bool
+	}{
+		{
+			delivered: blk,
+		},
+		{
+			// the transactions dropped
+			delivered:  types.NewBlockWithHeader(blk.Header()),
+			expErr:     errInvalidData,
+			expRemoved: true,
+		},
+		{
+			// another block
+			delivered:  types.NewBlockWithHeader(blockfactory.NewTestHeader().With().Number(big.NewInt(6)).Header()),
+			expErr:     errInvalidData,
+			expRemoved: true,
+		},
+	}
+	for i, test := range tests {
+		protocol := &testProtocol{blocks: []*types.Block{test.delivered}}
+		backend := makeTestBackend(protocol)
+
+		got, err := backend.BlockByHeader(context.Background(), blk.Header())
+		if !errors.Is(err, test.expErr) {
+			t.Errorf("Test %v: unexpected error %v / %v", i, err, test.expErr)
+		}
+		if err == nil && got.Hash() != blk.Hash() {
+			t.Errorf("Test %v: unexpected block %x", i, got.Hash())
+		}
+		if (len(protocol.removed) != 0) != test.expRemoved {
+			t.Errorf("Test %v: unexpected removed streams %v", i, protocol.removed)
+		}
+	}
+}
+
+func TestBackend_GetReceipts(t *testing.T) {
+	blk, receipts := makeTestBlock()
+	tampered := &types.Receipt{
+		Status:            types.ReceiptStatusFailed,
+		CumulativeGasUsed: receipts[0].CumulativeGasUsed,
+		Logs:              []*types.Log{},
+		TxHash:            receipts[0].TxHash,
+	}
+	tests := []struct {
+		delivered types.Receipts
+		expErr    error
+	}{
+		{
+			delivered: receipts,
+		},
+		{
+			delivered: types.Receipts{tampered},
+			expErr:    errInvalidData,
+		},
+	}
+	for i, test := range tests {
+		protocol := &testProtocol{receipts: test.delivered}
+		backend := makeTestBackend(protocol)
+
+		got, err := backend.GetReceipts(context.Background(), blk)
+		if !errors.Is(err, test.expErr) {
+			t.Errorf("Test %v: unexpected error %v / %v", i, err, test.expErr)
+		}
+		if err == nil && len(got) != len(receipts) {
+			t.Errorf("Test %v: unexpected receipts size %v / %v", i, len(got), len(receipts))
+		}
+	}
+}
+
+func makeTestBackend(protocol syncProtocol) *Backend {
+	hc := &HeaderChain{db: rawdb.NewMemoryDatabase()}
+	return NewBackend(hc, nil, protocol)
+}
+
+func makeTestBlock() (*types.Block, types.Receipts) {
+	tx := types.NewTransaction(0, common.Address{0x01}, 0, big.NewInt(1), 21000, big.NewInt(1), nil)
+	receipts := types.Receipts{
+		{
+			Status:            types.ReceiptStatusSuccessful,
+			CumulativeGasUsed: 21000,
+			Logs:              []*types.Log{},
+			TxHash:            tx.Hash(),
+		},
+	}
+	header := blockfactory.NewTestHeader().With().Number(big.NewInt(5)).Header()
+	return types.NewBlock(header, types.Transactions{tx}, receipts, nil, nil, nil), receipts
+}
+
+type testProtocol struct {
+	blocks   []*types.Block
+	receipts types.Receipts
+	removed  []sttypes.StreamID
+}
+
+func (p *testProtocol) GetCurrentBlockNumber(ctx context.Context, opts ...syncproto.Option) (uint64, sttypes.StreamID, error) {
+	return 0, "", errors.New("not implemented")
+}
+
+func (p *testProtocol) GetBlocksByNumber(ctx context.Context, bns []uint64, opts ...syncproto.Option) ([]*types.Block, sttypes.StreamID, error) {
+	return p.blocks, "test stream", nil
+}
+
+func (p *testProtocol) GetReceipts(ctx context.Context, hs []common.Hash, opts ...syncproto.Option) ([]types.Receipts, sttypes.StreamID, error) {
+	return []types.Receipts{p.receipts}, "test stream", nil
+}
+
+func (p *testProtocol) GetAccountProof(ctx context.Context, root, account common.Hash, opts ...syncproto.Option) ([]byte, [][]byte, sttypes.StreamID, error) {
+	return nil, nil, "", errors.New("not implemented")
+}
+
+func (p *testProtocol) GetStorageProof(ctx context.Context, root, account, storageRoot, slot common.Hash, opts ...syncproto.Option) ([]byte, [][]byte, sttypes.StreamID, error) {
+	return nil, nil, "", errors.New("not implemented")
+}
+
+func (p *testProtocol) RemoveStream(stID sttypes.StreamID) {
+	p.removed = append(p.removed, stID)
+}
+
+func (p *testProtocol) StreamFailed(stID sttypes.StreamID, reason string) {}
@@ -0,0 +1,160 @@
+package lightnode
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/harmony-one/harmony/block"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/internal/chain"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrEpochChainBehind is returned when the epoch chain does not have the
+	// committees of the header epoch yet
+	ErrEpochChainBehind = errors.New("epoch chain has not synced the committees of the epoch")
+)
+
+// HeaderChain is the chain of the headers verified by the light node. Each header
+// is verified against its commit signature, with the committee of its shard read
+// from the epoch chain, so the headers do not need to be contiguous.
+type HeaderChain struct {
+	db         ethdb.Database
+	epochChain core.BlockChain
+	shardID    uint32
+
+	current atomic.Value // *block.Header
+	lock    sync.Mutex   // lock of the head update
+}
+
+// NewHeaderChain creates the header chain of the shard of the genesis header,
+// stored in db. The committees are read from the epoch chain.
+func NewHeaderChain(db ethdb.Database, epochChain core.BlockChain, genesis *block.Header) (*HeaderChain, error) {
+	hc := &HeaderChain{
+		db:         db,
+		epochChain: epochChain,
+		shardID:    genesis.ShardID(),
+	}
+	if head := hc.GetHeaderByHash(rawdb.ReadHeadHeaderHash(db)); head != nil {
+		hc.current.Store(head)
+		return hc, nil
+	}
+	batch := db.NewBatch()
+	if err := writeHeader(batch, genesis, nil); err != nil {
+		return nil, err
+	}
+	if err := rawdb.WriteHeadHeaderHash(batch, genesis.Hash()); err != nil {
+		return nil, err
+	}
+	if err := batch.Write(); err != nil {
+		return nil, err
+	}
+	hc.current.Store(genesis)
+	return hc, nil
+}
+
+// ShardID returns the shard of the headers
+func (hc *HeaderChain) ShardID() uint32 {
+	return hc.shardID
+}
+
+// CurrentHeader returns the highest verified header
+func (hc *HeaderChain) CurrentHeader() *block.Header {
+	return hc.current.Load().(*block.Header)
+}
+
+// GetHeaderByNumber returns the verified header of the block number, nil if it
+// is not known
+func (hc *HeaderChain) GetHeaderByNumber(number uint64) *block.Header {
+	hash := rawdb.ReadCanonicalHash(hc.db, number)
+	if hash == (common.Hash{}) {
+		return nil
+	}
+	return rawdb.ReadHeader(hc.db, hash, number)
+}
+
+// GetHeaderByHash returns the verified header of the hash, nil if it is not known
+func (hc *HeaderChain) GetHeaderByHash(hash common.Hash) *block.Header {
+	number := rawdb.ReadHeaderNumber(hc.db, hash)
+	if number == nil {
+		return nil
+	}
+	return rawdb.ReadHeader(hc.db, hash, *number)
+}
+
+// ReadCommitSig returns the commit signature and bitmap of the verified header
+// of the block number
+func (hc *HeaderChain) ReadCommitSig(number uint64) ([]byte, error) {
+	return rawdb.ReadBlockCommitSig(hc.db, number)
+}
+
+// VerifyHeader verifies the header against its commit signature and bitmap,
+// signed by the committee of the header shard and epoch
+func (hc *HeaderChain) VerifyHeader(header *block.Header, commitSig []byte) error {
+	if header.ShardID() != hc.shardID {
+		return errors.Errorf("header of shard %v, expect shard %v", header.ShardID(), hc.shardID)
+	}
+	// the engine skips the verification on a chain at the genesis
+	if hc.epochChain.CurrentHeader().Number().Uint64() <= 1 {
+		return ErrEpochChainBehind
+	}
+	if _, err := hc.epochChain.ReadShardState(header.Epoch()); err != nil {
+		return errors.Wrapf(ErrEpochChainBehind, "epoch %v", header.Epoch())
+	}
+	sig, bitmap, err := chain.ParseCommitSigAndBitmap(commitSig)
+	if err != nil {
+		return errors.Wrap(err, "parse commit sig")
+	}
+	if err := hc.epochChain.Engine().VerifyHeaderSignature(hc.epochChain, header, sig, bitmap); err != nil {
+		return errors.Wrapf(err, "verify header %v", header.Number())
+	}
+	return nil
+}
+
+// InsertHeader verifies the header and writes it with its commit signature. The
+// header becomes the head if it is higher than the current one.
+func (hc *HeaderChain) InsertHeader(header *block.Header, commitSig []byte) error {
+	if err := hc.VerifyHeader(header, commitSig); err != nil {
+		return err
+	}
+	hc.lock.Lock()
+	defer hc.lock.Unlock()
+
+	batch := hc.db.NewBatch()
+	if err := writeHeader(batch, header, commitSig); err != nil {
+		return err
+	}
+	isHead := header.Number().Cmp(hc.CurrentHeader().Number()) > 0
+	if isHead {
+		if err := rawdb.WriteHeadHeaderHash(batch, header.Hash()); err != nil {
+			return err
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	if isHead {
+		hc.current.Store(header)
+	}
+	return nil
+}
+
+// writeHeader writes the header as the canonical one of its number, the blocks
+// signed by a quorum being final
+func writeHeader(db ethdb.KeyValueWriter, header *block.Header, commitSig []byte) error {
+	number := header.Number().Uint64()
+	if err := rawdb.WriteHeader(db, header); err != nil {
+		return err
+	}
+	if err := rawdb.WriteCanonicalHash(db, header.Hash(), number); err != nil {
+		return err
+	}
+	if len(commitSig) != 0 {
+		return rawdb.WriteBlockCommitSig(db, number, commitSig)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package lightnode
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// followHeadInterval is the interval the head of the chain is checked at
+	followHeadInterval = 2 * time.Second
+	// followHeadTimeout is the timeout of a round of the head following
+	followHeadTimeout = 30 * time.Second
+)
+
+// Service follows the head of the shard chain, verifying the headers of the new
+// blocks. The older headers are verified when requested.
+type Service struct {
+	backend *Backend
+
+	ctx    context.Context
+	cancel func()
+}
+
+// NewService creates the light node service of the backend
+func NewService(backend *Backend) *Service {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Service{
+		backend: backend,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+// Start starts the service
+func (s *Service) Start() error {
+	go s.loop()
+	return nil
+}
+
+// Stop stops the service
+func (s *Service) Stop() error {
+	s.cancel()
+	return nil
+}
+
+func (s *Service) loop() {
+	ticker := time.NewTicker(followHeadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(s.ctx, followHeadTimeout)
+			if err := s.backend.followHead(ctx); err != nil && s.ctx.Err() == nil {
+				s.backend.logger.Info().Err(err).Msg("failed to follow the chain head")
+			} else {
+				head := s.backend.CurrentHeader()
+				s.backend.logger.Debug().
+					Uint64("number", head.Number().Uint64()).
+					Uint64("epoch", head.Epoch().Uint64()).
+					Msg("verified chain head")
+			}
+			cancel()
+
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
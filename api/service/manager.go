@@ -24,6 +24,8 @@ const (
 	Synchronize
 	CrosslinkSending
 	StagedStreamSync
+	TxFetch
+	LightNode
 )
 
 func (t Type) String() string {
@@ -48,6 +50,10 @@ func (t Type) String() string {
 		return "CrosslinkSending"
 	case StagedStreamSync:
 		return "StagedStreamSync"
+	case TxFetch:
+		return "TxFetch"
+	case LightNode:
+		return "LightNode"
 	default:
 		return "Unknown"
 	}
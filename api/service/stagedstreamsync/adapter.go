@@ -20,6 +20,10 @@ type syncProtocol interface {
 	GetBlocksByHashes(ctx context.Context, hs []common.Hash, opts ...syncproto.Option) ([]*types.Block, sttypes.StreamID, error)
 	GetReceipts(ctx context.Context, hs []common.Hash, opts ...syncproto.Option) (receipts []types.Receipts, stid sttypes.StreamID, err error)
 	GetNodeData(ctx context.Context, hs []common.Hash, opts ...syncproto.Option) (data [][]byte, stid sttypes.StreamID, err error)
+	GetAccountRange(ctx context.Context, root, origin, limit common.Hash, bytes uint64, opts ...syncproto.Option) (hashes []common.Hash, accounts [][]byte, more bool, stid sttypes.StreamID, err error)
+	GetStorageRanges(ctx context.Context, root common.Hash, accounts []common.Hash, roots []common.Hash, origin, limit []byte, bytes uint64, opts ...syncproto.Option) (hashes [][]common.Hash, slots [][][]byte, more bool, stid sttypes.StreamID, err error)
+	GetByteCodes(ctx context.Context, hs []common.Hash, bytes uint64, opts ...syncproto.Option) (codes [][]byte, stid sttypes.StreamID, err error)
+	GetTrieNodes(ctx context.Context, root common.Hash, paths [][][]byte, hs []common.Hash, bytes uint64, opts ...syncproto.Option) (nodes [][]byte, stid sttypes.StreamID, err error)
 
 	RemoveStream(stID sttypes.StreamID) // If a stream delivers invalid data, remove the stream
 	StreamFailed(stID sttypes.StreamID, reason string)
@@ -0,0 +1,173 @@
+package stagedstreamsync
+
+import (
+	"encoding/json"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/harmony-one/harmony/block"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/internal/chain"
+	"github.com/harmony-one/harmony/internal/params"
+	"github.com/harmony-one/harmony/internal/utils"
+	"github.com/harmony-one/harmony/shard"
+	"github.com/pkg/errors"
+)
+
+// Checkpoint is a trusted last block of an epoch to bootstrap the sync from,
+// instead of verifying the chain from the genesis. The committees of the
+// checkpoint epoch, which the commit signature of the block is verified
+// against, are taken from the trusted last block of the previous epoch. The
+// block holds the committees of the next epoch, which the blocks synced after
+// the checkpoint are verified against.
+type Checkpoint struct {
+	Hash   common.Hash
+	Header *block.Header
+	// PrevHash is the hash of the last block of the previous epoch, whose
+	// header holds the shard state of the checkpoint epoch
+	PrevHash   common.Hash
+	PrevHeader *block.Header
+	// ShardState is the shard state of the checkpoint epoch
+	ShardState *shard.State
+	// CommitSig is the commit signature and bitmap of the checkpoint block
+	CommitSig []byte
+}
+
+// checkpointJSON is the checkpoint file format
+type checkpointJSON struct {
+	Hash       common.Hash   `json:"hash"`
+	Header     hexutil.Bytes `json:"header"` // RLP encoded header
+	PrevHash   common.Hash   `json:"prevHash"`
+	PrevHeader hexutil.Bytes `json:"prevHeader"` // RLP encoded header
+	CommitSig  hexutil.Bytes `json:"commitSig"`
+}
+
+// LoadCheckpoint reads the checkpoint from a JSON file. The checkpoint is to be
+// verified before use.
+func LoadCheckpoint(path string) (*Checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "read checkpoint file")
+	}
+	var enc checkpointJSON
+	if err := json.Unmarshal(data, &enc); err != nil {
+		return nil, errors.Wrap(err, "parse checkpoint file")
+	}
+	header := new(block.Header)
+	if err := rlp.DecodeBytes(enc.Header, header); err != nil {
+		return nil, errors.Wrap(err, "decode checkpoint header")
+	}
+	prevHeader := new(block.Header)
+	if err := rlp.DecodeBytes(enc.PrevHeader, prevHeader); err != nil {
+		return nil, errors.Wrap(err, "decode checkpoint previous epoch header")
+	}
+	ss, err := shard.DecodeWrapper(prevHeader.ShardState())
+	if err != nil {
+		return nil, errors.Wrap(err, "decode checkpoint shard state")
+	}
+	return &Checkpoint{
+		Hash:       enc.Hash,
+		Header:     header,
+		PrevHash:   enc.PrevHash,
+		PrevHeader: prevHeader,
+		ShardState: ss,
+		CommitSig:  enc.CommitSig,
+	}, nil
+}
+
+// Epoch returns the epoch of the checkpoint
+func (cp *Checkpoint) Epoch() *big.Int {
+	return cp.Header.Epoch()
+}
+
+// NumberU64 returns the block number of the checkpoint
+func (cp *Checkpoint) NumberU64() uint64 {
+	return cp.Header.Number().Uint64()
+}
+
+// Verify verifies that the header is the checkpoint block, that it is the last
+// block of its epoch, and that it is signed by the committee of the checkpoint
+// epoch held by the last block of the previous epoch.
+func (cp *Checkpoint) Verify(config *params.ChainConfig) error {
+	if hash := cp.Header.Hash(); hash != cp.Hash {
+		return errors.Errorf("checkpoint header hash mismatch: have %x, want %x", hash, cp.Hash)
+	}
+	if !cp.Header.IsLastBlockInEpoch() {
+		return errors.New("checkpoint is not the last block of an epoch")
+	}
+	// the committee is bound to the trusted hash of the previous epoch block
+	if hash := cp.PrevHeader.Hash(); hash != cp.PrevHash {
+		return errors.Errorf("checkpoint previous epoch header hash mismatch: have %x, want %x", hash, cp.PrevHash)
+	}
+	if !cp.PrevHeader.IsLastBlockInEpoch() || cp.PrevHeader.ShardID() != cp.Header.ShardID() ||
+		new(big.Int).Add(cp.PrevHeader.Epoch(), common.Big1).Cmp(cp.Epoch()) != 0 {
+		return errors.Errorf("checkpoint previous epoch header is not the last block of epoch %v", new(big.Int).Sub(cp.Epoch(), common.Big1))
+	}
+	if cp.ShardState.Epoch != nil && cp.ShardState.Epoch.Cmp(cp.Epoch()) != 0 {
+		return errors.Errorf("checkpoint shard state epoch mismatch: have %v, want %v",
+			cp.ShardState.Epoch, cp.Epoch())
+	}
+	next, err := shard.DecodeWrapper(cp.Header.ShardState())
+	if err != nil {
+		return errors.Wrap(err, "decode checkpoint header shard state")
+	}
+	if next.Epoch == nil || next.Epoch.Cmp(new(big.Int).Add(cp.Epoch(), common.Big1)) != 0 {
+		return errors.Errorf("checkpoint header shard state is not the one of epoch %v", cp.Epoch().Uint64()+1)
+	}
+	sig, bitmap, err := chain.ParseCommitSigAndBitmap(cp.CommitSig)
+	if err != nil {
+		return errors.Wrap(err, "parse checkpoint commit sig")
+	}
+	if err := verifyCommitSigByState(config, cp.ShardState, cp.Header, sig, bitmap); err != nil {
+		return errors.Wrap(err, "verify checkpoint commit sig")
+	}
+	return nil
+}
+
+// writeShardStates writes the shard states of the checkpoint epoch and of the
+// next epoch to the chain, if it does not have them yet
+func (cp *Checkpoint) writeShardStates(bc core.BlockChain) error {
+	epoch := cp.Epoch()
+	if _, err := bc.ReadShardState(epoch); err != nil {
+		if _, err := bc.WriteShardStateBytes(bc.ChainDb(), epoch, cp.PrevHeader.ShardState()); err != nil {
+			return err
+		}
+	}
+	next := new(big.Int).Add(epoch, common.Big1)
+	if _, err := bc.ReadShardState(next); err != nil {
+		if _, err := bc.WriteShardStateBytes(bc.ChainDb(), next, cp.Header.ShardState()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyToEpochChain makes the checkpoint the head of the epoch chain, if the
+// chain is behind the checkpoint. The epoch blocks after the checkpoint are
+// then synced and verified as usual.
+func (cp *Checkpoint) applyToEpochChain(bc core.BlockChain) (bool, error) {
+	if cp.Header.ShardID() != shard.BeaconChainShardID {
+		return false, nil
+	}
+	if bc.CurrentHeader().Epoch().Cmp(cp.Epoch()) >= 0 {
+		return false, nil
+	}
+	if err := cp.writeShardStates(bc); err != nil {
+		return false, err
+	}
+	b := types.NewBlockWithHeader(cp.Header)
+	b.SetCurrentCommitSig(cp.CommitSig)
+	if _, err := bc.InsertChain(types.Blocks{b}, true); err != nil {
+		return false, errors.Wrap(err, "insert checkpoint")
+	}
+	utils.Logger().Info().
+		Uint64("number", cp.NumberU64()).
+		Uint64("epoch", cp.Epoch().Uint64()).
+		Str("hash", cp.Hash.Hex()).
+		Msg(WrapStagedSyncMsg("epoch chain bootstrapped from the checkpoint"))
+	return true, nil
+}
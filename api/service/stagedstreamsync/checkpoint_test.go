@@ -0,0 +1,145 @@
+package stagedstreamsync
+
+import (
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/harmony-one/harmony/block"
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/internal/params"
+	"github.com/harmony-one/harmony/shard"
+)
+
+func TestCheckpoint_Verify(t *testing.T) {
+	epochBlock := makeTestCheckpointHeader(t, 2, 3)
+	prevEpochBlock := makeTestCheckpointHeader(t, 1, 2)
+	tests := []struct {
+		header, prevHeader *block.Header
+		hash, prevHash     common.Hash
+		expErr             string
+	}{
+		{
+			header: epochBlock,
+			hash:   common.Hash{0x01},
+			expErr: "hash mismatch",
+		},
+		{
+			// not the last block of the epoch
+			header: blockfactory.NewTestHeader().With().Epoch(big.NewInt(2)).Header(),
+			expErr: "not the last block",
+		},
+		{
+			// the committee is not the one of the trusted previous epoch block
+			header:   epochBlock,
+			prevHash: common.Hash{0x01},
+			expErr:   "previous epoch header hash mismatch",
+		},
+		{
+			// the previous epoch block is not the one before the checkpoint epoch
+			header:     epochBlock,
+			prevHeader: makeTestCheckpointHeader(t, 0, 1),
+			expErr:     "not the last block of epoch 1",
+		},
+		{
+			// shard state of a wrong epoch
+			header: makeTestCheckpointHeader(t, 2, 4),
+			expErr: "not the one of epoch 3",
+		},
+		{
+			header: epochBlock,
+			expErr: "commit sig",
+		},
+	}
+	for i, test := range tests {
+		hash, prevHash, prevHeader := test.hash, test.prevHash, test.prevHeader
+		if hash == (common.Hash{}) {
+			hash = test.header.Hash()
+		}
+		if prevHeader == nil {
+			prevHeader = prevEpochBlock
+		}
+		if prevHash == (common.Hash{}) {
+			prevHash = prevHeader.Hash()
+		}
+		path := writeTestCheckpoint(t, hash, test.header, prevHash, prevHeader)
+		cp, err := LoadCheckpoint(path)
+		if err != nil {
+			t.Fatalf("Test %v: %v", i, err)
+		}
+		err = cp.Verify(params.TestChainConfig)
+		if err == nil || !strings.Contains(err.Error(), test.expErr) {
+			t.Errorf("Test %v: unexpected error %v / %v", i, err, test.expErr)
+		}
+	}
+}
+
+func TestLoadCheckpoint(t *testing.T) {
+	header := makeTestCheckpointHeader(t, 2, 3)
+	prevHeader := makeTestCheckpointHeader(t, 1, 2)
+	cp, err := LoadCheckpoint(writeTestCheckpoint(t, header.Hash(), header, prevHeader.Hash(), prevHeader))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cp.Hash != header.Hash() || cp.NumberU64() != header.Number().Uint64() {
+		t.Errorf("unexpected checkpoint block %v %v", cp.NumberU64(), cp.Hash.Hex())
+	}
+	if cp.PrevHash != prevHeader.Hash() || cp.PrevHeader.Hash() != prevHeader.Hash() {
+		t.Errorf("unexpected checkpoint previous epoch block %v", cp.PrevHash.Hex())
+	}
+	if cp.Epoch().Uint64() != 2 || cp.ShardState.Epoch.Uint64() != 2 {
+		t.Errorf("unexpected checkpoint epoch %v / %v", cp.Epoch(), cp.ShardState.Epoch)
+	}
+
+	if _, err := LoadCheckpoint(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Errorf("missing checkpoint file loaded")
+	}
+}
+
+func makeTestCheckpointHeader(t *testing.T, epoch, nextEpoch int64) *block.Header {
+	return blockfactory.NewTestHeader().With().
+		Number(big.NewInt(1000)).
+		Epoch(big.NewInt(epoch)).
+		ShardState(encodeTestShardState(t, nextEpoch)).
+		Header()
+}
+
+func encodeTestShardState(t *testing.T, epoch int64) []byte {
+	b, err := shard.EncodeWrapper(shard.State{Epoch: big.NewInt(epoch)}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func writeTestCheckpoint(t *testing.T, hash common.Hash, header *block.Header, prevHash common.Hash, prevHeader *block.Header) string {
+	headerBytes, err := rlp.EncodeToBytes(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prevHeaderBytes, err := rlp.EncodeToBytes(prevHeader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(checkpointJSON{
+		Hash:       hash,
+		Header:     headerBytes,
+		PrevHash:   prevHash,
+		PrevHeader: prevHeaderBytes,
+		CommitSig:  hexutil.Bytes(make([]byte, 96)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
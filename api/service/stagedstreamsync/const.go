@@ -26,6 +26,30 @@ const (
 	// ShortRangeTimeout is the timeout for each short range sync, which allow short range sync
 	// to restart automatically when stuck in `getBlockHashes`
 	ShortRangeTimeout time.Duration = 1 * time.Minute
+
+	// SnapSyncMinDistance is the minimum number of blocks behind the target height
+	// for the state of a recent block to be downloaded instead of executing the blocks
+	SnapSyncMinDistance uint64 = 10000
+	// SnapSyncPivotOffset is the distance of the pivot block, whose state is
+	// downloaded, to the target height. The peers only serve the recent states.
+	SnapSyncPivotOffset uint64 = 64
+	// SnapSyncAccountChunks is the number of ranges the account hash space is split
+	// into to download the accounts concurrently
+	SnapSyncAccountChunks int = 16
+	// SnapSyncStoragesPerRequest is the number of storages requested at once
+	SnapSyncStoragesPerRequest int = 64
+	// SnapSyncCodesPerRequest is the number of byte codes requested at once
+	SnapSyncCodesPerRequest int = 64
+	// SnapSyncNodesPerRequest is the number of trie nodes requested at once while healing
+	SnapSyncNodesPerRequest int = 256
+	// SnapSyncResponseBytes is the soft size limit of the state responses
+	SnapSyncResponseBytes uint64 = 512 * 1024
+	// SnapSyncMaxAttempts is the number of failed attempts of a state request
+	// before the snap sync is aborted
+	SnapSyncMaxAttempts int = 10
+	// SnapSyncUnavailableAttempts is the number of requests answered with an
+	// unavailable state before the pivot is moved to a more recent block
+	SnapSyncUnavailableAttempts int = 3
 )
 
 type (
@@ -53,6 +77,14 @@ type (
 		// use memory db
 		UseMemDB bool
 
+		// download the state of a recent block with range proofs on the initial
+		// sync of a shard chain, instead of executing all the blocks
+		SnapSync bool
+
+		// trusted epoch block to bootstrap the epoch chain and the shard states
+		// from, instead of verifying them from the genesis
+		Checkpoint *Checkpoint
+
 		// log the stage progress
 		LogProgress bool
 
@@ -12,6 +12,7 @@ var DefaultForwardOrder = ForwardOrder{
 	Heads,
 	SyncEpoch,
 	ShortRange,
+	SnapSync,
 	BlockBodies,
 	// Stages below don't use Internet
 	States,
@@ -24,6 +25,7 @@ var DefaultRevertOrder = RevertOrder{
 	LastMile,
 	States,
 	BlockBodies,
+	SnapSync,
 	ShortRange,
 	SyncEpoch,
 	Heads,
@@ -34,6 +36,7 @@ var DefaultCleanUpOrder = CleanUpOrder{
 	LastMile,
 	States,
 	BlockBodies,
+	SnapSync,
 	ShortRange,
 	SyncEpoch,
 	Heads,
@@ -43,6 +46,7 @@ func DefaultStages(ctx context.Context,
 	headsCfg StageHeadsCfg,
 	seCfg StageEpochCfg,
 	srCfg StageShortRangeCfg,
+	snapCfg StageSnapSyncCfg,
 	bodiesCfg StageBodiesCfg,
 	statesCfg StageStatesCfg,
 	lastMileCfg StageLastMileCfg,
@@ -52,6 +56,7 @@ func DefaultStages(ctx context.Context,
 	handlerStageHeads := NewStageHeads(headsCfg)
 	handlerStageShortRange := NewStageShortRange(srCfg)
 	handlerStageEpochSync := NewStageEpoch(seCfg)
+	handlerStageSnapSync := NewStageSnapSync(snapCfg)
 	handlerStageBodies := NewStageBodies(bodiesCfg)
 	handlerStageStates := NewStageStates(statesCfg)
 	handlerStageLastMile := NewStageLastMile(lastMileCfg)
@@ -73,6 +78,11 @@ func DefaultStages(ctx context.Context,
 			Description: "Short Range Sync",
 			Handler:     handlerStageShortRange,
 		},
+		{
+			ID:          SnapSync,
+			Description: "Download State of a Recent Block",
+			Handler:     handlerStageSnapSync,
+		},
 		{
 			ID:          BlockBodies,
 			Description: "Retrieve Block Bodies",
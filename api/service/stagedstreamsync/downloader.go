@@ -129,6 +129,13 @@ func (d *Downloader) NumPeers() int {
 	return d.syncProtocol.NumStreams()
 }
 
+// SyncProtocol returns the sync protocol of the downloader, for the services
+// requesting data from the same streams
+func (d *Downloader) SyncProtocol() *sync.Protocol {
+	sp, _ := d.syncProtocol.(*sync.Protocol)
+	return sp
+}
+
 // SyncStatus returns the current sync status
 func (d *Downloader) SyncStatus() (bool, uint64, uint64) {
 	syncing, target := d.stagedSyncInstance.status.get()
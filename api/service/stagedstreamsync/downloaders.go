@@ -35,6 +35,18 @@ func NewDownloaders(host p2p.Host, bcs []core.BlockChain, consensus *consensus.C
 	}
 }
 
+// NewEpochChainDownloaders creates Downloaders for sync of the epoch chain only,
+// for the nodes not keeping the shard chains, e.g. the light nodes
+func NewEpochChainDownloaders(host p2p.Host, epochChain core.BlockChain, consensus *consensus.Consensus, dbDir string, config Config) *Downloaders {
+	ds := make(map[uint32]*Downloader)
+	ds[epochChain.ShardID()] = NewDownloader(host, epochChain, consensus, dbDir, false, config)
+	return &Downloaders{
+		ds:     ds,
+		active: abool.New(),
+		config: config,
+	}
+}
+
 // Start starts the downloaders
 func (ds *Downloaders) Start() {
 	if ds.config.ServerOnly {
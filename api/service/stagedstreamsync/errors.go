@@ -22,6 +22,8 @@ var (
 	ErrEmptyWhitelist                = WrapStagedSyncError("empty white list")
 	ErrWrongGetBlockNumberType       = WrapStagedSyncError("wrong type of getBlockNumber interface")
 	ErrSaveBlocksToDbFailed          = WrapStagedSyncError("saving downloaded blocks to db failed")
+	ErrSnapSyncStateUnavailable      = WrapStagedSyncError("state of a recent block not available for snap sync")
+	ErrSaveSnapSyncProgressFail      = WrapStagedSyncError("saving progress for snap sync stage failed")
 )
 
 // WrapStagedSyncError wraps errors for staged sync and returns error object
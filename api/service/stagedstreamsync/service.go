@@ -18,6 +18,14 @@ func NewService(host p2p.Host, bcs []core.BlockChain, consensus *consensus.Conse
 	}
 }
 
+// NewEpochChainService creates a new downloader service which only syncs the
+// epoch chain
+func NewEpochChainService(host p2p.Host, epochChain core.BlockChain, consensus *consensus.Consensus, config Config, dbDir string) *StagedStreamSyncService {
+	return &StagedStreamSyncService{
+		Downloaders: NewEpochChainDownloaders(host, epochChain, consensus, dbDir, config),
+	}
+}
+
 // Start starts the service
 func (s *StagedStreamSyncService) Start() error {
 	s.Downloaders.Start()
@@ -3,9 +3,14 @@ package stagedstreamsync
 import (
 	"fmt"
 
+	"github.com/harmony-one/harmony/block"
+	"github.com/harmony-one/harmony/consensus/quorum"
+	"github.com/harmony-one/harmony/consensus/signature"
 	"github.com/harmony-one/harmony/core/types"
 	"github.com/harmony-one/harmony/crypto/bls"
 	"github.com/harmony-one/harmony/internal/chain"
+	"github.com/harmony-one/harmony/internal/params"
+	"github.com/harmony-one/harmony/shard"
 	"github.com/pkg/errors"
 )
 
@@ -58,3 +63,46 @@ func verifyAndInsertBlock(bc blockChain, block *types.Block, nextBlocks ...*type
 	}
 	return nil
 }
+
+// verifyCommitSig verifies the commit signature of the header against the
+// committee of its epoch stored in the chain. Unlike the engine, it verifies
+// the signature even when the chain has no block beyond the genesis yet.
+func verifyCommitSig(bc blockChain, header *block.Header, sigBytes bls.SerializedSignature, bitmap []byte) error {
+	ss, err := bc.ReadShardState(header.Epoch())
+	if err != nil {
+		return errors.Wrapf(err, "read shard state for epoch %v", header.Epoch())
+	}
+	return verifyCommitSigByState(bc.Config(), ss, header, sigBytes, bitmap)
+}
+
+// verifyCommitSigByState verifies the commit signature of the header against the
+// committee of the given shard state, which is the one of the header epoch
+func verifyCommitSigByState(config *params.ChainConfig, ss *shard.State, header *block.Header,
+	sigBytes bls.SerializedSignature, bitmap []byte) error {
+
+	committee, err := ss.FindCommitteeByID(header.ShardID())
+	if err != nil {
+		return err
+	}
+	pubKeys, err := committee.BLSPublicKeys()
+	if err != nil {
+		return err
+	}
+	qrVerifier, err := quorum.NewVerifier(committee, header.Epoch(), config.IsStaking(header.Epoch()))
+	if err != nil {
+		return err
+	}
+	aggSig, mask, err := chain.DecodeSigBitmap(sigBytes, bitmap, pubKeys)
+	if err != nil {
+		return errors.Wrap(err, "deserialize signature and bitmap")
+	}
+	if !qrVerifier.IsQuorumAchievedByMask(mask) {
+		return &sigVerifyErr{errors.New("not enough signature collected")}
+	}
+	payload := signature.ConstructCommitPayload(config, header.Epoch(), header.Hash(),
+		header.Number().Uint64(), header.ViewID().Uint64())
+	if !aggSig.VerifyHash(mask.AggregatePublic, payload) {
+		return &sigVerifyErr{errors.New("unable to verify aggregated signature for block")}
+	}
+	return nil
+}
@@ -0,0 +1,949 @@
+package stagedstreamsync
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/state/snapshot"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/internal/chain"
+	syncproto "github.com/harmony-one/harmony/p2p/stream/protocols/sync"
+	sttypes "github.com/harmony-one/harmony/p2p/stream/types"
+	"github.com/harmony-one/harmony/shard"
+	"github.com/harmony-one/harmony/staking"
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+var (
+	// snapMaxHash is the end of the hash space
+	snapMaxHash = common.HexToHash("0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+
+	// validatorSlot is the hashed storage key of the validator flag of an account
+	validatorSlot = crypto.Keccak256Hash(staking.IsValidatorKey.Bytes())
+
+	// errPivotMoved is returned by a request made for a former pivot
+	errPivotMoved = errors.New("pivot moved")
+	// errStaleState is returned by a request for the data of a former pivot
+	// which could not be downloaded, the data is left to the healing
+	errStaleState = errors.New("stale state")
+)
+
+// storageTask is a storage to be downloaded, by its root and an account holding it
+type storageTask struct {
+	account common.Hash
+	root    common.Hash
+}
+
+// snapSyncer downloads the state of a recent pivot block from the streams with
+// range proofs, and writes it to the chain database:
+//
+//  1. the accounts are downloaded over concurrent ranges of the hash space,
+//  2. the storages of the accounts, once per storage root,
+//  3. the byte codes of the accounts,
+//  4. the account and storage tries are built from the downloaded leaves, and
+//  5. the tries are healed with the trie nodes which do not match the pivot.
+//
+// The peers only serve the states of the recent blocks, so the pivot is moved to
+// a more recent block when its state is not served anymore. The leaves of the
+// former pivots are kept, and the healing fixes up the tries they are built into.
+type snapSyncer struct {
+	bc          core.BlockChain
+	db          kv.RwDB
+	protocol    syncProtocol
+	concurrency int
+	estimate    func(ctx context.Context) (uint64, error)
+	checkpoint  *Checkpoint
+
+	pivot *types.Block
+	// gen is incremented whenever the pivot moves
+	gen  int
+	lock sync.Mutex
+
+	// the storage roots which are fully downloaded or already in the chain
+	storages     map[common.Hash]struct{}
+	storagesLock sync.Mutex
+
+	logger zerolog.Logger
+}
+
+func newSnapSyncer(bc core.BlockChain, db kv.RwDB, protocol syncProtocol, concurrency int,
+	estimate func(ctx context.Context) (uint64, error), checkpoint *Checkpoint, logger zerolog.Logger) *snapSyncer {
+
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	return &snapSyncer{
+		bc:          bc,
+		db:          db,
+		protocol:    protocol,
+		concurrency: concurrency,
+		estimate:    estimate,
+		checkpoint:  checkpoint,
+		storages:    make(map[common.Hash]struct{}),
+		logger:      logger.With().Str("mode", "snap sync").Logger(),
+	}
+}
+
+// sync downloads the state of the pivot block, or of a more recent block if the
+// pivot state is not served anymore, and makes the block the head of the chain.
+// It returns the block whose state is downloaded.
+func (ss *snapSyncer) sync(ctx context.Context, pivotBN uint64) (*types.Block, error) {
+	if err := ss.db.Update(ctx, func(tx kv.RwTx) error {
+		if err := tx.ClearBucket(SnapAccountsBucket); err != nil {
+			return err
+		}
+		return tx.ClearBucket(SnapStoragesBucket)
+	}); err != nil {
+		return nil, err
+	}
+	if ss.checkpoint != nil {
+		if err := ss.checkpoint.writeShardStates(ss.bc); err != nil {
+			return nil, errors.Wrap(err, "write checkpoint shard states")
+		}
+	}
+	pivot, err := ss.fetchPivot(ctx, pivotBN)
+	if err != nil {
+		return nil, err
+	}
+	ss.pivot = pivot
+	ss.logger.Info().Uint64("pivot", pivot.NumberU64()).Str("root", pivot.Root().Hex()).
+		Msg(WrapStagedSyncMsg("snap sync started"))
+
+	if err := ss.syncAccounts(ctx); err != nil {
+		return nil, errors.Wrap(err, "sync accounts")
+	}
+	if err := ss.syncStorages(ctx); err != nil {
+		return nil, errors.Wrap(err, "sync storages")
+	}
+	if err := ss.syncByteCodes(ctx); err != nil {
+		return nil, errors.Wrap(err, "sync byte codes")
+	}
+	if err := ss.buildTries(ctx); err != nil {
+		return nil, errors.Wrap(err, "build tries")
+	}
+	if err := ss.heal(ctx); err != nil {
+		return nil, errors.Wrap(err, "heal tries")
+	}
+	pivot, _ = ss.currentPivot()
+	if err := ss.commitPivot(pivot); err != nil {
+		return nil, errors.Wrap(err, "commit pivot")
+	}
+	ss.logger.Info().Uint64("pivot", pivot.NumberU64()).Str("root", pivot.Root().Hex()).
+		Msg(WrapStagedSyncMsg("snap sync finished"))
+	return pivot, nil
+}
+
+// currentPivot returns the current pivot and its generation
+func (ss *snapSyncer) currentPivot() (*types.Block, int) {
+	ss.lock.Lock()
+	defer ss.lock.Unlock()
+
+	return ss.pivot, ss.gen
+}
+
+// fetchPivot fetches the block of the given number, and verifies its commit
+// signature against the committee of its epoch
+func (ss *snapSyncer) fetchPivot(ctx context.Context, bn uint64) (*types.Block, error) {
+	pivot, stid, err := ss.fetchBlock(ctx, bn)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetch pivot")
+	}
+	if hash := types.DeriveSha(pivot.Transactions(), pivot.StakingTransactions()); hash != pivot.Header().TxHash() {
+		ss.protocol.StreamFailed(stid, "invalid pivot block")
+		return nil, errors.Errorf("pivot transaction root hash mismatch: have %x, want %x", hash, pivot.Header().TxHash())
+	}
+	if err := ss.syncShardState(ctx, pivot); err != nil {
+		return nil, errors.Wrap(err, "sync shard state")
+	}
+	sig, bitmap, err := chain.ParseCommitSigAndBitmap(pivot.GetCurrentCommitSig())
+	if err != nil {
+		ss.protocol.StreamFailed(stid, "invalid pivot commit sig")
+		return nil, ErrParseCommitSigAndBitmapFail
+	}
+	if err := verifyCommitSig(ss.bc, pivot.Header(), sig, bitmap); err != nil {
+		ss.protocol.StreamFailed(stid, "invalid pivot commit sig")
+		return nil, err
+	}
+	return pivot, nil
+}
+
+// syncShardState makes sure the shard state of the pivot epoch is in the chain.
+// The shard state of an epoch is in the last block of the previous epoch, which
+// is found by bisecting the blocks by their epoch.
+//
+// The shard states are synced epoch by epoch, from the epoch after the current
+// block or the one after the checkpoint, whichever is later, and every last block
+// is verified against the committee of its epoch, known from the previous one.
+func (ss *snapSyncer) syncShardState(ctx context.Context, pivot *types.Block) error {
+	epoch := pivot.Epoch()
+	if _, err := ss.bc.ReadShardState(epoch); err == nil {
+		return nil
+	}
+	// the first epoch to sync the shard state of, and a block before it
+	current := ss.bc.CurrentBlock()
+	next, lo := new(big.Int).Add(current.Epoch(), common.Big1), current.NumberU64()
+	if cp := ss.checkpoint; cp != nil && cp.Epoch().Cmp(epoch) < 0 {
+		// the checkpoint holds the shard state of the epoch after it
+		if cpNext := new(big.Int).Add(cp.Epoch(), big.NewInt(2)); cpNext.Cmp(next) > 0 {
+			next = cpNext
+		}
+		if cp.Header.ShardID() == ss.bc.ShardID() && cp.NumberU64() > lo {
+			lo = cp.NumberU64()
+		}
+	}
+	for ; next.Cmp(epoch) <= 0; next.Add(next, common.Big1) {
+		if _, err := ss.bc.ReadShardState(next); err == nil {
+			continue
+		}
+		last, err := ss.syncEpochShardState(ctx, next, lo, pivot.NumberU64())
+		if err != nil {
+			return err
+		}
+		lo = last
+	}
+	return nil
+}
+
+// syncEpochShardState fetches the last block before the epoch, between block lo
+// which is before the epoch and block hi which is in it, verifies it against the
+// committee of its epoch and writes the shard state of the epoch from it. It
+// returns the number of the last block.
+func (ss *snapSyncer) syncEpochShardState(ctx context.Context, epoch *big.Int, lo, hi uint64) (uint64, error) {
+	prev := new(big.Int).Sub(epoch, common.Big1)
+	if _, err := ss.bc.ReadShardState(prev); err != nil {
+		return 0, errors.Wrapf(err, "committee of epoch %v unknown", prev)
+	}
+	start := lo
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		b, _, err := ss.fetchBlock(ctx, mid)
+		if err != nil {
+			return 0, err
+		}
+		if b.Epoch().Cmp(epoch) < 0 {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	if lo == start {
+		return 0, errors.Errorf("no shard state for epoch %v", epoch)
+	}
+	last, stid, err := ss.fetchBlock(ctx, lo)
+	if err != nil {
+		return 0, err
+	}
+	if !last.IsLastBlockInEpoch() || last.Epoch().Cmp(prev) != 0 {
+		ss.protocol.StreamFailed(stid, "invalid last block of epoch")
+		return 0, errors.Errorf("block %v is not the last block of epoch %v", lo, prev)
+	}
+	sig, bitmap, err := chain.ParseCommitSigAndBitmap(last.GetCurrentCommitSig())
+	if err != nil {
+		ss.protocol.StreamFailed(stid, "invalid commit sig")
+		return 0, ErrParseCommitSigAndBitmapFail
+	}
+	if err := verifyCommitSig(ss.bc, last.Header(), sig, bitmap); err != nil {
+		ss.protocol.StreamFailed(stid, "invalid commit sig")
+		return 0, err
+	}
+	// the shard states before staking are legacy ones, without their epoch
+	ssNext, err := shard.DecodeWrapper(last.Header().ShardState())
+	if err != nil || (ssNext.Epoch != nil && ssNext.Epoch.Cmp(epoch) != 0) {
+		ss.protocol.StreamFailed(stid, "invalid shard state")
+		return 0, errors.Errorf("invalid shard state for epoch %v in block %v", epoch, lo)
+	}
+	if _, err := ss.bc.WriteShardStateBytes(ss.bc.ChainDb(), epoch, last.Header().ShardState()); err != nil {
+		return 0, err
+	}
+	return lo, nil
+}
+
+// fetchBlock fetches the block of the given number along with its commit signature
+func (ss *snapSyncer) fetchBlock(ctx context.Context, bn uint64) (*types.Block, sttypes.StreamID, error) {
+	for attempt := 0; ; attempt++ {
+		blocks, stid, err := ss.protocol.GetBlocksByNumber(ctx, []uint64{bn})
+		if err == nil && (len(blocks) != 1 || blocks[0] == nil || blocks[0].NumberU64() != bn) {
+			err = errors.Errorf("unexpected block %v response", bn)
+		}
+		if err == nil {
+			return blocks[0], stid, nil
+		}
+		if ctx.Err() != nil {
+			return nil, stid, ctx.Err()
+		}
+		if stid != "" {
+			ss.protocol.StreamFailed(stid, "getBlocksByNumber failed")
+		}
+		if attempt+1 >= SnapSyncMaxAttempts {
+			return nil, stid, err
+		}
+	}
+}
+
+// movePivot moves the pivot to a recent block, if it was not moved since the
+// given generation of the pivot
+func (ss *snapSyncer) movePivot(ctx context.Context, gen int) error {
+	ss.lock.Lock()
+	defer ss.lock.Unlock()
+
+	if ss.gen != gen {
+		return nil
+	}
+	bn, err := ss.estimate(ctx)
+	if err != nil {
+		return err
+	}
+	if bn < SnapSyncPivotOffset || bn-SnapSyncPivotOffset <= ss.pivot.NumberU64() {
+		return ErrSnapSyncStateUnavailable
+	}
+	pivot, err := ss.fetchPivot(ctx, bn-SnapSyncPivotOffset)
+	if err != nil {
+		return err
+	}
+	ss.logger.Info().Uint64("from", ss.pivot.NumberU64()).Uint64("to", pivot.NumberU64()).
+		Msg(WrapStagedSyncMsg("snap sync pivot moved"))
+	ss.pivot = pivot
+	ss.gen++
+	return nil
+}
+
+// request does the state request for the current pivot until it succeeds. The
+// pivot is moved if its state is not served anymore. If skipStale is set, the
+// request for the data of a former pivot returns errStaleState on failure instead
+// of being retried.
+func (ss *snapSyncer) request(ctx context.Context, name string, skipStale bool,
+	do func(root common.Hash, gen int) (sttypes.StreamID, error)) error {
+
+	var failures, unavailable int
+	for {
+		pivot, gen := ss.currentPivot()
+		stid, err := do(pivot.Root(), gen)
+		switch {
+		case err == nil:
+			return nil
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case errors.Is(err, errPivotMoved):
+			return err
+		case errors.Is(err, syncproto.ErrStateUnavailable):
+			unavailable++
+			if unavailable >= SnapSyncUnavailableAttempts {
+				if err := ss.movePivot(ctx, gen); err != nil {
+					return err
+				}
+				unavailable = 0
+			}
+		case skipStale && gen > 0:
+			// the data may have changed since the former pivot
+			return errStaleState
+		default:
+			if stid != "" {
+				ss.protocol.StreamFailed(stid, name+" failed")
+			}
+			failures++
+			if failures >= SnapSyncMaxAttempts {
+				return errors.Wrap(err, name)
+			}
+		}
+	}
+}
+
+// runTasks runs the tasks on concurrent workers, and returns the first error
+func (ss *snapSyncer) runTasks(ctx context.Context, n int, run func(ctx context.Context, i int) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		taskC = make(chan int, n)
+		errC  = make(chan error, ss.concurrency)
+		wg    sync.WaitGroup
+	)
+	for i := 0; i != n; i++ {
+		taskC <- i
+	}
+	close(taskC)
+	for w := 0; w != ss.concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range taskC {
+				if err := run(ctx, i); err != nil {
+					errC <- err
+					cancel()
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errC)
+	return <-errC
+}
+
+// syncAccounts downloads the accounts over concurrent ranges of the hash space
+func (ss *snapSyncer) syncAccounts(ctx context.Context) error {
+	var (
+		origins = make([]common.Hash, 0, SnapSyncAccountChunks)
+		limits  = make([]common.Hash, 0, SnapSyncAccountChunks)
+		step    = new(big.Int).Div(new(big.Int).Add(snapMaxHash.Big(), common.Big1), big.NewInt(int64(SnapSyncAccountChunks)))
+		next    = new(big.Int)
+	)
+	for i := 0; i != SnapSyncAccountChunks; i++ {
+		origins = append(origins, common.BigToHash(next))
+		next = new(big.Int).Add(next, step)
+		if i == SnapSyncAccountChunks-1 {
+			limits = append(limits, snapMaxHash)
+		} else {
+			limits = append(limits, common.BigToHash(new(big.Int).Sub(next, common.Big1)))
+		}
+	}
+	return ss.runTasks(ctx, len(origins), func(ctx context.Context, i int) error {
+		return ss.syncAccountRange(ctx, origins[i], limits[i])
+	})
+}
+
+// syncAccountRange downloads the accounts from origin to limit
+func (ss *snapSyncer) syncAccountRange(ctx context.Context, origin, limit common.Hash) error {
+	for {
+		var (
+			hashes   []common.Hash
+			accounts [][]byte
+			more     bool
+		)
+		err := ss.request(ctx, "getAccountRange", false, func(root common.Hash, gen int) (stid sttypes.StreamID, err error) {
+			hashes, accounts, more, stid, err = ss.protocol.GetAccountRange(ctx, root, origin, limit, SnapSyncResponseBytes)
+			return
+		})
+		if err != nil {
+			return err
+		}
+		var done bool
+		if err := ss.db.Update(ctx, func(tx kv.RwTx) error {
+			for i, hash := range hashes {
+				// the response may hold an account beyond the limit to prove the range
+				if bytes.Compare(hash[:], limit[:]) > 0 {
+					done = true
+					break
+				}
+				if err := tx.Put(SnapAccountsBucket, hash[:], accounts[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		if done || !more || len(hashes) == 0 {
+			return nil
+		}
+		var ok bool
+		if origin, ok = incHash(hashes[len(hashes)-1]); !ok || bytes.Compare(origin[:], limit[:]) > 0 {
+			return nil
+		}
+	}
+}
+
+// syncStorages downloads the storages of the downloaded accounts, once per
+// storage root
+func (ss *snapSyncer) syncStorages(ctx context.Context) error {
+	var (
+		chainDb = ss.bc.ChainDb()
+		seen    = make(map[common.Hash]struct{})
+		tasks   []storageTask
+	)
+	if err := ss.db.View(ctx, func(tx kv.Tx) error {
+		return tx.ForEach(SnapAccountsBucket, nil, func(k, v []byte) error {
+			account, err := snapshot.FullAccount(v)
+			if err != nil {
+				return err
+			}
+			root := common.BytesToHash(account.Root)
+			if root == state.EmptyRootHash {
+				return nil
+			}
+			if _, ok := seen[root]; ok {
+				return nil
+			}
+			seen[root] = struct{}{}
+			if ok, _ := chainDb.Has(root[:]); ok {
+				ss.storages[root] = struct{}{}
+				return nil
+			}
+			tasks = append(tasks, storageTask{account: common.BytesToHash(k), root: root})
+			return nil
+		})
+	}); err != nil {
+		return err
+	}
+	batches := divideCeil(len(tasks), SnapSyncStoragesPerRequest)
+	return ss.runTasks(ctx, batches, func(ctx context.Context, i int) error {
+		end := (i + 1) * SnapSyncStoragesPerRequest
+		if end > len(tasks) {
+			end = len(tasks)
+		}
+		return ss.syncStorageBatch(ctx, tasks[i*SnapSyncStoragesPerRequest:end])
+	})
+}
+
+// syncStorageBatch downloads the storages of the tasks
+func (ss *snapSyncer) syncStorageBatch(ctx context.Context, tasks []storageTask) error {
+	for len(tasks) > 0 {
+		var (
+			accounts = make([]common.Hash, 0, len(tasks))
+			roots    = make([]common.Hash, 0, len(tasks))
+			hashes   [][]common.Hash
+			slots    [][][]byte
+			more     bool
+		)
+		for _, task := range tasks {
+			accounts = append(accounts, task.account)
+			roots = append(roots, task.root)
+		}
+		err := ss.request(ctx, "getStorageRanges", true, func(root common.Hash, gen int) (stid sttypes.StreamID, err error) {
+			hashes, slots, more, stid, err = ss.protocol.GetStorageRanges(ctx, root, accounts, roots, nil, nil, SnapSyncResponseBytes)
+			return
+		})
+		if errors.Is(err, errStaleState) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		for i := range hashes {
+			if err := ss.storeSlots(ctx, tasks[i].root, hashes[i], slots[i]); err != nil {
+				return err
+			}
+			if i < len(hashes)-1 || !more {
+				ss.storageDone(tasks[i].root)
+				continue
+			}
+			// the last storage is too large for a single response
+			var (
+				origin common.Hash
+				ok     = len(hashes[i]) > 0
+			)
+			if ok {
+				origin, ok = incHash(hashes[i][len(hashes[i])-1])
+			}
+			if !ok {
+				ss.storageDone(tasks[i].root)
+				continue
+			}
+			if err := ss.syncStorageRange(ctx, tasks[i], origin); err != nil {
+				return err
+			}
+		}
+		tasks = tasks[len(hashes):]
+	}
+	return nil
+}
+
+// syncStorageRange downloads the slots of the storage from origin
+func (ss *snapSyncer) syncStorageRange(ctx context.Context, task storageTask, origin common.Hash) error {
+	for {
+		var (
+			hashes [][]common.Hash
+			slots  [][][]byte
+			more   bool
+		)
+		err := ss.request(ctx, "getStorageRanges", true, func(root common.Hash, gen int) (stid sttypes.StreamID, err error) {
+			hashes, slots, more, stid, err = ss.protocol.GetStorageRanges(ctx, root, []common.Hash{task.account},
+				[]common.Hash{task.root}, origin[:], snapMaxHash[:], SnapSyncResponseBytes)
+			return
+		})
+		if errors.Is(err, errStaleState) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := ss.storeSlots(ctx, task.root, hashes[0], slots[0]); err != nil {
+			return err
+		}
+		var ok bool
+		if more && len(hashes[0]) > 0 {
+			origin, ok = incHash(hashes[0][len(hashes[0])-1])
+		}
+		if !ok {
+			ss.storageDone(task.root)
+			return nil
+		}
+	}
+}
+
+// storeSlots stores the slots of the storage, keyed by the storage root and the slot hash
+func (ss *snapSyncer) storeSlots(ctx context.Context, root common.Hash, hashes []common.Hash, slots [][]byte) error {
+	return ss.db.Update(ctx, func(tx kv.RwTx) error {
+		for i, hash := range hashes {
+			if err := tx.Put(SnapStoragesBucket, append(root[:], hash[:]...), slots[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (ss *snapSyncer) storageDone(root common.Hash) {
+	ss.storagesLock.Lock()
+	defer ss.storagesLock.Unlock()
+
+	ss.storages[root] = struct{}{}
+}
+
+// syncByteCodes downloads the byte codes of the downloaded accounts. The code of
+// a validator is told apart by the validator flag in the storage of the account.
+func (ss *snapSyncer) syncByteCodes(ctx context.Context) error {
+	var (
+		chainDb   = ss.bc.ChainDb()
+		validator = make(map[common.Hash]bool)
+		hashes    []common.Hash
+	)
+	if err := ss.db.View(ctx, func(tx kv.Tx) error {
+		return tx.ForEach(SnapAccountsBucket, nil, func(k, v []byte) error {
+			account, err := snapshot.FullAccount(v)
+			if err != nil {
+				return err
+			}
+			codeHash := common.BytesToHash(account.CodeHash)
+			if codeHash == state.EmptyCodeHash {
+				return nil
+			}
+			if _, ok := validator[codeHash]; ok {
+				return nil
+			}
+			if rawdb.HasCodeWithPrefix(chainDb, codeHash) || rawdb.HasValidatorCodeWithPrefix(chainDb, codeHash) {
+				return nil
+			}
+			root := common.BytesToHash(account.Root)
+			isValidator, err := tx.Has(SnapStoragesBucket, append(root[:], validatorSlot[:]...))
+			if err != nil {
+				return err
+			}
+			validator[codeHash] = isValidator
+			hashes = append(hashes, codeHash)
+			return nil
+		})
+	}); err != nil {
+		return err
+	}
+	batches := divideCeil(len(hashes), SnapSyncCodesPerRequest)
+	return ss.runTasks(ctx, batches, func(ctx context.Context, i int) error {
+		end := (i + 1) * SnapSyncCodesPerRequest
+		if end > len(hashes) {
+			end = len(hashes)
+		}
+		return ss.syncByteCodesBatch(ctx, hashes[i*SnapSyncCodesPerRequest:end], validator)
+	})
+}
+
+// syncByteCodesBatch downloads the byte codes of the hashes. The codes which
+// cannot be downloaded are left to the healing.
+func (ss *snapSyncer) syncByteCodesBatch(ctx context.Context, hashes []common.Hash, validator map[common.Hash]bool) error {
+	for failures := 0; len(hashes) > 0 && failures < SnapSyncMaxAttempts; {
+		var codes [][]byte
+		err := ss.request(ctx, "getByteCodes", false, func(root common.Hash, gen int) (stid sttypes.StreamID, err error) {
+			codes, stid, err = ss.protocol.GetByteCodes(ctx, hashes, SnapSyncResponseBytes)
+			return
+		})
+		if err != nil {
+			return err
+		}
+		var (
+			batch   = ss.bc.ChainDb().NewBatch()
+			missing []common.Hash
+		)
+		for i, code := range codes {
+			if code == nil {
+				missing = append(missing, hashes[i])
+				continue
+			}
+			if validator[hashes[i]] {
+				rawdb.WriteValidatorCode(batch, hashes[i], code)
+			} else {
+				rawdb.WriteCode(batch, hashes[i], code)
+			}
+		}
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		if len(missing) == len(hashes) {
+			failures++
+		}
+		hashes = missing
+	}
+	return nil
+}
+
+// buildTries builds the storage and account tries from the downloaded leaves into
+// the chain database. The accounts whose storage or code is missing are left out
+// of the account trie, so that the healing fetches them.
+func (ss *snapSyncer) buildTries(ctx context.Context) error {
+	var (
+		chainDb  = ss.bc.ChainDb()
+		batch    = chainDb.NewBatch()
+		writeErr error
+	)
+	writeFn := func(owner common.Hash, path []byte, hash common.Hash, blob []byte) {
+		rawdb.WriteTrieNode(batch, owner, path, hash, blob, rawdb.HashScheme)
+		if batch.ValueSize() > ethdb.IdealBatchSize {
+			if err := batch.Write(); err != nil && writeErr == nil {
+				writeErr = err
+			}
+			batch.Reset()
+		}
+	}
+	// the slots are sorted by storage root then by slot hash
+	var (
+		current common.Hash
+		st      *trie.StackTrie
+	)
+	commitStorage := func() error {
+		if st == nil {
+			return nil
+		}
+		root, err := st.Commit()
+		if err != nil {
+			return err
+		}
+		if root != current {
+			// not fully downloaded, left to the healing
+			delete(ss.storages, current)
+		}
+		return nil
+	}
+	if err := ss.db.View(ctx, func(tx kv.Tx) error {
+		return tx.ForEach(SnapStoragesBucket, nil, func(k, v []byte) error {
+			root := common.BytesToHash(k[:common.HashLength])
+			if st == nil || root != current {
+				if err := commitStorage(); err != nil {
+					return err
+				}
+				current, st = root, trie.NewStackTrie(writeFn)
+			}
+			return st.TryUpdate(common.CopyBytes(k[common.HashLength:]), common.CopyBytes(v))
+		})
+	}); err != nil {
+		return err
+	}
+	if err := commitStorage(); err != nil {
+		return err
+	}
+
+	st = trie.NewStackTrie(writeFn)
+	if err := ss.db.View(ctx, func(tx kv.Tx) error {
+		return tx.ForEach(SnapAccountsBucket, nil, func(k, v []byte) error {
+			account, err := snapshot.FullAccount(v)
+			if err != nil {
+				return err
+			}
+			if root := common.BytesToHash(account.Root); root != state.EmptyRootHash {
+				if _, ok := ss.storages[root]; !ok {
+					return nil
+				}
+			}
+			if codeHash := common.BytesToHash(account.CodeHash); codeHash != state.EmptyCodeHash {
+				if !rawdb.HasCode(chainDb, codeHash) && !rawdb.HasValidatorCode(chainDb, codeHash) {
+					return nil
+				}
+			}
+			full, err := snapshot.FullAccountRLP(v)
+			if err != nil {
+				return err
+			}
+			return st.TryUpdate(common.CopyBytes(k), full)
+		})
+	}); err != nil {
+		return err
+	}
+	root, err := st.Commit()
+	if err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	if writeErr != nil {
+		return writeErr
+	}
+	pivot, _ := ss.currentPivot()
+	ss.logger.Info().Bool("complete", root == pivot.Root()).
+		Msg(WrapStagedSyncMsg("snap sync tries built"))
+	return nil
+}
+
+// heal downloads the trie nodes and the byte codes missing from the state of the
+// pivot, until the state is complete
+func (ss *snapSyncer) heal(ctx context.Context) error {
+	for {
+		pivot, gen := ss.currentPivot()
+		sched := state.NewStateSync(pivot.Root(), ss.bc.ChainDb(), nil, rawdb.HashScheme)
+		err := ss.healState(ctx, sched, gen)
+		if errors.Is(err, errPivotMoved) {
+			// heal the state of the new pivot
+			continue
+		}
+		return err
+	}
+}
+
+// healState downloads the state entries of the scheduler, until there is none or
+// the pivot moves
+func (ss *snapSyncer) healState(ctx context.Context, sched *trie.Sync, gen int) error {
+	batch := ss.bc.ChainDb().NewBatch()
+	for sched.Pending() > 0 {
+		paths, nodeHashes, codeHashes := sched.Missing(SnapSyncNodesPerRequest)
+		if len(paths) == 0 && len(codeHashes) == 0 {
+			return errors.New("no state entry to heal")
+		}
+		err := ss.healTrieNodes(ctx, sched, gen, paths, nodeHashes)
+		if err == nil {
+			err = ss.healByteCodes(ctx, sched, gen, codeHashes)
+		}
+		if errors.Is(err, errPivotMoved) {
+			// keep the entries committed so far, the new pivot shares most of them
+			if werr := batch.Write(); werr != nil {
+				return werr
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if err := sched.Commit(batch); err != nil {
+			return err
+		}
+		if batch.ValueSize() > ethdb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+	}
+	if err := sched.Commit(batch); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// healTrieNodes downloads the trie nodes of the paths and delivers them to the scheduler
+func (ss *snapSyncer) healTrieNodes(ctx context.Context, sched *trie.Sync, gen int, paths []string, hashes []common.Hash) error {
+	for failures := 0; len(paths) > 0; {
+		syncPaths := make([][][]byte, 0, len(paths))
+		for _, path := range paths {
+			syncPaths = append(syncPaths, trie.NewSyncPath([]byte(path)))
+		}
+		var nodes [][]byte
+		err := ss.request(ctx, "getTrieNodes", false, func(root common.Hash, current int) (stid sttypes.StreamID, err error) {
+			if current != gen {
+				return "", errPivotMoved
+			}
+			nodes, stid, err = ss.protocol.GetTrieNodes(ctx, root, syncPaths, hashes, SnapSyncResponseBytes)
+			return
+		})
+		if err != nil {
+			return err
+		}
+		var (
+			missingPaths  []string
+			missingHashes []common.Hash
+		)
+		for i, node := range nodes {
+			if node == nil {
+				missingPaths = append(missingPaths, paths[i])
+				missingHashes = append(missingHashes, hashes[i])
+				continue
+			}
+			if err := sched.ProcessNode(trie.NodeSyncResult{Path: paths[i], Data: node}); err != nil {
+				return err
+			}
+		}
+		if len(missingPaths) == len(paths) {
+			if failures++; failures >= SnapSyncMaxAttempts {
+				return errors.New("trie nodes not delivered")
+			}
+		}
+		paths, hashes = missingPaths, missingHashes
+	}
+	return nil
+}
+
+// healByteCodes downloads the byte codes of the hashes and delivers them to the
+// scheduler. The scheduler writes them as contract codes, which are read as
+// validator codes as well.
+func (ss *snapSyncer) healByteCodes(ctx context.Context, sched *trie.Sync, gen int, hashes []common.Hash) error {
+	for failures := 0; len(hashes) > 0; {
+		var codes [][]byte
+		err := ss.request(ctx, "getByteCodes", false, func(root common.Hash, current int) (stid sttypes.StreamID, err error) {
+			if current != gen {
+				return "", errPivotMoved
+			}
+			codes, stid, err = ss.protocol.GetByteCodes(ctx, hashes, SnapSyncResponseBytes)
+			return
+		})
+		if err != nil {
+			return err
+		}
+		var missing []common.Hash
+		for i, code := range codes {
+			if code == nil {
+				missing = append(missing, hashes[i])
+				continue
+			}
+			if err := sched.ProcessCode(trie.CodeSyncResult{Hash: hashes[i], Data: code}); err != nil {
+				return err
+			}
+		}
+		if len(missing) == len(hashes) {
+			if failures++; failures >= SnapSyncMaxAttempts {
+				return errors.New("byte codes not delivered")
+			}
+		}
+		hashes = missing
+	}
+	return nil
+}
+
+// commitPivot writes the pivot block as the head of the chain, and rebuilds the
+// state snapshot from its state
+func (ss *snapSyncer) commitPivot(pivot *types.Block) error {
+	if pivot.IsLastBlockInEpoch() {
+		nextEpoch := new(big.Int).Add(pivot.Epoch(), common.Big1)
+		if _, err := ss.bc.WriteShardStateBytes(ss.bc.ChainDb(), nextEpoch, pivot.Header().ShardState()); err != nil {
+			return err
+		}
+	}
+	if err := ss.bc.WriteBlockWithoutState(pivot, new(big.Int)); err != nil {
+		return err
+	}
+	if err := ss.bc.WriteCommitSig(pivot.NumberU64(), pivot.GetCurrentCommitSig()); err != nil {
+		return err
+	}
+	if err := ss.bc.WriteHeadBlock(pivot); err != nil {
+		return err
+	}
+	if snaps := ss.bc.GetSnapshotTrie(); snaps != nil {
+		snaps.Rebuild(pivot.Root())
+	}
+	return nil
+}
+
+// incHash returns the hash following h, and false if h is the last hash
+func incHash(h common.Hash) (common.Hash, bool) {
+	for i := len(h) - 1; i >= 0; i-- {
+		h[i]++
+		if h[i] != 0 {
+			return h, true
+		}
+	}
+	return h, false
+}
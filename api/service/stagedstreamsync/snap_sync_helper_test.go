@@ -0,0 +1,687 @@
+package stagedstreamsync
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	bls_core "github.com/harmony-one/bls/ffi/go/bls"
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/consensus/signature"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/state/snapshot"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/crypto/bls"
+	"github.com/harmony-one/harmony/internal/params"
+	syncproto "github.com/harmony-one/harmony/p2p/stream/protocols/sync"
+	sttypes "github.com/harmony-one/harmony/p2p/stream/types"
+	"github.com/harmony-one/harmony/shard"
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/erigon-lib/kv/memdb"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+const (
+	testSnapAccounts            = 24
+	testSnapLargeSlots          = 50
+	testSnapAccountsPerResponse = 3
+	testSnapSlotsPerResponse    = 16
+
+	testSnapStreamID sttypes.StreamID = "snap"
+)
+
+var (
+	// the pivot epoch is before staking, so that the committee votes uniformly
+	testSnapConfig = params.LocalnetChainConfig
+	testSnapEpoch  = big.NewInt(1)
+
+	testSnapCode          = common.FromHex("6001600055")
+	testSnapValidatorCode = []byte("validator wrapper")
+
+	// testSnapContract and testSnapTwin share their code and storage
+	testSnapContract  = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	testSnapTwin      = common.HexToAddress("0x2000000000000000000000000000000000000002")
+	testSnapLarge     = common.HexToAddress("0x3000000000000000000000000000000000000003")
+	testSnapValidator = common.HexToAddress("0x4000000000000000000000000000000000000004")
+)
+
+func TestSnapSyncer_Sync(t *testing.T) {
+	env := makeTestSnapEnv(t)
+
+	pivot, err := env.syncer.sync(context.Background(), 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pivot.NumberU64() != 100 || env.chain.head != pivot {
+		t.Fatalf("unexpected pivot %v", pivot.NumberU64())
+	}
+	if !bytes.Equal(env.chain.commitSigs[100], pivot.GetCurrentCommitSig()) {
+		t.Errorf("pivot commit sig not written")
+	}
+	checkTestSnapState(t, env.protocol.db, env.chain.db, env.roots[100])
+
+	// the validator code is stored apart from the contract codes
+	codeHash := crypto.Keccak256Hash(testSnapValidatorCode)
+	if len(rawdb.ReadValidatorCodeWithPrefix(env.chain.db, codeHash)) == 0 ||
+		len(rawdb.ReadCodeWithPrefix(env.chain.db, codeHash)) != 0 {
+		t.Errorf("validator code not stored as validator code")
+	}
+	if len(env.protocol.failed) != 0 {
+		t.Errorf("unexpected stream failures %v", env.protocol.failed)
+	}
+}
+
+func TestSnapSyncer_SyncMovedPivot(t *testing.T) {
+	env := makeTestSnapEnv(t)
+	// the state of block 100 is not served anymore after a few requests
+	env.protocol.expire[env.roots[100]] = 5
+	env.estimate = 200 + SnapSyncPivotOffset
+
+	pivot, err := env.syncer.sync(context.Background(), 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pivot.NumberU64() != 200 || env.chain.head != pivot {
+		t.Fatalf("unexpected pivot %v", pivot.NumberU64())
+	}
+	checkTestSnapState(t, env.protocol.db, env.chain.db, env.roots[200])
+}
+
+func TestSnapSyncer_fetchPivot(t *testing.T) {
+	env := makeTestSnapEnv(t)
+	// block 300 is signed by another committee
+	env.protocol.blocks[300] = makeTestSnapBlock(makeTestSnapCommittee(4), 300, env.roots[200])
+	// block 400 does not match its transaction root
+	header := env.blocks[200].Header().With().Number(big.NewInt(400)).TxHash(common.Hash{0x01}).Header()
+	env.protocol.blocks[400] = types.NewBlockWithHeader(header)
+
+	tests := []struct {
+		bn     uint64
+		expErr string
+	}{
+		{bn: 100},
+		{bn: 300, expErr: "verify"},
+		{bn: 400, expErr: "transaction root"},
+	}
+	for i, test := range tests {
+		env.protocol.failed = nil
+		pivot, err := env.syncer.fetchPivot(context.Background(), test.bn)
+		if test.expErr == "" {
+			if err != nil || pivot.NumberU64() != test.bn {
+				t.Errorf("Test %v: unexpected pivot %v", i, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), test.expErr) {
+			t.Errorf("Test %v: unexpected error %v / %v", i, err, test.expErr)
+		}
+		if len(env.protocol.failed) == 0 {
+			t.Errorf("Test %v: stream of the invalid pivot not failed", i)
+		}
+	}
+}
+
+func TestSnapSyncer_syncShardState(t *testing.T) {
+	tests := []struct {
+		// the last block of epoch 0 is signed by another committee
+		forged bool
+		// the chain knows the committee of epoch 0
+		known  bool
+		expErr string
+	}{
+		{known: true},
+		{known: true, forged: true, expErr: "verify"},
+		{expErr: "unknown"},
+	}
+	for i, test := range tests {
+		env := makeTestSnapEnv(t)
+		committee := makeTestSnapCommittee(4)
+		committee.state.Epoch = common.Big0
+		signer := committee
+		if test.forged {
+			signer = makeTestSnapCommittee(4)
+			signer.state.Epoch = common.Big0
+		}
+		pivotState := env.chain.shardStates[testSnapEpoch.Uint64()]
+		shardState, err := shard.EncodeWrapper(*pivotState, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// block 50 is the last block of epoch 0, the pivot 100 is in epoch 1
+		for bn := uint64(1); bn < 100; bn++ {
+			switch {
+			case bn < 50:
+				env.protocol.blocks[bn] = makeTestSnapEpochBlock(committee, bn, common.Big0, nil)
+			case bn == 50:
+				env.protocol.blocks[bn] = makeTestSnapEpochBlock(signer, bn, common.Big0, shardState)
+			default:
+				env.protocol.blocks[bn] = makeTestSnapEpochBlock(committee, bn, testSnapEpoch, nil)
+			}
+		}
+		env.chain.head = makeTestSnapEpochBlock(committee, 0, common.Big0, nil)
+		env.chain.shardStates = make(map[uint64]*shard.State)
+		if test.known {
+			env.chain.shardStates[0] = committee.state
+		}
+
+		err = env.syncer.syncShardState(context.Background(), env.blocks[100])
+		if test.expErr == "" {
+			if err != nil {
+				t.Errorf("Test %v: unexpected error %v", i, err)
+			}
+			// the shard state before staking is a legacy one
+			ss, err := env.chain.ReadShardState(testSnapEpoch)
+			if err != nil || len(ss.Shards) != 1 || len(ss.Shards[0].Slots) != len(pivotState.Shards[0].Slots) {
+				t.Errorf("Test %v: shard state of the pivot epoch not written: %v", i, err)
+			}
+			if len(env.protocol.failed) != 0 {
+				t.Errorf("Test %v: unexpected stream failures %v", i, env.protocol.failed)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), test.expErr) {
+			t.Errorf("Test %v: unexpected error %v / %v", i, err, test.expErr)
+		}
+		if _, err := env.chain.ReadShardState(testSnapEpoch); err == nil {
+			t.Errorf("Test %v: unverified shard state written", i)
+		}
+	}
+}
+
+func TestSnapSyncer_movePivot(t *testing.T) {
+	env := makeTestSnapEnv(t)
+	ctx := context.Background()
+	env.syncer.pivot = env.blocks[100]
+
+	// no block is recent enough to move to
+	env.estimate = 100 + SnapSyncPivotOffset
+	if err := env.syncer.movePivot(ctx, 0); err != ErrSnapSyncStateUnavailable {
+		t.Fatalf("unexpected error %v", err)
+	}
+	env.estimate = 200 + SnapSyncPivotOffset
+	if err := env.syncer.movePivot(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+	if pivot, gen := env.syncer.currentPivot(); pivot.NumberU64() != 200 || gen != 1 {
+		t.Fatalf("unexpected pivot %v at generation %v", pivot.NumberU64(), gen)
+	}
+	// the requests made for the former pivot don't move it again
+	env.estimate = 300 + SnapSyncPivotOffset
+	if err := env.syncer.movePivot(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+	if pivot, gen := env.syncer.currentPivot(); pivot.NumberU64() != 200 || gen != 1 {
+		t.Fatalf("unexpected pivot %v at generation %v", pivot.NumberU64(), gen)
+	}
+}
+
+func TestSnapSyncer_syncAccountRange(t *testing.T) {
+	env := makeTestSnapEnv(t)
+	ctx := context.Background()
+	env.syncer.pivot = env.blocks[100]
+
+	tr, err := trie.New(trie.StateTrieID(env.roots[100]), env.protocol.triedb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var hashes []common.Hash
+	it := trie.NewIterator(tr.NodeIterator(nil))
+	for it.Next() {
+		hashes = append(hashes, common.BytesToHash(it.Key))
+	}
+	// the range ends in the middle of the accounts, the responses prove it with
+	// the account following the limit
+	limit := hashes[len(hashes)/2]
+	if err := env.syncer.syncAccountRange(ctx, common.Hash{}, limit); err != nil {
+		t.Fatal(err)
+	}
+	var stored []common.Hash
+	if err := env.syncer.db.View(ctx, func(tx kv.Tx) error {
+		return tx.ForEach(SnapAccountsBucket, nil, func(k, v []byte) error {
+			stored = append(stored, common.BytesToHash(k))
+			return nil
+		})
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if exp := hashes[:len(hashes)/2+1]; len(stored) != len(exp) {
+		t.Fatalf("unexpected number of accounts %v / %v", len(stored), len(exp))
+	}
+	for i, hash := range stored {
+		if hash != hashes[i] {
+			t.Errorf("unexpected account %v: %x / %x", i, hash, hashes[i])
+		}
+	}
+}
+
+func TestSnapSyncer_heal(t *testing.T) {
+	env := makeTestSnapEnv(t)
+	env.syncer.pivot = env.blocks[100]
+
+	// nothing is downloaded, the whole state is healed
+	if err := env.syncer.heal(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	checkTestSnapState(t, env.protocol.db, env.chain.db, env.roots[100])
+}
+
+// testSnapEnv holds the states of blocks 100 and 200, served to the snap syncer
+// of an empty chain
+type testSnapEnv struct {
+	roots    map[uint64]common.Hash
+	blocks   map[uint64]*types.Block
+	estimate uint64
+
+	protocol *testSnapProtocol
+	chain    *testSnapChain
+	syncer   *snapSyncer
+}
+
+func makeTestSnapEnv(t *testing.T) *testSnapEnv {
+	var (
+		srcDb     = rawdb.NewMemoryDatabase()
+		srcState  = state.NewDatabase(srcDb)
+		committee = makeTestSnapCommittee(4)
+		env       = &testSnapEnv{
+			roots:  make(map[uint64]common.Hash),
+			blocks: make(map[uint64]*types.Block),
+		}
+	)
+	env.roots[100] = commitTestSnapState(t, srcState, common.Hash{}, fillTestSnapState)
+	env.roots[200] = commitTestSnapState(t, srcState, env.roots[100], editTestSnapState)
+
+	blocks := make(map[uint64]*types.Block)
+	for bn, root := range env.roots {
+		env.blocks[bn] = makeTestSnapBlock(committee, bn, root)
+		blocks[bn] = env.blocks[bn]
+	}
+	env.protocol = &testSnapProtocol{
+		db:     srcDb,
+		triedb: srcState.TrieDB(),
+		blocks: blocks,
+		expire: make(map[common.Hash]int),
+	}
+	env.chain = &testSnapChain{
+		db:          rawdb.NewMemoryDatabase(),
+		shardStates: map[uint64]*shard.State{testSnapEpoch.Uint64(): committee.state},
+		commitSigs:  make(map[uint64][]byte),
+	}
+
+	db := memdb.New(t.TempDir())
+	t.Cleanup(db.Close)
+	if err := initDB(context.Background(), db, nil, 0); err != nil {
+		t.Fatal(err)
+	}
+	estimate := func(ctx context.Context) (uint64, error) {
+		return env.estimate, nil
+	}
+	env.syncer = newSnapSyncer(env.chain, db, env.protocol, 4, estimate, nil, zerolog.Nop())
+	return env
+}
+
+func commitTestSnapState(t *testing.T, db state.Database, parent common.Hash, edit func(sdb *state.DB)) common.Hash {
+	sdb, err := state.New(parent, db, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edit(sdb)
+	root, err := sdb.Commit(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.TrieDB().Commit(root, false); err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+func fillTestSnapState(sdb *state.DB) {
+	for i := 1; i <= testSnapAccounts; i++ {
+		addr := common.BigToAddress(big.NewInt(int64(i)))
+		sdb.SetNonce(addr, uint64(i))
+		sdb.AddBalance(addr, big.NewInt(int64(i)*1000))
+	}
+	for _, addr := range []common.Address{testSnapContract, testSnapTwin} {
+		sdb.SetCode(addr, testSnapCode, false)
+		for i := int64(1); i <= 8; i++ {
+			sdb.SetState(addr, common.BigToHash(big.NewInt(i)), common.BigToHash(big.NewInt(i*7)))
+		}
+	}
+	// the storage does not fit in a single response
+	sdb.SetCode(testSnapLarge, testSnapCode, false)
+	for i := int64(1); i <= testSnapLargeSlots; i++ {
+		sdb.SetState(testSnapLarge, common.BigToHash(big.NewInt(i)), common.BigToHash(big.NewInt(i*11)))
+	}
+	sdb.SetCode(testSnapValidator, testSnapValidatorCode, true)
+	sdb.SetValidatorFlag(testSnapValidator)
+}
+
+func editTestSnapState(sdb *state.DB) {
+	for i := 1; i <= 6; i++ {
+		sdb.AddBalance(common.BigToAddress(big.NewInt(int64(i))), big.NewInt(1))
+	}
+	sdb.SetState(testSnapContract, common.BigToHash(big.NewInt(9)), common.BigToHash(big.NewInt(63)))
+	sdb.SetState(testSnapLarge, common.BigToHash(big.NewInt(1)), common.Hash{})
+	sdb.AddBalance(common.BigToAddress(big.NewInt(testSnapAccounts+1)), big.NewInt(1))
+}
+
+func testSnapAddresses() []common.Address {
+	addrs := []common.Address{testSnapContract, testSnapTwin, testSnapLarge, testSnapValidator}
+	for i := 1; i <= testSnapAccounts+1; i++ {
+		addrs = append(addrs, common.BigToAddress(big.NewInt(int64(i))))
+	}
+	return addrs
+}
+
+// checkTestSnapState checks that the state of the root is complete in dst, and
+// holds the accounts of src
+func checkTestSnapState(t *testing.T, src, dst ethdb.Database, root common.Hash) {
+	srcState, err := state.New(root, state.NewDatabase(src), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dstState, err := state.New(root, state.NewDatabase(dst), nil)
+	if err != nil {
+		t.Fatalf("state root missing: %v", err)
+	}
+	it := state.NewNodeIterator(dstState)
+	for it.Next() {
+	}
+	if it.Error != nil {
+		t.Fatalf("state incomplete: %v", it.Error)
+	}
+	for _, addr := range testSnapAddresses() {
+		if srcState.GetBalance(addr).Cmp(dstState.GetBalance(addr)) != 0 ||
+			srcState.GetNonce(addr) != dstState.GetNonce(addr) ||
+			!bytes.Equal(srcState.GetCode(addr), dstState.GetCode(addr)) {
+			t.Errorf("unexpected account %x", addr)
+		}
+	}
+	for i := int64(1); i <= testSnapLargeSlots; i++ {
+		key := common.BigToHash(big.NewInt(i))
+		if srcState.GetState(testSnapLarge, key) != dstState.GetState(testSnapLarge, key) {
+			t.Errorf("unexpected slot %v", i)
+		}
+	}
+	if err := dstState.Error(); err != nil {
+		t.Errorf("state read failed: %v", err)
+	}
+}
+
+// testSnapCommittee is the committee of the pivot epoch
+type testSnapCommittee struct {
+	keys  []*bls_core.SecretKey
+	state *shard.State
+}
+
+func makeTestSnapCommittee(size int) *testSnapCommittee {
+	var (
+		keys      = make([]*bls_core.SecretKey, 0, size)
+		committee = shard.Committee{ShardID: 0}
+	)
+	for i := 0; i != size; i++ {
+		key := bls.RandPrivateKey()
+		var pub bls.SerializedPublicKey
+		copy(pub[:], key.GetPublicKey().Serialize())
+		keys = append(keys, key)
+		committee.Slots = append(committee.Slots, shard.Slot{
+			EcdsaAddress: common.BigToAddress(big.NewInt(int64(i))),
+			BLSPublicKey: pub,
+		})
+	}
+	return &testSnapCommittee{
+		keys:  keys,
+		state: &shard.State{Epoch: testSnapEpoch, Shards: []shard.Committee{committee}},
+	}
+}
+
+// sign returns the commit signature and bitmap of the block signed by the whole committee
+func (c *testSnapCommittee) sign(block *types.Block) []byte {
+	pubs, err := c.state.Shards[0].BLSPublicKeys()
+	if err != nil {
+		panic(err)
+	}
+	var (
+		mask    = bls.NewMask(pubs)
+		sigs    = make([]*bls_core.Sign, 0, len(c.keys))
+		payload = signature.ConstructCommitPayload(testSnapConfig, block.Epoch(), block.Hash(),
+			block.NumberU64(), block.Header().ViewID().Uint64())
+	)
+	for i, key := range c.keys {
+		sigs = append(sigs, key.SignHash(payload))
+		if err := mask.SetKey(pubs[i].Bytes, true); err != nil {
+			panic(err)
+		}
+	}
+	return append(bls.AggregateSig(sigs).Serialize(), mask.Bitmap...)
+}
+
+func makeTestSnapBlock(c *testSnapCommittee, bn uint64, root common.Hash) *types.Block {
+	header := blockfactory.NewTestHeader().With().
+		Number(new(big.Int).SetUint64(bn)).
+		Epoch(testSnapEpoch).
+		ViewID(new(big.Int).SetUint64(bn)).
+		ShardID(0).
+		Root(root).
+		Header()
+	block := types.NewBlock(header, nil, nil, nil, nil, nil)
+	block.SetCurrentCommitSig(c.sign(block))
+	return block
+}
+
+// makeTestSnapEpochBlock makes a block of the epoch signed by the committee, the
+// last block of the epoch holds the shard state of the next one
+func makeTestSnapEpochBlock(c *testSnapCommittee, bn uint64, epoch *big.Int, shardState []byte) *types.Block {
+	header := blockfactory.NewTestHeader().With().
+		Number(new(big.Int).SetUint64(bn)).
+		Epoch(epoch).
+		ViewID(new(big.Int).SetUint64(bn)).
+		ShardID(0).
+		ShardState(shardState).
+		Header()
+	block := types.NewBlock(header, nil, nil, nil, nil, nil)
+	block.SetCurrentCommitSig(c.sign(block))
+	return block
+}
+
+// testSnapProtocol serves the states of its database and its blocks to the snap
+// syncer, the way the nodes do
+type testSnapProtocol struct {
+	syncProtocol
+
+	db     ethdb.Database
+	triedb *trie.Database
+	blocks map[uint64]*types.Block
+
+	// expire is the number of state requests served for a root, after which the
+	// state of the root is not served anymore
+	expire map[common.Hash]int
+	failed []string
+	lock   sync.Mutex
+}
+
+// serve returns whether the state of the root is served, and counts the request
+func (p *testSnapProtocol) serve(root common.Hash) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	n, ok := p.expire[root]
+	if !ok {
+		return true
+	}
+	if n == 0 {
+		return false
+	}
+	p.expire[root] = n - 1
+	return true
+}
+
+func (p *testSnapProtocol) GetBlocksByNumber(ctx context.Context, bns []uint64, opts ...syncproto.Option) ([]*types.Block, sttypes.StreamID, error) {
+	blocks := make([]*types.Block, 0, len(bns))
+	for _, bn := range bns {
+		blocks = append(blocks, p.blocks[bn])
+	}
+	return blocks, testSnapStreamID, nil
+}
+
+func (p *testSnapProtocol) GetAccountRange(ctx context.Context, root, origin, limit common.Hash, bytes uint64, opts ...syncproto.Option) ([]common.Hash, [][]byte, bool, sttypes.StreamID, error) {
+	tr, err := trie.New(trie.StateTrieID(root), p.triedb)
+	if err != nil || !p.serve(root) {
+		return nil, nil, false, testSnapStreamID, syncproto.ErrStateUnavailable
+	}
+	var (
+		hashes   []common.Hash
+		accounts [][]byte
+		it       = trie.NewIterator(tr.NodeIterator(origin[:]))
+	)
+	for it.Next() {
+		if len(hashes) == testSnapAccountsPerResponse {
+			return hashes, accounts, true, testSnapStreamID, nil
+		}
+		var account snapshot.Account
+		if err := rlp.DecodeBytes(it.Value, &account); err != nil {
+			return nil, nil, false, testSnapStreamID, err
+		}
+		hash := common.BytesToHash(it.Key)
+		hashes = append(hashes, hash)
+		accounts = append(accounts, snapshot.SlimAccountRLP(account.Nonce, account.Balance,
+			common.BytesToHash(account.Root), account.CodeHash))
+		// the account reaching the limit ends the range
+		if hash.Big().Cmp(limit.Big()) >= 0 {
+			return hashes, accounts, it.Next(), testSnapStreamID, nil
+		}
+	}
+	return hashes, accounts, false, testSnapStreamID, it.Err
+}
+
+// GetStorageRanges serves the storages by their roots, the limit is ignored
+func (p *testSnapProtocol) GetStorageRanges(ctx context.Context, root common.Hash, accounts []common.Hash, roots []common.Hash, origin, limit []byte, bytes uint64, opts ...syncproto.Option) ([][]common.Hash, [][][]byte, bool, sttypes.StreamID, error) {
+	if _, err := trie.New(trie.StateTrieID(root), p.triedb); err != nil || !p.serve(root) {
+		return nil, nil, false, testSnapStreamID, syncproto.ErrStateUnavailable
+	}
+	var (
+		hashes [][]common.Hash
+		slots  [][][]byte
+		n      int
+	)
+	for i, account := range accounts {
+		tr, err := trie.New(trie.StorageTrieID(root, account, roots[i]), p.triedb)
+		if err != nil {
+			return nil, nil, false, testSnapStreamID, err
+		}
+		var start []byte
+		if i == 0 {
+			start = origin
+		}
+		var (
+			hs []common.Hash
+			vs [][]byte
+			it = trie.NewIterator(tr.NodeIterator(start))
+		)
+		for it.Next() {
+			if n == testSnapSlotsPerResponse {
+				// the rest of the storage is in the next responses
+				return append(hashes, hs), append(slots, vs), true, testSnapStreamID, nil
+			}
+			hs = append(hs, common.BytesToHash(it.Key))
+			vs = append(vs, common.CopyBytes(it.Value))
+			n++
+		}
+		hashes, slots = append(hashes, hs), append(slots, vs)
+		if n == testSnapSlotsPerResponse {
+			break
+		}
+	}
+	return hashes, slots, false, testSnapStreamID, nil
+}
+
+func (p *testSnapProtocol) GetByteCodes(ctx context.Context, hs []common.Hash, bytes uint64, opts ...syncproto.Option) ([][]byte, sttypes.StreamID, error) {
+	codes := make([][]byte, 0, len(hs))
+	for _, hash := range hs {
+		code := rawdb.ReadCodeWithPrefix(p.db, hash)
+		if len(code) == 0 {
+			code = rawdb.ReadValidatorCodeWithPrefix(p.db, hash)
+		}
+		if len(code) == 0 {
+			code = nil
+		}
+		codes = append(codes, code)
+	}
+	return codes, testSnapStreamID, nil
+}
+
+func (p *testSnapProtocol) GetTrieNodes(ctx context.Context, root common.Hash, paths [][][]byte, hs []common.Hash, bytes uint64, opts ...syncproto.Option) ([][]byte, sttypes.StreamID, error) {
+	if !p.serve(root) {
+		return nil, testSnapStreamID, syncproto.ErrStateUnavailable
+	}
+	nodes := make([][]byte, 0, len(hs))
+	for _, hash := range hs {
+		node, _ := p.db.Get(hash[:])
+		nodes = append(nodes, node)
+	}
+	return nodes, testSnapStreamID, nil
+}
+
+func (p *testSnapProtocol) StreamFailed(stID sttypes.StreamID, reason string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.failed = append(p.failed, reason)
+}
+
+// testSnapChain is the chain the state is synced to
+type testSnapChain struct {
+	core.BlockChain
+
+	db          ethdb.Database
+	shardStates map[uint64]*shard.State
+	commitSigs  map[uint64][]byte
+	head        *types.Block
+}
+
+func (bc *testSnapChain) ChainDb() ethdb.Database         { return bc.db }
+func (bc *testSnapChain) Config() *params.ChainConfig     { return testSnapConfig }
+func (bc *testSnapChain) ShardID() uint32                 { return 0 }
+func (bc *testSnapChain) CurrentBlock() *types.Block      { return bc.head }
+func (bc *testSnapChain) GetSnapshotTrie() *snapshot.Tree { return nil }
+
+func (bc *testSnapChain) ReadShardState(epoch *big.Int) (*shard.State, error) {
+	if ss, ok := bc.shardStates[epoch.Uint64()]; ok {
+		return ss, nil
+	}
+	return nil, errors.Errorf("no shard state for epoch %v", epoch)
+}
+
+func (bc *testSnapChain) WriteShardStateBytes(db rawdb.DatabaseWriter, epoch *big.Int, shardState []byte) (*shard.State, error) {
+	ss, err := shard.DecodeWrapper(shardState)
+	if err != nil {
+		return nil, err
+	}
+	bc.shardStates[epoch.Uint64()] = ss
+	return ss, nil
+}
+
+func (bc *testSnapChain) WriteBlockWithoutState(block *types.Block, td *big.Int) error {
+	return nil
+}
+
+func (bc *testSnapChain) WriteCommitSig(blockNum uint64, lastCommits []byte) error {
+	bc.commitSigs[blockNum] = lastCommits
+	return nil
+}
+
+func (bc *testSnapChain) WriteHeadBlock(block *types.Block) error {
+	bc.head = block
+	return nil
+}
@@ -16,8 +16,9 @@ type StageEpoch struct {
 }
 
 type StageEpochCfg struct {
-	bc core.BlockChain
-	db kv.RwDB
+	bc         core.BlockChain
+	db         kv.RwDB
+	checkpoint *Checkpoint
 }
 
 func NewStageEpoch(cfg StageEpochCfg) *StageEpoch {
@@ -26,10 +27,11 @@ func NewStageEpoch(cfg StageEpochCfg) *StageEpoch {
 	}
 }
 
-func NewStageEpochCfg(bc core.BlockChain, db kv.RwDB) StageEpochCfg {
+func NewStageEpochCfg(bc core.BlockChain, db kv.RwDB, checkpoint *Checkpoint) StageEpochCfg {
 	return StageEpochCfg{
-		bc: bc,
-		db: db,
+		bc:         bc,
+		db:         db,
+		checkpoint: checkpoint,
 	}
 }
 
@@ -48,6 +50,14 @@ func (sr *StageEpoch) Exec(ctx context.Context, firstCycle bool, invalidBlockRev
 		return nil
 	}
 
+	// start the epoch chain from the checkpoint instead of the genesis
+	if sr.configs.checkpoint != nil {
+		if _, err := sr.configs.checkpoint.applyToEpochChain(sr.configs.bc); err != nil {
+			utils.Logger().Error().Err(err).Msg(WrapStagedSyncMsg("applying checkpoint to epoch chain failed"))
+			return err
+		}
+	}
+
 	// doShortRangeSyncForEpochSync
 	n, err := sr.doShortRangeSyncForEpochSync(ctx, s)
 	s.state.inserted = n
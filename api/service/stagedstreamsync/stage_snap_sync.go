@@ -0,0 +1,153 @@
+package stagedstreamsync
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/internal/utils"
+	"github.com/harmony-one/harmony/shard"
+	"github.com/ledgerwatch/erigon-lib/kv"
+)
+
+type StageSnapSync struct {
+	configs StageSnapSyncCfg
+}
+
+type StageSnapSyncCfg struct {
+	bc          core.BlockChain
+	db          kv.RwDB
+	enabled     bool
+	checkpoint  *Checkpoint
+	logProgress bool
+}
+
+func NewStageSnapSync(cfg StageSnapSyncCfg) *StageSnapSync {
+	return &StageSnapSync{
+		configs: cfg,
+	}
+}
+
+func NewStageSnapSyncCfg(bc core.BlockChain, db kv.RwDB, enabled bool, checkpoint *Checkpoint, logProgress bool) StageSnapSyncCfg {
+	return StageSnapSyncCfg{
+		bc:          bc,
+		db:          db,
+		enabled:     enabled,
+		checkpoint:  checkpoint,
+		logProgress: logProgress,
+	}
+}
+
+// Exec downloads the state of a recent block instead of executing the blocks up
+// to it, if the node is far behind. The block becomes the head of the chain, and
+// the following blocks are synced by the next stages as usual.
+//
+// The blocks below the pivot are not downloaded, and the data which is not part
+// of the state (validator snapshots, cross links, ...) is not synced, so the
+// stage only runs for the shard chains.
+func (snap *StageSnapSync) Exec(ctx context.Context, firstCycle bool, invalidBlockRevert bool, s *StageState, reverter Reverter, tx kv.RwTx) (err error) {
+
+	// no need to sync the state if we are redoing the stages because of bad block
+	if invalidBlockRevert {
+		return nil
+	}
+	if !snap.configs.enabled || !s.state.initSync {
+		return nil
+	}
+	if snap.configs.bc.ShardID() == shard.BeaconChainShardID || s.state.isBeaconNode {
+		return nil
+	}
+	// the syncer writes in its own transactions
+	if tx != nil {
+		return nil
+	}
+
+	currentHead := snap.configs.bc.CurrentBlock().NumberU64()
+	targetHeight := s.state.status.targetBN
+	if targetHeight < currentHead+SnapSyncMinDistance {
+		return nil
+	}
+
+	ss := newSnapSyncer(snap.configs.bc, snap.configs.db, s.state.protocol, s.state.config.Concurrency,
+		s.state.estimateCurrentNumber, snap.configs.checkpoint, utils.Logger())
+	pivot, err := ss.sync(ctx, targetHeight-SnapSyncPivotOffset)
+	if err != nil {
+		utils.Logger().Error().
+			Err(err).
+			Uint64("targetHeight", targetHeight).
+			Msgf(WrapStagedSyncMsg("snap sync failed"))
+		return err
+	}
+	s.state.inserted = int(pivot.NumberU64() - currentHead)
+	if snap.configs.logProgress {
+		fmt.Println("snap synced state of block:", pivot.NumberU64(), "/", targetHeight)
+	}
+
+	// the next stages sync the blocks after the pivot
+	cycleTarget := pivot.NumberU64() + uint64(1024)
+	if cycleTarget > targetHeight {
+		cycleTarget = targetHeight
+	}
+	s.state.currentCycle.TargetHeight = cycleTarget
+
+	tx, err = snap.configs.db.BeginRw(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err = s.Update(tx, pivot.NumberU64()); err != nil {
+		utils.Logger().Error().
+			Err(err).
+			Msgf(WrapStagedSyncMsg("saving progress for snap sync stage failed"))
+		return ErrSaveSnapSyncProgressFail
+	}
+	return tx.Commit()
+}
+
+func (snap *StageSnapSync) Revert(ctx context.Context, firstCycle bool, u *RevertState, s *StageState, tx kv.RwTx) (err error) {
+	useInternalTx := tx == nil
+	if useInternalTx {
+		tx, err = snap.configs.db.BeginRw(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+	}
+
+	if err = u.Done(tx); err != nil {
+		return err
+	}
+
+	if useInternalTx {
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (snap *StageSnapSync) CleanUp(ctx context.Context, firstCycle bool, p *CleanUpState, tx kv.RwTx) (err error) {
+	useInternalTx := tx == nil
+	if useInternalTx {
+		tx, err = snap.configs.db.BeginRw(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+	}
+
+	if err = tx.ClearBucket(SnapAccountsBucket); err != nil {
+		return err
+	}
+	if err = tx.ClearBucket(SnapStoragesBucket); err != nil {
+		return err
+	}
+
+	if useInternalTx {
+		if err = tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -11,6 +11,7 @@ const (
 	Heads       SyncStageID = "Heads"       // Heads are downloaded
 	ShortRange  SyncStageID = "ShortRange"  // short range
 	SyncEpoch   SyncStageID = "SyncEpoch"   // epoch sync
+	SnapSync    SyncStageID = "SnapSync"    // state of a recent block is downloaded with range proofs
 	BlockBodies SyncStageID = "BlockBodies" // Block bodies are downloaded, TxHash and UncleHash are getting verified
 	States      SyncStageID = "States"      // will construct most recent state from downloaded blocks
 	LastMile    SyncStageID = "LastMile"    // update blocks after sync and update last mile blocks as well
@@ -25,6 +25,8 @@ const (
 	BlocksBucket          = "BlockBodies"
 	BlockSignaturesBucket = "BlockSignatures"
 	StageProgressBucket   = "StageProgress"
+	SnapAccountsBucket    = "SnapAccounts"
+	SnapStoragesBucket    = "SnapStorages"
 
 	// cache db keys
 	LastBlockHeight = "LastBlockHeight"
@@ -35,6 +37,8 @@ var Buckets = []string{
 	BlocksBucket,
 	BlockSignaturesBucket,
 	StageProgressBucket,
+	SnapAccountsBucket,
+	SnapStoragesBucket,
 }
 
 // CreateStagedSync creates an instance of staged sync
@@ -83,7 +87,8 @@ func CreateStagedSync(ctx context.Context,
 
 	stageHeadsCfg := NewStageHeadersCfg(bc, mainDB)
 	stageShortRangeCfg := NewStageShortRangeCfg(bc, mainDB)
-	stageSyncEpochCfg := NewStageEpochCfg(bc, mainDB)
+	stageSyncEpochCfg := NewStageEpochCfg(bc, mainDB, config.Checkpoint)
+	stageSnapSyncCfg := NewStageSnapSyncCfg(bc, mainDB, config.SnapSync, config.Checkpoint, config.LogProgress)
 	stageBodiesCfg := NewStageBodiesCfg(bc, mainDB, dbs, config.Concurrency, protocol, isBeaconNode, config.LogProgress)
 	stageStatesCfg := NewStageStatesCfg(bc, mainDB, dbs, config.Concurrency, logger, config.LogProgress)
 	lastMileCfg := NewStageLastMileCfg(ctx, bc, mainDB)
@@ -93,6 +98,7 @@ func CreateStagedSync(ctx context.Context,
 		stageHeadsCfg,
 		stageSyncEpochCfg,
 		stageShortRangeCfg,
+		stageSnapSyncCfg,
 		stageBodiesCfg,
 		stageStatesCfg,
 		lastMileCfg,
@@ -2,15 +2,18 @@ package block
 
 import (
 	"bytes"
+	"math/big"
 	"reflect"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rlp"
 
 	blockif "github.com/harmony-one/harmony/block/interface"
 	v0 "github.com/harmony-one/harmony/block/v0"
 	v1 "github.com/harmony-one/harmony/block/v1"
 	v2 "github.com/harmony-one/harmony/block/v2"
+	v4 "github.com/harmony-one/harmony/block/v4"
 )
 
 func TestHeader_EncodeRLP(t *testing.T) {
@@ -745,6 +748,96 @@ func TestHeader_DecodeRLP(t *testing.T) {
 	}
 }
 
+func TestHeader_V4RLP(t *testing.T) {
+	hv4 := v4.NewHeader()
+	hv4.SetNumber(big.NewInt(1))
+	hv4.SetBaseFee(big.NewInt(100e9))
+	h := &Header{Header: hv4}
+
+	enc, err := rlp.EncodeToBytes(h)
+	if err != nil {
+		t.Fatalf("EncodeRLP() error = %v", err)
+	}
+	// the v4 header goes in the tagged RLP envelope, after the v4 tag
+	envelope, _, err := rlp.SplitList(enc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature, rest, err := rlp.SplitString(envelope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(signature) != "HmnyTgd" {
+		t.Errorf("EncodeRLP() signature %q, want %q", signature, "HmnyTgd")
+	}
+	tag, rest, err := rlp.SplitString(rest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(tag) != "v4" {
+		t.Errorf("EncodeRLP() tag %q, want %q", tag, "v4")
+	}
+	want, err := rlp.EncodeToBytes(hv4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(rest, want) {
+		t.Errorf("EncodeRLP() header got  %x", rest)
+		t.Errorf("EncodeRLP() header want %x", want)
+	}
+	// the base fee is the last field of the header
+	fields, _, err := rlp.SplitList(rest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var last []byte
+	for len(fields) > 0 {
+		if _, last, fields, err = rlp.Split(fields); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !bytes.Equal(last, hv4.BaseFee().Bytes()) {
+		t.Errorf("EncodeRLP() base fee got %x, want %x", last, hv4.BaseFee().Bytes())
+	}
+
+	decoded := &Header{}
+	if err := rlp.DecodeBytes(enc, decoded); err != nil {
+		t.Fatalf("DecodeRLP() error = %v", err)
+	}
+	got, ok := decoded.Header.(*v4.Header)
+	if !ok {
+		t.Fatalf("DecodeRLP() got %T, want %T", decoded.Header, hv4)
+	}
+	if got.BaseFee().Cmp(hv4.BaseFee()) != 0 {
+		t.Errorf("DecodeRLP() base fee got %v, want %v", got.BaseFee(), hv4.BaseFee())
+	}
+	if !compareHeaders(got, hv4) {
+		t.Errorf("DecodeRLP() got  %#v", got)
+		t.Errorf("DecodeRLP() want %#v", hv4)
+	}
+}
+
+func TestHeader_V4Hash(t *testing.T) {
+	hv4 := v4.NewHeader()
+	hv4.SetBaseFee(big.NewInt(100e9))
+	h := &Header{Header: hv4}
+
+	enc, err := rlp.EncodeToBytes(h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the hash is over the tagged encoding
+	if got, want := h.Hash(), crypto.Keccak256Hash(enc); got != want {
+		t.Errorf("Hash() got %x, want %x", got, want)
+	}
+	// and it commits to the base fee
+	other := v4.NewHeader()
+	other.SetBaseFee(big.NewInt(101e9))
+	if h.Hash() == (&Header{Header: other}).Hash() {
+		t.Errorf("Hash() does not depend on the base fee")
+	}
+}
+
 func equal(x, y interface{}) bool {
 	xv := reflect.ValueOf(x)
 	yv := reflect.ValueOf(y)
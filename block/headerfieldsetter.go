@@ -179,6 +179,12 @@ func (s HeaderFieldSetter) CrossLinks(newCrossLinks []byte) HeaderFieldSetter {
 	return s
 }
 
+// BaseFee sets the EIP-1559 base fee per gas.
+func (s HeaderFieldSetter) BaseFee(newBaseFee *big.Int) HeaderFieldSetter {
+	s.h.SetBaseFee(newBaseFee)
+	return s
+}
+
 // Header returns the header whose fields have been set.  Call this at the end
 // of a field setter chain.
 func (s HeaderFieldSetter) Header() *Header {
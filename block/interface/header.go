@@ -236,4 +236,14 @@ type Header interface {
 	// SetSlashes sets the RLP-encoded form of slashes
 	// It stores a copy; the caller may freely modify the original.
 	SetSlashes(newSlashes []byte)
+
+	// BaseFee is the EIP-1559 base fee per gas, nil before the fee market.
+	//
+	// The returned value is a copy; the caller may do anything with it.
+	BaseFee() *big.Int
+
+	// SetBaseFee sets the EIP-1559 base fee per gas.
+	//
+	// It stores a copy; the caller may freely modify the original.
+	SetBaseFee(newBaseFee *big.Int)
 }
@@ -379,6 +379,18 @@ func (h *Header) SetSlashes(newSlashes []byte) {
 		Msg("cannot store slashes in V1 header")
 }
 
+// BaseFee returns nil, there is no base fee in V1 header.
+func (h *Header) BaseFee() *big.Int {
+	return nil
+}
+
+// SetBaseFee ..
+func (h *Header) SetBaseFee(newBaseFee *big.Int) {
+	h.Logger(utils.Logger()).Error().
+		Str("baseFee", newBaseFee.String()).
+		Msg("cannot store base fee in V1 header")
+}
+
 // field type overrides for gencodec
 type headerMarshaling struct {
 	Difficulty *hexutil.Big
@@ -378,6 +378,18 @@ func (h *Header) SetSlashes(newSlashes []byte) {
 		Msg("cannot store slashes in V2 header")
 }
 
+// BaseFee returns nil, there is no base fee in V2 header.
+func (h *Header) BaseFee() *big.Int {
+	return nil
+}
+
+// SetBaseFee ..
+func (h *Header) SetBaseFee(newBaseFee *big.Int) {
+	h.Logger(utils.Logger()).Error().
+		Str("baseFee", newBaseFee.String()).
+		Msg("cannot store base fee in V2 header")
+}
+
 // field type overrides for gencodec
 type headerMarshaling struct {
 	Difficulty *hexutil.Big
@@ -380,6 +380,18 @@ func (h *Header) SetSlashes(newSlashes []byte) {
 	h.fields.Slashes = append(newSlashes[:0:0], newSlashes...)
 }
 
+// BaseFee returns nil, there is no base fee in V3 header.
+func (h *Header) BaseFee() *big.Int {
+	return nil
+}
+
+// SetBaseFee ..
+func (h *Header) SetBaseFee(newBaseFee *big.Int) {
+	h.Logger(utils.Logger()).Error().
+		Str("baseFee", newBaseFee.String()).
+		Msg("cannot store base fee in V3 header")
+}
+
 // Hash returns the block hash of the header, which is simply the keccak256 hash of its
 // RLP encoding.
 func (h *Header) Hash() common.Hash {
@@ -0,0 +1,85 @@
+// bls-signer is a reference remote signer. It loads bls keys from key files and
+// serves the remote signer api, so that a node started with --bls.remote.url can
+// sign without holding the secret keys.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/harmony-one/harmony/crypto/bls/remotesigner"
+	"github.com/harmony-one/harmony/internal/blsgen"
+	"github.com/harmony-one/harmony/internal/utils"
+)
+
+var (
+	version string
+	builtBy string
+	builtAt string
+	commit  string
+)
+
+func printVersion(me string) {
+	fmt.Fprintf(os.Stderr, "Harmony (C) 2019. %v, version %v-%v (%v %v)\n", path.Base(me), version, commit, builtBy, builtAt)
+	os.Exit(0)
+}
+
+func main() {
+	addr := flag.String("addr", "127.0.0.1:9700", "address to serve the signer api on")
+	keyDir := flag.String("bls_dir", "./.hmy/blskeys", "directory of the bls key files to sign with")
+	passFile := flag.String("pass_file", "", "passphrase file of the key files. Default to the .pass file of each key file")
+	tlsCert := flag.String("tls_cert", "", "tls certificate file. Serve over https if set with --tls_key")
+	tlsKey := flag.String("tls_key", "", "tls key file")
+	versionFlag := flag.Bool("version", false, "Output version info")
+	verbosity := flag.Int("verbosity", 3, "Logging verbosity: 0=silent, 1=error, 2=warn, 3=info, 4=debug, 5=detail (default: 3)")
+
+	flag.Parse()
+
+	if *versionFlag {
+		printVersion(os.Args[0])
+	}
+	utils.SetLogVerbosity(log.Lvl(*verbosity))
+
+	cfg := blsgen.Config{
+		BlsDir:        keyDir,
+		PassSrcType:   blsgen.PassSrcFile,
+		AwsCfgSrcType: blsgen.AwsCfgSrcNil,
+	}
+	if *passFile != "" {
+		cfg.PassFile = passFile
+	}
+	keys, err := blsgen.LoadKeys(cfg)
+	if err != nil {
+		utils.FatalErrMsg(err, "cannot load bls keys from %s", *keyDir)
+	}
+	if len(keys) == 0 {
+		fmt.Fprintf(os.Stderr, "no bls keys found in %s\n", *keyDir)
+		os.Exit(1)
+	}
+	for _, key := range keys {
+		fmt.Println("serving key", key.Pub.Bytes.Hex())
+	}
+
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           remotesigner.NewServer(keys),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	if *tlsCert != "" && *tlsKey != "" {
+		fmt.Println("remote signer listening on https://" + *addr)
+		err = server.ListenAndServeTLS(*tlsCert, *tlsKey)
+	} else {
+		fmt.Println("remote signer listening on http://" + *addr)
+		err = server.ListenAndServe()
+	}
+	if err != nil {
+		utils.FatalErrMsg(err, "remote signer stopped")
+	}
+}
@@ -59,6 +59,12 @@ func parseBLSLoadingConfig(raw harmonyconfig.BlsConfig) (blsgen.Config, error) {
 		config.MultiBlsKeys = raw.KeyFiles
 	}
 	config.BlsDir = &raw.KeyDir
+	if raw.RemoteSignerURL != "" {
+		config.RemoteSignerURL = raw.RemoteSignerURL
+		config.RemoteSignerKeys = raw.RemoteSignerKeys
+		config.RemoteSignerTimeout = raw.RemoteSignerTimeout
+		return config, nil
+	}
 
 	config, err = parseBLSPassConfig(config, raw)
 	if err != nil {
@@ -19,7 +19,7 @@ func validateHarmonyConfig(config harmonyconfig.HarmonyConfig) error {
 	var accepts []string
 
 	nodeType := config.General.NodeType
-	accepts = []string{nodeTypeValidator, nodeTypeExplorer}
+	accepts = []string{nodeTypeValidator, nodeTypeExplorer, nodeTypeLight}
 	if err := checkStringAccepted("--run", nodeType, accepts); err != nil {
 		return err
 	}
@@ -46,6 +46,10 @@ func validateHarmonyConfig(config harmonyconfig.HarmonyConfig) error {
 		return errors.New("flag --run.shard must be specified for explorer node")
 	}
 
+	if config.General.NodeType == nodeTypeLight && config.General.ShardID < 0 {
+		return errors.New("flag --run.shard must be specified for light node")
+	}
+
 	if config.General.IsOffline && config.P2P.IP != nodeconfig.DefaultLocalListenIP {
 		return fmt.Errorf("flag --run.offline must have p2p IP be %v", nodeconfig.DefaultLocalListenIP)
 	}
@@ -92,6 +92,7 @@ var defaultConfig = harmonyconfig.HarmonyConfig{
 		Lifetime:          core.DefaultTxPoolConfig.Lifetime,
 		PriceLimit:        harmonyconfig.PriceLimit(core.DefaultTxPoolConfig.PriceLimit),
 		PriceBump:         core.DefaultTxPoolConfig.PriceBump,
+		AnnounceTxs:       false,
 	},
 	Sync: getDefaultSyncConfig(defNetworkType),
 	Pprof: harmonyconfig.PprofConfig{
@@ -157,14 +158,21 @@ var defaultPreimageConfig = harmonyconfig.PreimageConfig{
 	GenerateEnd:   0,
 }
 
+var defaultIPCConfig = harmonyconfig.IpcConfig{
+	Enabled: false,
+	Path:    "harmony.ipc",
+}
+
 var defaultLogContext = harmonyconfig.LogContext{
 	IP:   "127.0.0.1",
 	Port: 9000,
 }
 
 var defaultConsensusConfig = harmonyconfig.ConsensusConfig{
-	MinPeers:     6,
-	AggregateSig: true,
+	MinPeers:           6,
+	AggregateSig:       true,
+	SlashingProtection: false,
+	DoppelgangerBlocks: 0,
 }
 
 var defaultPrometheusConfig = harmonyconfig.PrometheusConfig{
@@ -186,6 +194,7 @@ var defaultStagedSyncConfig = harmonyconfig.StagedSyncConfig{
 	MaxMemSyncCycleSize:    1024,  // max number of blocks to use a single transaction for staged sync
 	UseMemDB:               true,  // it uses memory by default. set it to false to use disk
 	LogProgress:            false, // log the full sync progress in console
+	SnapSync:               false, // download the state of a recent block instead of executing all the blocks
 	DebugMode:              false, // log every single process and error to help to debug the syncing (DebugMode is not accessible to the end user and is only an aid for development)
 }
 
@@ -304,6 +313,11 @@ func getDefaultPreimageConfigCopy() harmonyconfig.PreimageConfig {
 	return config
 }
 
+func getDefaultIPCConfigCopy() harmonyconfig.IpcConfig {
+	config := defaultIPCConfig
+	return config
+}
+
 func getDefaultLogContextCopy() harmonyconfig.LogContext {
 	config := defaultLogContext
 	return config
@@ -322,6 +336,7 @@ func getDefaultPrometheusConfigCopy() harmonyconfig.PrometheusConfig {
 const (
 	nodeTypeValidator = "validator"
 	nodeTypeExplorer  = "explorer"
+	nodeTypeLight     = "light"
 )
 
 const (
@@ -11,6 +11,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/harmony-one/harmony/api/service/legacysync"
+	"github.com/harmony-one/harmony/crypto/bls/remotesigner"
 	"github.com/harmony-one/harmony/internal/cli"
 	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
 )
@@ -75,6 +76,7 @@ var (
 		httpIPFlag,
 		httpPortFlag,
 		httpAuthPortFlag,
+		httpAuthJWTSecretFlag,
 		httpRosettaPortFlag,
 		httpReadTimeoutFlag,
 		httpWriteTimeoutFlag,
@@ -86,6 +88,7 @@ var (
 		wsIPFlag,
 		wsPortFlag,
 		wsAuthPortFlag,
+		wsAuthJWTSecretFlag,
 	}
 
 	rpcOptFlags = []cli.Flag{
@@ -98,6 +101,10 @@ var (
 		rpcRateLimiterEnabledFlag,
 		rpcRateLimitFlag,
 		rpcEvmCallTimeoutFlag,
+		rpcClientRateLimiterEnabledFlag,
+		rpcClientRateLimitFlag,
+		rpcClientBurstFlag,
+		rpcClientKeyHeaderFlag,
 	}
 
 	blsFlags = append(newBLSFlags, legacyBLSFlags...)
@@ -113,6 +120,9 @@ var (
 		kmsEnabledFlag,
 		kmsConfigSrcTypeFlag,
 		kmsConfigFileFlag,
+		remoteSignerURLFlag,
+		remoteSignerKeysFlag,
+		remoteSignerTimeoutFlag,
 	}
 
 	legacyBLSFlags = []cli.Flag{
@@ -130,6 +140,8 @@ var (
 	consensusValidFlags = []cli.Flag{
 		consensusMinPeersFlag,
 		consensusAggregateSigFlag,
+		consensusSlashingProtectionFlag,
+		consensusDoppelgangerBlocksFlag,
 		legacyConsensusMinPeersFlag,
 	}
 
@@ -152,6 +164,7 @@ var (
 		allowedTxsFileFlag,
 		tpPriceLimitFlag,
 		tpPriceBumpFlag,
+		tpAnnounceTxsFlag,
 	}
 
 	pprofFlags = []cli.Flag{
@@ -213,6 +226,11 @@ var (
 		preimageGenerateEndFlag,
 	}
 
+	ipcFlags = []cli.Flag{
+		ipcEnabledFlag,
+		ipcPathFlag,
+	}
+
 	legacyRevertFlags = []cli.Flag{
 		legacyRevertBeaconFlag,
 		legacyRevertBeforeFlag,
@@ -240,6 +258,7 @@ var (
 		syncStreamEnabledFlag,
 		syncDownloaderFlag,
 		syncStagedSyncFlag,
+		syncSnapSyncFlag,
 		syncConcurrencyFlag,
 		syncMinPeersFlag,
 		syncInitStreamsFlag,
@@ -247,6 +266,7 @@ var (
 		syncDiscHardLowFlag,
 		syncDiscHighFlag,
 		syncDiscBatchFlag,
+		syncCheckpointFlag,
 	}
 
 	shardDataFlags = []cli.Flag{
@@ -276,7 +296,7 @@ var (
 var (
 	nodeTypeFlag = cli.StringFlag{
 		Name:     "run",
-		Usage:    "run node type (validator, explorer)",
+		Usage:    "run node type (validator, explorer, light)",
 		DefValue: defaultConfig.General.NodeType,
 	}
 	// TODO: Can we rename the legacy to internal?
@@ -379,6 +399,7 @@ func getRootFlags() []cli.Flag {
 	flags = append(flags, devnetFlags...)
 	flags = append(flags, revertFlags...)
 	flags = append(flags, preimageFlags...)
+	flags = append(flags, ipcFlags...)
 	flags = append(flags, legacyMiscFlags...)
 	flags = append(flags, prometheusFlags...)
 	flags = append(flags, syncFlags...)
@@ -715,6 +736,11 @@ var (
 		Usage:    "rpc port to listen for auth HTTP requests",
 		DefValue: defaultConfig.HTTP.AuthPort,
 	}
+	httpAuthJWTSecretFlag = cli.StringFlag{
+		Name:     "http.auth.jwtsecret",
+		Usage:    "hex secret file to authenticate the auth HTTP requests with HS256 jwt. Generated if missing",
+		DefValue: defaultConfig.HTTP.AuthJWTSecret,
+	}
 	httpRosettaEnabledFlag = cli.BoolFlag{
 		Name:     "http.rosetta",
 		Usage:    "enable HTTP / Rosetta requests",
@@ -760,6 +786,10 @@ func applyHTTPFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
 		isRPCSpecified = true
 	}
 
+	if cli.IsFlagChanged(cmd, httpAuthJWTSecretFlag) {
+		config.HTTP.AuthJWTSecret = cli.GetStringFlagValue(cmd, httpAuthJWTSecretFlag)
+	}
+
 	if cli.IsFlagChanged(cmd, httpRosettaPortFlag) {
 		config.HTTP.RosettaPort = cli.GetIntFlagValue(cmd, httpRosettaPortFlag)
 		isRosettaSpecified = true
@@ -811,6 +841,11 @@ var (
 		Usage:    "port for websocket auth endpoint",
 		DefValue: defaultConfig.WS.AuthPort,
 	}
+	wsAuthJWTSecretFlag = cli.StringFlag{
+		Name:     "ws.auth.jwtsecret",
+		Usage:    "hex secret file to authenticate the auth websocket connections with HS256 jwt. Generated if missing",
+		DefValue: defaultConfig.WS.AuthJWTSecret,
+	}
 )
 
 func applyWSFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
@@ -826,6 +861,9 @@ func applyWSFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
 	if cli.IsFlagChanged(cmd, wsAuthPortFlag) {
 		config.WS.AuthPort = cli.GetIntFlagValue(cmd, wsAuthPortFlag)
 	}
+	if cli.IsFlagChanged(cmd, wsAuthJWTSecretFlag) {
+		config.WS.AuthJWTSecret = cli.GetStringFlagValue(cmd, wsAuthJWTSecretFlag)
+	}
 }
 
 // rpc opt flags
@@ -888,6 +926,30 @@ var (
 		Usage:    "timeout for evm execution (eth_call); 0 means infinite timeout",
 		DefValue: defaultConfig.RPCOpt.EvmCallTimeout,
 	}
+
+	rpcClientRateLimiterEnabledFlag = cli.BoolFlag{
+		Name:     "rpc.clientratelimiter",
+		Usage:    "enable per-client rate limiter for RPCs",
+		DefValue: defaultConfig.RPCOpt.ClientRateLimiterEnabled,
+	}
+
+	rpcClientRateLimitFlag = cli.IntFlag{
+		Name:     "rpc.clientratelimit",
+		Usage:    "the number of requests per second of each client for RPCs",
+		DefValue: defaultConfig.RPCOpt.ClientRequestsPerSecond,
+	}
+
+	rpcClientBurstFlag = cli.IntFlag{
+		Name:     "rpc.clientratelimit.burst",
+		Usage:    "the maximum number of requests of a client at once for RPCs",
+		DefValue: defaultConfig.RPCOpt.ClientBurst,
+	}
+
+	rpcClientKeyHeaderFlag = cli.StringFlag{
+		Name:     "rpc.clientratelimit.keyheader",
+		Usage:    "http header of the api key identifying the clients, only the keys of the config are honored",
+		DefValue: defaultConfig.RPCOpt.ClientKeyHeader,
+	}
 )
 
 func applyRPCOptFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
@@ -918,6 +980,18 @@ func applyRPCOptFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
 	if cli.IsFlagChanged(cmd, rpcEvmCallTimeoutFlag) {
 		config.RPCOpt.EvmCallTimeout = cli.GetStringFlagValue(cmd, rpcEvmCallTimeoutFlag)
 	}
+	if cli.IsFlagChanged(cmd, rpcClientRateLimiterEnabledFlag) {
+		config.RPCOpt.ClientRateLimiterEnabled = cli.GetBoolFlagValue(cmd, rpcClientRateLimiterEnabledFlag)
+	}
+	if cli.IsFlagChanged(cmd, rpcClientRateLimitFlag) {
+		config.RPCOpt.ClientRequestsPerSecond = cli.GetIntFlagValue(cmd, rpcClientRateLimitFlag)
+	}
+	if cli.IsFlagChanged(cmd, rpcClientBurstFlag) {
+		config.RPCOpt.ClientBurst = cli.GetIntFlagValue(cmd, rpcClientBurstFlag)
+	}
+	if cli.IsFlagChanged(cmd, rpcClientKeyHeaderFlag) {
+		config.RPCOpt.ClientKeyHeader = cli.GetStringFlagValue(cmd, rpcClientKeyHeaderFlag)
+	}
 }
 
 // bls flags
@@ -974,6 +1048,21 @@ var (
 		Usage:    "json config file for KMS service (region and credentials)",
 		DefValue: defaultConfig.BLSKeys.KMSConfigFile,
 	}
+	remoteSignerURLFlag = cli.StringFlag{
+		Name:     "bls.remote.url",
+		Usage:    "http(s) url of the remote signer holding the bls keys. If set, no key file is loaded",
+		DefValue: defaultConfig.BLSKeys.RemoteSignerURL,
+	}
+	remoteSignerKeysFlag = cli.StringSliceFlag{
+		Name:     "bls.remote.keys",
+		Usage:    "a list of BLS public keys of the remote signer to use (separated by ,). Use all keys if not set",
+		DefValue: defaultConfig.BLSKeys.RemoteSignerKeys,
+	}
+	remoteSignerTimeoutFlag = cli.StringFlag{
+		Name:     "bls.remote.timeout",
+		Usage:    "timeout of a request to the remote signer as a golang duration string",
+		DefValue: remotesigner.DefaultTimeout.String(),
+	}
 	legacyBLSKeyFileFlag = cli.StringSliceFlag{
 		Name:       "blskey_file",
 		Usage:      "The encrypted file of bls serialized private key by passphrase.",
@@ -1031,6 +1120,22 @@ func applyBLSFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
 		config.BLSKeys.MaxKeys = cli.GetIntFlagValue(cmd, legacyBLSKeysPerNodeFlag)
 	}
 
+	if cli.IsFlagChanged(cmd, remoteSignerURLFlag) {
+		config.BLSKeys.RemoteSignerURL = cli.GetStringFlagValue(cmd, remoteSignerURLFlag)
+	}
+
+	if cli.IsFlagChanged(cmd, remoteSignerKeysFlag) {
+		config.BLSKeys.RemoteSignerKeys = cli.GetStringSliceFlagValue(cmd, remoteSignerKeysFlag)
+	}
+
+	if cli.IsFlagChanged(cmd, remoteSignerTimeoutFlag) {
+		value, err := time.ParseDuration(cli.GetStringFlagValue(cmd, remoteSignerTimeoutFlag))
+		if err != nil {
+			panic(fmt.Sprintf("Invalid value for bls.remote.timeout: %v", err))
+		}
+		config.BLSKeys.RemoteSignerTimeout = value
+	}
+
 	if cli.HasFlagsChanged(cmd, newBLSFlags) {
 		applyBLSPassFlags(cmd, config)
 		applyKMSFlags(cmd, config)
@@ -1147,6 +1252,16 @@ var (
 		Usage:    "(multi-key) aggregate bls signatures before sending",
 		DefValue: defaultConsensusConfig.AggregateSig,
 	}
+	consensusSlashingProtectionFlag = cli.BoolFlag{
+		Name:     "consensus.slashing-protection",
+		Usage:    "refuse to sign votes conflicting with the local signing history of the bls keys",
+		DefValue: defaultConsensusConfig.SlashingProtection,
+	}
+	consensusDoppelgangerBlocksFlag = cli.IntFlag{
+		Name:     "consensus.doppelganger-blocks",
+		Usage:    "number of blocks to watch for the bls keys signing on another node before signing, 0 to disable",
+		DefValue: int(defaultConsensusConfig.DoppelgangerBlocks),
+	}
 	legacyDelayCommitFlag = cli.StringFlag{
 		Name:       "delay_commit",
 		Usage:      "how long to delay sending commit messages in consensus, ex: 500ms, 1s",
@@ -1181,6 +1296,14 @@ func applyConsensusFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig
 	if cli.IsFlagChanged(cmd, consensusAggregateSigFlag) {
 		config.Consensus.AggregateSig = cli.GetBoolFlagValue(cmd, consensusAggregateSigFlag)
 	}
+
+	if cli.IsFlagChanged(cmd, consensusSlashingProtectionFlag) {
+		config.Consensus.SlashingProtection = cli.GetBoolFlagValue(cmd, consensusSlashingProtectionFlag)
+	}
+
+	if cli.IsFlagChanged(cmd, consensusDoppelgangerBlocksFlag) {
+		config.Consensus.DoppelgangerBlocks = uint64(cli.GetIntFlagValue(cmd, consensusDoppelgangerBlocksFlag))
+	}
 }
 
 // transaction pool flags
@@ -1246,6 +1369,11 @@ var (
 		Usage:    "minimum price bump to replace an already existing transaction (nonce)",
 		DefValue: int(defaultConfig.TxPool.PriceLimit),
 	}
+	tpAnnounceTxsFlag = cli.BoolFlag{
+		Name:     "txpool.announce",
+		Usage:    "gossip the hashes of the transactions instead of the full transactions, for the peers to fetch them",
+		DefValue: defaultConfig.TxPool.AnnounceTxs,
+	}
 )
 
 func applyTxPoolFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
@@ -1312,6 +1440,9 @@ func applyTxPoolFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
 		}
 		config.TxPool.PriceBump = uint64(value)
 	}
+	if cli.IsFlagChanged(cmd, tpAnnounceTxsFlag) {
+		config.TxPool.AnnounceTxs = cli.GetBoolFlagValue(cmd, tpAnnounceTxsFlag)
+	}
 }
 
 // pprof flags
@@ -1720,6 +1851,32 @@ func applyPreimageFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig)
 	}
 }
 
+var (
+	ipcEnabledFlag = cli.BoolFlag{
+		Name:     "ipc",
+		Usage:    "enable the IPC endpoint serving the public and auth RPCs",
+		DefValue: defaultIPCConfig.Enabled,
+	}
+	ipcPathFlag = cli.StringFlag{
+		Name:     "ipc.path",
+		Usage:    "path of the IPC unix socket, relative to the data dir if not absolute",
+		DefValue: defaultIPCConfig.Path,
+	}
+)
+
+func applyIPCFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
+	if config.IPC == nil && cli.HasFlagsChanged(cmd, ipcFlags) {
+		cfg := getDefaultIPCConfigCopy()
+		config.IPC = &cfg
+	}
+	if cli.IsFlagChanged(cmd, ipcEnabledFlag) {
+		config.IPC.Enabled = cli.GetBoolFlagValue(cmd, ipcEnabledFlag)
+	}
+	if cli.IsFlagChanged(cmd, ipcPathFlag) {
+		config.IPC.Path = cli.GetStringFlagValue(cmd, ipcPathFlag)
+	}
+}
+
 var (
 	legacyPortFlag = cli.IntFlag{
 		Name:       "port",
@@ -1889,6 +2046,12 @@ var (
 		Hidden:   false,
 		DefValue: false,
 	}
+	syncSnapSyncFlag = cli.BoolFlag{
+		Name:     "sync.snapsync",
+		Usage:    "Download the state of a recent block instead of executing the whole chain in staged sync (shard chains only)",
+		Hidden:   false,
+		DefValue: false,
+	}
 	syncConcurrencyFlag = cli.IntFlag{
 		Name:   "sync.concurrency",
 		Usage:  "Concurrency when doing p2p sync requests",
@@ -1929,6 +2092,11 @@ var (
 		Usage:  "batch size of the sync discovery",
 		Hidden: true,
 	}
+	syncCheckpointFlag = cli.StringFlag{
+		Name:     "sync.checkpoint",
+		Usage:    "JSON file of a trusted epoch block (hash, header and commit sig, and hash and header of the last block of the previous epoch) to bootstrap the stream sync from",
+		DefValue: "",
+	}
 )
 
 // applySyncFlags apply the sync flags.
@@ -1945,6 +2113,10 @@ func applySyncFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
 		config.Sync.StagedSync = cli.GetBoolFlagValue(cmd, syncStagedSyncFlag)
 	}
 
+	if cli.IsFlagChanged(cmd, syncSnapSyncFlag) {
+		config.Sync.StagedSyncCfg.SnapSync = cli.GetBoolFlagValue(cmd, syncSnapSyncFlag)
+	}
+
 	if cli.IsFlagChanged(cmd, syncConcurrencyFlag) {
 		config.Sync.Concurrency = cli.GetIntFlagValue(cmd, syncConcurrencyFlag)
 	}
@@ -1976,6 +2148,10 @@ func applySyncFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
 	if cli.IsFlagChanged(cmd, syncDiscBatchFlag) {
 		config.Sync.DiscBatch = cli.GetIntFlagValue(cmd, syncDiscBatchFlag)
 	}
+
+	if cli.IsFlagChanged(cmd, syncCheckpointFlag) {
+		config.Sync.Checkpoint = cli.GetStringFlagValue(cmd, syncCheckpointFlag)
+	}
 }
 
 // shard data flags
@@ -273,6 +273,17 @@ func TestGeneralFlags(t *testing.T) {
 				TriesInMemory: 128,
 			},
 		},
+		{
+			args: []string{"--run", "light", "--run.shard", "1"},
+			expConfig: harmonyconfig.GeneralConfig{
+				NodeType:      "light",
+				NoStaking:     false,
+				ShardID:       1,
+				IsArchival:    false,
+				DataDir:       "./",
+				TriesInMemory: 128,
+			},
+		},
 		{
 			args: []string{"--blockchain.tries_in_memory", "64"},
 			expConfig: harmonyconfig.GeneralConfig{
@@ -641,6 +652,21 @@ func TestRPCFlags(t *testing.T) {
 				IdleTimeout:    "30s",
 			},
 		},
+		{
+			args: []string{"--http.auth.jwtsecret", "./.hmy/jwtsecret"},
+			expConfig: harmonyconfig.HttpConfig{
+				Enabled:        defaultConfig.HTTP.Enabled,
+				RosettaEnabled: false,
+				IP:             defaultConfig.HTTP.IP,
+				Port:           defaultConfig.HTTP.Port,
+				AuthPort:       defaultConfig.HTTP.AuthPort,
+				RosettaPort:    defaultConfig.HTTP.RosettaPort,
+				ReadTimeout:    defaultConfig.HTTP.ReadTimeout,
+				WriteTimeout:   defaultConfig.HTTP.WriteTimeout,
+				IdleTimeout:    defaultConfig.HTTP.IdleTimeout,
+				AuthJWTSecret:  "./.hmy/jwtsecret",
+			},
+		},
 	}
 	for i, test := range tests {
 		ts := newFlagTestSuite(t, append(httpFlags, legacyMiscFlags...),
@@ -712,6 +738,16 @@ func TestWSFlags(t *testing.T) {
 				AuthPort: 9802,
 			},
 		},
+		{
+			args: []string{"--ws.auth.jwtsecret", "./.hmy/jwtsecret"},
+			expConfig: harmonyconfig.WsConfig{
+				Enabled:       defaultConfig.WS.Enabled,
+				IP:            defaultConfig.WS.IP,
+				Port:          defaultConfig.WS.Port,
+				AuthPort:      defaultConfig.WS.AuthPort,
+				AuthJWTSecret: "./.hmy/jwtsecret",
+			},
+		},
 	}
 	for i, test := range tests {
 		ts := newFlagTestSuite(t, append(wsFlags, legacyMiscFlags...),
@@ -891,6 +927,26 @@ func TestRPCOptFlags(t *testing.T) {
 				PreimagesEnabled:   true,
 			},
 		},
+
+		{
+			args: []string{"--rpc.clientratelimiter", "--rpc.clientratelimit", "50",
+				"--rpc.clientratelimit.burst", "100", "--rpc.clientratelimit.keyheader", "X-Api-Key"},
+			expConfig: harmonyconfig.RpcOptConfig{
+				DebugEnabled:             false,
+				EthRPCsEnabled:           true,
+				StakingRPCsEnabled:       true,
+				LegacyRPCsEnabled:        true,
+				RpcFilterFile:            "./.hmy/rpc_filter.txt",
+				RateLimterEnabled:        true,
+				RequestsPerSecond:        1000,
+				EvmCallTimeout:           defaultConfig.RPCOpt.EvmCallTimeout,
+				PreimagesEnabled:         defaultConfig.RPCOpt.PreimagesEnabled,
+				ClientRateLimiterEnabled: true,
+				ClientRequestsPerSecond:  50,
+				ClientBurst:              100,
+				ClientKeyHeader:          "X-Api-Key",
+			},
+		},
 	}
 	for i, test := range tests {
 		ts := newFlagTestSuite(t, rpcOptFlags, applyRPCOptFlags)
@@ -966,6 +1022,25 @@ func TestBLSFlags(t *testing.T) {
 				KMSConfigFile:    "config.json",
 			},
 		},
+		{
+			args: []string{"--bls.remote.url", "http://127.0.0.1:9700", "--bls.remote.keys", "key1,key2",
+				"--bls.remote.timeout", "5s"},
+			expConfig: harmonyconfig.BlsConfig{
+				KeyDir:              defaultConfig.BLSKeys.KeyDir,
+				KeyFiles:            defaultConfig.BLSKeys.KeyFiles,
+				MaxKeys:             defaultConfig.BLSKeys.MaxKeys,
+				PassEnabled:         defaultConfig.BLSKeys.PassEnabled,
+				PassSrcType:         defaultConfig.BLSKeys.PassSrcType,
+				PassFile:            defaultConfig.BLSKeys.PassFile,
+				SavePassphrase:      defaultConfig.BLSKeys.SavePassphrase,
+				KMSEnabled:          defaultConfig.BLSKeys.KMSEnabled,
+				KMSConfigSrcType:    defaultConfig.BLSKeys.KMSConfigSrcType,
+				KMSConfigFile:       defaultConfig.BLSKeys.KMSConfigFile,
+				RemoteSignerURL:     "http://127.0.0.1:9700",
+				RemoteSignerKeys:    []string{"key1", "key2"},
+				RemoteSignerTimeout: 5 * time.Second,
+			},
+		},
 	}
 	for i, test := range tests {
 		ts := newFlagTestSuite(t, blsFlags, applyBLSFlags)
@@ -1011,6 +1086,22 @@ func TestConsensusFlags(t *testing.T) {
 				AggregateSig: true,
 			},
 		},
+		{
+			args: []string{"--consensus.slashing-protection"},
+			expConfig: &harmonyconfig.ConsensusConfig{
+				MinPeers:           6,
+				AggregateSig:       true,
+				SlashingProtection: true,
+			},
+		},
+		{
+			args: []string{"--consensus.doppelganger-blocks", "10"},
+			expConfig: &harmonyconfig.ConsensusConfig{
+				MinPeers:           6,
+				AggregateSig:       true,
+				DoppelgangerBlocks: 10,
+			},
+		},
 	}
 	for i, test := range tests {
 		ts := newFlagTestSuite(t, consensusFlags, applyConsensusFlags)
@@ -1149,6 +1240,23 @@ func TestTxPoolFlags(t *testing.T) {
 				PriceBump:         2,
 			},
 		},
+		{
+			args: []string{"--txpool.announce"},
+			expConfig: harmonyconfig.TxPoolConfig{
+				BlacklistFile:     defaultConfig.TxPool.BlacklistFile,
+				AllowedTxsFile:    defaultConfig.TxPool.AllowedTxsFile,
+				RosettaFixFile:    defaultConfig.TxPool.RosettaFixFile,
+				AccountSlots:      defaultConfig.TxPool.AccountSlots,
+				LocalAccountsFile: defaultConfig.TxPool.LocalAccountsFile,
+				GlobalSlots:       defaultConfig.TxPool.GlobalSlots,
+				AccountQueue:      defaultConfig.TxPool.AccountQueue,
+				GlobalQueue:       defaultConfig.TxPool.GlobalQueue,
+				Lifetime:          defaultConfig.TxPool.Lifetime,
+				PriceLimit:        100e9,
+				PriceBump:         1,
+				AnnounceTxs:       true,
+			},
+		},
 	}
 	for i, test := range tests {
 		ts := newFlagTestSuite(t, txPoolFlags, applyTxPoolFlags)
@@ -1598,6 +1706,41 @@ func TestPreimageFlags(t *testing.T) {
 	}
 }
 
+func TestIPCFlags(t *testing.T) {
+	tests := []struct {
+		args      []string
+		expConfig *harmonyconfig.IpcConfig
+	}{
+		{
+			args:      []string{},
+			expConfig: nil,
+		},
+		{
+			args: []string{"--ipc"},
+			expConfig: &harmonyconfig.IpcConfig{
+				Enabled: true,
+				Path:    defaultIPCConfig.Path,
+			},
+		},
+		{
+			args: []string{"--ipc", "--ipc.path", "/tmp/hmy.ipc"},
+			expConfig: &harmonyconfig.IpcConfig{
+				Enabled: true,
+				Path:    "/tmp/hmy.ipc",
+			},
+		},
+	}
+	for i, test := range tests {
+		ts := newFlagTestSuite(t, ipcFlags, applyIPCFlags)
+		hc, _ := ts.run(test.args)
+
+		if !reflect.DeepEqual(hc.IPC, test.expConfig) {
+			t.Errorf("Test %v:\n\t%+v\n\t%+v", i, hc.IPC, test.expConfig)
+		}
+		ts.tearDown()
+	}
+}
+
 func TestDNSSyncFlags(t *testing.T) {
 	tests := []struct {
 		args      []string
@@ -1681,6 +1824,27 @@ func TestSyncFlags(t *testing.T) {
 				return cfgSync
 			}(),
 		},
+		{
+			args:    []string{"--sync", "--sync.downloader", "--sync.stagedsync", "--sync.snapsync"},
+			network: "mainnet",
+			expConfig: func() harmonyconfig.SyncConfig {
+				cfgSync := defaultMainnetSyncConfig
+				cfgSync.Enabled = true
+				cfgSync.Downloader = true
+				cfgSync.StagedSync = true
+				cfgSync.StagedSyncCfg.SnapSync = true
+				return cfgSync
+			}(),
+		},
+		{
+			args:    []string{"--sync.checkpoint", "checkpoint.json"},
+			network: "mainnet",
+			expConfig: func() harmonyconfig.SyncConfig {
+				cfgSync := defaultMainnetSyncConfig
+				cfgSync.Checkpoint = "checkpoint.json"
+				return cfgSync
+			}(),
+		},
 	}
 	for i, test := range tests {
 		ts := newFlagTestSuite(t, syncFlags, func(command *cobra.Command, config *harmonyconfig.HarmonyConfig) {
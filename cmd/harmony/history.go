@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/history"
+	"github.com/harmony-one/harmony/internal/chain"
+	"github.com/harmony-one/harmony/internal/cli"
+	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
+	"github.com/harmony-one/harmony/internal/shardchain"
+	"github.com/harmony-one/harmony/shard"
+)
+
+var (
+	historyShardFlag = cli.IntFlag{
+		Name:     "shard",
+		Usage:    "shard ID of the chain",
+		DefValue: 0,
+	}
+	historyFromFlag = cli.Uint64Flag{
+		Name:     "from",
+		Usage:    "number of the first block to export",
+		DefValue: 0,
+	}
+	historyToFlag = cli.Uint64Flag{
+		Name:     "to",
+		Usage:    "number of the last block to export (default the chain head)",
+		DefValue: 0,
+	}
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "export and import the block history archive files",
+	Long:  "export and import the block history of a shard chain as checksummed archive files, one per epoch. Stop the node before using any of the sub commands.",
+}
+
+var historyExportCmd = &cobra.Command{
+	Use:     "export dir",
+	Short:   "export the blocks of a shard chain to archive files",
+	Long:    "export the blocks and commit signatures of the block range to archive files in dir, one per epoch",
+	Example: "harmony history export --shard 1 --from 0 --to 100000 ./history",
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		files, err := exportHistory(cmd, args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "export failed: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("%d archive files exported to %s\n", len(files), args[0])
+	},
+}
+
+var historyImportCmd = &cobra.Command{
+	Use:     "import file...",
+	Short:   "import the blocks of a shard chain from archive files",
+	Long:    "verify the archive files and insert their blocks into the shard chain, in the order of the file names",
+	Example: "harmony history import --shard 1 ./history/shard1-*.hist",
+	Args:    cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		inserted, err := importHistory(cmd, args)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "import failed: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("%d blocks imported\n", inserted)
+	},
+}
+
+func registerHistoryFlags() error {
+	if err := cli.RegisterFlags(historyExportCmd, []cli.Flag{
+		historyShardFlag, historyFromFlag, historyToFlag, dataDirFlag, networkTypeFlag,
+	}); err != nil {
+		return err
+	}
+	return cli.RegisterFlags(historyImportCmd, []cli.Flag{
+		historyShardFlag, dataDirFlag, networkTypeFlag,
+	})
+}
+
+func exportHistory(cmd *cobra.Command, dir string) ([]string, error) {
+	bc, err := openHistoryChain(cmd)
+	if err != nil {
+		return nil, err
+	}
+	defer bc.Stop()
+
+	from := cli.GetUint64FlagValue(cmd, historyFromFlag)
+	to := bc.CurrentBlock().NumberU64()
+	if cli.IsFlagChanged(cmd, historyToFlag) {
+		to = cli.GetUint64FlagValue(cmd, historyToFlag)
+	}
+	return history.Export(bc, dir, from, to)
+}
+
+func importHistory(cmd *cobra.Command, files []string) (int, error) {
+	bc, err := openHistoryChain(cmd)
+	if err != nil {
+		return 0, err
+	}
+	defer bc.Stop()
+
+	sort.Strings(files)
+	total := 0
+	for _, file := range files {
+		inserted, err := history.Import(bc, file)
+		total += inserted
+		if err != nil {
+			return total, errors.Wrapf(err, "import %s", file)
+		}
+		fmt.Printf("%s: %d blocks imported, chain head %d\n", file, inserted, bc.CurrentBlock().NumberU64())
+	}
+	return total, nil
+}
+
+// openHistoryChain opens the shard chain in the data dir, along with the beacon
+// epoch chain a shard chain depends on
+func openHistoryChain(cmd *cobra.Command) (core.BlockChain, error) {
+	networkType := getNetworkType(cmd)
+	schedule := getShardSchedule(networkType)
+	if schedule == nil {
+		return nil, errors.Errorf("unsupported network type %v", networkType)
+	}
+	shard.Schedule = schedule
+	nodeconfig.SetShardingSchedule(schedule)
+
+	shardID := uint32(cli.GetIntFlagValue(cmd, historyShardFlag))
+	if numShards := schedule.InstanceForEpoch(big.NewInt(core.GenesisEpoch)).NumShards(); shardID >= numShards {
+		return nil, errors.Errorf("invalid shard %v of %v shards", shardID, numShards)
+	}
+
+	chainConfig := networkType.ChainConfig()
+	collection := shardchain.NewCollection(
+		nil, &shardchain.LDBFactory{RootDir: cli.GetStringFlagValue(cmd, dataDirFlag)},
+		&core.GenesisInitializer{NetworkType: networkType}, chain.NewEngine(), &chainConfig,
+	)
+	if shardID != shard.BeaconChainShardID {
+		if _, err := collection.ShardChain(shard.BeaconChainShardID, core.Options{EpochChain: true}); err != nil {
+			return nil, errors.Wrap(err, "open beacon chain")
+		}
+	}
+	return collection.ShardChain(shardID)
+}
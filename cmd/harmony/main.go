@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/harmony-one/harmony/consensus/quorum"
+	"github.com/harmony-one/harmony/consensus/slashprotect"
 	"github.com/harmony-one/harmony/internal/chain"
 	"github.com/harmony-one/harmony/internal/registry"
 	"github.com/harmony-one/harmony/internal/shardchain/tikv_manage"
@@ -36,6 +37,7 @@ import (
 	"github.com/harmony-one/bls/ffi/go/bls"
 
 	"github.com/harmony-one/harmony/api/service"
+	"github.com/harmony-one/harmony/api/service/lightnode"
 	"github.com/harmony-one/harmony/api/service/pprof"
 	"github.com/harmony-one/harmony/api/service/prometheus"
 	"github.com/harmony-one/harmony/api/service/stagedstreamsync"
@@ -44,6 +46,7 @@ import (
 	"github.com/harmony-one/harmony/common/ntp"
 	"github.com/harmony-one/harmony/consensus"
 	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/rawdb"
 	"github.com/harmony-one/harmony/hmy/downloader"
 	"github.com/harmony-one/harmony/internal/cli"
 	"github.com/harmony-one/harmony/internal/common"
@@ -57,6 +60,8 @@ import (
 	"github.com/harmony-one/harmony/node"
 	"github.com/harmony-one/harmony/numeric"
 	"github.com/harmony-one/harmony/p2p"
+	syncproto "github.com/harmony-one/harmony/p2p/stream/protocols/sync"
+	"github.com/harmony-one/harmony/p2p/stream/protocols/txfetch"
 	"github.com/harmony-one/harmony/shard"
 	"github.com/harmony-one/harmony/webhooks"
 )
@@ -111,6 +116,12 @@ func init() {
 	rootCmd.AddCommand(dumpConfigLegacyCmd)
 	rootCmd.AddCommand(dumpDBCmd)
 	rootCmd.AddCommand(inspectDBCmd)
+	slashProtectCmd.AddCommand(slashProtectExportCmd)
+	slashProtectCmd.AddCommand(slashProtectImportCmd)
+	rootCmd.AddCommand(slashProtectCmd)
+	historyCmd.AddCommand(historyExportCmd)
+	historyCmd.AddCommand(historyImportCmd)
+	rootCmd.AddCommand(historyCmd)
 
 	if err := registerRootCmdFlags(); err != nil {
 		os.Exit(2)
@@ -124,6 +135,9 @@ func init() {
 	if err := registerInspectionFlags(); err != nil {
 		os.Exit(2)
 	}
+	if err := registerHistoryFlags(); err != nil {
+		os.Exit(2)
+	}
 }
 
 func main() {
@@ -246,6 +260,7 @@ func applyRootFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
 	applyDevnetFlags(cmd, config)
 	applyRevertFlags(cmd, config)
 	applyPreimageFlags(cmd, config)
+	applyIPCFlags(cmd, config)
 	applyPrometheusFlags(cmd, config)
 	applySyncFlags(cmd, config)
 	applyShardDataFlags(cmd, config)
@@ -458,6 +473,13 @@ func setupNodeAndRun(hc harmonyconfig.HarmonyConfig) {
 		}).Msg("verbose prints config")
 	}
 
+	// A light node only syncs the epoch chain and verifies the shard chain headers,
+	// serving the verified data over RPC
+	if hc.General.NodeType == nodeTypeLight {
+		runLightNode(currentNode, myHost, hc)
+		return
+	}
+
 	// Setup services
 	if hc.Sync.Enabled {
 		if hc.Sync.StagedSync {
@@ -466,6 +488,9 @@ func setupNodeAndRun(hc harmonyconfig.HarmonyConfig) {
 			setupSyncService(currentNode, myHost, hc)
 		}
 	}
+	if !hc.General.IsOffline {
+		setupTxFetchService(currentNode, myHost, hc)
+	}
 	if currentNode.NodeConfig.Role() == nodeconfig.Validator {
 		currentNode.RegisterValidatorServices()
 	} else if currentNode.NodeConfig.Role() == nodeconfig.ExplorerNode {
@@ -537,6 +562,41 @@ func setupNodeAndRun(hc harmonyconfig.HarmonyConfig) {
 	select {}
 }
 
+func runLightNode(node *node.Node, host p2p.Host, hc harmonyconfig.HarmonyConfig) {
+	backend, err := setupLightNodeService(node, host, hc)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR cannot set up light node: %v\n", err)
+		os.Exit(1)
+	}
+	if hc.Pprof.Enabled {
+		setupPprofService(node, hc)
+	}
+	if hc.Prometheus.Enabled {
+		setupPrometheusService(node, hc, node.NodeConfig.ShardID)
+	}
+
+	if err := node.StartServices(); err != nil {
+		fmt.Fprint(os.Stderr, err.Error())
+		os.Exit(-1)
+	}
+
+	if err := node.StartLightRPC(backend); err != nil {
+		utils.Logger().Warn().
+			Err(err).
+			Msg("StartLightRPC failed")
+	}
+
+	go listenOSSigAndShutDown(node)
+
+	if err := host.Start(); err != nil {
+		utils.Logger().Fatal().
+			Err(err).
+			Msg("Start p2p host failed")
+	}
+
+	select {}
+}
+
 func nodeconfigSetShardSchedule(config harmonyconfig.HarmonyConfig) {
 	switch config.Network.NetworkType {
 	case nodeconfig.Mainnet:
@@ -791,9 +851,13 @@ func setupConsensusAndNode(hc harmonyconfig.HarmonyConfig, nodeConfig *nodeconfi
 	// Parse minPeers from harmonyconfig.HarmonyConfig
 	var minPeers int
 	var aggregateSig bool
+	var slashingProtection bool
+	var doppelgangerBlocks uint64
 	if hc.Consensus != nil {
 		minPeers = hc.Consensus.MinPeers
 		aggregateSig = hc.Consensus.AggregateSig
+		slashingProtection = hc.Consensus.SlashingProtection
+		doppelgangerBlocks = hc.Consensus.DoppelgangerBlocks
 	} else {
 		minPeers = defaultConsensusConfig.MinPeers
 		aggregateSig = defaultConsensusConfig.AggregateSig
@@ -820,6 +884,17 @@ func setupConsensusAndNode(hc harmonyconfig.HarmonyConfig, nodeConfig *nodeconfi
 	cxPool := core.NewCxPool(core.CxPoolSize)
 	registry.SetCxPool(cxPool)
 
+	if slashingProtection {
+		dir := filepath.Join(nodeConfig.DBDir, slashprotect.DefaultDirName)
+		db, err := slashprotect.Open(dir)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Error :%v \n", err)
+			os.Exit(1)
+		}
+		utils.Logger().Info().Str("dir", dir).Msg("slashing protection enabled")
+		registry.SetSlashingProtection(db)
+	}
+
 	// Consensus object.
 	decider := quorum.NewDecider(quorum.SuperMajorityVote, nodeConfig.ShardID)
 	registry.SetIsBackup(isBackup(hc))
@@ -830,6 +905,9 @@ func setupConsensusAndNode(hc harmonyconfig.HarmonyConfig, nodeConfig *nodeconfi
 		_, _ = fmt.Fprintf(os.Stderr, "Error :%v \n", err)
 		os.Exit(1)
 	}
+	if hc.General.NodeType == nodeTypeValidator && doppelgangerBlocks > 0 {
+		currentConsensus.StartDoppelgangerProtection(doppelgangerBlocks)
+	}
 
 	currentNode := node.New(myHost, currentConsensus, blacklist, allowedTxs, localAccounts, &hc, registry)
 
@@ -1001,12 +1079,9 @@ func setupSyncService(node *node.Node, host p2p.Host, hc harmonyconfig.HarmonyCo
 	}
 }
 
-func setupStagedSyncService(node *node.Node, host p2p.Host, hc harmonyconfig.HarmonyConfig) {
-	blockchains := []core.BlockChain{node.Blockchain()}
-	if node.Blockchain().ShardID() != shard.BeaconChainShardID {
-		blockchains = append(blockchains, node.EpochChain())
-	}
-
+// newStagedSyncConfig returns the config of the staged stream sync, with the
+// sync checkpoint verified
+func newStagedSyncConfig(node *node.Node, hc harmonyconfig.HarmonyConfig) stagedstreamsync.Config {
 	sConfig := stagedstreamsync.Config{
 		ServerOnly:           !hc.Sync.Downloader,
 		Network:              nodeconfig.NetworkType(hc.Network.NetworkType),
@@ -1020,9 +1095,33 @@ func setupStagedSyncService(node *node.Node, host p2p.Host, hc harmonyconfig.Har
 		SmDiscBatch:          hc.Sync.DiscBatch,
 		UseMemDB:             hc.Sync.StagedSyncCfg.UseMemDB,
 		LogProgress:          hc.Sync.StagedSyncCfg.LogProgress,
+		SnapSync:             hc.Sync.StagedSyncCfg.SnapSync,
 		DebugMode:            hc.Sync.StagedSyncCfg.DebugMode,
 	}
 
+	if hc.Sync.Checkpoint != "" {
+		checkpoint, err := stagedstreamsync.LoadCheckpoint(hc.Sync.Checkpoint)
+		if err == nil {
+			err = checkpoint.Verify(node.Blockchain().Config())
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "ERROR invalid sync checkpoint: %v\n", err)
+			os.Exit(1)
+		}
+		sConfig.Checkpoint = checkpoint
+	}
+
+	return sConfig
+}
+
+func setupStagedSyncService(node *node.Node, host p2p.Host, hc harmonyconfig.HarmonyConfig) {
+	blockchains := []core.BlockChain{node.Blockchain()}
+	if node.Blockchain().ShardID() != shard.BeaconChainShardID {
+		blockchains = append(blockchains, node.EpochChain())
+	}
+
+	sConfig := newStagedSyncConfig(node, hc)
+
 	// If we are running side chain, we will need to do some extra works for beacon
 	// sync.
 	if !node.IsRunningBeaconChain() {
@@ -1042,6 +1141,73 @@ func setupStagedSyncService(node *node.Node, host p2p.Host, hc harmonyconfig.Har
 	}
 }
 
+// setupLightNodeService sets up the sync of the epoch chain, and the light node
+// service verifying the shard chain headers against the committees of the epoch
+// chain. The sync protocol of the shard only requests data from the peers.
+func setupLightNodeService(node *node.Node, host p2p.Host, hc harmonyconfig.HarmonyConfig) (*lightnode.Backend, error) {
+	sConfig := newStagedSyncConfig(node, hc)
+	// the committees are always synced
+	sConfig.ServerOnly = false
+	s := stagedstreamsync.NewEpochChainService(host, node.EpochChain(), node.Consensus, sConfig, hc.General.DataDir)
+	node.RegisterService(service.StagedStreamSync, s)
+
+	shardID := node.Blockchain().ShardID()
+	var protocol *syncproto.Protocol
+	if shardID == shard.BeaconChainShardID {
+		// the epoch chain downloader has the streams of the beacon shard
+		protocol = s.Downloaders.GetShardDownloader(shardID).SyncProtocol()
+	} else {
+		protocol = syncproto.NewProtocol(syncproto.Config{
+			Chain:                node.Blockchain(),
+			Host:                 host.GetP2PHost(),
+			Discovery:            host.GetDiscovery(),
+			ShardID:              nodeconfig.ShardID(shardID),
+			Network:              nodeconfig.NetworkType(hc.Network.NetworkType),
+			ClientOnly:           true,
+			MaxAdvertiseWaitTime: hc.Sync.MaxAdvertiseWaitTime,
+			SmSoftLowCap:         hc.Sync.DiscSoftLowCap,
+			SmHardLowCap:         hc.Sync.DiscHardLowCap,
+			SmHiCap:              hc.Sync.DiscHighCap,
+			DiscBatch:            hc.Sync.DiscBatch,
+		})
+		host.AddStreamProtocol(protocol)
+	}
+
+	db, err := rawdb.NewLevelDBDatabase(filepath.Join(hc.General.DataDir, fmt.Sprintf("harmony_light_%d", shardID)), 256, 1024, "", false)
+	if err != nil {
+		return nil, err
+	}
+	headerChain, err := lightnode.NewHeaderChain(db, node.EpochChain(), node.Blockchain().GetHeaderByNumber(0))
+	if err != nil {
+		return nil, err
+	}
+	backend := lightnode.NewBackend(headerChain, node.EpochChain(), protocol)
+	node.RegisterService(service.LightNode, lightnode.NewService(backend))
+	return backend, nil
+}
+
+// setupTxFetchService sets up the protocol serving the pooled transactions to
+// the peers, and the fetcher of the transactions they announce
+func setupTxFetchService(node *node.Node, host p2p.Host, hc harmonyconfig.HarmonyConfig) {
+	tp := txfetch.NewProtocol(txfetch.Config{
+		Pool:                 node.TxPool,
+		Host:                 host.GetP2PHost(),
+		Discovery:            host.GetDiscovery(),
+		ShardID:              nodeconfig.ShardID(node.Blockchain().ShardID()),
+		Network:              nodeconfig.NetworkType(hc.Network.NetworkType),
+		MaxAdvertiseWaitTime: hc.Sync.MaxAdvertiseWaitTime,
+		SmSoftLowCap:         hc.Sync.DiscSoftLowCap,
+		SmHardLowCap:         hc.Sync.DiscHardLowCap,
+		SmHiCap:              hc.Sync.DiscHighCap,
+		DiscBatch:            hc.Sync.DiscBatch,
+	})
+	host.AddStreamProtocol(tp)
+
+	fetcher := txfetch.NewFetcher(tp, node.AddFetchedTransactions)
+	node.SetTxFetcher(fetcher)
+	node.RegisterService(service.TxFetch, fetcher)
+}
+
 func setupBlacklist(hc harmonyconfig.HarmonyConfig) (map[ethCommon.Address]struct{}, error) {
 	rosetta_common.InitRosettaFile(hc.TxPool.RosettaFixFile)
 
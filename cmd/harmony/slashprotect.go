@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harmony-one/harmony/consensus/slashprotect"
+)
+
+var slashProtectCmd = &cobra.Command{
+	Use:   "slashprotect",
+	Short: "manage the slashing protection db of the bls keys",
+	Long:  "manage the slashing protection db of the bls keys. Stop the node before using any of the sub commands.",
+}
+
+var slashProtectExportCmd = &cobra.Command{
+	Use:     "export dbdir file",
+	Short:   "export the signing history to an interchange file",
+	Long:    "export the signing history of all bls keys in the slashing protection db to an interchange file",
+	Example: "harmony slashprotect export ./slashing_protection history.json",
+	Args:    cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := exportSlashingProtection(args[0], args[1]); err != nil {
+			fmt.Fprintf(os.Stderr, "export failed: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("slashing protection history exported to", args[1])
+	},
+}
+
+var slashProtectImportCmd = &cobra.Command{
+	Use:     "import dbdir file",
+	Short:   "import the signing history from an interchange file",
+	Long:    "import the signing history of bls keys from an interchange file into the slashing protection db",
+	Example: "harmony slashprotect import ./slashing_protection history.json",
+	Args:    cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := importSlashingProtection(args[0], args[1]); err != nil {
+			fmt.Fprintf(os.Stderr, "import failed: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("slashing protection history imported from", args[1])
+	},
+}
+
+func exportSlashingProtection(dbDir, file string) error {
+	db, err := slashprotect.Open(dbDir)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return db.Export(f)
+}
+
+func importSlashingProtection(dbDir, file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	db, err := slashprotect.Open(dbDir)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return db.Import(f)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/harmony-one/abool"
 	bls_core "github.com/harmony-one/bls/ffi/go/bls"
 	"github.com/harmony-one/harmony/consensus/quorum"
+	"github.com/harmony-one/harmony/consensus/slashprotect"
 	"github.com/harmony-one/harmony/core/types"
 	bls_cosi "github.com/harmony-one/harmony/crypto/bls"
 	"github.com/harmony-one/harmony/internal/utils"
@@ -130,6 +131,11 @@ type Consensus struct {
 
 	dHelper *downloadHelper
 
+	// slashProtect is the persistent signing history of the local keys, nil if disabled
+	slashProtect *slashprotect.DB
+	// doppelganger is the doppelganger protection state, nil if disabled
+	doppelganger *doppelganger
+
 	// Both flags only for initialization state.
 	start           bool
 	isInitialLeader bool
@@ -280,6 +286,7 @@ func New(
 		host:            host,
 		msgSender:       NewMessageSender(host),
 		BlockNumLowChan: make(chan struct{}, 1),
+		slashProtect:    registry.GetSlashingProtection(),
 		// FBFT timeout
 		consensusTimeout: createTimeout(),
 	}
@@ -309,6 +316,10 @@ func New(
 	verifier := VerifyNewBlock(registry.GetWebHooks(), consensus.Blockchain(), consensus.Beaconchain())
 	consensus.BlockVerifier = verifier
 	consensus.vc.verifyBlock = consensus.verifyBlock
+	consensus.vc.isSafeToSign = consensus.isSafeToSign
+	if bc := consensus.Blockchain(); bc != nil {
+		consensus.restoreFBFTLog(bc.ChainDb())
+	}
 
 	// init prometheus metrics
 	initMetrics()
@@ -18,6 +18,7 @@ import (
 	consensus_engine "github.com/harmony-one/harmony/consensus/engine"
 	"github.com/harmony-one/harmony/consensus/quorum"
 	"github.com/harmony-one/harmony/consensus/signature"
+	"github.com/harmony-one/harmony/consensus/slashprotect"
 	bls_cosi "github.com/harmony-one/harmony/crypto/bls"
 	"github.com/harmony-one/harmony/crypto/hash"
 	"github.com/harmony-one/harmony/internal/chain"
@@ -62,7 +63,7 @@ var (
 
 // Signs the consensus message and returns the marshaled message.
 func (consensus *Consensus) signAndMarshalConsensusMessage(message *msg_pb.Message,
-	priKey *bls_core.SecretKey) ([]byte, error) {
+	priKey bls_cosi.Signer) ([]byte, error) {
 	if err := consensus.signConsensusMessage(message, priKey); err != nil {
 		return empty, err
 	}
@@ -119,22 +120,28 @@ func (consensus *Consensus) updatePublicKeys(pubKeys, allowlist []bls_cosi.Publi
 }
 
 // Sign on the hash of the message
-func (consensus *Consensus) signMessage(message []byte, priKey *bls_core.SecretKey) []byte {
+func (consensus *Consensus) signMessage(message []byte, priKey bls_cosi.Signer) ([]byte, error) {
 	hash := hash.Keccak256(message)
 	signature := priKey.SignHash(hash[:])
-	return signature.Serialize()
+	if signature == nil {
+		return nil, errSignFailed
+	}
+	return signature.Serialize(), nil
 }
 
 // Sign on the consensus message signature field.
 func (consensus *Consensus) signConsensusMessage(message *msg_pb.Message,
-	priKey *bls_core.SecretKey) error {
+	priKey bls_cosi.Signer) error {
 	message.Signature = nil
 	marshaledMessage, err := protobuf.Marshal(message)
 	if err != nil {
 		return err
 	}
 	// 64 byte of signature on previous data
-	signature := consensus.signMessage(marshaledMessage, priKey)
+	signature, err := consensus.signMessage(marshaledMessage, priKey)
+	if err != nil {
+		return err
+	}
 	message.Signature = signature
 	return nil
 }
@@ -564,6 +571,7 @@ func (consensus *Consensus) switchPhase(subject string, desired FBFTPhase) {
 var (
 	errGetPreparedBlock  = errors.New("failed to get prepared block for self commit")
 	errReadBitmapPayload = errors.New("failed to read signature bitmap payload")
+	errSignFailed        = errors.New("failed to sign with bls key")
 )
 
 // selfCommit will create a commit message and commit it locally
@@ -595,6 +603,18 @@ func (consensus *Consensus) selfCommit(payload []byte) error {
 	commitPayload := signature.ConstructCommitPayload(consensus.ChainReader().Config(),
 		block.Epoch(), block.Hash(), block.NumberU64(), block.Header().ViewID().Uint64())
 	for i, key := range consensus.priKey {
+		if !consensus.isSafeToSign(slashprotect.Commit, key.Pub,
+			block.NumberU64(), block.Header().ViewID().Uint64(), block.Hash()) {
+			continue
+		}
+		sig := key.Pri.SignHash(commitPayload)
+		if sig == nil {
+			consensus.getLogger().Error().
+				Int("Index", i).
+				Str("Key", key.Pub.Bytes.Hex()).
+				Msg("[selfCommit] New Leader failed to sign commit")
+			continue
+		}
 		if err := consensus.commitBitmap.SetKey(key.Pub.Bytes, true); err != nil {
 			consensus.getLogger().Error().
 				Err(err).
@@ -607,7 +627,7 @@ func (consensus *Consensus) selfCommit(payload []byte) error {
 		if _, err := consensus.Decider.AddNewVote(
 			quorum.Commit,
 			[]*bls_cosi.PublicKeyWrapper{key.Pub},
-			key.Pri.SignHash(commitPayload),
+			sig,
 			common.BytesToHash(consensus.blockHash[:]),
 			block.NumberU64(),
 			block.Header().ViewID().Uint64(),
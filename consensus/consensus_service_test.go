@@ -1,15 +1,23 @@
 package consensus
 
 import (
+	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/types"
 	"github.com/harmony-one/harmony/crypto/bls"
+	"github.com/harmony-one/harmony/internal/params"
 	"github.com/harmony-one/harmony/internal/registry"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
 	msg_pb "github.com/harmony-one/harmony/api/proto/message"
+	blockfactory "github.com/harmony-one/harmony/block/factory"
 	"github.com/harmony-one/harmony/consensus/quorum"
+	"github.com/harmony-one/harmony/consensus/slashprotect"
 	"github.com/harmony-one/harmony/internal/utils"
 	"github.com/harmony-one/harmony/multibls"
 	"github.com/harmony-one/harmony/p2p"
@@ -76,6 +84,73 @@ func TestSetViewID(t *testing.T) {
 	}
 }
 
+// configChain is a chain which only has a config
+type configChain struct {
+	core.BlockChain
+	config *params.ChainConfig
+}
+
+func (c configChain) Config() *params.ChainConfig { return c.config }
+
+func TestSelfCommit_SlashingProtection(t *testing.T) {
+	leader := p2p.Peer{IP: "127.0.0.1", Port: "9902"}
+	priKey, _, _ := utils.GenKeyP2P("127.0.0.1", "9902")
+	host, err := p2p.NewHost(p2p.HostConfig{
+		Self:   &leader,
+		BLSKey: priKey,
+	})
+	if err != nil {
+		t.Fatalf("newhost failure: %v", err)
+	}
+	priKeys := multibls.GetPrivateKeys(bls.RandPrivateKey(), bls.RandPrivateKey())
+	members := []bls.PublicKeyWrapper{*priKeys[0].Pub, *priKeys[1].Pub}
+	block := types.NewBlockWithHeader(blockfactory.NewTestHeader().With().
+		Number(big.NewInt(10)).ViewID(big.NewInt(5)).Header())
+
+	// before a restart, the first key committed to another block of the height
+	// and view
+	protect := slashprotect.New(rawdb.NewMemoryDatabase())
+	require.NoError(t, protect.CheckAndRecord(priKeys[0].Pub.Bytes, slashprotect.Vote{
+		Phase:     slashprotect.Commit,
+		BlockNum:  10,
+		ViewID:    5,
+		BlockHash: common.Hash{0x01},
+	}))
+
+	decider := quorum.NewDecider(quorum.SuperMajorityVote, shard.BeaconChainShardID)
+	reg := registry.New().SetSlashingProtection(protect)
+	consensus, err := New(host, shard.BeaconChainShardID, priKeys, reg, decider, 3, false)
+	if err != nil {
+		t.Fatalf("Cannot craeate consensus: %v", err)
+	}
+	reg.SetBlockchain(configChain{config: params.TestChainConfig})
+	consensus.Decider.UpdateParticipants(members, []bls.PublicKeyWrapper{})
+	consensus.updateBitmaps()
+	consensus.fBFTLog.AddBlock(block)
+
+	// the m1 payload of the view change, prepared by both keys
+	hash := block.Hash()
+	aggSig := priKeys[0].Pri.SignHash(hash[:])
+	aggSig.Add(priKeys[1].Pri.SignHash(hash[:]))
+	mask := bls.NewMask(members)
+	require.NoError(t, mask.SetKey(priKeys[0].Pub.Bytes, true))
+	require.NoError(t, mask.SetKey(priKeys[1].Pub.Bytes, true))
+	payload := append(hash[:], aggSig.Serialize()...)
+	payload = append(payload, mask.Bitmap...)
+
+	require.NoError(t, consensus.selfCommit(payload))
+
+	// only the key with no conflicting commit signs
+	enabled, err := consensus.commitBitmap.KeyEnabled(priKeys[0].Pub.Bytes)
+	require.NoError(t, err)
+	require.False(t, enabled)
+	require.Nil(t, consensus.Decider.ReadBallot(quorum.Commit, priKeys[0].Pub.Bytes))
+	enabled, err = consensus.commitBitmap.KeyEnabled(priKeys[1].Pub.Bytes)
+	require.NoError(t, err)
+	require.True(t, enabled)
+	require.NotNil(t, consensus.Decider.ReadBallot(quorum.Commit, priKeys[1].Pub.Bytes))
+}
+
 func TestErrors(t *testing.T) {
 	e1 := errors.New("e1")
 	require.True(t, errors.Is(e1, e1))
@@ -12,6 +12,7 @@ import (
 	"github.com/harmony-one/harmony/api/proto"
 	msg_pb "github.com/harmony-one/harmony/api/proto/message"
 	"github.com/harmony-one/harmony/consensus/quorum"
+	"github.com/harmony-one/harmony/consensus/slashprotect"
 	"github.com/harmony-one/harmony/internal/utils"
 )
 
@@ -64,6 +65,17 @@ func (consensus *Consensus) construct(
 	if len(priKeys) == 0 {
 		return nil, errors.New("no elected bls keys provided")
 	}
+	switch p {
+	case msg_pb.MessageType_PREPARE:
+		priKeys = consensus.keysSafeToSign(slashprotect.Prepare, priKeys,
+			consensus.getBlockNum(), consensus.getCurBlockViewID(), consensus.blockHash)
+	case msg_pb.MessageType_COMMIT:
+		priKeys = consensus.keysSafeToSign(slashprotect.Commit, priKeys,
+			consensus.getBlockNum(), consensus.getCurBlockViewID(), consensus.blockHash)
+	}
+	if len(priKeys) == 0 {
+		return nil, errors.New("all bls keys refused by slashing protection")
+	}
 	message := &msg_pb.Message{
 		ServiceType: msg_pb.ServiceType_CONSENSUS,
 		Type:        p,
@@ -0,0 +1,288 @@
+package consensus
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	bls_core "github.com/harmony-one/bls/ffi/go/bls"
+	"github.com/prometheus/client_golang/prometheus"
+
+	msg_pb "github.com/harmony-one/harmony/api/proto/message"
+	"github.com/harmony-one/harmony/block"
+	"github.com/harmony-one/harmony/consensus/signature"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/crypto/bls"
+	"github.com/harmony-one/harmony/multibls"
+	"github.com/harmony-one/harmony/webhooks"
+)
+
+// Where a doppelganger was seen, used as metrics label and in the webhook
+const (
+	doppelgangerFromMessage = "message"
+	doppelgangerFromBitmap  = "bitmap"
+)
+
+// doppelganger is the state of the doppelganger protection. While it observes the
+// network, the keys of the node are withheld from consensus so that nothing is
+// signed with them. Only blocks produced after the start count towards the
+// observation, so that the signatures of the previous host of the keys are not
+// taken for a doppelganger while catching up.
+type doppelganger struct {
+	mu        sync.Mutex
+	keys      multibls.PrivateKeys
+	startTime int64
+	remaining uint64
+	detected  bool
+}
+
+func newDoppelganger(keys multibls.PrivateKeys, blocks uint64, start time.Time) *doppelganger {
+	return &doppelganger{
+		keys:      keys,
+		startTime: start.Unix(),
+		remaining: blocks,
+	}
+}
+
+// observing returns whether the observation is still running
+func (dg *doppelganger) observing() bool {
+	dg.mu.Lock()
+	defer dg.mu.Unlock()
+	return dg.remaining > 0 && !dg.detected
+}
+
+// match returns the keys of the node among the signers
+func (dg *doppelganger) match(signers []*bls.PublicKeyWrapper) []bls.SerializedPublicKey {
+	var found []bls.SerializedPublicKey
+	for _, signer := range signers {
+		for _, key := range dg.keys {
+			if key.Pub.Bytes == signer.Bytes {
+				found = append(found, signer.Bytes)
+			}
+		}
+	}
+	return found
+}
+
+// detect records a doppelganger. It returns false if one was already detected.
+func (dg *doppelganger) detect() bool {
+	dg.mu.Lock()
+	defer dg.mu.Unlock()
+	if dg.detected {
+		return false
+	}
+	dg.detected = true
+	return true
+}
+
+// countBlock counts the block with the given timestamp towards the observation.
+// It returns true once the observation finished without detecting a doppelganger.
+func (dg *doppelganger) countBlock(blockTime int64) bool {
+	dg.mu.Lock()
+	defer dg.mu.Unlock()
+	if dg.detected || dg.remaining == 0 || blockTime < dg.startTime {
+		return false
+	}
+	dg.remaining--
+	return dg.remaining == 0
+}
+
+// StartDoppelgangerProtection withholds the keys of the node from consensus and
+// observes prepare/commit messages and block signer bitmaps for the given number
+// of blocks. If none of the keys is seen signing elsewhere, the keys are given
+// back to consensus. It must be called before the node handles consensus messages.
+func (consensus *Consensus) StartDoppelgangerProtection(blocks uint64) {
+	if blocks == 0 || consensus.Blockchain() == nil {
+		return
+	}
+	consensus.mutex.Lock()
+	dg := newDoppelganger(consensus.priKey, blocks, time.Now())
+	consensus.priKey = multibls.PrivateKeys{}
+	consensus.doppelganger = dg
+	consensus.mutex.Unlock()
+
+	consensusDoppelgangerGauge.Set(1)
+	consensus.getLogger().Warn().
+		Uint64("blocks", blocks).
+		Str("keys", dg.keys.GetPublicKeys().SerializeToHexStr()).
+		Msg("[Doppelganger] Observing the network before signing with the bls keys")
+
+	go consensus.observeDoppelgangerBlocks(dg)
+}
+
+// ObserveDoppelganger checks whether the prepare or commit message is signed with
+// one of the keys withheld by the doppelganger protection.
+func (consensus *Consensus) ObserveDoppelganger(msg *msg_pb.Message) {
+	if msg.Type != msg_pb.MessageType_PREPARE && msg.Type != msg_pb.MessageType_COMMIT {
+		return
+	}
+	req := msg.GetConsensus()
+	if req == nil || req.ShardId != consensus.ShardID {
+		return
+	}
+
+	// snapshot the consensus state under the lock, the committee may be updated
+	// concurrently
+	consensus.mutex.RLock()
+	dg := consensus.doppelganger
+	participants := consensus.Decider.Participants()
+	var blockObj *types.Block
+	if msg.Type == msg_pb.MessageType_COMMIT {
+		blockObj = consensus.fBFTLog.GetBlockByHash(common.BytesToHash(req.BlockHash))
+	}
+	consensus.mutex.RUnlock()
+
+	if dg == nil || !dg.observing() {
+		return
+	}
+	mask := bls.NewMask(participants)
+	if len(req.SenderPubkey) == bls.PublicKeySizeInBytes {
+		var key bls.SerializedPublicKey
+		copy(key[:], req.SenderPubkey)
+		if err := mask.SetKey(key, true); err != nil {
+			return
+		}
+	} else if err := mask.SetMask(req.SenderPubkeyBitmap); err != nil {
+		return
+	}
+	signers, err := mask.GetSignedPubKeysFromBitmap(mask.Bitmap)
+	if err != nil {
+		return
+	}
+	found := dg.match(signers)
+	if len(found) == 0 {
+		return
+	}
+
+	// check the signature so that a forged message can't keep the node from signing
+	sig := bls_core.Sign{}
+	if err := sig.Deserialize(req.Payload); err != nil {
+		return
+	}
+	payload := req.BlockHash
+	if msg.Type == msg_pb.MessageType_COMMIT {
+		// the commit payload depends on the epoch of the block committed, which is
+		// known only with the block
+		bc := consensus.Blockchain()
+		var header *block.Header
+		if blockObj != nil {
+			header = blockObj.Header()
+		} else {
+			header = bc.GetHeaderByHash(common.BytesToHash(req.BlockHash))
+		}
+		if header == nil || header.Number().Uint64() != req.BlockNum {
+			return
+		}
+		payload = signature.ConstructCommitPayload(bc.Config(), header.Epoch(),
+			header.Hash(), req.BlockNum, req.ViewId)
+	}
+	if !sig.VerifyHash(mask.AggregatePublic, payload) {
+		return
+	}
+	consensus.onDoppelganger(dg, found, req.BlockNum, doppelgangerFromMessage)
+}
+
+// observeDoppelgangerBlocks checks the signer bitmaps of the new blocks until the
+// observation finishes.
+func (consensus *Consensus) observeDoppelgangerBlocks(dg *doppelganger) {
+	bc := consensus.Blockchain()
+	chainEvents := make(chan core.ChainEvent, 16)
+	sub := bc.SubscribeChainEvent(chainEvents)
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case ev := <-chainEvents:
+			if !dg.observing() {
+				return
+			}
+			consensus.checkDoppelgangerBitmap(dg, ev.Block)
+			if dg.countBlock(int64(ev.Block.Time().Uint64())) {
+				consensus.finishDoppelgangerProtection(dg)
+				return
+			}
+		case <-sub.Err():
+			return
+		}
+	}
+}
+
+// checkDoppelgangerBitmap checks the signers of the parent block, if the parent
+// block was produced after the start of the observation.
+func (consensus *Consensus) checkDoppelgangerBitmap(dg *doppelganger, block *types.Block) {
+	bc := consensus.Blockchain()
+	parent := bc.GetHeaderByHash(block.ParentHash())
+	if parent == nil || int64(parent.Time().Uint64()) < dg.startTime {
+		return
+	}
+	shardState, err := bc.ReadShardState(parent.Epoch())
+	if err != nil {
+		return
+	}
+	committee, err := shardState.FindCommitteeByID(block.ShardID())
+	if err != nil {
+		return
+	}
+	keys, err := committee.BLSPublicKeys()
+	if err != nil {
+		return
+	}
+	mask := bls.NewMask(keys)
+	signers, err := mask.GetSignedPubKeysFromBitmap(block.Header().LastCommitBitmap())
+	if err != nil {
+		return
+	}
+	if found := dg.match(signers); len(found) != 0 {
+		consensus.onDoppelganger(dg, found, parent.Number().Uint64(), doppelgangerFromBitmap)
+	}
+}
+
+// onDoppelganger keeps the keys withheld for good and reports the doppelganger
+func (consensus *Consensus) onDoppelganger(
+	dg *doppelganger, keys []bls.SerializedPublicKey, blockNum uint64, source string,
+) {
+	if !dg.detect() {
+		return
+	}
+	consensusDoppelgangerGauge.Set(0)
+	consensusDoppelgangerCounterVec.With(prometheus.Labels{"source": source}).Inc()
+
+	hexKeys := make([]string, 0, len(keys))
+	for _, key := range keys {
+		hexKeys = append(hexKeys, key.Hex())
+	}
+	consensus.getLogger().Error().
+		Strs("keys", hexKeys).
+		Uint64("blockNum", blockNum).
+		Str("source", source).
+		Msg("[Doppelganger] Bls keys are signing on another node, refusing to sign. " +
+			"Stop the other node and restart this one")
+
+	if hooks := consensus.registry.GetWebHooks(); hooks != nil {
+		if s := hooks.Slashing; s != nil && s.OnDoppelganger != "" {
+			go webhooks.DoPost(s.OnDoppelganger, map[string]interface{}{
+				"keys":     hexKeys,
+				"blockNum": blockNum,
+				"source":   source,
+			})
+		}
+	}
+}
+
+// finishDoppelgangerProtection gives the keys back to consensus
+func (consensus *Consensus) finishDoppelgangerProtection(dg *doppelganger) {
+	consensus.mutex.Lock()
+	defer consensus.mutex.Unlock()
+
+	consensus.priKey = dg.keys
+	consensusDoppelgangerGauge.Set(0)
+	consensus.getLogger().Info().
+		Str("keys", dg.keys.GetPublicKeys().SerializeToHexStr()).
+		Msg("[Doppelganger] No doppelganger seen, start signing with the bls keys")
+
+	consensus.AddPubkeyMetrics()
+	if consensus.current.Mode() != Syncing {
+		consensus.current.SetMode(consensus.updateConsensusInformation())
+	}
+}
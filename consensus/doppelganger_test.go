@@ -0,0 +1,43 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/harmony-one/harmony/crypto/bls"
+	"github.com/harmony-one/harmony/multibls"
+)
+
+func TestDoppelganger(t *testing.T) {
+	keys := multibls.GetPrivateKeys(bls.RandPrivateKey(), bls.RandPrivateKey())
+	other := bls.WrapperFromPrivateKey(bls.RandPrivateKey())
+	start := time.Unix(1000, 0)
+	dg := newDoppelganger(keys, 2, start)
+
+	if found := dg.match([]*bls.PublicKeyWrapper{other.Pub}); len(found) != 0 {
+		t.Errorf("unexpected match %v", found)
+	}
+	found := dg.match([]*bls.PublicKeyWrapper{other.Pub, keys[1].Pub})
+	if len(found) != 1 || found[0] != keys[1].Pub.Bytes {
+		t.Errorf("unexpected match %v", found)
+	}
+
+	// blocks produced before the start don't count
+	if dg.countBlock(999) || !dg.observing() {
+		t.Fatal("observation finished early")
+	}
+	if dg.countBlock(1000) || !dg.observing() {
+		t.Fatal("observation finished early")
+	}
+	if !dg.countBlock(1001) || dg.observing() {
+		t.Fatal("observation not finished")
+	}
+
+	dg = newDoppelganger(keys, 2, start)
+	if !dg.detect() || dg.detect() {
+		t.Error("doppelganger must be reported once")
+	}
+	if dg.countBlock(1000) || dg.countBlock(1001) || dg.observing() {
+		t.Error("observation must not finish after a doppelganger is detected")
+	}
+}
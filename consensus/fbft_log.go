@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
 	bls_core "github.com/harmony-one/bls/ffi/go/bls"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
@@ -113,6 +114,10 @@ type FBFTLog struct {
 	blocks         map[common.Hash]*types.Block // store blocks received in FBFT
 	verifiedBlocks map[common.Hash]struct{}     // store block hashes for blocks that has already been verified
 	messages       map[fbftMsgID]*FBFTMessage   // store messages received in FBFT
+	db             ethdb.KeyValueStore          // persist the log to survive restarts, nil if in memory only
+	batch          ethdb.Batch                  // pending writes to db, until flushed
+	writeC         chan ethdb.Batch             // flushed batches, written to db by the background writer
+	writes         sync.WaitGroup               // flushed batches not written yet
 }
 
 // NewFBFTLog returns new instance of FBFTLog
@@ -128,11 +133,13 @@ func NewFBFTLog() *FBFTLog {
 // AddBlock add a new block into the log
 func (log *FBFTLog) AddBlock(block *types.Block) {
 	log.blocks[block.Hash()] = block
+	log.persistBlock(block)
 }
 
 // MarkBlockVerified marks the block as verified
 func (log *FBFTLog) MarkBlockVerified(block *types.Block) {
 	log.verifiedBlocks[block.Hash()] = struct{}{}
+	log.persistBlock(block)
 }
 
 // IsBlockVerified checks whether the block is verified
@@ -163,6 +170,7 @@ func (log *FBFTLog) deleteBlocksLessThan(number uint64) {
 		if block.NumberU64() < number {
 			delete(log.blocks, h)
 			delete(log.verifiedBlocks, h)
+			log.unpersistBlock(block)
 		}
 	}
 }
@@ -173,6 +181,7 @@ func (log *FBFTLog) DeleteBlockByNumber(number uint64) {
 		if block.NumberU64() == number {
 			delete(log.blocks, h)
 			delete(log.verifiedBlocks, h)
+			log.unpersistBlock(block)
 		}
 	}
 }
@@ -182,6 +191,7 @@ func (log *FBFTLog) deleteMessagesLessThan(number uint64) {
 	for h, msg := range log.messages {
 		if msg.BlockNum < number {
 			delete(log.messages, h)
+			log.unpersistMessage(h, msg)
 		}
 	}
 }
@@ -191,6 +201,12 @@ func (log *FBFTLog) AddVerifiedMessage(msg *FBFTMessage) {
 	msg.Verified = true
 
 	log.messages[msg.id()] = msg
+	log.persistMessage(msg)
+	if msg.MessageType == msg_pb.MessageType_PREPARED {
+		// the prepared block is restored after a restart in the round, it
+		// must be in the db before the node moves on to commit
+		log.flushSync()
+	}
 }
 
 // AddNotVerifiedMessage adds a not signature verified pbft message into the log
@@ -198,6 +214,7 @@ func (log *FBFTLog) AddNotVerifiedMessage(msg *FBFTMessage) {
 	msg.Verified = false
 
 	log.messages[msg.id()] = msg
+	log.persistMessage(msg)
 }
 
 // GetNotVerifiedCommittedMessages returns not verified committed pbft messages with matching blockNum, viewID and blockHash
@@ -370,6 +387,7 @@ func (log *FBFTLog) GetCommittedBlockAndMsgsFromNumber(bn uint64, logger *zerolo
 func (log *FBFTLog) PruneCacheBeforeBlock(bn uint64) {
 	log.deleteBlocksLessThan(bn - 1)
 	log.deleteMessagesLessThan(bn - 1)
+	log.flush()
 }
 
 type threadsafeFBFTLog struct {
@@ -0,0 +1,323 @@
+package consensus
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+
+	msg_pb "github.com/harmony-one/harmony/api/proto/message"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/crypto/bls"
+	"github.com/harmony-one/harmony/internal/utils"
+)
+
+// fbftBlockStorage is the storage format of a block in the FBFT log
+type fbftBlockStorage struct {
+	Block    *types.Block
+	Verified bool
+}
+
+// fbftMessageStorage is the storage format of a message in the FBFT log.
+// Only the fields used by the messages kept in the log (announce, prepared
+// and committed) are stored, the view change fields are dropped.
+type fbftMessageStorage struct {
+	MessageType        uint32
+	ViewID             uint64
+	BlockNum           uint64
+	BlockHash          common.Hash
+	Block              []byte
+	SenderPubkeys      [][]byte
+	SenderPubkeyBitmap []byte
+	LeaderPubkey       []byte
+	Payload            []byte
+	Verified           bool
+}
+
+func encodeFBFTMessage(msg *FBFTMessage) ([]byte, error) {
+	stored := fbftMessageStorage{
+		MessageType:        uint32(msg.MessageType),
+		ViewID:             msg.ViewID,
+		BlockNum:           msg.BlockNum,
+		BlockHash:          msg.BlockHash,
+		Block:              msg.Block,
+		SenderPubkeys:      make([][]byte, 0, len(msg.SenderPubkeys)),
+		SenderPubkeyBitmap: msg.SenderPubkeyBitmap,
+		Payload:            msg.Payload,
+		Verified:           msg.Verified,
+	}
+	for _, key := range msg.SenderPubkeys {
+		stored.SenderPubkeys = append(stored.SenderPubkeys, key.Bytes[:])
+	}
+	if msg.LeaderPubkey != nil {
+		stored.LeaderPubkey = msg.LeaderPubkey.Bytes[:]
+	}
+	return rlp.EncodeToBytes(stored)
+}
+
+func decodeFBFTMessage(data []byte) (*FBFTMessage, error) {
+	var stored fbftMessageStorage
+	if err := rlp.DecodeBytes(data, &stored); err != nil {
+		return nil, err
+	}
+	msg := &FBFTMessage{
+		MessageType:        msg_pb.MessageType(stored.MessageType),
+		ViewID:             stored.ViewID,
+		BlockNum:           stored.BlockNum,
+		BlockHash:          stored.BlockHash,
+		Block:              stored.Block,
+		SenderPubkeyBitmap: stored.SenderPubkeyBitmap,
+		Payload:            stored.Payload,
+		Verified:           stored.Verified,
+	}
+	for _, b := range stored.SenderPubkeys {
+		key, err := decodePublicKeyWrapper(b)
+		if err != nil {
+			return nil, err
+		}
+		msg.SenderPubkeys = append(msg.SenderPubkeys, key)
+	}
+	if len(stored.LeaderPubkey) != 0 {
+		key, err := decodePublicKeyWrapper(stored.LeaderPubkey)
+		if err != nil {
+			return nil, err
+		}
+		msg.LeaderPubkey = key
+	}
+	return msg, nil
+}
+
+func decodePublicKeyWrapper(b []byte) (*bls.PublicKeyWrapper, error) {
+	pubKey, err := bls.BytesToBLSPublicKey(b)
+	if err != nil {
+		return nil, err
+	}
+	wrapper := &bls.PublicKeyWrapper{Object: pubKey}
+	copy(wrapper.Bytes[:], b)
+	return wrapper, nil
+}
+
+// fbftLogWriteQueue is the number of flushed batches of the FBFT log waiting
+// for the background writer
+const fbftLogWriteQueue = 16
+
+// setDB makes the log persistent in the db. The writes of the log are batched,
+// and the batch is handed to a background writer when flushed, so that the
+// consensus does not wait for the db.
+func (log *FBFTLog) setDB(db ethdb.KeyValueStore) {
+	log.db = db
+	log.batch = db.NewBatch()
+	log.writeC = make(chan ethdb.Batch, fbftLogWriteQueue)
+	go writeFBFTLogBatches(log.writeC, &log.writes)
+}
+
+// writeFBFTLogBatches writes the flushed batches in order
+func writeFBFTLogBatches(writeC <-chan ethdb.Batch, writes *sync.WaitGroup) {
+	for batch := range writeC {
+		if err := batch.Write(); err != nil {
+			utils.Logger().Warn().Err(err).Msg("[FBFTLog] failed to write persisted log")
+		}
+		writes.Done()
+	}
+}
+
+// flush hands the pending writes to the background writer, if the log is
+// persistent
+func (log *FBFTLog) flush() {
+	if log.db == nil || log.batch.ValueSize() == 0 {
+		return
+	}
+	log.writes.Add(1)
+	log.writeC <- log.batch
+	log.batch = log.db.NewBatch()
+}
+
+// flushSync writes the pending writes to the db before returning, after the
+// batches flushed before them, if the log is persistent
+func (log *FBFTLog) flushSync() {
+	if log.db == nil || log.batch.ValueSize() == 0 {
+		return
+	}
+	log.writes.Wait()
+	if err := log.batch.Write(); err != nil {
+		utils.Logger().Warn().Err(err).Msg("[FBFTLog] failed to write persisted log")
+	}
+	log.batch = log.db.NewBatch()
+}
+
+// closeDB writes the pending writes, stops the background writer once the
+// flushed batches are written and makes the log in memory only, so that the
+// db can be closed
+func (log *FBFTLog) closeDB() {
+	if log.db == nil {
+		return
+	}
+	log.flush()
+	close(log.writeC)
+	log.writes.Wait()
+	log.db, log.batch, log.writeC = nil, nil, nil
+}
+
+// persistBlock writes the block to the db, if the log is persistent
+func (log *FBFTLog) persistBlock(block *types.Block) {
+	if log.db == nil {
+		return
+	}
+	_, verified := log.verifiedBlocks[block.Hash()]
+	data, err := rlp.EncodeToBytes(fbftBlockStorage{Block: block, Verified: verified})
+	if err == nil {
+		err = rawdb.WriteFBFTLogBlock(log.batch, block.NumberU64(), block.Hash(), data)
+	}
+	if err != nil {
+		utils.Logger().Warn().Err(err).
+			Uint64("blockNum", block.NumberU64()).
+			Str("blockHash", block.Hash().Hex()).
+			Msg("[FBFTLog] failed to persist block")
+	}
+}
+
+// unpersistBlock removes the block from the db, if the log is persistent
+func (log *FBFTLog) unpersistBlock(block *types.Block) {
+	if log.db == nil {
+		return
+	}
+	if err := rawdb.DeleteFBFTLogBlock(log.batch, block.NumberU64(), block.Hash()); err != nil {
+		utils.Logger().Warn().Err(err).
+			Uint64("blockNum", block.NumberU64()).
+			Msg("[FBFTLog] failed to delete persisted block")
+	}
+}
+
+// persistMessage writes the message to the db, if the log is persistent
+func (log *FBFTLog) persistMessage(msg *FBFTMessage) {
+	if log.db == nil {
+		return
+	}
+	id := msg.id()
+	data, err := encodeFBFTMessage(msg)
+	if err == nil {
+		err = rawdb.WriteFBFTLogMessage(log.batch, msg.BlockNum, id[:], data)
+	}
+	if err != nil {
+		utils.Logger().Warn().Err(err).
+			Str("msg", msg.String()).
+			Msg("[FBFTLog] failed to persist message")
+	}
+}
+
+// unpersistMessage removes the message from the db, if the log is persistent
+func (log *FBFTLog) unpersistMessage(id fbftMsgID, msg *FBFTMessage) {
+	if log.db == nil {
+		return
+	}
+	if err := rawdb.DeleteFBFTLogMessage(log.batch, msg.BlockNum, id[:]); err != nil {
+		utils.Logger().Warn().Err(err).
+			Str("msg", msg.String()).
+			Msg("[FBFTLog] failed to delete persisted message")
+	}
+}
+
+// restore loads the blocks and messages persisted in the db into the log.
+// Entries below minBlockNum are stale and removed from the db instead.
+func (log *FBFTLog) restore(minBlockNum uint64) (int, int, error) {
+	if log.db == nil {
+		return 0, 0, nil
+	}
+	var (
+		numBlocks, numMsgs int
+		batch              = log.db.NewBatch()
+	)
+	err := rawdb.IterateFBFTLogBlocks(log.db, func(number uint64, hash common.Hash, data []byte) error {
+		if number < minBlockNum {
+			return rawdb.DeleteFBFTLogBlock(batch, number, hash)
+		}
+		var stored fbftBlockStorage
+		if err := rlp.DecodeBytes(data, &stored); err != nil {
+			return errors.Wrapf(err, "cannot decode fbft log block %d %s", number, hash.Hex())
+		}
+		log.blocks[hash] = stored.Block
+		if stored.Verified {
+			log.verifiedBlocks[hash] = struct{}{}
+		}
+		numBlocks++
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	err = rawdb.IterateFBFTLogMessages(log.db, func(number uint64, id []byte, data []byte) error {
+		if number < minBlockNum {
+			return rawdb.DeleteFBFTLogMessage(batch, number, id)
+		}
+		msg, err := decodeFBFTMessage(data)
+		if err != nil {
+			return errors.Wrapf(err, "cannot decode fbft log message at block %d", number)
+		}
+		log.messages[msg.id()] = msg
+		numMsgs++
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return numBlocks, numMsgs, batch.Write()
+}
+
+// Close stops the persistence of the FBFT log, once the log is written to the
+// db. It is called when the consensus stops, before the chain db closes.
+func (consensus *Consensus) Close() {
+	consensus.mutex.Lock()
+	defer consensus.mutex.Unlock()
+
+	consensus.fBFTLog.closeDB()
+}
+
+// restoreFBFTLog makes the FBFT log of the consensus persistent in the chain db and
+// loads the log of the previous run, so that a restarted node can rejoin the
+// ongoing round or hand over its prepared block in a view change.
+func (consensus *Consensus) restoreFBFTLog(db ethdb.KeyValueStore) {
+	consensus.fBFTLog.setDB(db)
+
+	var minBlockNum uint64
+	if curNum := consensus.Blockchain().CurrentBlock().NumberU64(); curNum > 0 {
+		// keep the last committed block, the same as PruneCacheBeforeBlock
+		minBlockNum = curNum - 1
+	}
+	numBlocks, numMsgs, err := consensus.fBFTLog.restore(minBlockNum)
+	if err != nil {
+		utils.Logger().Error().Err(err).Msg("[FBFTLog] failed to restore persisted log")
+		return
+	}
+	utils.Logger().Info().
+		Int("blocks", numBlocks).
+		Int("messages", numMsgs).
+		Uint64("fromBlockNum", minBlockNum).
+		Msg("[FBFTLog] restored persisted log")
+
+	// Pick up the block prepared in the round that was interrupted, if any.
+	nextNum := consensus.Blockchain().CurrentBlock().NumberU64() + 1
+	preparedMsg := consensus.fBFTLog.FindMessageByMaxViewID(
+		consensus.fBFTLog.GetMessagesByTypeSeq(msg_pb.MessageType_PREPARED, nextNum),
+	)
+	if preparedMsg == nil {
+		return
+	}
+	block := consensus.fBFTLog.GetBlockByHash(preparedMsg.BlockHash)
+	if block == nil {
+		return
+	}
+	encodedBlock, err := rlp.EncodeToBytes(block)
+	if err != nil {
+		return
+	}
+	consensus.blockHash = preparedMsg.BlockHash
+	consensus.block = encodedBlock
+	utils.Logger().Info().
+		Uint64("blockNum", nextNum).
+		Uint64("viewID", preparedMsg.ViewID).
+		Str("blockHash", preparedMsg.BlockHash.Hex()).
+		Msg("[FBFTLog] restored prepared block of the interrupted round")
+}
@@ -3,9 +3,13 @@ package consensus
 import (
 	"bytes"
 	"encoding/binary"
+	"math/big"
 	"testing"
 
 	msg_pb "github.com/harmony-one/harmony/api/proto/message"
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/types"
 	"github.com/harmony-one/harmony/crypto/bls"
 )
 
@@ -102,3 +106,76 @@ func TestHasMatchingAnnounce(t *testing.T) {
 		t.Error("notFound should be false")
 	}
 }
+
+func TestFBFTLog_restore(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	log := NewFBFTLog()
+	log.setDB(db)
+
+	var blocks []*types.Block
+	for i := int64(1); i <= 3; i++ {
+		block := types.NewBlockWithHeader(blockfactory.NewTestHeader().With().Number(big.NewInt(i)).Header())
+		blocks = append(blocks, block)
+		log.AddBlock(block)
+		log.AddVerifiedMessage(&FBFTMessage{
+			MessageType: msg_pb.MessageType_PREPARED,
+			BlockNum:    uint64(i),
+			ViewID:      uint64(i) + 1,
+			BlockHash:   block.Hash(),
+			Payload:     []byte{0x01, 0x02},
+		})
+	}
+	log.MarkBlockVerified(blocks[2])
+	log.AddNotVerifiedMessage(&FBFTMessage{
+		MessageType: msg_pb.MessageType_COMMITTED,
+		BlockNum:    3,
+		ViewID:      4,
+		BlockHash:   blocks[2].Hash(),
+	})
+	// the writes are batched until flushed, the prepared messages write them
+	// before returning
+	pending := NewFBFTLog()
+	pending.db = db
+	numBlocks, numMsgs, err := pending.restore(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if numBlocks != 3 || numMsgs != 3 || pending.IsBlockVerified(blocks[2].Hash()) {
+		t.Fatalf("unexpected written entries: %v blocks, %v messages", numBlocks, numMsgs)
+	}
+	// closing the log writes the rest before the db closes
+	log.closeDB()
+	log.AddBlock(types.NewBlockWithHeader(blockfactory.NewTestHeader().With().Number(big.NewInt(4)).Header()))
+
+	restored := NewFBFTLog()
+	restored.db = db
+	numBlocks, numMsgs, err = restored.restore(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if numBlocks != 2 || numMsgs != 3 {
+		t.Fatalf("unexpected restored entries: %v blocks, %v messages", numBlocks, numMsgs)
+	}
+	if restored.GetBlockByHash(blocks[0].Hash()) != nil {
+		t.Error("stale block restored")
+	}
+	if restored.GetBlockByHash(blocks[2].Hash()) == nil || !restored.IsBlockVerified(blocks[2].Hash()) {
+		t.Error("verified block not restored")
+	}
+	if !restored.HasMatchingViewPrepared(3, 4, blocks[2].Hash()) {
+		t.Error("prepared message not restored")
+	}
+	if len(restored.GetNotVerifiedCommittedMessages(3, 4, blocks[2].Hash())) != 1 {
+		t.Error("not verified committed message not restored")
+	}
+
+	// stale entries are removed from the db on restore
+	again := NewFBFTLog()
+	again.db = db
+	if numBlocks, numMsgs, err = again.restore(0); err != nil {
+		t.Fatal(err)
+	}
+	if numBlocks != 2 || numMsgs != 3 {
+		t.Errorf("stale entries not removed: %v blocks, %v messages", numBlocks, numMsgs)
+	}
+}
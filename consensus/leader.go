@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/harmony-one/harmony/consensus/signature"
+	"github.com/harmony-one/harmony/consensus/slashprotect"
 	"github.com/harmony-one/harmony/crypto/bls"
 	"github.com/harmony-one/harmony/internal/common"
 	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
@@ -54,6 +55,17 @@ func (consensus *Consensus) announce(block *types.Block) {
 
 	// Leader sign the block hash itself
 	for i, key := range consensus.priKey {
+		if !consensus.isSafeToSign(slashprotect.Prepare, key.Pub,
+			block.NumberU64(), block.Header().ViewID().Uint64(), block.Hash()) {
+			continue
+		}
+		sig := key.Pri.SignHash(consensus.blockHash[:])
+		if sig == nil {
+			consensus.getLogger().Warn().Msgf(
+				"[Announce] Leader failed to sign prepare for key at index %d", i,
+			)
+			continue
+		}
 		if err := consensus.prepareBitmap.SetKey(key.Pub.Bytes, true); err != nil {
 			consensus.getLogger().Warn().Err(err).Msgf(
 				"[Announce] Leader prepareBitmap SetKey failed for key at index %d", i,
@@ -64,7 +76,7 @@ func (consensus *Consensus) announce(block *types.Block) {
 		if _, err := consensus.Decider.AddNewVote(
 			quorum.Prepare,
 			[]*bls.PublicKeyWrapper{key.Pub},
-			key.Pri.SignHash(consensus.blockHash[:]),
+			sig,
 			block.Hash(),
 			block.NumberU64(),
 			block.Header().ViewID().Uint64(),
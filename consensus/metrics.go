@@ -128,6 +128,41 @@ var (
 		},
 	)
 
+	// consensusSlashingProtectionCounterVec is used to keep track of votes refused by slashing protection
+	consensusSlashingProtectionCounterVec = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "hmy",
+			Subsystem: "consensus",
+			Name:      "slashing_protection_refused",
+			Help:      "number of votes refused by the slashing protection db",
+		},
+		[]string{
+			"phase",
+		},
+	)
+
+	// consensusDoppelgangerGauge is 1 while the doppelganger protection observes the network
+	consensusDoppelgangerGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "hmy",
+			Subsystem: "consensus",
+			Name:      "doppelganger_observing",
+			Help:      "whether the bls keys are withheld by the doppelganger protection",
+		},
+	)
+	// consensusDoppelgangerCounterVec is used to keep track of the doppelgangers detected
+	consensusDoppelgangerCounterVec = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "hmy",
+			Subsystem: "consensus",
+			Name:      "doppelganger_detected",
+			Help:      "number of doppelgangers of the bls keys detected",
+		},
+		[]string{
+			"source",
+		},
+	)
+
 	onceMetrics sync.Once
 
 	// TODO: add last consensus timestamp, add view ID
@@ -188,6 +223,9 @@ func initMetrics() {
 			consensusGaugeVec,
 			consensusPubkeyVec,
 			consensusFinalityHistogram,
+			consensusSlashingProtectionCounterVec,
+			consensusDoppelgangerGauge,
+			consensusDoppelgangerCounterVec,
 			lastPreimageImportGauge,
 			preimageEndGauge,
 			preimageStartGauge,
@@ -0,0 +1,65 @@
+package misc
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/harmony-one/harmony/block"
+	"github.com/harmony-one/harmony/internal/params"
+)
+
+// VerifyEIP1559Header verifies the base fee of an EIP-1559 header, the parent
+// may be the last block before the fork.
+func VerifyEIP1559Header(config *params.ChainConfig, parent, header *block.Header) error {
+	baseFee := header.BaseFee()
+	if baseFee == nil {
+		return fmt.Errorf("header is missing baseFee")
+	}
+	if expected := CalcBaseFee(config, parent); baseFee.Cmp(expected) != 0 {
+		return fmt.Errorf("invalid baseFee: have %s, want %s, parentBaseFee %s, parentGasUsed %d",
+			baseFee, expected, parent.BaseFee(), parent.GasUsed())
+	}
+	return nil
+}
+
+// CalcBaseFee calculates the base fee of the child of the parent header. The
+// base fee goes up or down by up to 1/8 depending on whether the parent used
+// more or less than the target, half of its gas limit. It never goes below
+// the minimum base fee.
+func CalcBaseFee(config *params.ChainConfig, parent *block.Header) *big.Int {
+	// The first block of the fee market uses the initial base fee
+	if !config.IsEIP1559(parent.Epoch()) || parent.BaseFee() == nil {
+		return new(big.Int).Set(params.InitialBaseFee)
+	}
+	var (
+		parentBaseFee = parent.BaseFee()
+		parentGasUsed = parent.GasUsed()
+		gasTarget     = parent.GasLimit() / params.ElasticityMultiplier
+		baseFee       *big.Int
+	)
+	switch {
+	case gasTarget == 0 || parentGasUsed == gasTarget:
+		baseFee = parentBaseFee
+	case parentGasUsed > gasTarget:
+		// parentBaseFee * (gasUsed - target) / target / denominator, at least 1
+		delta := new(big.Int).SetUint64(parentGasUsed - gasTarget)
+		delta.Mul(delta, parentBaseFee)
+		delta.Div(delta, new(big.Int).SetUint64(gasTarget))
+		delta.Div(delta, new(big.Int).SetUint64(params.BaseFeeChangeDenominator))
+		if delta.Sign() == 0 {
+			delta.SetUint64(1)
+		}
+		baseFee = delta.Add(parentBaseFee, delta)
+	default:
+		// parentBaseFee * (target - gasUsed) / target / denominator
+		delta := new(big.Int).SetUint64(gasTarget - parentGasUsed)
+		delta.Mul(delta, parentBaseFee)
+		delta.Div(delta, new(big.Int).SetUint64(gasTarget))
+		delta.Div(delta, new(big.Int).SetUint64(params.BaseFeeChangeDenominator))
+		baseFee = delta.Sub(parentBaseFee, delta)
+	}
+	if baseFee.Cmp(params.MinimumBaseFee) < 0 {
+		return new(big.Int).Set(params.MinimumBaseFee)
+	}
+	return baseFee
+}
@@ -0,0 +1,64 @@
+package misc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/harmony-one/harmony/block"
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/internal/params"
+)
+
+func eip1559Config() *params.ChainConfig {
+	config := *params.TestChainConfig
+	config.EIP1559Epoch = big.NewInt(1)
+	return &config
+}
+
+func newHeader(config *params.ChainConfig, epoch int64, gasLimit, gasUsed uint64, baseFee *big.Int) *block.Header {
+	header := blockfactory.NewFactory(config).NewHeader(big.NewInt(epoch))
+	header.SetGasLimit(gasLimit)
+	header.SetGasUsed(gasUsed)
+	if baseFee != nil {
+		header.SetBaseFee(baseFee)
+	}
+	return header
+}
+
+func TestCalcBaseFee(t *testing.T) {
+	config := eip1559Config()
+	baseFee := new(big.Int).Mul(params.InitialBaseFee, big.NewInt(2))
+	tests := []struct {
+		parent *block.Header
+		exp    *big.Int
+	}{
+		// the first block of the fee market
+		{newHeader(config, 0, 30000000, 30000000, nil), params.InitialBaseFee},
+		// usage at the target
+		{newHeader(config, 1, 30000000, 15000000, baseFee), baseFee},
+		// full block: +12.5%
+		{newHeader(config, 1, 30000000, 30000000, baseFee), big.NewInt(225e9)},
+		// empty block: -12.5%
+		{newHeader(config, 1, 30000000, 0, baseFee), big.NewInt(175e9)},
+		// never below the minimum
+		{newHeader(config, 1, 30000000, 0, params.MinimumBaseFee), params.MinimumBaseFee},
+	}
+	for i, test := range tests {
+		if have := CalcBaseFee(config, test.parent); have.Cmp(test.exp) != 0 {
+			t.Errorf("test %d: unexpected base fee %v, expected %v", i, have, test.exp)
+		}
+	}
+}
+
+func TestVerifyEIP1559Header(t *testing.T) {
+	config := eip1559Config()
+	parent := newHeader(config, 1, 30000000, 30000000, params.InitialBaseFee)
+	header := newHeader(config, 1, 30000000, 0, big.NewInt(1125e8))
+	if err := VerifyEIP1559Header(config, parent, header); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	header.SetBaseFee(params.InitialBaseFee)
+	if err := VerifyEIP1559Header(config, parent, header); err == nil {
+		t.Errorf("expected error for invalid base fee")
+	}
+}
@@ -0,0 +1,70 @@
+package consensus
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/harmony-one/harmony/consensus/slashprotect"
+	"github.com/harmony-one/harmony/crypto/bls"
+)
+
+// isSafeToSign checks the vote against the slashing protection db and records it.
+// It returns false if the key must not sign the vote.
+// Without a slashing protection db, all votes are safe to sign.
+func (consensus *Consensus) isSafeToSign(
+	phase slashprotect.Phase, pubKey *bls.PublicKeyWrapper, blockNum, viewID uint64, blockHash common.Hash,
+) bool {
+	if consensus.slashProtect == nil {
+		return true
+	}
+	vote := slashprotect.Vote{
+		Phase:     phase,
+		BlockNum:  blockNum,
+		ViewID:    viewID,
+		BlockHash: blockHash,
+	}
+	if err := consensus.slashProtect.CheckAndRecord(pubKey.Bytes, vote); err != nil {
+		consensusSlashingProtectionCounterVec.With(prometheus.Labels{"phase": phase.String()}).Inc()
+		consensus.getLogger().Error().Err(err).
+			Str("phase", phase.String()).
+			Str("key", pubKey.Bytes.Hex()).
+			Uint64("blockNum", blockNum).
+			Uint64("viewID", viewID).
+			Hex("blockHash", blockHash[:]).
+			Msg("[SlashingProtection] Refused to sign vote")
+		return false
+	}
+	return true
+}
+
+// keysSafeToSign returns the private keys which are safe to sign the vote.
+func (consensus *Consensus) keysSafeToSign(
+	phase slashprotect.Phase, priKeys []*bls.PrivateKeyWrapper, blockNum, viewID uint64, blockHash common.Hash,
+) []*bls.PrivateKeyWrapper {
+	if consensus.slashProtect == nil {
+		return priKeys
+	}
+	safe := make([]*bls.PrivateKeyWrapper, 0, len(priKeys))
+	for _, key := range priKeys {
+		if consensus.isSafeToSign(phase, key.Pub, blockNum, viewID, blockHash) {
+			safe = append(safe, key)
+		}
+	}
+	return safe
+}
+
+// slashProtectHistory is the number of blocks of signing history kept in the slashing protection db.
+const slashProtectHistory = 1024
+
+// pruneSlashingProtection drops the signing history older than slashProtectHistory
+// blocks. It only runs once every slashProtectHistory blocks.
+func (consensus *Consensus) pruneSlashingProtection(blockNum uint64) {
+	if consensus.slashProtect == nil || blockNum < slashProtectHistory || blockNum%slashProtectHistory != 0 {
+		return
+	}
+	if err := consensus.slashProtect.Prune(blockNum - slashProtectHistory); err != nil {
+		consensus.getLogger().Warn().Err(err).
+			Uint64("blockNum", blockNum).
+			Msg("[SlashingProtection] Failed to prune signing history")
+	}
+}
@@ -0,0 +1,171 @@
+package slashprotect
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+
+	"github.com/harmony-one/harmony/crypto/bls"
+)
+
+// InterchangeFormatVersion is the version of the interchange format written by Export
+const InterchangeFormatVersion = "1"
+
+// Interchange is the JSON document used to move the signing history of BLS keys
+// between machines. See README.md for the format description.
+type Interchange struct {
+	Metadata InterchangeMetadata `json:"metadata"`
+	Data     []InterchangeKey    `json:"data"`
+}
+
+// InterchangeMetadata is the header of the interchange document
+type InterchangeMetadata struct {
+	InterchangeFormatVersion string `json:"interchange_format_version"`
+}
+
+// InterchangeKey is the signing history of a single BLS key
+type InterchangeKey struct {
+	PubKey      string                `json:"pubkey"`
+	Watermark   *InterchangeWatermark `json:"watermark,omitempty"`
+	SignedVotes []InterchangeVote     `json:"signed_votes"`
+}
+
+// InterchangeWatermark is the low watermark of a key
+type InterchangeWatermark struct {
+	BlockNum uint64 `json:"block_num,string"`
+	ViewID   uint64 `json:"view_id,string"`
+}
+
+// InterchangeVote is a single signed vote
+type InterchangeVote struct {
+	Phase     string      `json:"phase"`
+	BlockNum  uint64      `json:"block_num,string"`
+	ViewID    uint64      `json:"view_id,string"`
+	BlockHash common.Hash `json:"block_hash"`
+}
+
+// Export writes the whole signing history in the interchange format to w.
+func (p *DB) Export(w io.Writer) error {
+	keys, err := p.Keys()
+	if err != nil {
+		return err
+	}
+	doc := Interchange{
+		Metadata: InterchangeMetadata{InterchangeFormatVersion: InterchangeFormatVersion},
+		Data:     make([]InterchangeKey, 0, len(keys)),
+	}
+
+	p.mu.Lock()
+	for _, key := range keys {
+		entry := InterchangeKey{
+			PubKey:      key.Hex(),
+			SignedVotes: []InterchangeVote{},
+		}
+		wm, err := p.watermark(key)
+		if err != nil {
+			p.mu.Unlock()
+			return err
+		}
+		if wm != nil {
+			entry.Watermark = &InterchangeWatermark{BlockNum: wm.BlockNum, ViewID: wm.ViewID}
+		}
+		votes, err := p.votes(key)
+		if err != nil {
+			p.mu.Unlock()
+			return err
+		}
+		for _, vote := range votes {
+			entry.SignedVotes = append(entry.SignedVotes, InterchangeVote{
+				Phase:     vote.Phase.String(),
+				BlockNum:  vote.BlockNum,
+				ViewID:    vote.ViewID,
+				BlockHash: vote.BlockHash,
+			})
+		}
+		doc.Data = append(doc.Data, entry)
+	}
+	p.mu.Unlock()
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+// Import merges the signing history read from r into the DB. The import is
+// atomic: if any imported vote conflicts with the local history, nothing is
+// written. After the import, the watermark of each imported key is raised to
+// its highest imported vote, so that no vote older than the imported history
+// can be signed.
+func (p *DB) Import(r io.Reader) error {
+	var doc Interchange
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return errors.Wrap(err, "cannot decode interchange document")
+	}
+	if doc.Metadata.InterchangeFormatVersion != InterchangeFormatVersion {
+		return errors.Errorf("unsupported interchange format version %q",
+			doc.Metadata.InterchangeFormatVersion)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	batch := p.db.NewBatch()
+	imported := make(map[string]common.Hash)
+	for _, entry := range doc.Data {
+		key, err := parsePubKey(entry.PubKey)
+		if err != nil {
+			return err
+		}
+		var highest *Watermark
+		if entry.Watermark != nil {
+			highest = &Watermark{BlockNum: entry.Watermark.BlockNum, ViewID: entry.Watermark.ViewID}
+		}
+		for _, iv := range entry.SignedVotes {
+			phase, err := parsePhase(iv.Phase)
+			if err != nil {
+				return errors.Wrapf(err, "key %s", entry.PubKey)
+			}
+			vote := Vote{Phase: phase, BlockNum: iv.BlockNum, ViewID: iv.ViewID, BlockHash: iv.BlockHash}
+			// Votes below the local watermark are already covered by it, only
+			// conflicting votes above it need to be rejected.
+			if err := p.check(key, vote); err != nil && !errors.Is(err, ErrBelowWatermark) {
+				return err
+			}
+			k := voteKey(key, vote.Phase, vote.BlockNum, vote.ViewID)
+			if hash, ok := imported[string(k)]; ok && hash != vote.BlockHash {
+				return errors.Wrapf(ErrDoubleSign, "key %s %s vote at block %d view %d: conflicting votes in document",
+					entry.PubKey, vote.Phase, vote.BlockNum, vote.ViewID)
+			}
+			imported[string(k)] = vote.BlockHash
+			if err := batch.Put(k, vote.BlockHash.Bytes()); err != nil {
+				return err
+			}
+			if highest == nil || highest.before(vote.BlockNum, vote.ViewID) {
+				highest = &Watermark{BlockNum: vote.BlockNum, ViewID: vote.ViewID}
+			}
+		}
+		if highest != nil {
+			if err := p.raiseWatermark(batch, key, *highest); err != nil {
+				return err
+			}
+		}
+	}
+	return batch.Write()
+}
+
+func parsePubKey(s string) (bls.SerializedPublicKey, error) {
+	var key bls.SerializedPublicKey
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return key, errors.Wrapf(err, "invalid pubkey %q", s)
+	}
+	if len(b) != len(key) {
+		return key, errors.Errorf("invalid pubkey %q: wrong length %d", s, len(b))
+	}
+	copy(key[:], b)
+	return key, nil
+}
@@ -0,0 +1,338 @@
+package slashprotect
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/pkg/errors"
+
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/crypto/bls"
+)
+
+// Phase is the consensus phase a vote was signed for
+type Phase byte
+
+const (
+	// Prepare is the signature on the block hash sent in the prepare phase
+	Prepare Phase = iota
+	// Commit is the signature on the commit payload sent in the commit phase
+	Commit
+	// ViewChange is the m1 or m2 signature of a view change message, on the
+	// prepared block (m1) or on NIL (m2), recorded with the zero hash. The m3
+	// signature is on the view ID only, so that it can't conflict and is not
+	// recorded.
+	ViewChange
+)
+
+var phaseNames = map[Phase]string{
+	Prepare:    "prepare",
+	Commit:     "commit",
+	ViewChange: "viewchange",
+}
+
+func (p Phase) String() string {
+	if name, ok := phaseNames[p]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown phase %d", byte(p))
+}
+
+func parsePhase(s string) (Phase, error) {
+	for p, name := range phaseNames {
+		if name == s {
+			return p, nil
+		}
+	}
+	return 0, errors.Errorf("unknown phase %q", s)
+}
+
+var (
+	// ErrDoubleSign is returned when the vote conflicts with a vote previously
+	// signed with the same key for the same block number and view ID.
+	ErrDoubleSign = errors.New("conflicting vote already signed")
+	// ErrBelowWatermark is returned when the vote is older than the low watermark
+	// of the key. History below the watermark is unknown (e.g. imported or pruned),
+	// so any vote there is refused.
+	ErrBelowWatermark = errors.New("vote is below the signing watermark")
+)
+
+// key layout:
+//
+//	votePrefix + pubKey (48) + phase (1) + blockNum (8) + viewID (8) -> blockHash (32)
+//	watermarkPrefix + pubKey (48) -> blockNum (8) + viewID (8)
+var (
+	votePrefix      = []byte("sp-v-")
+	watermarkPrefix = []byte("sp-w-")
+)
+
+const (
+	// DefaultDirName is the directory name of the database under the node data dir
+	DefaultDirName = "slashing_protection"
+
+	dbCache   = 16
+	dbHandles = 16
+)
+
+// Vote is a single signed prepare, commit or view change vote
+type Vote struct {
+	Phase     Phase
+	BlockNum  uint64
+	ViewID    uint64
+	BlockHash common.Hash
+}
+
+// Watermark is the lowest (blockNum, viewID) a key is still allowed to sign
+type Watermark struct {
+	BlockNum uint64
+	ViewID   uint64
+}
+
+// before returns whether the vote position (blockNum, viewID) is lower than the watermark
+func (w Watermark) before(blockNum, viewID uint64) bool {
+	if blockNum != w.BlockNum {
+		return blockNum < w.BlockNum
+	}
+	return viewID < w.ViewID
+}
+
+// DB is the persistent signing history of the local BLS keys. All prepare,
+// commit and view change signatures are checked against and recorded in the DB
+// before being sent out, so that a restarted node, or the same key running on
+// two hosts sharing the DB, never signs two different blocks for the same block
+// number and view ID.
+type DB struct {
+	db ethdb.KeyValueStore
+	mu sync.Mutex
+}
+
+// New returns a slashing protection DB backed by the given key value store.
+func New(db ethdb.KeyValueStore) *DB {
+	return &DB{db: db}
+}
+
+// Open opens (or creates) a LevelDB backed slashing protection DB at dir.
+func Open(dir string) (*DB, error) {
+	db, err := rawdb.NewLevelDBDatabase(dir, dbCache, dbHandles, "", false)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot open slashing protection db at %s", dir)
+	}
+	return New(db), nil
+}
+
+// Close closes the underlying database.
+func (p *DB) Close() error {
+	return p.db.Close()
+}
+
+// CheckAndRecord checks whether signing the vote with the key is safe. If it
+// is, the vote is persisted before returning nil, so that the caller may sign.
+// Signing the exact same vote again is allowed.
+func (p *DB) CheckAndRecord(key bls.SerializedPublicKey, vote Vote) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.check(key, vote); err != nil {
+		return err
+	}
+	return p.db.Put(voteKey(key, vote.Phase, vote.BlockNum, vote.ViewID), vote.BlockHash.Bytes())
+}
+
+// Check checks whether signing the vote with the key is safe without recording it.
+func (p *DB) Check(key bls.SerializedPublicKey, vote Vote) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.check(key, vote)
+}
+
+func (p *DB) check(key bls.SerializedPublicKey, vote Vote) error {
+	wm, err := p.watermark(key)
+	if err != nil {
+		return err
+	}
+	if wm != nil && wm.before(vote.BlockNum, vote.ViewID) {
+		return errors.Wrapf(ErrBelowWatermark, "key %s %s vote at block %d view %d, watermark block %d view %d",
+			key.Hex(), vote.Phase, vote.BlockNum, vote.ViewID, wm.BlockNum, wm.ViewID)
+	}
+	k := voteKey(key, vote.Phase, vote.BlockNum, vote.ViewID)
+	if has, err := p.db.Has(k); err != nil || !has {
+		// nothing signed at this position yet
+		return err
+	}
+	signed, err := p.db.Get(k)
+	if err != nil {
+		return err
+	}
+	if common.BytesToHash(signed) != vote.BlockHash {
+		return errors.Wrapf(ErrDoubleSign, "key %s %s vote at block %d view %d: signed %x, requested %x",
+			key.Hex(), vote.Phase, vote.BlockNum, vote.ViewID, signed, vote.BlockHash)
+	}
+	return nil
+}
+
+// Votes returns all recorded votes of the key, ordered by phase, block number and view ID.
+func (p *DB) Votes(key bls.SerializedPublicKey) ([]Vote, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.votes(key)
+}
+
+func (p *DB) votes(key bls.SerializedPublicKey) ([]Vote, error) {
+	prefix := append(append([]byte{}, votePrefix...), key[:]...)
+	it := p.db.NewIterator(prefix, nil)
+	defer it.Release()
+
+	var votes []Vote
+	for it.Next() {
+		vote, err := decodeVote(it.Key()[len(prefix):], it.Value())
+		if err != nil {
+			return nil, err
+		}
+		votes = append(votes, vote)
+	}
+	return votes, it.Error()
+}
+
+// Keys returns all the public keys that have a signing history in the DB.
+func (p *DB) Keys() ([]bls.SerializedPublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	seen := make(map[bls.SerializedPublicKey]struct{})
+	var keys []bls.SerializedPublicKey
+	for _, prefix := range [][]byte{votePrefix, watermarkPrefix} {
+		it := p.db.NewIterator(prefix, nil)
+		for it.Next() {
+			var key bls.SerializedPublicKey
+			if len(it.Key()) < len(prefix)+len(key) {
+				continue
+			}
+			copy(key[:], it.Key()[len(prefix):])
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			keys = append(keys, key)
+		}
+		err := it.Error()
+		it.Release()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+// Watermark returns the low watermark of the key, nil if there is none.
+func (p *DB) Watermark(key bls.SerializedPublicKey) (*Watermark, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.watermark(key)
+}
+
+func (p *DB) watermark(key bls.SerializedPublicKey) (*Watermark, error) {
+	if has, err := p.db.Has(watermarkKey(key)); err != nil || !has {
+		return nil, err
+	}
+	b, err := p.db.Get(watermarkKey(key))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != 16 {
+		return nil, errors.Errorf("corrupted watermark for key %s", key.Hex())
+	}
+	return &Watermark{
+		BlockNum: binary.BigEndian.Uint64(b[:8]),
+		ViewID:   binary.BigEndian.Uint64(b[8:]),
+	}, nil
+}
+
+// raiseWatermark sets the watermark of the key if it is higher than the current one.
+func (p *DB) raiseWatermark(w ethdb.KeyValueWriter, key bls.SerializedPublicKey, wm Watermark) error {
+	cur, err := p.watermark(key)
+	if err != nil {
+		return err
+	}
+	if cur != nil && !cur.before(wm.BlockNum, wm.ViewID) {
+		return nil
+	}
+	b := make([]byte, 16)
+	binary.BigEndian.PutUint64(b[:8], wm.BlockNum)
+	binary.BigEndian.PutUint64(b[8:], wm.ViewID)
+	return w.Put(watermarkKey(key), b)
+}
+
+// Prune removes the recorded votes of all keys below the given block number and
+// raises the watermark of the keys accordingly, so that the pruned history can
+// never be signed again.
+func (p *DB) Prune(blockNum uint64) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	batch := p.db.NewBatch()
+	raised := make(map[bls.SerializedPublicKey]struct{})
+
+	it := p.db.NewIterator(votePrefix, nil)
+	defer it.Release()
+	for it.Next() {
+		var key bls.SerializedPublicKey
+		rest := it.Key()[len(votePrefix):]
+		if len(rest) < len(key) {
+			continue
+		}
+		copy(key[:], rest)
+		vote, err := decodeVote(rest[len(key):], it.Value())
+		if err != nil {
+			return err
+		}
+		if vote.BlockNum >= blockNum {
+			continue
+		}
+		if err := batch.Delete(common.CopyBytes(it.Key())); err != nil {
+			return err
+		}
+		if _, ok := raised[key]; !ok {
+			if err := p.raiseWatermark(batch, key, Watermark{BlockNum: blockNum}); err != nil {
+				return err
+			}
+			raised[key] = struct{}{}
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+func voteKey(key bls.SerializedPublicKey, phase Phase, blockNum, viewID uint64) []byte {
+	b := make([]byte, 0, len(votePrefix)+len(key)+1+8+8)
+	b = append(b, votePrefix...)
+	b = append(b, key[:]...)
+	b = append(b, byte(phase))
+	b = binary.BigEndian.AppendUint64(b, blockNum)
+	b = binary.BigEndian.AppendUint64(b, viewID)
+	return b
+}
+
+func watermarkKey(key bls.SerializedPublicKey) []byte {
+	return append(append([]byte{}, watermarkPrefix...), key[:]...)
+}
+
+// decodeVote decodes the vote from the key suffix after the public key and the stored hash.
+func decodeVote(suffix, value []byte) (Vote, error) {
+	if len(suffix) != 1+8+8 || len(value) != common.HashLength {
+		return Vote{}, errors.New("corrupted slashing protection vote entry")
+	}
+	return Vote{
+		Phase:     Phase(suffix[0]),
+		BlockNum:  binary.BigEndian.Uint64(suffix[1:9]),
+		ViewID:    binary.BigEndian.Uint64(suffix[9:17]),
+		BlockHash: common.BytesToHash(value),
+	}, nil
+}
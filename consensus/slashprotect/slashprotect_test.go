@@ -0,0 +1,148 @@
+package slashprotect
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/crypto/bls"
+)
+
+var (
+	testKey1 = bls.SerializedPublicKey{0x01, 0x02}
+	testKey2 = bls.SerializedPublicKey{0x03, 0x04}
+)
+
+func TestDB_CheckAndRecord(t *testing.T) {
+	p := New(rawdb.NewMemoryDatabase())
+
+	vote := Vote{Phase: Prepare, BlockNum: 10, ViewID: 12, BlockHash: common.Hash{0x01}}
+	if err := p.CheckAndRecord(testKey1, vote); err != nil {
+		t.Fatal(err)
+	}
+	// signing the same vote again is fine
+	if err := p.CheckAndRecord(testKey1, vote); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		key  bls.SerializedPublicKey
+		vote Vote
+		err  error
+	}{
+		{testKey1, Vote{Prepare, 10, 12, common.Hash{0x02}}, ErrDoubleSign},
+		{testKey1, Vote{Commit, 10, 12, common.Hash{0x02}}, nil},
+		{testKey1, Vote{Prepare, 10, 13, common.Hash{0x02}}, nil},
+		{testKey1, Vote{Prepare, 11, 12, common.Hash{0x02}}, nil},
+		{testKey2, Vote{Prepare, 10, 12, common.Hash{0x02}}, nil},
+		{testKey1, Vote{ViewChange, 10, 12, common.Hash{}}, nil},
+	}
+	for i, test := range tests {
+		err := p.Check(test.key, test.vote)
+		if !errors.Is(err, test.err) {
+			t.Errorf("Test %v: unexpected error [%v] / [%v]", i, err, test.err)
+		}
+	}
+
+	// a NIL view change vote conflicts with the view change vote on the
+	// prepared block of the same view
+	m1 := Vote{Phase: ViewChange, BlockNum: 10, ViewID: 13, BlockHash: common.Hash{0x01}}
+	if err := p.CheckAndRecord(testKey1, m1); err != nil {
+		t.Fatal(err)
+	}
+	m2 := Vote{Phase: ViewChange, BlockNum: 10, ViewID: 13}
+	if err := p.CheckAndRecord(testKey1, m2); !errors.Is(err, ErrDoubleSign) {
+		t.Errorf("unexpected error [%v] / [%v]", err, ErrDoubleSign)
+	}
+}
+
+func TestDB_Prune(t *testing.T) {
+	p := New(rawdb.NewMemoryDatabase())
+	for i := uint64(1); i <= 5; i++ {
+		vote := Vote{Phase: Commit, BlockNum: i, ViewID: i, BlockHash: common.Hash{byte(i)}}
+		if err := p.CheckAndRecord(testKey1, vote); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := p.Prune(3); err != nil {
+		t.Fatal(err)
+	}
+	votes, err := p.Votes(testKey1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(votes) != 3 {
+		t.Fatalf("unexpected votes after prune: %v", len(votes))
+	}
+	err = p.Check(testKey1, Vote{Phase: Commit, BlockNum: 2, ViewID: 2, BlockHash: common.Hash{0x02}})
+	if !errors.Is(err, ErrBelowWatermark) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := p.Check(testKey1, Vote{Phase: Commit, BlockNum: 3, ViewID: 3, BlockHash: common.Hash{0x03}}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestDB_ExportImport(t *testing.T) {
+	src := New(rawdb.NewMemoryDatabase())
+	votes := []Vote{
+		{Prepare, 10, 12, common.Hash{0x01}},
+		{Commit, 10, 12, common.Hash{0x01}},
+		{Prepare, 11, 13, common.Hash{0x02}},
+	}
+	for _, vote := range votes {
+		if err := src.CheckAndRecord(testKey1, vote); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := src.Export(&buf); err != nil {
+		t.Fatal(err)
+	}
+	exported := buf.Bytes()
+
+	dst := New(rawdb.NewMemoryDatabase())
+	if err := dst.Import(bytes.NewReader(exported)); err != nil {
+		t.Fatal(err)
+	}
+	got, err := dst.Votes(testKey1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(votes) {
+		t.Fatalf("unexpected imported votes: %v / %v", len(got), len(votes))
+	}
+	wm, err := dst.Watermark(testKey1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wm == nil || wm.BlockNum != 11 || wm.ViewID != 13 {
+		t.Errorf("unexpected watermark %+v", wm)
+	}
+	if err := dst.Check(testKey1, Vote{Prepare, 11, 13, common.Hash{0x03}}); !errors.Is(err, ErrDoubleSign) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := dst.Check(testKey1, Vote{Prepare, 11, 12, common.Hash{0x03}}); !errors.Is(err, ErrBelowWatermark) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// a conflicting local history must make the whole import fail
+	conflict := New(rawdb.NewMemoryDatabase())
+	if err := conflict.CheckAndRecord(testKey1, Vote{Prepare, 11, 13, common.Hash{0x05}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := conflict.Import(bytes.NewReader(exported)); !errors.Is(err, ErrDoubleSign) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err = conflict.Votes(testKey1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 {
+		t.Errorf("import is not atomic: %v votes", len(got))
+	}
+}
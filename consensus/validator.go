@@ -416,7 +416,7 @@ func (consensus *Consensus) constructP2pMessages(msgType msg_pb.MessageType, pay
 			logger := consensus.getLogger().Err(err).
 				Str("message-type", msgType.String())
 			for _, key := range priKeys {
-				logger.Str("key", key.Pri.SerializeToHexStr())
+				logger.Str("key", key.Pub.Bytes.Hex())
 			}
 			logger.Msg("could not construct message")
 		} else {
@@ -429,7 +429,7 @@ func (consensus *Consensus) constructP2pMessages(msgType msg_pb.MessageType, pay
 			if err != nil {
 				consensus.getLogger().Err(err).
 					Str("message-type", msgType.String()).
-					Str("key", key.Pri.SerializeToHexStr()).
+					Str("key", key.Pub.Bytes.Hex()).
 					Msg("could not construct message")
 				continue
 			}
@@ -301,6 +301,10 @@ func (consensus *Consensus) startViewChange() {
 			continue
 		}
 		msgToSend := consensus.constructViewChangeMessage(&key)
+		if msgToSend == nil {
+			// refused by the slashing protection
+			continue
+		}
 		if err := consensus.msgSender.SendWithRetry(
 			consensus.getBlockNum(),
 			msg_pb.MessageType_VIEWCHANGE,
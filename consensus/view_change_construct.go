@@ -8,6 +8,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/harmony-one/harmony/consensus/quorum"
+	"github.com/harmony-one/harmony/consensus/slashprotect"
 	"github.com/harmony-one/harmony/core/types"
 
 	bls_core "github.com/harmony-one/bls/ffi/go/bls"
@@ -48,6 +49,8 @@ type viewChange struct {
 
 	verifyBlock        VerifyBlockFunc
 	viewChangeDuration time.Duration
+	// isSafeToSign checks the m1/m2 signatures against the slashing protection
+	isSafeToSign func(phase slashprotect.Phase, pubKey *bls.PublicKeyWrapper, blockNum, viewID uint64, blockHash common.Hash) bool
 }
 
 // newViewChange returns a new viewChange object
@@ -409,11 +412,19 @@ func (vc *viewChange) InitPayload(
 					vc.getLogger().Info().Uint64("viewID", viewID).Uint64("blockNum", blockNum).Int("size", binary.Size(preparedBlock)).Msg("[InitPayload] add my M1 (prepared) type messaage")
 					msgToSign := append(preparedMsg.BlockHash[:], preparedMsg.Payload...)
 					for _, key := range privKeys {
+						if !vc.safeToSign(key.Pub, blockNum, viewID, preparedMsg.BlockHash) {
+							continue
+						}
 						// update the dictionary key if the viewID is first time received
 						if _, ok := vc.bhpBitmap[viewID]; !ok {
 							bhpBitmap := bls_cosi.NewMask(members)
 							vc.bhpBitmap[viewID] = bhpBitmap
 						}
+						sig := key.Pri.SignHash(msgToSign)
+						if sig == nil {
+							vc.getLogger().Warn().Str("key", key.Pub.Bytes.Hex()).Msg("[InitPayload] failed to sign M1 payload")
+							continue
+						}
 						if err := vc.bhpBitmap[viewID].SetKey(key.Pub.Bytes, true); err != nil {
 							vc.getLogger().Warn().Str("key", key.Pub.Bytes.Hex()).Msg("[InitPayload] bhpBitmap setkey failed")
 							continue
@@ -421,7 +432,7 @@ func (vc *viewChange) InitPayload(
 						if _, ok := vc.bhpSigs[viewID]; !ok {
 							vc.bhpSigs[viewID] = map[string]*bls_core.Sign{}
 						}
-						vc.bhpSigs[viewID][key.Pub.Bytes.Hex()] = key.Pri.SignHash(msgToSign)
+						vc.bhpSigs[viewID][key.Pub.Bytes.Hex()] = sig
 					}
 					hasBlock = true
 					// if m1Payload is empty, we just add one
@@ -434,10 +445,18 @@ func (vc *viewChange) InitPayload(
 		if !hasBlock {
 			vc.getLogger().Info().Uint64("viewID", viewID).Uint64("blockNum", blockNum).Msg("[InitPayload] add my M2 (NIL) type messaage")
 			for _, key := range privKeys {
+				if !vc.safeToSign(key.Pub, blockNum, viewID, common.Hash{}) {
+					continue
+				}
 				if _, ok := vc.nilBitmap[viewID]; !ok {
 					nilBitmap := bls_cosi.NewMask(members)
 					vc.nilBitmap[viewID] = nilBitmap
 				}
+				sig := key.Pri.SignHash(NIL)
+				if sig == nil {
+					vc.getLogger().Warn().Str("key", key.Pub.Bytes.Hex()).Msg("[InitPayload] failed to sign M2 payload")
+					continue
+				}
 				if err := vc.nilBitmap[viewID].SetKey(key.Pub.Bytes, true); err != nil {
 					vc.getLogger().Warn().Err(err).
 						Str("key", key.Pub.Bytes.Hex()).Msg("[InitPayload] nilBitmap setkey failed")
@@ -446,7 +465,7 @@ func (vc *viewChange) InitPayload(
 				if _, ok := vc.nilSigs[viewID]; !ok {
 					vc.nilSigs[viewID] = map[string]*bls_core.Sign{}
 				}
-				vc.nilSigs[viewID][key.Pub.Bytes.Hex()] = key.Pri.SignHash(NIL)
+				vc.nilSigs[viewID][key.Pub.Bytes.Hex()] = sig
 			}
 		}
 	}
@@ -470,6 +489,11 @@ func (vc *viewChange) InitPayload(
 				viewIDBitmap := bls_cosi.NewMask(members)
 				vc.viewIDBitmap[viewID] = viewIDBitmap
 			}
+			sig := key.Pri.SignHash(viewIDBytes)
+			if sig == nil {
+				vc.getLogger().Warn().Str("key", key.Pub.Bytes.Hex()).Msg("[InitPayload] failed to sign M3 payload")
+				continue
+			}
 			if err := vc.viewIDBitmap[viewID].SetKey(key.Pub.Bytes, true); err != nil {
 				vc.getLogger().Warn().Err(err).
 					Str("key", key.Pub.Bytes.Hex()).Msg("[InitPayload] viewIDBitmap setkey failed")
@@ -478,13 +502,22 @@ func (vc *viewChange) InitPayload(
 			if _, ok := vc.viewIDSigs[viewID]; !ok {
 				vc.viewIDSigs[viewID] = map[string]*bls_core.Sign{}
 			}
-			vc.viewIDSigs[viewID][key.Pub.Bytes.Hex()] = key.Pri.SignHash(viewIDBytes)
+			vc.viewIDSigs[viewID][key.Pub.Bytes.Hex()] = sig
 		}
 	}
 
 	return nil
 }
 
+// safeToSign returns whether the key may sign the m1 payload of the block hash,
+// or the m2 payload if the hash is empty, for the view ID
+func (vc *viewChange) safeToSign(pubKey *bls.PublicKeyWrapper, blockNum, viewID uint64, blockHash common.Hash) bool {
+	if vc.isSafeToSign == nil {
+		return true
+	}
+	return vc.isSafeToSign(slashprotect.ViewChange, pubKey, blockNum, viewID, blockHash)
+}
+
 // isM1PayloadEmpty returns true if m1Payload is not set
 // this is an unlocked internal function call
 func (vc *viewChange) isM1PayloadEmpty() bool {
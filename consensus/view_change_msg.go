@@ -5,18 +5,20 @@ import (
 
 	"github.com/harmony-one/harmony/crypto/bls"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/rlp"
 
 	bls_core "github.com/harmony-one/bls/ffi/go/bls"
 	"github.com/harmony-one/harmony/api/proto"
 	msg_pb "github.com/harmony-one/harmony/api/proto/message"
+	"github.com/harmony-one/harmony/consensus/slashprotect"
 	bls_cosi "github.com/harmony-one/harmony/crypto/bls"
 
 	"github.com/harmony-one/harmony/multibls"
 	"github.com/pkg/errors"
 )
 
-// construct the view change message
+// construct the view change message, nil if the slashing protection refuses the key
 func (consensus *Consensus) constructViewChangeMessage(priKey *bls.PrivateKeyWrapper) []byte {
 	message := &msg_pb.Message{
 		ServiceType: msg_pb.ServiceType_CONSENSUS,
@@ -58,7 +60,10 @@ func (consensus *Consensus) constructViewChangeMessage(priKey *bls.PrivateKeyWra
 	}
 
 	vcMsg := message.GetViewchange()
-	var msgToSign []byte
+	var (
+		msgToSign []byte
+		voteHash  common.Hash
+	)
 	if len(encodedBlock) == 0 {
 		msgToSign = NIL // m2 type message
 		vcMsg.Payload = []byte{}
@@ -67,6 +72,11 @@ func (consensus *Consensus) constructViewChangeMessage(priKey *bls.PrivateKeyWra
 		msgToSign = append(preparedMsg.BlockHash[:], preparedMsg.Payload...)
 		vcMsg.Payload = append(msgToSign[:0:0], msgToSign...)
 		vcMsg.PreparedBlock = encodedBlock
+		voteHash = preparedMsg.BlockHash
+	}
+	if !consensus.isSafeToSign(slashprotect.ViewChange, priKey.Pub,
+		vcMsg.BlockNum, vcMsg.ViewId, voteHash) {
+		return nil
 	}
 
 	consensus.getLogger().Info().
@@ -3,7 +3,11 @@ package consensus
 import (
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/consensus/slashprotect"
+	"github.com/harmony-one/harmony/core/rawdb"
 	"github.com/harmony-one/harmony/crypto/bls"
+	"github.com/harmony-one/harmony/multibls"
 
 	bls_core "github.com/harmony-one/bls/ffi/go/bls"
 	harmony_bls "github.com/harmony-one/harmony/crypto/bls"
@@ -119,3 +123,30 @@ func TestGetNextLeaderKeyShouldSucceed(t *testing.T) {
 
 	assert.Equal(t, nextKey, &wrappedBLSKeys[1])
 }
+
+func TestViewChange_InitPayloadSlashingProtection(t *testing.T) {
+	priKeys := multibls.GetPrivateKeys(bls.RandPrivateKey(), bls.RandPrivateKey())
+	members := multibls.PublicKeys{*priKeys[0].Pub, *priKeys[1].Pub}
+	protect := slashprotect.New(rawdb.NewMemoryDatabase())
+
+	// the first key signed the m1 payload of a prepared block for the view already
+	assert.NoError(t, protect.CheckAndRecord(priKeys[0].Pub.Bytes, slashprotect.Vote{
+		Phase:     slashprotect.ViewChange,
+		BlockNum:  10,
+		ViewID:    5,
+		BlockHash: common.Hash{0x01},
+	}))
+
+	vc := newViewChange()
+	vc.isSafeToSign = func(phase slashprotect.Phase, pubKey *bls.PublicKeyWrapper, blockNum, viewID uint64, blockHash common.Hash) bool {
+		vote := slashprotect.Vote{Phase: phase, BlockNum: blockNum, ViewID: viewID, BlockHash: blockHash}
+		return protect.CheckAndRecord(pubKey.Bytes, vote) == nil
+	}
+	// there is no prepared block, the keys sign the m2 payload
+	assert.NoError(t, vc.InitPayload(NewFBFTLog(), 5, 10, priKeys, members))
+
+	assert.NotContains(t, vc.nilSigs[5], priKeys[0].Pub.Bytes.Hex())
+	assert.Contains(t, vc.nilSigs[5], priKeys[1].Pub.Bytes.Hex())
+	// the m3 payload can't conflict
+	assert.Len(t, vc.viewIDSigs[5], 2)
+}
@@ -3104,8 +3104,26 @@ func (bc *BlockChainImpl) prepareStakingMetaData(
 				blockNum); err != nil {
 				return nil, nil, err
 			}
+		} else if createValidator, ok := stakeMsg.(*staking.CreateValidator); ok {
+			newList, err := processCreateValidatorMetadata(createValidator,
+				newValidators,
+				newDelegations,
+				bc,
+				blockNum)
+			if err != nil {
+				return nil, nil, err
+			}
+			newValidators = newList
+		} else if redelegate, ok := stakeMsg.(*staking.Redelegate); ok {
+			if err := processRedelegateMetadata(redelegate,
+				newDelegations,
+				state,
+				bc,
+				blockNum); err != nil {
+				return nil, nil, err
+			}
 		} else {
-			panic("Only *staking.Delegate stakeMsgs are supported at the moment")
+			panic("Only *staking.Delegate, *staking.CreateValidator and *staking.Redelegate stakeMsgs are supported at the moment")
 		}
 	}
 	for _, txn := range block.StakingTransactions() {
@@ -3121,30 +3139,13 @@ func (bc *BlockChainImpl) prepareStakingMetaData(
 		switch txn.StakingType() {
 		case staking.DirectiveCreateValidator:
 			createValidator := decodePayload.(*staking.CreateValidator)
-			newList, appended := utils.AppendIfMissing(
-				newValidators, createValidator.ValidatorAddress,
-			)
-			if !appended {
-				return nil, nil, errValidatorExist
-			}
-			newValidators = newList
-
-			// Add self delegation into the index
-			selfIndex := staking.DelegationIndex{
-				ValidatorAddress: createValidator.ValidatorAddress,
-				Index:            uint64(0),
-				BlockNum:         blockNum,
-			}
-			delegations, ok := newDelegations[createValidator.ValidatorAddress]
-			if !ok {
-				// If the cache doesn't have it, load it from DB for the first time.
-				delegations, err = bc.ReadDelegationsByDelegator(createValidator.ValidatorAddress)
-				if err != nil {
-					return nil, nil, err
-				}
+			if newValidators, err = processCreateValidatorMetadata(createValidator,
+				newValidators,
+				newDelegations,
+				bc,
+				blockNum); err != nil {
+				return nil, nil, err
 			}
-			delegations = append(delegations, selfIndex)
-			newDelegations[createValidator.ValidatorAddress] = delegations
 		case staking.DirectiveEditValidator:
 		case staking.DirectiveDelegate:
 			delegate := decodePayload.(*staking.Delegate)
@@ -3158,6 +3159,16 @@ func (bc *BlockChainImpl) prepareStakingMetaData(
 
 		case staking.DirectiveUndelegate:
 		case staking.DirectiveCollectRewards:
+		case staking.DirectiveEditDelegation:
+		case staking.DirectiveRedelegate:
+			redelegate := decodePayload.(*staking.Redelegate)
+			if err := processRedelegateMetadata(redelegate,
+				newDelegations,
+				state,
+				bc,
+				blockNum); err != nil {
+				return nil, nil, err
+			}
 		default:
 		}
 	}
@@ -3165,6 +3176,38 @@ func (bc *BlockChainImpl) prepareStakingMetaData(
 	return newValidators, newDelegations, nil
 }
 
+func processCreateValidatorMetadata(createValidator *staking.CreateValidator,
+	newValidators []common.Address,
+	newDelegations map[common.Address]staking.DelegationIndexes,
+	bc *BlockChainImpl, blockNum *big.Int,
+) ([]common.Address, error) {
+	newList, appended := utils.AppendIfMissing(
+		newValidators, createValidator.ValidatorAddress,
+	)
+	if !appended {
+		return nil, errValidatorExist
+	}
+
+	// Add self delegation into the index
+	selfIndex := staking.DelegationIndex{
+		ValidatorAddress: createValidator.ValidatorAddress,
+		Index:            uint64(0),
+		BlockNum:         blockNum,
+	}
+	delegations, ok := newDelegations[createValidator.ValidatorAddress]
+	if !ok {
+		// If the cache doesn't have it, load it from DB for the first time.
+		var err error
+		delegations, err = bc.ReadDelegationsByDelegator(createValidator.ValidatorAddress)
+		if err != nil {
+			return nil, err
+		}
+	}
+	delegations = append(delegations, selfIndex)
+	newDelegations[createValidator.ValidatorAddress] = delegations
+	return newList, nil
+}
+
 func processDelegateMetadata(delegate *staking.Delegate,
 	newDelegations map[common.Address]staking.DelegationIndexes,
 	state *state.DB, bc *BlockChainImpl, blockNum *big.Int,
@@ -3186,6 +3229,19 @@ func processDelegateMetadata(delegate *staking.Delegate,
 	return nil
 }
 
+// processRedelegateMetadata indexes the delegation the stake is redelegated
+// to, the delegation it is redelegated from is indexed already
+func processRedelegateMetadata(redelegate *staking.Redelegate,
+	newDelegations map[common.Address]staking.DelegationIndexes,
+	state *state.DB, bc *BlockChainImpl, blockNum *big.Int,
+) error {
+	return processDelegateMetadata(&staking.Delegate{
+		DelegatorAddress: redelegate.DelegatorAddress,
+		ValidatorAddress: redelegate.ToValidatorAddress,
+		Amount:           redelegate.Amount,
+	}, newDelegations, state, bc, blockNum)
+}
+
 func (bc *BlockChainImpl) ReadBlockRewardAccumulator(number uint64) (*big.Int, error) {
 	if !bc.chainConfig.IsStaking(shard.Schedule.CalcEpochNumber(number)) {
 		return big.NewInt(0), nil
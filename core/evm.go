@@ -73,6 +73,7 @@ func NewEVMContext(msg Message, header *block.Header, chain ChainContext, author
 		vrfAndProof := header.Vrf()
 		copy(vrf[:], vrfAndProof[:32])
 	}
+	baseFee := header.BaseFee()
 	return vm.Context{
 		CanTransfer:           CanTransfer,
 		Transfer:              Transfer,
@@ -80,19 +81,22 @@ func NewEVMContext(msg Message, header *block.Header, chain ChainContext, author
 		GetVRF:                GetVRFFn(header, chain),
 		IsValidator:           IsValidator,
 		Origin:                msg.From(),
-		GasPrice:              new(big.Int).Set(msg.GasPrice()),
+		GasPrice:              effectiveGasPrice(msg, baseFee),
 		Coinbase:              beneficiary,
 		GasLimit:              header.GasLimit(),
 		BlockNumber:           header.Number(),
 		EpochNumber:           header.Epoch(),
 		Time:                  header.Time(),
 		VRF:                   vrf,
+		BaseFee:               baseFee,
 		TxType:                0,
 		CreateValidator:       CreateValidatorFn(header, chain),
 		EditValidator:         EditValidatorFn(header, chain),
 		Delegate:              DelegateFn(header, chain),
 		Undelegate:            UndelegateFn(header, chain),
 		CollectRewards:        CollectRewardsFn(header, chain),
+		Redelegate:            RedelegateFn(header, chain),
+		EditDelegation:        EditDelegationFn(header, chain),
 		CalculateMigrationGas: CalculateMigrationGasFn(chain),
 		ShardID:               chain.ShardID(),
 		NumShards:             shard.Schedule.InstanceForEpoch(header.Epoch()).NumShards(),
@@ -112,10 +116,12 @@ func CreateValidatorFn(ref *block.Header, chain ChainContext) vm.CreateValidator
 		if err != nil {
 			return err
 		}
-		if err := db.UpdateValidatorWrapper(wrapper.Address, wrapper); err != nil {
+		// with revert since the precompile can create validators too
+		if err := db.UpdateValidatorWrapperWithRevert(wrapper.Address, wrapper); err != nil {
 			return err
 		}
 		db.SetValidatorFlag(createValidator.ValidatorAddress)
+		db.AddBlockDelegation(createValidator.ValidatorAddress, createValidator.ValidatorAddress)
 		db.SubBalance(createValidator.ValidatorAddress, createValidator.Amount)
 
 		//add rosetta log
@@ -147,7 +153,7 @@ func EditValidatorFn(ref *block.Header, chain ChainContext) vm.EditValidatorFunc
 		if err != nil {
 			return err
 		}
-		return db.UpdateValidatorWrapper(wrapper.Address, wrapper)
+		return db.UpdateValidatorWrapperWithRevert(wrapper.Address, wrapper)
 	}
 }
 
@@ -169,6 +175,7 @@ func DelegateFn(ref *block.Header, chain ChainContext) vm.DelegateFunc {
 			}
 		}
 
+		db.AddBlockDelegation(delegate.DelegatorAddress, delegate.ValidatorAddress)
 		db.SubBalance(delegate.DelegatorAddress, balanceToBeDeducted)
 
 		if rosettaTracer != nil && balanceToBeDeducted != big.NewInt(0) {
@@ -270,6 +277,62 @@ func UndelegateFn(ref *block.Header, chain ChainContext) vm.UndelegateFunc {
 	}
 }
 
+func RedelegateFn(ref *block.Header, chain ChainContext) vm.RedelegateFunc {
+	return func(db vm.StateDB, rosettaTracer vm.RosettaTracer, redelegate *stakingTypes.Redelegate) error {
+		if chain == nil {
+			return errors.New("[Redelegate] No chain context provided")
+		}
+		delegations, err := chain.ReadDelegationsByDelegatorAt(redelegate.DelegatorAddress, big.NewInt(0).Sub(ref.Number(), big.NewInt(1)))
+		if err != nil {
+			return err
+		}
+		updatedValidatorWrappers, err := VerifyAndRedelegateFromMsg(
+			db, ref.Epoch(), redelegate, delegations, chain.Config(),
+		)
+		if err != nil {
+			return err
+		}
+		for _, wrapper := range updatedValidatorWrappers {
+			if err := db.UpdateValidatorWrapperWithRevert(wrapper.Address, wrapper); err != nil {
+				return err
+			}
+		}
+		db.AddBlockDelegation(redelegate.DelegatorAddress, redelegate.ToValidatorAddress)
+
+		//add rosetta log
+		if rosettaTracer != nil {
+			rosettaTracer.AddRosettaLog(
+				vm.CALL,
+				&vm.RosettaLogAddressItem{
+					Account:    &redelegate.DelegatorAddress,
+					SubAccount: &redelegate.FromValidatorAddress,
+					Metadata:   map[string]interface{}{"type": "delegation"},
+				},
+				&vm.RosettaLogAddressItem{
+					Account:    &redelegate.DelegatorAddress,
+					SubAccount: &redelegate.ToValidatorAddress,
+					Metadata:   map[string]interface{}{"type": "delegation"},
+				},
+				redelegate.Amount,
+			)
+		}
+		return nil
+	}
+}
+
+func EditDelegationFn(ref *block.Header, chain ChainContext) vm.EditDelegationFunc {
+	return func(db vm.StateDB, rosettaTracer vm.RosettaTracer, editDelegation *stakingTypes.EditDelegation) error {
+		if chain == nil {
+			return errors.New("[EditDelegation] No chain context provided")
+		}
+		wrapper, err := VerifyAndEditDelegationFromMsg(db, ref.Epoch(), editDelegation, chain.Config())
+		if err != nil {
+			return err
+		}
+		return db.UpdateValidatorWrapperWithRevert(wrapper.Address, wrapper)
+	}
+}
+
 func CollectRewardsFn(ref *block.Header, chain ChainContext) vm.CollectRewardsFunc {
 	return func(db vm.StateDB, rosettaTracer vm.RosettaTracer, collectRewards *stakingTypes.CollectRewards) error {
 		if chain == nil {
@@ -385,6 +448,7 @@ func CalculateMigrationGasFn(chain ChainContext) vm.CalculateMigrationGasFunc {
 				homestead,
 				istanbul,
 				false, // isValidatorCreation
+				false, // shanghai
 			)
 			if err != nil {
 				return 0, err
@@ -408,6 +472,7 @@ func CalculateMigrationGasFn(chain ChainContext) vm.CalculateMigrationGasFunc {
 				homestead,
 				istanbul,
 				false, // isValidatorCreation
+				false, // shanghai
 			)
 		}
 	}
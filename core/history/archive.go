@@ -0,0 +1,217 @@
+// Package history implements the archive files of the block history of a shard
+// chain, for moving the history between nodes without the p2p network. An archive
+// file holds the blocks of one epoch, along with their commit signatures. The
+// receipts are not archived since the import re-executes the blocks, which is
+// the only source of their receipts, checked against the receipt root of each
+// header. The crosslinks are in the headers already. It is laid out as
+//
+//	rlp(Header)
+//	rlp(Record) of each block from Header.First to Header.Last
+//	sha256 checksum of all the above
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"io"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+
+	"github.com/harmony-one/harmony/core/types"
+)
+
+const (
+	// Magic identifies the archive files
+	Magic = "harmony-history"
+	// Version is the version of the archive file format
+	Version uint64 = 1
+	// FileExt is the extension of the archive files
+	FileExt = ".hist"
+)
+
+var (
+	// ErrInvalidArchive is returned for the malformed archive files
+	ErrInvalidArchive = errors.New("invalid archive file")
+	// ErrChecksumMismatch is returned when the checksum of an archive file does
+	// not match its content
+	ErrChecksumMismatch = errors.New("archive checksum mismatch")
+)
+
+// Header describes the content of an archive file
+type Header struct {
+	Magic   string
+	Version uint64
+	Genesis common.Hash // genesis block hash of the network
+	ShardID uint32
+	Epoch   uint64
+	First   uint64 // number of the first block
+	Last    uint64 // number of the last block
+}
+
+// Record is the archived data of a block
+type Record struct {
+	Block     *types.Block
+	CommitSig []byte // commit signature and bitmap signed on the block
+}
+
+// FileName returns the name of the archive file of the blocks. The numbers are
+// padded so that the files of a shard sort in block order.
+func FileName(shardID uint32, epoch, first, last uint64) string {
+	return fmt.Sprintf("shard%d-epoch%08d-%012d-%012d%s", shardID, epoch, first, last, FileExt)
+}
+
+// Writer writes an archive file
+type Writer struct {
+	buf    *bufio.Writer
+	w      io.Writer // writes to buf and hasher
+	hasher hash.Hash
+	header Header
+	next   uint64
+}
+
+// NewWriter writes the header of an archive file to w, and returns the writer of
+// its records
+func NewWriter(w io.Writer, header Header) (*Writer, error) {
+	header.Magic, header.Version = Magic, Version
+	if header.Last < header.First {
+		return nil, errors.Errorf("invalid block range %v-%v", header.First, header.Last)
+	}
+	buf := bufio.NewWriter(w)
+	hasher := sha256.New()
+	aw := &Writer{
+		buf:    buf,
+		w:      io.MultiWriter(buf, hasher),
+		hasher: hasher,
+		header: header,
+		next:   header.First,
+	}
+	if err := rlp.Encode(aw.w, &aw.header); err != nil {
+		return nil, errors.Wrap(err, "write header")
+	}
+	return aw, nil
+}
+
+// Append verifies and writes the record of the next block
+func (w *Writer) Append(rec *Record) error {
+	if w.next > w.header.Last {
+		return errors.Errorf("block range %v-%v already written", w.header.First, w.header.Last)
+	}
+	if err := verifyRecord(&w.header, rec, w.next); err != nil {
+		return err
+	}
+	if err := rlp.Encode(w.w, rec); err != nil {
+		return errors.Wrapf(err, "write block %v", w.next)
+	}
+	w.next++
+	return nil
+}
+
+// Finish writes the checksum and flushes the file. All the blocks of the header
+// are to be appended.
+func (w *Writer) Finish() error {
+	if w.next <= w.header.Last {
+		return errors.Errorf("blocks %v-%v not written", w.next, w.header.Last)
+	}
+	if _, err := w.buf.Write(w.hasher.Sum(nil)); err != nil {
+		return errors.Wrap(err, "write checksum")
+	}
+	return w.buf.Flush()
+}
+
+// Reader reads the records of an archive file
+type Reader struct {
+	header Header
+	stream *rlp.Stream
+	next   uint64
+}
+
+// NewReader verifies the checksum of the archive file of the given size, and
+// reads its header
+func NewReader(r io.ReaderAt, size int64) (*Reader, error) {
+	if size < sha256.Size {
+		return nil, errors.Wrap(ErrInvalidArchive, "file too short")
+	}
+	bodySize := size - sha256.Size
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, io.NewSectionReader(r, 0, bodySize)); err != nil {
+		return nil, err
+	}
+	checksum := make([]byte, sha256.Size)
+	if _, err := r.ReadAt(checksum, bodySize); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(checksum, hasher.Sum(nil)) {
+		return nil, ErrChecksumMismatch
+	}
+
+	stream := rlp.NewStream(bufio.NewReader(io.NewSectionReader(r, 0, bodySize)), uint64(bodySize))
+	var header Header
+	if err := stream.Decode(&header); err != nil {
+		return nil, errors.Wrapf(ErrInvalidArchive, "decode header: %v", err)
+	}
+	if header.Magic != Magic {
+		return nil, errors.Wrapf(ErrInvalidArchive, "unknown magic %q", header.Magic)
+	}
+	if header.Version != Version {
+		return nil, errors.Wrapf(ErrInvalidArchive, "unsupported version %v", header.Version)
+	}
+	if header.Last < header.First {
+		return nil, errors.Wrapf(ErrInvalidArchive, "block range %v-%v", header.First, header.Last)
+	}
+	return &Reader{
+		header: header,
+		stream: stream,
+		next:   header.First,
+	}, nil
+}
+
+// Header returns the header of the archive file
+func (r *Reader) Header() Header {
+	return r.header
+}
+
+// Next reads and verifies the record of the next block. It returns io.EOF after
+// the last block.
+func (r *Reader) Next() (*Record, error) {
+	if r.next > r.header.Last {
+		if _, _, err := r.stream.Kind(); err != io.EOF {
+			return nil, errors.Wrap(ErrInvalidArchive, "data after the last block")
+		}
+		return nil, io.EOF
+	}
+	var rec Record
+	if err := r.stream.Decode(&rec); err != nil {
+		return nil, errors.Wrapf(ErrInvalidArchive, "decode block %v: %v", r.next, err)
+	}
+	if err := verifyRecord(&r.header, &rec, r.next); err != nil {
+		return nil, errors.Wrap(ErrInvalidArchive, err.Error())
+	}
+	r.next++
+	return &rec, nil
+}
+
+// verifyRecord verifies the record is of the expected block
+func verifyRecord(header *Header, rec *Record, number uint64) error {
+	if rec.Block == nil {
+		return errors.Errorf("block %v missing", number)
+	}
+	h := rec.Block.Header()
+	if h.Number().Uint64() != number {
+		return errors.Errorf("block %v, want %v", h.Number(), number)
+	}
+	if h.ShardID() != header.ShardID {
+		return errors.Errorf("block %v of shard %v, want %v", number, h.ShardID(), header.ShardID)
+	}
+	if h.Epoch().Uint64() != header.Epoch {
+		return errors.Errorf("block %v of epoch %v, want %v", number, h.Epoch(), header.Epoch)
+	}
+	if len(rec.CommitSig) == 0 {
+		return errors.Errorf("commit sig of block %v missing", number)
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package history
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/core/types"
+)
+
+func TestArchive_RoundTrip(t *testing.T) {
+	header, records := makeTestRecords(10, 12)
+	data := writeTestArchive(t, header, records)
+
+	r, err := NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := r.Header(); got.Genesis != header.Genesis || got.First != 10 || got.Last != 12 || got.Version != Version {
+		t.Errorf("unexpected header %+v", got)
+	}
+	for i, want := range records {
+		rec, err := r.Next()
+		if err != nil {
+			t.Fatalf("record %v: %v", i, err)
+		}
+		if rec.Block.Hash() != want.Block.Hash() {
+			t.Errorf("record %v: unexpected block %x", i, rec.Block.Hash())
+		}
+		if !bytes.Equal(rec.CommitSig, want.CommitSig) {
+			t.Errorf("record %v: unexpected commit sig %x", i, rec.CommitSig)
+		}
+	}
+	if _, err := r.Next(); err != io.EOF {
+		t.Errorf("unexpected error after the last record: %v", err)
+	}
+}
+
+func TestArchive_Checksum(t *testing.T) {
+	header, records := makeTestRecords(10, 12)
+	data := writeTestArchive(t, header, records)
+
+	tests := []struct {
+		data   []byte
+		expErr error
+	}{
+		{
+			data:   corrupt(data, len(data)/2),
+			expErr: ErrChecksumMismatch,
+		},
+		{
+			data:   corrupt(data, len(data)-1),
+			expErr: ErrChecksumMismatch,
+		},
+		{
+			data:   data[:len(data)-1],
+			expErr: ErrChecksumMismatch,
+		},
+		{
+			data:   data[:10],
+			expErr: ErrInvalidArchive,
+		},
+	}
+	for i, test := range tests {
+		_, err := NewReader(bytes.NewReader(test.data), int64(len(test.data)))
+		if !errors.Is(err, test.expErr) {
+			t.Errorf("Test %v: unexpected error %v / %v", i, err, test.expErr)
+		}
+	}
+}
+
+func TestWriter_Append(t *testing.T) {
+	header, records := makeTestRecords(10, 12)
+	tampered := *records[1]
+	tampered.CommitSig = nil
+	otherEpoch, _ := makeTestRecords(11, 11)
+	otherEpoch[0].Block = types.NewBlockWithHeader(blockfactory.NewTestHeader().With().
+		Number(big.NewInt(11)).ShardID(1).Epoch(big.NewInt(3)).Header())
+
+	tests := []struct {
+		rec    *Record
+		expErr bool
+	}{
+		{rec: records[1]},
+		{rec: &tampered, expErr: true},
+		{rec: otherEpoch[0], expErr: true},
+		{rec: records[2], expErr: true},
+	}
+	for i, test := range tests {
+		w, err := NewWriter(io.Discard, header)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Append(records[0]); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Append(test.rec); (err != nil) != test.expErr {
+			t.Errorf("Test %v: unexpected error %v", i, err)
+		}
+	}
+
+	w, err := NewWriter(io.Discard, header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Append(records[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Finish(); err == nil {
+		t.Errorf("finished with missing blocks")
+	}
+}
+
+func writeTestArchive(t *testing.T, header Header, records []*Record) []byte {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rec := range records {
+		if err := w.Append(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Finish(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func makeTestRecords(first, last uint64) (Header, []*Record) {
+	header := Header{
+		Genesis: common.Hash{0x01},
+		ShardID: 1,
+		Epoch:   2,
+		First:   first,
+		Last:    last,
+	}
+	var records []*Record
+	for number := first; number <= last; number++ {
+		tx := types.NewTransaction(number, common.Address{0x01}, 1, big.NewInt(1), 21000, big.NewInt(1), nil)
+		receipts := types.Receipts{
+			{
+				Status:            types.ReceiptStatusSuccessful,
+				CumulativeGasUsed: 21000,
+				Logs:              []*types.Log{},
+				TxHash:            tx.Hash(),
+			},
+		}
+		h := blockfactory.NewTestHeader().With().
+			Number(new(big.Int).SetUint64(number)).ShardID(1).Epoch(big.NewInt(2)).Header()
+		records = append(records, &Record{
+			Block:     types.NewBlock(h, types.Transactions{tx}, receipts, nil, nil, nil),
+			CommitSig: bytes.Repeat([]byte{byte(number)}, 100),
+		})
+	}
+	return header, records
+}
+
+func corrupt(data []byte, i int) []byte {
+	c := append([]byte{}, data...)
+	c[i] ^= 0xff
+	return c
+}
@@ -0,0 +1,112 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/harmony-one/harmony/core"
+)
+
+// Export writes the blocks from..to of the chain into archive files in dir, one
+// file per epoch, and returns the paths of the files written
+func Export(bc core.BlockChain, dir string, from, to uint64) ([]string, error) {
+	if to < from {
+		return nil, errors.Errorf("invalid block range %v-%v", from, to)
+	}
+	if current := bc.CurrentBlock().NumberU64(); to > current {
+		return nil, errors.Errorf("block %v above the chain head %v", to, current)
+	}
+	genesis := bc.GetHeaderByNumber(0)
+	if genesis == nil {
+		return nil, errors.New("genesis block not found")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for first := from; first <= to; {
+		header := bc.GetHeaderByNumber(first)
+		if header == nil {
+			return files, errors.Errorf("block %v not found", first)
+		}
+		// the last block of the range in the same epoch
+		last := first
+		for last < to {
+			next := bc.GetHeaderByNumber(last + 1)
+			if next == nil {
+				return files, errors.Errorf("block %v not found", last+1)
+			}
+			if next.Epoch().Cmp(header.Epoch()) != 0 {
+				break
+			}
+			last++
+		}
+		epoch := header.Epoch().Uint64()
+		path := filepath.Join(dir, FileName(bc.ShardID(), epoch, first, last))
+		err := exportFile(bc, path, Header{
+			Genesis: genesis.Hash(),
+			ShardID: bc.ShardID(),
+			Epoch:   epoch,
+			First:   first,
+			Last:    last,
+		})
+		if err != nil {
+			return files, errors.Wrapf(err, "export epoch %v", epoch)
+		}
+		files = append(files, path)
+		first = last + 1
+	}
+	return files, nil
+}
+
+// exportFile writes the archive file of the header. The file is written to a
+// temporary path first, so that no partial archive file is left on failure.
+func exportFile(bc core.BlockChain, path string, header Header) error {
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+	defer f.Close()
+
+	w, err := NewWriter(f, header)
+	if err != nil {
+		return err
+	}
+	for number := header.First; number <= header.Last; number++ {
+		rec, err := readRecord(bc, number)
+		if err != nil {
+			return err
+		}
+		if err := w.Append(rec); err != nil {
+			return err
+		}
+	}
+	if err := w.Finish(); err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// readRecord reads the archived data of the block from the chain
+func readRecord(bc core.BlockChain, number uint64) (*Record, error) {
+	block := bc.GetBlockByNumber(number)
+	if block == nil {
+		return nil, errors.Errorf("block %v not found", number)
+	}
+	sig, err := bc.ReadCommitSig(number)
+	if err != nil {
+		return nil, errors.Wrapf(err, "read commit sig of block %v", number)
+	}
+	return &Record{
+		Block:     block,
+		CommitSig: sig,
+	}, nil
+}
@@ -0,0 +1,90 @@
+package history
+
+import (
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/internal/chain"
+)
+
+// Import verifies the archive file and inserts its blocks into the chain. The
+// blocks already in the chain are skipped, and the first block to insert is to
+// be the child of the chain head. The blocks are re-executed by the insertion,
+// which writes their receipts and crosslinks as for the blocks synced. It
+// returns the number of blocks inserted.
+func Import(bc core.BlockChain, path string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+	r, err := NewReader(f, info.Size())
+	if err != nil {
+		return 0, err
+	}
+	header := r.Header()
+	if header.ShardID != bc.ShardID() {
+		return 0, errors.Errorf("archive of shard %v, chain of shard %v", header.ShardID, bc.ShardID())
+	}
+	if genesis := bc.GetHeaderByNumber(0); genesis == nil || genesis.Hash() != header.Genesis {
+		return 0, errors.Errorf("archive of another network with genesis %x", header.Genesis)
+	}
+
+	inserted := 0
+	for {
+		rec, err := r.Next()
+		if err == io.EOF {
+			return inserted, nil
+		}
+		if err != nil {
+			return inserted, err
+		}
+		ok, err := insertRecord(bc, rec)
+		if err != nil {
+			return inserted, err
+		}
+		if ok {
+			inserted++
+		}
+	}
+}
+
+// insertRecord verifies the commit sig of the block and inserts it into the chain,
+// returning false if the block is in the chain already
+func insertRecord(bc core.BlockChain, rec *Record) (bool, error) {
+	number := rec.Block.NumberU64()
+	current := bc.CurrentBlock().NumberU64()
+	if number <= current {
+		if header := bc.GetHeaderByNumber(number); header == nil || header.Hash() != rec.Block.Hash() {
+			return false, errors.Errorf("block %v conflicts with the chain", number)
+		}
+		return false, nil
+	}
+	if number != current+1 {
+		return false, errors.Errorf("block %v not connected to the chain head %v", number, current)
+	}
+
+	sig, bitmap, err := chain.ParseCommitSigAndBitmap(rec.CommitSig)
+	if err != nil {
+		return false, errors.Wrapf(err, "parse commit sig of block %v", number)
+	}
+	if err := bc.Engine().VerifyHeaderSignature(bc, rec.Block.Header(), sig, bitmap); err != nil {
+		return false, errors.Wrapf(err, "verify commit sig of block %v", number)
+	}
+	if _, err := bc.InsertChain(types.Blocks{rec.Block}, true); err != nil {
+		return false, errors.Wrapf(err, "insert block %v", number)
+	}
+	if err := bc.WriteCommitSig(number, rec.CommitSig); err != nil {
+		return false, errors.Wrapf(err, "write commit sig of block %v", number)
+	}
+	return true, nil
+}
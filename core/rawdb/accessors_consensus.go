@@ -0,0 +1,60 @@
+package rawdb
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+)
+
+// WriteFBFTLogBlock stores a block of the FBFT log.
+func WriteFBFTLogBlock(db DatabaseWriter, number uint64, hash common.Hash, data []byte) error {
+	return db.Put(fbftLogBlockKey(number, hash), data)
+}
+
+// DeleteFBFTLogBlock removes a block of the FBFT log.
+func DeleteFBFTLogBlock(db DatabaseDeleter, number uint64, hash common.Hash) error {
+	return db.Delete(fbftLogBlockKey(number, hash))
+}
+
+// WriteFBFTLogMessage stores a message of the FBFT log.
+func WriteFBFTLogMessage(db DatabaseWriter, number uint64, id []byte, data []byte) error {
+	return db.Put(fbftLogMessageKey(number, id), data)
+}
+
+// DeleteFBFTLogMessage removes a message of the FBFT log.
+func DeleteFBFTLogMessage(db DatabaseDeleter, number uint64, id []byte) error {
+	return db.Delete(fbftLogMessageKey(number, id))
+}
+
+// IterateFBFTLogBlocks calls fn on every stored FBFT log block in ascending block number order.
+func IterateFBFTLogBlocks(db ethdb.Iteratee, fn func(number uint64, hash common.Hash, data []byte) error) error {
+	it := db.NewIterator(fbftLogBlockPrefix, nil)
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()[len(fbftLogBlockPrefix):]
+		if len(key) != 8+common.HashLength {
+			continue
+		}
+		if err := fn(decodeBlockNumber(key[:8]), common.BytesToHash(key[8:]), it.Value()); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+// IterateFBFTLogMessages calls fn on every stored FBFT log message in ascending block number order.
+func IterateFBFTLogMessages(db ethdb.Iteratee, fn func(number uint64, id []byte, data []byte) error) error {
+	it := db.NewIterator(fbftLogMessagePrefix, nil)
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()[len(fbftLogMessagePrefix):]
+		if len(key) < 8 {
+			continue
+		}
+		if err := fn(decodeBlockNumber(key[:8]), common.CopyBytes(key[8:]), it.Value()); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
@@ -149,6 +149,9 @@ var (
 
 	CliqueSnapshotPrefix = []byte("clique-")
 
+	fbftLogBlockPrefix   = []byte("fbft-blk-") // fbftLogBlockPrefix + num (uint64 big endian) + hash -> fbft log block
+	fbftLogMessagePrefix = []byte("fbft-msg-") // fbftLogMessagePrefix + num (uint64 big endian) + message id -> fbft log message
+
 	preImageImportKey   = []byte("preimage-import")
 	preImageGenStartKey = []byte("preimage-gen-start")
 	preImageGenEndKey   = []byte("preimage-gen-end")
@@ -387,3 +390,13 @@ func blockRewardAccumKey(number uint64) []byte {
 func blockCommitSigKey(number uint64) []byte {
 	return append(blockCommitSigPrefix, encodeBlockNumber(number)...)
 }
+
+// fbftLogBlockKey = fbftLogBlockPrefix + num (uint64 big endian) + hash
+func fbftLogBlockKey(number uint64, hash common.Hash) []byte {
+	return append(append(common.CopyBytes(fbftLogBlockPrefix), encodeBlockNumber(number)...), hash.Bytes()...)
+}
+
+// fbftLogMessageKey = fbftLogMessagePrefix + num (uint64 big endian) + id
+func fbftLogMessageKey(number uint64, id []byte) []byte {
+	return append(append(common.CopyBytes(fbftLogMessagePrefix), encodeBlockNumber(number)...), id...)
+}
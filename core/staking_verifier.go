@@ -410,6 +410,182 @@ func VerifyAndUndelegateFromMsg(
 	return nil, errNoDelegationToUndelegate
 }
 
+// VerifyAndRedelegateFromMsg verifies the redelegate message using the stateDB
+// and returns the edited validatorWrappers of the two validators, with the
+// amount moved from the delegation of the first one to that of the second one.
+//
+// The moved amount is recorded in the delegation it is moved from, it can't
+// be redelegated again before the cooldown and stays slashable by the
+// validator it is moved from for as long. The delegations are the indexes of
+// all the delegations of the delegator, to find the tokens in cooldown, along
+// with the delegations made since, recorded in the stateDB for the block.
+//
+// Note that this function never updates the stateDB, it only reads from stateDB.
+func VerifyAndRedelegateFromMsg(
+	stateDB vm.StateDB, epoch *big.Int, msg *staking.Redelegate, delegations []staking.DelegationIndex, chainConfig *params.ChainConfig,
+) ([]*staking.ValidatorWrapper, error) {
+	if stateDB == nil {
+		return nil, errStateDBIsMissing
+	}
+	if epoch == nil {
+		return nil, errEpochMissing
+	}
+	if !chainConfig.IsAtomicRedelegation(epoch) {
+		return nil, errRedelegateNotSupported
+	}
+	if msg.Amount.Sign() == -1 {
+		return nil, errNegativeAmount
+	}
+	if msg.Amount.Cmp(minimumDelegationV2) < 0 {
+		return nil, errDelegationTooSmallV2
+	}
+	if bytes.Equal(msg.FromValidatorAddress.Bytes(), msg.ToValidatorAddress.Bytes()) {
+		return nil, errRedelegateToSameValidator
+	}
+	if !stateDB.IsValidator(msg.FromValidatorAddress) || !stateDB.IsValidator(msg.ToValidatorAddress) {
+		return nil, errValidatorNotExist
+	}
+
+	// the tokens redelegated to the validator we redelegate from
+	// in the last epochs can't be redelegated again yet
+	inCooldown := big.NewInt(0)
+	counted := make(map[common.Address]struct{}, len(delegations))
+	for i := range delegations {
+		delegationIndex := &delegations[i]
+		wrapper, err := stateDB.ValidatorWrapper(delegationIndex.ValidatorAddress, true, false)
+		if err != nil {
+			return nil, err
+		}
+		if uint64(len(wrapper.Delegations)) <= delegationIndex.Index {
+			return nil, errors.New("Delegation index out of bound")
+		}
+		delegation := &wrapper.Delegations[delegationIndex.Index]
+		inCooldown.Add(inCooldown, delegation.TotalInRedelegationCooldown(
+			epoch, msg.FromValidatorAddress, staking.RedelegationCooldownInEpoch,
+		))
+		counted[delegationIndex.ValidatorAddress] = struct{}{}
+	}
+	// the indexes are those of the previous block, the delegations made
+	// earlier in this block are recorded by the state
+	for _, addr := range stateDB.BlockDelegations(msg.DelegatorAddress) {
+		if _, ok := counted[addr]; ok {
+			continue
+		}
+		wrapper, err := stateDB.ValidatorWrapper(addr, true, false)
+		if err != nil {
+			return nil, err
+		}
+		for j := range wrapper.Delegations {
+			delegation := &wrapper.Delegations[j]
+			if delegation.DelegatorAddress == msg.DelegatorAddress {
+				inCooldown.Add(inCooldown, delegation.TotalInRedelegationCooldown(
+					epoch, msg.FromValidatorAddress, staking.RedelegationCooldownInEpoch,
+				))
+				break
+			}
+		}
+	}
+
+	// request a copy, and since delegations will be changed, copy them too
+	fromWrapper, err := stateDB.ValidatorWrapper(msg.FromValidatorAddress, false, true)
+	if err != nil {
+		return nil, err
+	}
+	toWrapper, err := stateDB.ValidatorWrapper(msg.ToValidatorAddress, false, true)
+	if err != nil {
+		return nil, err
+	}
+	if toWrapper.Status == effective.Banned {
+		return nil, errRedelegateToBannedValidator
+	}
+
+	found := false
+	for i := range fromWrapper.Delegations {
+		delegation := &fromWrapper.Delegations[i]
+		if bytes.Equal(delegation.DelegatorAddress.Bytes(), msg.DelegatorAddress.Bytes()) {
+			available := new(big.Int).Sub(delegation.Amount, inCooldown)
+			if inCooldown.Sign() > 0 && available.Cmp(msg.Amount) < 0 {
+				return nil, errors.Wrapf(
+					errRedelegationInCooldown, "available: %v, in cooldown: %v; trying to redelegate %v",
+					available, inCooldown, msg.Amount,
+				)
+			}
+			if err := delegation.Redelegate(epoch, msg.ToValidatorAddress, msg.Amount); err != nil {
+				return nil, err
+			}
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, errNoDelegationToRedelegate
+	}
+	if err := fromWrapper.SanityCheck(); err != nil {
+		// same as undelegate, allow self delegation to go below
+		// min self delegation but set the status to inactive
+		if errors.Cause(err) == staking.ErrInvalidSelfDelegation {
+			fromWrapper.Status = effective.Inactive
+		} else {
+			return nil, err
+		}
+	}
+
+	// Add to existing delegation if any
+	found = false
+	for i := range toWrapper.Delegations {
+		delegation := &toWrapper.Delegations[i]
+		if bytes.Equal(delegation.DelegatorAddress.Bytes(), msg.DelegatorAddress.Bytes()) {
+			delegation.Amount.Add(delegation.Amount, msg.Amount)
+			found = true
+			break
+		}
+	}
+	if !found {
+		toWrapper.Delegations = append(
+			toWrapper.Delegations, staking.NewDelegation(
+				msg.DelegatorAddress, new(big.Int).Set(msg.Amount),
+			),
+		)
+	}
+	if err := toWrapper.SanityCheck(); err != nil {
+		return nil, err
+	}
+	return []*staking.ValidatorWrapper{fromWrapper, toWrapper}, nil
+}
+
+// VerifyAndEditDelegationFromMsg verifies the edit delegation message using
+// the stateDB and returns the edited validatorWrapper.
+//
+// Note that this function never updates the stateDB, it only reads from stateDB.
+func VerifyAndEditDelegationFromMsg(
+	stateDB vm.StateDB, epoch *big.Int, msg *staking.EditDelegation, chainConfig *params.ChainConfig,
+) (*staking.ValidatorWrapper, error) {
+	if stateDB == nil {
+		return nil, errStateDBIsMissing
+	}
+	if epoch == nil {
+		return nil, errEpochMissing
+	}
+	if !chainConfig.IsAutoCompound(epoch) {
+		return nil, errEditDelegationNotSupported
+	}
+	if !stateDB.IsValidator(msg.ValidatorAddress) {
+		return nil, errValidatorNotExist
+	}
+	wrapper, err := stateDB.ValidatorWrapper(msg.ValidatorAddress, false, true)
+	if err != nil {
+		return nil, err
+	}
+	for i := range wrapper.Delegations {
+		delegation := &wrapper.Delegations[i]
+		if bytes.Equal(delegation.DelegatorAddress.Bytes(), msg.DelegatorAddress.Bytes()) {
+			delegation.SetAutoCompound(msg.AutoCompound)
+			return wrapper, nil
+		}
+	}
+	return nil, errNoDelegationToEdit
+}
+
 // VerifyAndMigrateFromMsg verifies and transfers all delegations of
 // msg.From to msg.To. Returns all modified validator wrappers and delegate msgs
 // for metadata
@@ -1452,6 +1452,344 @@ func defaultVWrapperSelfUndelegate(t *testing.T) staking.ValidatorWrapper {
 	return w
 }
 
+func TestVerifyAndRedelegateFromMsg(t *testing.T) {
+	tests := []struct {
+		sdb         vm.StateDB
+		epoch       *big.Int
+		msg         staking.Redelegate
+		delegations []staking.DelegationIndex
+		config      *params.ChainConfig
+
+		expVWrappers []staking.ValidatorWrapper
+		expErr       error
+	}{
+		{
+			// 0: Redelegate to a validator without delegation yet
+			sdb:    makeDefaultStateForUndelegate(t),
+			epoch:  big.NewInt(defaultEpoch),
+			msg:    defaultMsgRedelegate(),
+			config: redelegateChainConfig(),
+
+			expVWrappers: defaultExpVWrappersRedelegate(t),
+		},
+		{
+			// 1: Redelegate before the fork
+			sdb:    makeDefaultStateForUndelegate(t),
+			epoch:  big.NewInt(defaultEpoch),
+			msg:    defaultMsgRedelegate(),
+			config: &params.ChainConfig{AtomicRedelegationEpoch: big.NewInt(defaultNextEpoch)},
+
+			expErr: errRedelegateNotSupported,
+		},
+		{
+			// 2: Redelegate to the same validator
+			sdb:   makeDefaultStateForUndelegate(t),
+			epoch: big.NewInt(defaultEpoch),
+			msg: func() staking.Redelegate {
+				msg := defaultMsgRedelegate()
+				msg.ToValidatorAddress = validatorAddr
+				return msg
+			}(),
+			config: redelegateChainConfig(),
+
+			expErr: errRedelegateToSameValidator,
+		},
+		{
+			// 3: Redelegate to an address which is not a validator
+			sdb:   makeDefaultStateForUndelegate(t),
+			epoch: big.NewInt(defaultEpoch),
+			msg: func() staking.Redelegate {
+				msg := defaultMsgRedelegate()
+				msg.ToValidatorAddress = makeTestAddr("not exist")
+				return msg
+			}(),
+			config: redelegateChainConfig(),
+
+			expErr: errValidatorNotExist,
+		},
+		{
+			// 4: No delegation to redelegate
+			sdb:   makeDefaultStateForUndelegate(t),
+			epoch: big.NewInt(defaultEpoch),
+			msg: func() staking.Redelegate {
+				msg := defaultMsgRedelegate()
+				msg.DelegatorAddress = makeTestAddr("not exist")
+				return msg
+			}(),
+			config: redelegateChainConfig(),
+
+			expErr: errNoDelegationToRedelegate,
+		},
+		{
+			// 5: Insufficient balance to redelegate
+			sdb:   makeDefaultStateForUndelegate(t),
+			epoch: big.NewInt(defaultEpoch),
+			msg: func() staking.Redelegate {
+				msg := defaultMsgRedelegate()
+				msg.Amount = new(big.Int).Set(hundredKOnes)
+				return msg
+			}(),
+			config: redelegateChainConfig(),
+
+			expErr: errors.New("insufficient balance to redelegate"),
+		},
+		{
+			// 6: Redelegate to a banned validator
+			sdb: func(t *testing.T) *state.DB {
+				sdb := makeDefaultStateForUndelegate(t)
+				w, err := sdb.ValidatorWrapper(validatorAddr2, false, true)
+				if err != nil {
+					t.Fatal(err)
+				}
+				w.Status = effective.Banned
+				if err := sdb.UpdateValidatorWrapper(validatorAddr2, w); err != nil {
+					t.Fatal(err)
+				}
+				return sdb
+			}(t),
+			epoch:  big.NewInt(defaultEpoch),
+			msg:    defaultMsgRedelegate(),
+			config: redelegateChainConfig(),
+
+			expErr: errRedelegateToBannedValidator,
+		},
+		{
+			// 7: The tokens just redelegated to the validator are in cooldown
+			sdb: func(t *testing.T) *state.DB {
+				sdb := makeDefaultStateForUndelegate(t)
+				w, err := sdb.ValidatorWrapper(validatorAddr2, false, true)
+				if err != nil {
+					t.Fatal(err)
+				}
+				delegation := staking.NewDelegation(delegatorAddr, new(big.Int).Set(twentyKOnes))
+				if err := delegation.Redelegate(big.NewInt(defaultEpoch), validatorAddr, twentyKOnes); err != nil {
+					t.Fatal(err)
+				}
+				w.Delegations = append(w.Delegations, delegation)
+				if err := sdb.UpdateValidatorWrapper(validatorAddr2, w); err != nil {
+					t.Fatal(err)
+				}
+				return sdb
+			}(t),
+			epoch:  big.NewInt(defaultNextEpoch),
+			msg:    defaultMsgRedelegate(),
+			config: redelegateChainConfig(),
+			delegations: []staking.DelegationIndex{
+				{ValidatorAddress: validatorAddr, Index: 1},
+				{ValidatorAddress: validatorAddr2, Index: 1},
+			},
+
+			expErr: errRedelegationInCooldown,
+		},
+		{
+			// 8: The tokens redelegated to the validator earlier in the block, from a
+			// delegation not indexed yet, are in cooldown
+			sdb:   makeStateForRedelegateChain(t, fiveKOnes),
+			epoch: big.NewInt(defaultNextEpoch),
+			msg: func() staking.Redelegate {
+				msg := defaultMsgRedelegate()
+				msg.Amount = new(big.Int).Set(fifteenKOnes)
+				return msg
+			}(),
+			config: redelegateChainConfig(),
+			delegations: []staking.DelegationIndex{
+				{ValidatorAddress: validatorAddr, Index: 1},
+			},
+
+			expErr: errRedelegationInCooldown,
+		},
+		{
+			// 9: The tokens in cooldown are counted once for an indexed delegation
+			sdb:   makeStateForRedelegateChain(t, fiveKOnes),
+			epoch: big.NewInt(defaultNextEpoch),
+			msg: func() staking.Redelegate {
+				msg := defaultMsgRedelegate()
+				msg.Amount = new(big.Int).Set(tenKOnes)
+				return msg
+			}(),
+			config: redelegateChainConfig(),
+			delegations: []staking.DelegationIndex{
+				{ValidatorAddress: validatorAddr, Index: 1},
+				{ValidatorAddress: validatorAddr2, Index: 1},
+			},
+
+			expVWrappers: func(t *testing.T) []staking.ValidatorWrapper {
+				from := makeDefaultSnapVWrapperForUndelegate(t)
+				from.Delegations[1].Amount = new(big.Int).Sub(from.Delegations[1].Amount, tenKOnes)
+				from.Delegations[1].Redelegations = staking.Redelegations{
+					staking.Redelegation{
+						ValidatorAddress: validatorAddr2,
+						Amount:           tenKOnes,
+						Epoch:            big.NewInt(defaultNextEpoch),
+					},
+				}
+
+				to := makeVWrapperByIndex(validator2Index)
+				delegation := staking.NewDelegation(delegatorAddr, new(big.Int).Set(fiveKOnes))
+				if err := delegation.Redelegate(big.NewInt(defaultEpoch), validatorAddr, fiveKOnes); err != nil {
+					t.Fatal(err)
+				}
+				delegation.Amount = new(big.Int).Set(tenKOnes)
+				to.Delegations = append(to.Delegations, delegation)
+				return []staking.ValidatorWrapper{from, to}
+			}(t),
+		},
+	}
+	for i, test := range tests {
+		ws, err := VerifyAndRedelegateFromMsg(test.sdb, test.epoch, &test.msg, test.delegations, test.config)
+
+		if assErr := assertError(err, test.expErr); assErr != nil {
+			t.Errorf("Test %v: %v", i, assErr)
+		}
+		if err != nil || test.expErr != nil {
+			continue
+		}
+
+		if len(ws) != len(test.expVWrappers) {
+			t.Fatalf("Test %v: size not expected %v / %v", i, len(ws), len(test.expVWrappers))
+		}
+		for j := range ws {
+			if err := staketest.CheckValidatorWrapperEqual(*ws[j], test.expVWrappers[j]); err != nil {
+				t.Errorf("Test %v: wrapper %v: %v", i, j, err)
+			}
+		}
+	}
+}
+
+func TestVerifyAndEditDelegationFromMsg(t *testing.T) {
+	tests := []struct {
+		sdb    vm.StateDB
+		epoch  *big.Int
+		msg    staking.EditDelegation
+		config *params.ChainConfig
+
+		expVWrapper staking.ValidatorWrapper
+		expErr      error
+	}{
+		{
+			// 0: Set the flag of the delegation
+			sdb:    makeDefaultStateForUndelegate(t),
+			epoch:  big.NewInt(defaultEpoch),
+			msg:    defaultMsgEditDelegation(),
+			config: &params.ChainConfig{AutoCompoundEpoch: big.NewInt(0)},
+
+			expVWrapper: func(t *testing.T) staking.ValidatorWrapper {
+				w := makeDefaultSnapVWrapperForUndelegate(t)
+				w.Delegations[1].AutoCompound = true
+				return w
+			}(t),
+		},
+		{
+			// 1: Edit before the fork
+			sdb:    makeDefaultStateForUndelegate(t),
+			epoch:  big.NewInt(defaultEpoch),
+			msg:    defaultMsgEditDelegation(),
+			config: &params.ChainConfig{AutoCompoundEpoch: big.NewInt(defaultNextEpoch)},
+
+			expErr: errEditDelegationNotSupported,
+		},
+		{
+			// 2: Validator not exist
+			sdb:   makeDefaultStateForUndelegate(t),
+			epoch: big.NewInt(defaultEpoch),
+			msg: func() staking.EditDelegation {
+				msg := defaultMsgEditDelegation()
+				msg.ValidatorAddress = makeTestAddr("not exist")
+				return msg
+			}(),
+			config: &params.ChainConfig{AutoCompoundEpoch: big.NewInt(0)},
+
+			expErr: errValidatorNotExist,
+		},
+		{
+			// 3: No delegation to edit
+			sdb:   makeDefaultStateForUndelegate(t),
+			epoch: big.NewInt(defaultEpoch),
+			msg: func() staking.EditDelegation {
+				msg := defaultMsgEditDelegation()
+				msg.DelegatorAddress = makeTestAddr("not exist")
+				return msg
+			}(),
+			config: &params.ChainConfig{AutoCompoundEpoch: big.NewInt(0)},
+
+			expErr: errNoDelegationToEdit,
+		},
+	}
+	for i, test := range tests {
+		w, err := VerifyAndEditDelegationFromMsg(test.sdb, test.epoch, &test.msg, test.config)
+
+		if assErr := assertError(err, test.expErr); assErr != nil {
+			t.Errorf("Test %v: %v", i, assErr)
+		}
+		if err != nil || test.expErr != nil {
+			continue
+		}
+
+		if err := staketest.CheckValidatorWrapperEqual(*w, test.expVWrapper); err != nil {
+			t.Errorf("Test %v: %v", i, err)
+		}
+	}
+}
+
+func defaultMsgEditDelegation() staking.EditDelegation {
+	return staking.EditDelegation{
+		DelegatorAddress: delegatorAddr,
+		ValidatorAddress: validatorAddr,
+		AutoCompound:     true,
+	}
+}
+
+func redelegateChainConfig() *params.ChainConfig {
+	return &params.ChainConfig{AtomicRedelegationEpoch: big.NewInt(0)}
+}
+
+// redelegate from the delegation which has an undelegation entry
+func defaultMsgRedelegate() staking.Redelegate {
+	return staking.Redelegate{
+		DelegatorAddress:     delegatorAddr,
+		FromValidatorAddress: validatorAddr,
+		ToValidatorAddress:   validatorAddr2,
+		Amount:               fiveKOnes,
+	}
+}
+
+// makeStateForRedelegateChain makes the state where the delegator delegated
+// to the second validator and redelegated the amount to the first one, in
+// the block being processed
+func makeStateForRedelegateChain(t *testing.T, amount *big.Int) *state.DB {
+	sdb := makeDefaultStateForUndelegate(t)
+	w, err := sdb.ValidatorWrapper(validatorAddr2, false, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	delegation := staking.NewDelegation(delegatorAddr, new(big.Int).Set(amount))
+	if err := delegation.Redelegate(big.NewInt(defaultEpoch), validatorAddr, amount); err != nil {
+		t.Fatal(err)
+	}
+	w.Delegations = append(w.Delegations, delegation)
+	if err := sdb.UpdateValidatorWrapper(validatorAddr2, w); err != nil {
+		t.Fatal(err)
+	}
+	sdb.AddBlockDelegation(delegatorAddr, validatorAddr2)
+	return sdb
+}
+
+func defaultExpVWrappersRedelegate(t *testing.T) []staking.ValidatorWrapper {
+	from := makeDefaultSnapVWrapperForUndelegate(t)
+	from.Delegations[1].Amount = new(big.Int).Sub(from.Delegations[1].Amount, fiveKOnes)
+	from.Delegations[1].Redelegations = staking.Redelegations{
+		staking.Redelegation{
+			ValidatorAddress: validatorAddr2,
+			Amount:           fiveKOnes,
+			Epoch:            big.NewInt(defaultEpoch),
+		},
+	}
+
+	to := makeVWrapperByIndex(validator2Index)
+	to.Delegations = append(to.Delegations, staking.NewDelegation(delegatorAddr, fiveKOnes))
+	return []staking.ValidatorWrapper{from, to}
+}
+
 var (
 	reward00 = twentyKOnes
 	reward01 = tenKOnes
@@ -121,6 +121,9 @@ type (
 		address *common.Address
 		prev    *stk.ValidatorWrapper
 	}
+	blockDelegationChange struct {
+		delegator *common.Address
+	}
 
 	// Changes to other state values.
 	refundChange struct {
@@ -157,9 +160,27 @@ func (v validatorWrapperChange) dirtied() *common.Address {
 
 // revert undoes the changes introduced by this journal entry.
 func (v validatorWrapperChange) revert(s *DB) {
+	// a validator created in the reverted changes was not cached before
+	if v.prev == nil {
+		delete(s.stateValidators, *(v.address))
+		return
+	}
 	s.stateValidators[*(v.address)] = v.prev
 }
 
+func (ch blockDelegationChange) revert(s *DB) {
+	validators := s.blockDelegations[*ch.delegator]
+	if len(validators) == 1 {
+		delete(s.blockDelegations, *ch.delegator)
+	} else {
+		s.blockDelegations[*ch.delegator] = validators[:len(validators)-1]
+	}
+}
+
+func (ch blockDelegationChange) dirtied() *common.Address {
+	return nil
+}
+
 func (ch createObjectChange) revert(s *DB) {
 	delete(s.stateObjects, *ch.account)
 	delete(s.stateObjectsDirty, *ch.account)
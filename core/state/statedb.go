@@ -93,6 +93,11 @@ type DB struct {
 	stateObjectsDirty    map[common.Address]struct{} // State objects modified in the current execution
 	stateObjectsDestruct map[common.Address]struct{} // State objects destructed in the block
 	stateValidators      map[common.Address]*stk.ValidatorWrapper
+	// the validators delegated to in the block, by delegator
+	blockDelegations map[common.Address][]common.Address
+	// the number of chunks of the contract validator wrappers set from zero
+	// and of those changed otherwise, to charge their writes
+	chunksSet, chunksReset uint64
 
 	// DB error.
 	// State objects are used by the consensus core and VM which are
@@ -160,6 +165,7 @@ func New(root common.Hash, db Database, snaps *snapshot.Tree) (*DB, error) {
 		stateObjectsDirty:    make(map[common.Address]struct{}),
 		stateObjectsDestruct: make(map[common.Address]struct{}),
 		stateValidators:      make(map[common.Address]*stk.ValidatorWrapper),
+		blockDelegations:     make(map[common.Address][]common.Address),
 		logs:                 make(map[common.Hash][]*types2.Log),
 		preimages:            make(map[common.Hash][]byte),
 		journal:              newJournal(),
@@ -221,6 +227,7 @@ func (db *DB) Reset(root common.Hash) error {
 	db.stateObjectsPending = make(map[common.Address]struct{})
 	db.stateObjectsDirty = make(map[common.Address]struct{})
 	db.stateValidators = make(map[common.Address]*stk.ValidatorWrapper)
+	db.blockDelegations = make(map[common.Address][]common.Address)
 	db.thash = common.Hash{}
 	db.bhash = common.Hash{}
 	db.ethTxHash = common.Hash{}
@@ -760,6 +767,7 @@ func (db *DB) Copy() *DB {
 		stateObjectsDirty:    make(map[common.Address]struct{}, len(db.journal.dirties)),
 		stateObjectsDestruct: make(map[common.Address]struct{}, len(db.stateObjectsDestruct)),
 		stateValidators:      make(map[common.Address]*stk.ValidatorWrapper),
+		blockDelegations:     make(map[common.Address][]common.Address, len(db.blockDelegations)),
 		refund:               db.refund,
 		logs:                 make(map[common.Hash][]*types2.Log, len(db.logs)),
 		logSize:              db.logSize,
@@ -807,6 +815,9 @@ func (db *DB) Copy() *DB {
 		copied := staketest.CopyValidatorWrapper(*wrapper)
 		state.stateValidators[addr] = &copied
 	}
+	for delegator, validators := range db.blockDelegations {
+		state.blockDelegations[delegator] = append([]common.Address(nil), validators...)
+	}
 	for hash, logs := range db.logs {
 		cpy := make([]*types2.Log, len(logs))
 		for i, l := range logs {
@@ -895,7 +906,9 @@ func (db *DB) Finalise(deleteEmptyObjects bool) {
 	// TODO: remove validator cache after commit
 	for addr, wrapper := range db.stateValidators {
 		if err := db.UpdateValidatorWrapper(addr, wrapper); err != nil {
-			utils.Logger().Warn().Err(err).Msg("Unable to update the validator wrapper on the finalize")
+			// the changes can't be reverted anymore, the state must not be
+			// committed without them
+			db.setError(errors.Wrapf(err, "unable to update the validator wrapper %x on the finalize", addr))
 		}
 	}
 	addressesToPrefetch := make([][]byte, 0, len(db.journal.dirties))
@@ -1012,6 +1025,10 @@ func (db *DB) Prepare(thash, bhash common.Hash, ti int) {
 	db.thash = thash
 	db.bhash = bhash
 	db.txIndex = ti
+	// The transient storage is discarded at the end of each transaction (EIP-1153)
+	db.transientStorage = newTransientStorage()
+	// So is the access list (EIP-2929)
+	db.accessList = newAccessList()
 }
 
 // SetTxContext sets the current transaction hash and index which are
@@ -1169,6 +1186,32 @@ func (db *DB) Commit(deleteEmptyObjects bool) (common.Hash, error) {
 	return root, nil
 }
 
+// PrepareAccessList clears the access list and warms the addresses and slots
+// accessed by any transaction (EIP-2929), along with those of the optional
+// access list of the transaction (EIP-2930):
+// - the sender and the destination, if any
+// - the precompiles
+// - the addresses and storage slots of the access list
+//
+// This method should only be called if the EIP-2929 access costs apply.
+func (db *DB) PrepareAccessList(sender common.Address, dst *common.Address, precompiles []common.Address, list types2.AccessList) {
+	db.accessList = newAccessList()
+	db.AddAddressToAccessList(sender)
+	if dst != nil {
+		db.AddAddressToAccessList(*dst)
+		// If it's a create-tx, the destination will be added inside evm.create
+	}
+	for _, addr := range precompiles {
+		db.AddAddressToAccessList(addr)
+	}
+	for _, el := range list {
+		db.AddAddressToAccessList(el.Address)
+		for _, key := range el.StorageKeys {
+			db.AddSlotToAccessList(el.Address, key)
+		}
+	}
+}
+
 // AddAddressToAccessList adds the given address to the access list
 func (db *DB) AddAddressToAccessList(addr common.Address) {
 	if db.accessList.AddAddress(addr) {
@@ -1243,7 +1286,7 @@ func (db *DB) ValidatorWrapper(
 		return copyValidatorWrapperIfNeeded(cached, sendOriginal, copyDelegations), nil
 	}
 
-	by := db.GetCode(addr)
+	by := db.validatorWrapperCode(addr)
 	if len(by) == 0 {
 		return nil, ErrAddressNotPresent
 	}
@@ -1279,6 +1322,25 @@ func copyValidatorWrapperIfNeeded(
 	}
 }
 
+// BlockDelegations returns the validators the delegator delegated to since
+// the state was opened, in the order of the delegations. These delegations
+// are not in the delegation indexes of the previous block yet.
+func (db *DB) BlockDelegations(delegator common.Address) []common.Address {
+	return db.blockDelegations[delegator]
+}
+
+// AddBlockDelegation records that the delegator delegated to the validator
+// in the block being processed
+func (db *DB) AddBlockDelegation(delegator, validator common.Address) {
+	for _, addr := range db.blockDelegations[delegator] {
+		if addr == validator {
+			return
+		}
+	}
+	db.journal.append(blockDelegationChange{delegator: &delegator})
+	db.blockDelegations[delegator] = append(db.blockDelegations[delegator], validator)
+}
+
 // UpdateValidatorWrapper updates staking information of
 // a given validator (including delegation info)
 func (db *DB) UpdateValidatorWrapper(
@@ -1291,8 +1353,10 @@ func (db *DB) UpdateValidatorWrapper(
 	if err != nil {
 		return err
 	}
-	// has revert in-built for the code field
-	db.SetCode(addr, by, true)
+	// has revert in-built for the code field and the storage
+	if err := db.setValidatorWrapperCode(addr, by); err != nil {
+		return err
+	}
 	// update cache
 	db.stateValidators[addr] = val
 	return nil
@@ -1323,6 +1387,76 @@ func (db *DB) UpdateValidatorWrapperWithRevert(
 	return nil
 }
 
+// validatorWrapperCode returns the encoded wrapper of the validator, which is
+// the code of its account unless the validator is a contract
+func (db *DB) validatorWrapperCode(addr common.Address) []byte {
+	if !db.IsContractValidator(addr) {
+		return db.GetCode(addr)
+	}
+	// the size is capped, a contract could write any value to its storage
+	// before the staking keys were reserved
+	size := db.GetState(addr, staking.ContractValidatorWrapperKey).Big()
+	if !size.IsUint64() || size.Uint64() > staking.MaxContractValidatorWrapperSize {
+		return nil
+	}
+	by := make([]byte, 0, size.Uint64()+common.HashLength)
+	for i := uint64(0); uint64(len(by)) < size.Uint64(); i++ {
+		by = append(by, db.GetState(addr, staking.ContractValidatorWrapperChunkKey(i)).Bytes()...)
+	}
+	return by[:size.Uint64()]
+}
+
+// setValidatorWrapperCode stores the encoded wrapper of the validator in place
+// of the code of its account, or in its storage if the validator is a contract
+// since the code of a contract is needed to run it
+func (db *DB) setValidatorWrapperCode(addr common.Address, by []byte) error {
+	if !db.IsContractValidator(addr) {
+		db.SetCode(addr, by, true)
+		return nil
+	}
+	if len(by) > staking.MaxContractValidatorWrapperSize {
+		return errors.Errorf("validator wrapper of contract %x too large: %v", addr, len(by))
+	}
+	prevSize := db.GetState(addr, staking.ContractValidatorWrapperKey).Big()
+	if !prevSize.IsUint64() || prevSize.Uint64() > staking.MaxContractValidatorWrapperSize {
+		prevSize.SetUint64(staking.MaxContractValidatorWrapperSize)
+	}
+	db.SetState(addr, staking.ContractValidatorWrapperKey, common.BigToHash(big.NewInt(int64(len(by)))))
+	chunks := (uint64(len(by)) + common.HashLength - 1) / common.HashLength
+	for i := uint64(0); i < chunks; i++ {
+		var chunk common.Hash
+		copy(chunk[:], by[i*common.HashLength:])
+		db.setValidatorWrapperChunk(addr, i, chunk)
+	}
+	// clear the chunks left by a larger wrapper
+	for i := chunks; i*common.HashLength < prevSize.Uint64(); i++ {
+		db.setValidatorWrapperChunk(addr, i, common.Hash{})
+	}
+	return nil
+}
+
+// setValidatorWrapperChunk stores the i-th chunk of the wrapper of a contract
+// validator, and counts the write if the chunk changes
+func (db *DB) setValidatorWrapperChunk(addr common.Address, i uint64, chunk common.Hash) {
+	key := staking.ContractValidatorWrapperChunkKey(i)
+	switch prev := db.GetState(addr, key); {
+	case prev == chunk:
+		return
+	case prev == (common.Hash{}):
+		db.chunksSet++
+	default:
+		db.chunksReset++
+	}
+	db.SetState(addr, key, chunk)
+}
+
+// ContractValidatorChunkWrites returns the number of chunks of the contract
+// validator wrappers set from zero and of those changed otherwise, since the
+// state was opened. The writes are charged by the difference of the counts.
+func (db *DB) ContractValidatorChunkWrites() (set uint64, reset uint64) {
+	return db.chunksSet, db.chunksReset
+}
+
 // SetValidatorFirstElectionEpoch sets the epoch when the validator is first elected
 func (db *DB) SetValidatorFirstElectionEpoch(addr common.Address, epoch *big.Int) {
 	firstEpoch := db.GetValidatorFirstElectionEpoch(addr)
@@ -1359,6 +1493,18 @@ func (db *DB) IsValidator(addr common.Address) bool {
 	return so.IsValidator(db.db)
 }
 
+// SetContractValidatorFlag marks the validator as a contract, its wrapper is then
+// kept in its storage. It must be set before the wrapper is stored.
+func (db *DB) SetContractValidatorFlag(addr common.Address) {
+	db.SetState(addr, staking.IsContractValidatorKey, staking.IsValidator)
+}
+
+// IsContractValidator checks whether it is a validator object with the code of
+// a contract
+func (db *DB) IsContractValidator(addr common.Address) bool {
+	return db.GetState(addr, staking.IsContractValidatorKey) == staking.IsValidator
+}
+
 var (
 	zero = numeric.ZeroDec()
 )
@@ -1389,13 +1535,12 @@ func (db *DB) AddReward(
 
 	rewardPool := big.NewInt(0).Set(reward)
 	curValidator.BlockReward.Add(curValidator.BlockReward, reward)
+	// the compounded rewards can't take the validator over its max total delegation
+	room := new(big.Int).Sub(curValidator.MaxTotalDelegation, curValidator.TotalDelegation())
 	// Payout commission
 	if r := snapshot.Validator.CommissionRates.Rate; r.GT(zero) {
 		commissionInt := r.MulInt(reward).RoundInt()
-		curValidator.Delegations[0].Reward.Add(
-			curValidator.Delegations[0].Reward,
-			commissionInt,
-		)
+		addDelegationReward(&curValidator.Delegations[0], commissionInt, room)
 		rewardPool.Sub(rewardPool, commissionInt)
 	}
 
@@ -1410,16 +1555,31 @@ func (db *DB) AddReward(
 		}
 
 		rewardInt := percentage.MulInt(totalRewardForDelegators).RoundInt()
-		curDelegation := curValidator.Delegations[i]
-		curDelegation.Reward.Add(curDelegation.Reward, rewardInt)
+		addDelegationReward(&curValidator.Delegations[i], rewardInt, room)
 		rewardPool.Sub(rewardPool, rewardInt)
 	}
 
 	// The last remaining bit belongs to the validator (remember the validator's self delegation is
 	// always at index 0)
 	if rewardPool.Cmp(common.Big0) > 0 {
-		curValidator.Delegations[0].Reward.Add(curValidator.Delegations[0].Reward, rewardPool)
+		addDelegationReward(&curValidator.Delegations[0], rewardPool, room)
 	}
 
 	return nil
 }
+
+// addDelegationReward adds the reward to the delegation. If the delegation
+// compounds its rewards, the reward is added to its amount, as far as the
+// room left under the max total delegation allows, the rest is collectable.
+func addDelegationReward(delegation *stk.Delegation, reward, room *big.Int) {
+	compounded := big.NewInt(0)
+	if delegation.AutoCompound && room.Sign() > 0 {
+		compounded.Set(reward)
+		if compounded.Cmp(room) > 0 {
+			compounded.Set(room)
+		}
+		delegation.Amount.Add(delegation.Amount, compounded)
+		room.Sub(room, compounded)
+	}
+	delegation.Reward.Add(delegation.Reward, new(big.Int).Sub(reward, compounded))
+}
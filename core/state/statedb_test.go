@@ -32,6 +32,11 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/harmony-one/harmony/core/rawdb"
 	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/crypto/bls"
+	"github.com/harmony-one/harmony/numeric"
+	"github.com/harmony-one/harmony/staking"
+	stk "github.com/harmony-one/harmony/staking/types"
+	staketest "github.com/harmony-one/harmony/staking/types/test"
 )
 
 // Tests that updating a state trie does not leak any database writes prior to
@@ -998,3 +1003,191 @@ func TestStateDBTransientStorage(t *testing.T) {
 		t.Fatalf("transient storage mismatch: have %x, want %x", got, value)
 	}
 }
+
+func TestAddRewardAutoCompound(t *testing.T) {
+	var (
+		validatorAddr = common.HexToAddress("0x1000")
+		compoundAddr  = common.HexToAddress("0x1001")
+		collectAddr   = common.HexToAddress("0x1002")
+		tenKOnes      = new(big.Int).Mul(big.NewInt(10000), big.NewInt(1e18))
+		twentyKOnes   = new(big.Int).Mul(big.NewInt(20000), big.NewInt(1e18))
+		fortyKOnes    = new(big.Int).Mul(big.NewInt(40000), big.NewInt(1e18))
+	)
+	tests := []struct {
+		maxTotalDelegation *big.Int
+		expCompounded      *big.Int
+	}{
+		// all the reward of the delegation is compounded
+		{staketest.DefaultMaxTotalDel, big.NewInt(150)},
+		// only the room left under the max total delegation is compounded
+		{new(big.Int).Add(fortyKOnes, big.NewInt(100)), big.NewInt(100)},
+		// no room left, the reward is collectable
+		{fortyKOnes, big.NewInt(0)},
+	}
+	for i, test := range tests {
+		db, _ := New(common.Hash{}, NewDatabase(rawdb.NewMemoryDatabase()), nil)
+		wrapper := staketest.GetDefaultValidatorWrapperWithAddr(validatorAddr, []bls.SerializedPublicKey{{}})
+		wrapper.MaxTotalDelegation = test.maxTotalDelegation
+		wrapper.Delegations[0].Amount = new(big.Int).Set(twentyKOnes)
+		compounding := stk.NewDelegation(compoundAddr, new(big.Int).Set(tenKOnes))
+		compounding.SetAutoCompound(true)
+		wrapper.Delegations = append(wrapper.Delegations,
+			compounding, stk.NewDelegation(collectAddr, new(big.Int).Set(tenKOnes)),
+		)
+		if err := db.UpdateValidatorWrapper(validatorAddr, &wrapper); err != nil {
+			t.Fatal(err)
+		}
+		shares := map[common.Address]numeric.Dec{
+			validatorAddr: numeric.NewDecWithPrec(5, 1),
+			compoundAddr:  numeric.NewDecWithPrec(25, 2),
+			collectAddr:   numeric.NewDecWithPrec(25, 2),
+		}
+		// 400 of commission, then 300, 150 and 150 for the delegations
+		if err := db.AddReward(&wrapper, big.NewInt(1000), shares); err != nil {
+			t.Fatalf("Test %v: %v", i, err)
+		}
+
+		got, err := db.ValidatorWrapper(validatorAddr, true, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expAmount := new(big.Int).Add(tenKOnes, test.expCompounded)
+		if got.Delegations[1].Amount.Cmp(expAmount) != 0 {
+			t.Errorf("Test %v: unexpected amount %v / %v", i, got.Delegations[1].Amount, expAmount)
+		}
+		expReward := new(big.Int).Sub(big.NewInt(150), test.expCompounded)
+		if got.Delegations[1].Reward.Cmp(expReward) != 0 {
+			t.Errorf("Test %v: unexpected reward %v / %v", i, got.Delegations[1].Reward, expReward)
+		}
+		if got.Delegations[2].Reward.Cmp(big.NewInt(150)) != 0 {
+			t.Errorf("Test %v: unexpected reward without compounding %v", i, got.Delegations[2].Reward)
+		}
+		if got.Delegations[0].Reward.Cmp(big.NewInt(700)) != 0 {
+			t.Errorf("Test %v: unexpected validator reward %v", i, got.Delegations[0].Reward)
+		}
+		if err := got.SanityCheck(); err != nil {
+			t.Errorf("Test %v: %v", i, err)
+		}
+	}
+}
+
+func TestContractValidatorWrapper(t *testing.T) {
+	var (
+		validatorAddr = common.HexToAddress("0x1000")
+		code          = []byte{0x60, 0x00, 0x60, 0x00, 0xfd}
+		sdb           = NewDatabase(rawdb.NewMemoryDatabase())
+	)
+	db, _ := New(common.Hash{}, sdb, nil)
+	db.SetContractValidatorFlag(validatorAddr)
+	if _, err := db.ValidatorWrapper(validatorAddr, true, false); err != ErrAddressNotPresent {
+		t.Fatalf("unexpected error before the validator is stored: %v", err)
+	}
+	wrapper := staketest.GetDefaultValidatorWrapperWithAddr(validatorAddr, []bls.SerializedPublicKey{{}})
+	wrapper.Description.Details = strings.Repeat("details", 20)
+	if err := db.UpdateValidatorWrapper(validatorAddr, &wrapper); err != nil {
+		t.Fatal(err)
+	}
+	// the contract is deployed once its validator is stored
+	db.SetCode(validatorAddr, code, false)
+	db.SetValidatorFlag(validatorAddr)
+	root, err := db.Commit(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a smaller wrapper leaves no chunk of the previous one
+	db, _ = New(root, sdb, nil)
+	large := db.GetState(validatorAddr, staking.ContractValidatorWrapperKey).Big().Uint64()
+	smaller := staketest.CopyValidatorWrapper(wrapper)
+	smaller.Description.Details = ""
+	if err := db.UpdateValidatorWrapper(validatorAddr, &smaller); err != nil {
+		t.Fatal(err)
+	}
+	if root, err = db.Commit(true); err != nil {
+		t.Fatal(err)
+	}
+	db, _ = New(root, sdb, nil)
+	if !db.IsValidator(validatorAddr) || !db.IsContractValidator(validatorAddr) {
+		t.Fatal("expected a contract validator")
+	}
+	if got := db.GetCode(validatorAddr); !bytes.Equal(got, code) {
+		t.Errorf("unexpected code %x / %x", got, code)
+	}
+	got, err := db.ValidatorWrapper(validatorAddr, true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := staketest.CheckValidatorWrapperEqual(*got, smaller); err != nil {
+		t.Error(err)
+	}
+	size := db.GetState(validatorAddr, staking.ContractValidatorWrapperKey).Big().Uint64()
+	if size >= large {
+		t.Fatalf("unexpected size %v, previous %v", size, large)
+	}
+	for i := (size + 31) / 32; i*32 < large; i++ {
+		if chunk := db.GetState(validatorAddr, staking.ContractValidatorWrapperChunkKey(i)); chunk != (common.Hash{}) {
+			t.Errorf("chunk %v of the previous wrapper left: %x", i, chunk)
+		}
+	}
+}
+
+func TestBlockDelegations(t *testing.T) {
+	var (
+		delegator  = common.HexToAddress("0x2000")
+		validator1 = common.HexToAddress("0x1001")
+		validator2 = common.HexToAddress("0x1002")
+	)
+	db, _ := New(common.Hash{}, NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	db.AddBlockDelegation(delegator, validator1)
+
+	id := db.Snapshot()
+	db.AddBlockDelegation(delegator, validator2)
+	db.AddBlockDelegation(delegator, validator1)
+	if got := db.BlockDelegations(delegator); !reflect.DeepEqual(got, []common.Address{validator1, validator2}) {
+		t.Fatalf("unexpected block delegations %v", got)
+	}
+	cpy := db.Copy()
+	db.RevertToSnapshot(id)
+	if got := db.BlockDelegations(delegator); !reflect.DeepEqual(got, []common.Address{validator1}) {
+		t.Fatalf("unexpected block delegations after revert %v", got)
+	}
+	if got := cpy.BlockDelegations(delegator); !reflect.DeepEqual(got, []common.Address{validator1, validator2}) {
+		t.Fatalf("unexpected block delegations of the copy %v", got)
+	}
+	if err := db.Reset(common.Hash{}); err != nil {
+		t.Fatal(err)
+	}
+	if got := db.BlockDelegations(delegator); len(got) != 0 {
+		t.Fatalf("unexpected block delegations after reset %v", got)
+	}
+}
+
+func TestContractValidatorWrapperTooLarge(t *testing.T) {
+	var (
+		validatorAddr = common.HexToAddress("0x1000")
+		delegator     = common.HexToAddress("0x2000")
+	)
+	db, _ := New(common.Hash{}, NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	db.SetContractValidatorFlag(validatorAddr)
+	wrapper := staketest.GetDefaultValidatorWrapperWithAddr(validatorAddr, []bls.SerializedPublicKey{{}})
+	if err := db.UpdateValidatorWrapper(validatorAddr, &wrapper); err != nil {
+		t.Fatal(err)
+	}
+	db.SetValidatorFlag(validatorAddr)
+
+	// the cached wrapper is changed in place over the size cap
+	cached, err := db.ValidatorWrapper(validatorAddr, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < staking.MaxContractValidatorWrapperSize/20; i++ {
+		cached.Delegations = append(cached.Delegations, stk.NewDelegation(delegator, new(big.Int)))
+	}
+	db.Finalise(true)
+	if db.Error() == nil {
+		t.Fatal("expected an error of the finalize")
+	}
+	if _, err := db.Commit(true); err == nil {
+		t.Fatal("expected the commit to fail")
+	}
+}
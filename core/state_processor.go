@@ -229,6 +229,10 @@ func (p *StateProcessor) Process(
 	if err != nil {
 		return nil, nil, nil, nil, 0, nil, statedb, errors.WithMessage(err, "[Process] Cannot finalize block")
 	}
+	// a state write failed, e.g. a validator wrapper over its size cap
+	if err := statedb.Error(); err != nil {
+		return nil, nil, nil, nil, 0, nil, statedb, errors.WithMessage(err, "[Process] Cannot finalize block")
+	}
 
 	result := &ProcessorResult{
 		Receipts:   receipts,
@@ -285,6 +289,11 @@ func ApplyTransaction(bc ChainContext, author *common.Address, gp *GasPool, stat
 		)
 	}
 
+	// The typed transactions are ethereum-compatible only, enabled with EIP-1559
+	if tx.Type() != types.LegacyTxType && (!tx.IsEthCompatible() || !config.IsEIP1559(header.Epoch())) {
+		return nil, nil, nil, 0, types.ErrTxTypeNotSupported
+	}
+
 	var signer types.Signer
 	if tx.IsEthCompatible() {
 		if !config.IsEthCompatible(header.Epoch()) {
@@ -325,6 +334,7 @@ func ApplyTransaction(bc ChainContext, author *common.Address, gp *GasPool, stat
 	// Create a new receipt for the transaction, storing the intermediate root and gas used by the tx
 	// based on the eip phase, we're passing whether the root touch-delete accounts.
 	receipt := types.NewReceipt(root, failedExe, *usedGas)
+	receipt.Type = tx.Type()
 	receipt.TxHash = tx.Hash()
 	receipt.GasUsed = result.UsedGas
 	// if the transaction created a contract, store the creation address in the receipt.
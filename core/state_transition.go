@@ -24,6 +24,7 @@ import (
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/harmony-one/harmony/core/types"
 	"github.com/harmony-one/harmony/core/vm"
+	"github.com/harmony-one/harmony/internal/params"
 	"github.com/harmony-one/harmony/internal/utils"
 	"github.com/harmony-one/harmony/numeric"
 	"github.com/harmony-one/harmony/shard"
@@ -45,6 +46,13 @@ var (
 	errNegativeAmount              = errors.New("amount can not be negative")
 	errDupIdentity                 = errors.New("validator identity exists")
 	errDupBlsKey                   = errors.New("BLS key exists")
+	errRedelegateNotSupported      = errors.New("redelegate is not supported before the atomic redelegation epoch")
+	errNoDelegationToRedelegate    = errors.New("no delegation to redelegate")
+	errRedelegateToSameValidator   = errors.New("can not redelegate to the same validator")
+	errRedelegateToBannedValidator = errors.New("can not redelegate to a banned validator")
+	errRedelegationInCooldown      = errors.New("redelegated tokens can not be redelegated again before the cooldown")
+	errEditDelegationNotSupported  = errors.New("edit delegation is not supported before the auto compound epoch")
+	errNoDelegationToEdit          = errors.New("no delegation to edit")
 )
 
 /*
@@ -85,6 +93,8 @@ type Message interface {
 	To() *common.Address
 
 	GasPrice() *big.Int
+	GasFeeCap() *big.Int
+	GasTipCap() *big.Int
 	Gas() uint64
 	Value() *big.Int
 
@@ -93,6 +103,7 @@ type Message interface {
 	Data() []byte
 	Type() types.TransactionType
 	BlockNum() *big.Int
+	AccessList() types.AccessList
 }
 
 // ExecutionResult is the return value from a transaction committed to the DB
@@ -135,13 +146,34 @@ func NewStateTransition(evm *vm.EVM, msg Message, gp *GasPool) *StateTransition
 		gp:       gp,
 		evm:      evm,
 		msg:      msg,
-		gasPrice: msg.GasPrice(),
+		gasPrice: effectiveGasPrice(msg, evm.Context.BaseFee),
 		value:    msg.Value(),
 		data:     msg.Data(),
 		state:    evm.StateDB,
 	}
 }
 
+// effectiveGasPrice returns the gas price paid by the message: the base fee
+// plus the priority fee, capped by the fee cap. It is the gas price of the
+// message before EIP-1559.
+func effectiveGasPrice(msg Message, baseFee *big.Int) *big.Int {
+	if baseFee == nil || msg.GasFeeCap() == nil || msg.GasTipCap() == nil {
+		return new(big.Int).Set(msg.GasPrice())
+	}
+	price := new(big.Int).Add(msg.GasTipCap(), baseFee)
+	if price.Cmp(msg.GasFeeCap()) > 0 {
+		price.Set(msg.GasFeeCap())
+	}
+	return price
+}
+
+// accessListGas returns the gas paid upfront for the access list of the
+// message (EIP-2930)
+func accessListGas(accessList types.AccessList) uint64 {
+	return uint64(len(accessList))*params.TxAccessListAddressGas +
+		uint64(accessList.StorageKeys())*params.TxAccessListStorageKeyGas
+}
+
 // ApplyMessage computes the new state by applying the given message
 // against the old state within the environment.
 //
@@ -204,6 +236,23 @@ func (st *StateTransition) preCheck() error {
 			return ErrNonceTooLow
 		}
 	}
+	// Make sure that the fee caps cover the base fee. The calls with no gas
+	// price may skip the check.
+	if baseFee := st.evm.Context.BaseFee; baseFee != nil && st.msg.GasFeeCap() != nil && st.msg.GasTipCap() != nil {
+		feeCap, tip := st.msg.GasFeeCap(), st.msg.GasTipCap()
+		if !st.evm.NoBaseFee() || feeCap.Sign() > 0 || tip.Sign() > 0 {
+			if feeCap.Cmp(tip) < 0 {
+				return errors.Wrapf(
+					ErrTipAboveFeeCap, "maxPriorityFeePerGas: %s, maxFeePerGas: %s", tip, feeCap,
+				)
+			}
+			if feeCap.Cmp(baseFee) < 0 {
+				return errors.Wrapf(
+					ErrFeeCapTooLow, "maxFeePerGas: %s, baseFee: %s", feeCap, baseFee,
+				)
+			}
+		}
+	}
 	return st.buyGas()
 }
 
@@ -211,6 +260,7 @@ func (st *StateTransition) preCheck() error {
 // returning the result including the used gas. It returns an error if failed.
 // An error indicates a consensus issue.
 func (st *StateTransition) TransitionDb() (ExecutionResult, error) {
+	st.evm.CaptureTxStart(st.msg.Gas())
 	if err := st.preCheck(); err != nil {
 		return ExecutionResult{}, err
 	}
@@ -221,15 +271,25 @@ func (st *StateTransition) TransitionDb() (ExecutionResult, error) {
 	contractCreation := msg.To() == nil
 
 	// Pay intrinsic gas
-	gas, err := vm.IntrinsicGas(st.data, contractCreation, homestead, istanbul, false)
+	shanghai := st.evm.ChainConfig().IsShanghai(st.evm.EpochNumber)
+	// The init code of the contract creations is limited (EIP-3860)
+	if contractCreation && shanghai && len(st.data) > params.MaxInitCodeSize {
+		return ExecutionResult{}, fmt.Errorf("%w: code size %v limit %v", ErrMaxInitCodeSizeExceeded, len(st.data), params.MaxInitCodeSize)
+	}
+	gas, err := vm.IntrinsicGas(st.data, contractCreation, homestead, istanbul, false, shanghai)
 	if err != nil {
 		return ExecutionResult{}, err
 	}
+	gas += accessListGas(msg.AccessList())
 	if err = st.useGas(gas); err != nil {
 		return ExecutionResult{}, fmt.Errorf("%w: have %d, want %d", ErrIntrinsicGas, st.gas, gas)
 	}
 
 	evm := st.evm
+	// Warm the accesses of the transaction, and those of its access list
+	if rules := evm.ChainConfig().Rules(evm.EpochNumber); rules.IsEIP2929 {
+		st.state.PrepareAccessList(msg.From(), msg.To(), vm.ActivePrecompiles(rules), msg.AccessList())
+	}
 
 	var ret []byte
 	// All VM errors are valid except for insufficient balance, therefore returned separately
@@ -287,7 +347,20 @@ func (st *StateTransition) collectGas() {
 			st.gasPrice,
 		)
 		st.state.AddBalance(st.evm.Coinbase, txFee)
-	} else if feeCollectors := shard.Schedule.InstanceForEpoch(
+		return
+	}
+	gasPrice := st.gasPrice
+	if baseFee := st.evm.Context.BaseFee; baseFee != nil && gasPrice.Cmp(baseFee) > 0 {
+		// After EIP-1559, the priority fee goes to the block producer, and
+		// only the base fee is burned or collected
+		tip := new(big.Int).Mul(
+			new(big.Int).SetUint64(st.gasUsed()),
+			new(big.Int).Sub(gasPrice, baseFee),
+		)
+		st.state.AddBalance(st.evm.Coinbase, tip)
+		gasPrice = baseFee
+	}
+	if feeCollectors := shard.Schedule.InstanceForEpoch(
 		st.evm.EpochNumber,
 	).FeeCollectors(); len(feeCollectors) > 0 {
 		// The caller must ensure that the feeCollectors are accurately set
@@ -295,7 +368,7 @@ func (st *StateTransition) collectGas() {
 		txFee := numeric.NewDecFromBigInt(
 			new(big.Int).Mul(
 				new(big.Int).SetUint64(st.gasUsed()),
-				st.gasPrice,
+				gasPrice,
 			),
 		)
 		for address, percent := range feeCollectors {
@@ -324,7 +397,7 @@ func (st *StateTransition) StakingTransitionDb() (usedGas uint64, err error) {
 	istanbul := st.evm.ChainConfig().IsIstanbul(st.evm.EpochNumber)
 
 	// Pay intrinsic gas
-	gas, err := vm.IntrinsicGas(st.data, false, homestead, istanbul, msg.Type() == types.StakeCreateVal)
+	gas, err := vm.IntrinsicGas(st.data, false, homestead, istanbul, msg.Type() == types.StakeCreateVal, false)
 
 	if err != nil {
 		return 0, err
@@ -383,6 +456,26 @@ func (st *StateTransition) StakingTransitionDb() (usedGas uint64, err error) {
 			return 0, errInvalidSigner
 		}
 		err = st.evm.Undelegate(st.evm.StateDB, nil, stkMsg)
+	case types.Redelegate:
+		stkMsg := &stakingTypes.Redelegate{}
+		if err = rlp.DecodeBytes(msg.Data(), stkMsg); err != nil {
+			return 0, err
+		}
+		utils.Logger().Info().Msgf("[DEBUG STAKING] staking type: %s, gas: %d, txn: %+v", msg.Type(), gas, stkMsg)
+		if msg.From() != stkMsg.DelegatorAddress {
+			return 0, errInvalidSigner
+		}
+		err = st.evm.Redelegate(st.evm.StateDB, nil, stkMsg)
+	case types.EditDelegation:
+		stkMsg := &stakingTypes.EditDelegation{}
+		if err = rlp.DecodeBytes(msg.Data(), stkMsg); err != nil {
+			return 0, err
+		}
+		utils.Logger().Info().Msgf("[DEBUG STAKING] staking type: %s, gas: %d, txn: %+v", msg.Type(), gas, stkMsg)
+		if msg.From() != stkMsg.DelegatorAddress {
+			return 0, errInvalidSigner
+		}
+		err = st.evm.EditDelegation(st.evm.StateDB, nil, stkMsg)
 	case types.CollectRewards:
 		stkMsg := &stakingTypes.CollectRewards{}
 		if err = rlp.DecodeBytes(msg.Data(), stkMsg); err != nil {
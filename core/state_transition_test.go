@@ -111,6 +111,8 @@ func TestCollectGas(t *testing.T) {
 	from, _ := tx.SenderAddress()
 	initialBalance := big.NewInt(2e18)
 	db.AddBalance(from, initialBalance)
+	// the whole fee is the base fee, with no tip to the block producer
+	header.SetBaseFee(tx.GasPrice())
 	msg, _ := tx.AsMessage(types.NewEIP155Signer(common.Big2))
 	ctx := NewEVMContext(msg, header, chain, nil /* coinbase is nil, no block reward */)
 	ctx.TxType = types.SameShardTx
@@ -152,6 +154,86 @@ func TestCollectGas(t *testing.T) {
 	}
 }
 
+func TestCollectGasTip(t *testing.T) {
+	tests := []struct {
+		name  string
+		epoch *big.Int
+	}{
+		// the base fee is shared by the fee collectors
+		{"collect", params.LocalnetChainConfig.FeeCollectEpoch},
+		// no fee collectors, the base fee is burned
+		{"burn", common.Big0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			key, _ := crypto.GenerateKey()
+			chain, db, header, _ := getTestEnvironment(*key)
+			header.SetEpoch(new(big.Int).Set(test.epoch))
+
+			shard.Schedule = shardingconfig.LocalnetSchedule
+			feeCollectors := shard.Schedule.InstanceForEpoch(header.Epoch()).FeeCollectors()
+
+			tx := types.NewTransaction(
+				0, // nonce
+				common.BytesToAddress([]byte("to")),
+				0,                 // shardid
+				big.NewInt(1e18),  // amount, 1 ONE
+				50000,             // gasLimit
+				big.NewInt(150e9), // gasPrice, 50 gwei above the base fee
+				[]byte{},          // payload, intentionally empty
+			)
+			from, _ := tx.SenderAddress()
+			initialBalance := big.NewInt(2e18)
+			db.AddBalance(from, initialBalance)
+			baseFee := big.NewInt(100e9)
+			header.SetBaseFee(baseFee)
+			coinbase := common.BytesToAddress([]byte("coinbase"))
+			msg, _ := tx.AsMessage(types.NewEIP155Signer(common.Big2))
+			ctx := NewEVMContext(msg, header, chain, &coinbase)
+			ctx.TxType = types.SameShardTx
+
+			vmenv := vm.NewEVM(ctx, db, params.TestChainConfig, vm.Config{})
+			gasPool := new(GasPool).AddGas(math.MaxUint64)
+			if _, err := ApplyMessage(vmenv, msg, gasPool); err != nil {
+				t.Fatal(err)
+			}
+
+			// the sender pays the whole gas price
+			fee := new(big.Int).Mul(tx.GasPrice(), big.NewInt(21000))
+			expectedBalance := new(big.Int).Sub(initialBalance, new(big.Int).Add(fee, tx.Value()))
+			if balance := db.GetBalance(from); balance.Cmp(expectedBalance) != 0 {
+				t.Errorf("Balance mismatch for sender: got %v, expected %v", balance, expectedBalance)
+			}
+
+			// the block producer gets the tip
+			tip := new(big.Int).Mul(new(big.Int).Sub(tx.GasPrice(), baseFee), big.NewInt(21000))
+			if balance := db.GetBalance(coinbase); balance.Cmp(tip) != 0 {
+				t.Errorf("Balance mismatch for coinbase: got %v, expected %v", balance, tip)
+			}
+
+			// the fee collectors share the base fee, if any
+			collected := big.NewInt(0)
+			for collector, percent := range feeCollectors {
+				expected := percent.MulInt(new(big.Int).Mul(baseFee, big.NewInt(21000))).TruncateInt()
+				balance := db.GetBalance(collector)
+				if balance.Cmp(expected) != 0 {
+					t.Errorf("Balance mismatch for collector %v: got %v, expected %v",
+						collector, balance, expected)
+				}
+				collected.Add(collected, balance)
+			}
+			burned := new(big.Int).Sub(fee, new(big.Int).Add(tip, collected))
+			if len(feeCollectors) > 0 && burned.Sign() != 0 {
+				t.Errorf("Base fee burned with the fee collectors: %v", burned)
+			}
+			if expected := new(big.Int).Mul(baseFee, big.NewInt(21000)); len(feeCollectors) == 0 &&
+				burned.Cmp(expected) != 0 {
+				t.Errorf("Burned mismatch: got %v, expected %v", burned, expected)
+			}
+		})
+	}
+}
+
 func TestCollectGasRounding(t *testing.T) {
 	// We want to test that the fee collectors get the correct amount of fees
 	// even if the total fee is not a multiple of the fee ratio.
@@ -187,6 +269,8 @@ func TestCollectGasRounding(t *testing.T) {
 	from, _ := tx.SenderAddress()
 	initialBalance := big.NewInt(2e18)
 	db.AddBalance(from, initialBalance)
+	// the whole fee is the base fee, with no tip to the block producer
+	header.SetBaseFee(tx.GasPrice())
 	msg, _ := tx.AsMessage(types.NewEIP155Signer(common.Big2))
 	ctx := NewEVMContext(msg, header, chain, nil /* coinbase is nil, no block reward */)
 	ctx.TxType = types.SameShardTx
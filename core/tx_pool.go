@@ -305,6 +305,8 @@ type TxPool struct {
 
 	homestead bool
 	istanbul  bool
+	eip1559   bool
+	shanghai  bool
 }
 
 // NewTxPool creates a new transaction pool to gather, sort and filter inbound
@@ -391,6 +393,12 @@ func (pool *TxPool) loop() {
 				if pool.chainconfig.IsIstanbul(ev.Block.Epoch()) {
 					pool.istanbul = true
 				}
+				if pool.chainconfig.IsEIP1559(ev.Block.Epoch()) {
+					pool.eip1559 = true
+				}
+				if pool.chainconfig.IsShanghai(ev.Block.Epoch()) {
+					pool.shanghai = true
+				}
 				pool.reset(head.Header(), ev.Block.Header())
 				head = ev.Block
 				pool.mu.Unlock()
@@ -672,6 +680,23 @@ func (pool *TxPool) Content() (map[common.Address]types.PoolTransactions, map[co
 	return pending, queued
 }
 
+// ContentFrom retrieves the data content of the transaction pool, returning the
+// pending as well as queued transactions of this address, sorted by nonce.
+func (pool *TxPool) ContentFrom(addr common.Address) (types.PoolTransactions, types.PoolTransactions) {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	var pending types.PoolTransactions
+	if list, ok := pool.pending[addr]; ok {
+		pending = list.Flatten()
+	}
+	var queued types.PoolTransactions
+	if list, ok := pool.queue[addr]; ok {
+		queued = list.Flatten()
+	}
+	return pending, queued
+}
+
 // Pending retrieves all currently executable transactions, grouped by origin
 // account and sorted by nonce. The returned transaction set is a copy and can be
 // freely modified by calling code.
@@ -734,11 +759,27 @@ func (pool *TxPool) validateTx(tx types.PoolTransaction, local bool) error {
 	if tx.Size() >= types.MaxPoolTransactionDataSize {
 		return errors.WithMessagef(ErrOversizedData, "transaction size is %s", tx.Size().String())
 	}
+	// The init code of the contract creations is limited (EIP-3860)
+	if _, ok := tx.(*types.Transaction); ok && pool.shanghai && tx.To() == nil && len(tx.Data()) > params.MaxInitCodeSize {
+		return errors.WithMessagef(ErrMaxInitCodeSizeExceeded, "init code size is %d", len(tx.Data()))
+	}
 	// Transactions can't be negative. This may never happen using RLP decoded
 	// transactions but may occur if you create a transaction using the RPC.
 	if tx.Value().Sign() < 0 {
 		return errors.WithMessagef(ErrNegativeValue, "transaction value is %s", tx.Value().String())
 	}
+	// The typed transactions are ethereum-compatible only, after EIP-1559
+	if plainTx, ok := tx.(*types.Transaction); ok && plainTx.Type() != types.LegacyTxType {
+		if !pool.eip1559 || !plainTx.IsEthCompatible() {
+			return errors.WithMessagef(types.ErrTxTypeNotSupported, "transaction type is %d", plainTx.Type())
+		}
+		if plainTx.GasFeeCap().Cmp(plainTx.GasTipCap()) < 0 {
+			return errors.WithMessagef(
+				ErrTipAboveFeeCap, "maxPriorityFeePerGas: %s, maxFeePerGas: %s",
+				plainTx.GasTipCap().String(), plainTx.GasFeeCap().String(),
+			)
+		}
+	}
 	// Ensure the transaction doesn't exceed the current block limit gas.
 	if pool.currentMaxGas < tx.GasLimit() {
 		return errors.WithMessagef(ErrGasLimit, "transaction gas is %d", tx.GasLimit())
@@ -812,13 +853,16 @@ func (pool *TxPool) validateTx(tx types.PoolTransaction, local bool) error {
 	}
 	intrGas := uint64(0)
 	if isStakingTx {
-		intrGas, err = vm.IntrinsicGas(tx.Data(), false, pool.homestead, pool.istanbul, stakingTx.StakingType() == staking.DirectiveCreateValidator)
+		intrGas, err = vm.IntrinsicGas(tx.Data(), false, pool.homestead, pool.istanbul, stakingTx.StakingType() == staking.DirectiveCreateValidator, false)
 	} else {
-		intrGas, err = vm.IntrinsicGas(tx.Data(), tx.To() == nil, pool.homestead, pool.istanbul, false)
+		intrGas, err = vm.IntrinsicGas(tx.Data(), tx.To() == nil, pool.homestead, pool.istanbul, false, pool.shanghai)
 	}
 	if err != nil {
 		return err
 	}
+	if plainTx, ok := tx.(*types.Transaction); ok {
+		intrGas += accessListGas(plainTx.AccessList())
+	}
 	if tx.GasLimit() < intrGas {
 		return errors.WithMessagef(ErrIntrinsicGas, "transaction gas is %d", tx.GasLimit())
 	}
@@ -930,6 +974,45 @@ func (pool *TxPool) validateStakingTx(tx *staking.StakingTransaction) error {
 		}
 		_, err = VerifyAndUndelegateFromMsg(pool.currentState, pool.pendingEpoch(), stkMsg)
 		return err
+	case staking.DirectiveRedelegate:
+		msg, err := staking.RLPDecodeStakeMsg(tx.Data(), staking.DirectiveRedelegate)
+		if err != nil {
+			return err
+		}
+		stkMsg, ok := msg.(*staking.Redelegate)
+		if !ok {
+			return ErrInvalidMsgForStakingDirective
+		}
+		if from != stkMsg.DelegatorAddress {
+			return errors.WithMessagef(ErrInvalidSender, "staking transaction sender is %s", b32)
+		}
+		chain, ok := pool.chain.(ChainContext)
+		if !ok {
+			utils.Logger().Debug().Msg("Missing chain context in txPool")
+			return nil // for testing, chain could be testing blockchain
+		}
+		delegations, err := chain.ReadDelegationsByDelegator(stkMsg.DelegatorAddress)
+		if err != nil {
+			return err
+		}
+		_, err = VerifyAndRedelegateFromMsg(
+			pool.currentState, pool.pendingEpoch(), stkMsg, delegations, pool.chainconfig)
+		return err
+	case staking.DirectiveEditDelegation:
+		msg, err := staking.RLPDecodeStakeMsg(tx.Data(), staking.DirectiveEditDelegation)
+		if err != nil {
+			return err
+		}
+		stkMsg, ok := msg.(*staking.EditDelegation)
+		if !ok {
+			return ErrInvalidMsgForStakingDirective
+		}
+		if from != stkMsg.DelegatorAddress {
+			return errors.WithMessagef(ErrInvalidSender, "staking transaction sender is %s", b32)
+		}
+		_, err = VerifyAndEditDelegationFromMsg(
+			pool.currentState, pool.pendingEpoch(), stkMsg, pool.chainconfig)
+		return err
 	case staking.DirectiveCollectRewards:
 		msg, err := staking.RLPDecodeStakeMsg(tx.Data(), staking.DirectiveCollectRewards)
 		if err != nil {
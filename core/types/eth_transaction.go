@@ -17,6 +17,7 @@
 package types
 
 import (
+	"errors"
 	"io"
 	"math/big"
 	"sync/atomic"
@@ -62,6 +63,13 @@ type ethTxdata struct {
 	R *big.Int `json:"r" gencodec:"required"`
 	S *big.Int `json:"s" gencodec:"required"`
 
+	// Fields of the typed transactions, not part of the legacy encoding. The
+	// Price is the fee cap of the dynamic fee transactions.
+	Type       uint8      `json:"type"                           rlp:"-"`
+	ChainID    *big.Int   `json:"chainId,omitempty"              rlp:"-"`
+	GasTipCap  *big.Int   `json:"maxPriorityFeePerGas,omitempty" rlp:"-"`
+	AccessList AccessList `json:"accessList,omitempty"           rlp:"-"`
+
 	// This is only used when marshaling to JSON.
 	Hash *common.Hash `json:"hash" rlp:"-"`
 }
@@ -76,6 +84,10 @@ func (d *ethTxdata) CopyFrom(d2 *ethTxdata) {
 	d.V = new(big.Int).Set(d2.V)
 	d.R = new(big.Int).Set(d2.R)
 	d.S = new(big.Int).Set(d2.S)
+	d.Type = d2.Type
+	d.ChainID = copyBig(d2.ChainID)
+	d.GasTipCap = copyBig(d2.GasTipCap)
+	d.AccessList = d2.AccessList.copy()
 	d.Hash = copyHash(d2.Hash)
 }
 
@@ -88,6 +100,9 @@ type ethTxdataMarshaling struct {
 	V            *hexutil.Big
 	R            *hexutil.Big
 	S            *hexutil.Big
+	Type         hexutil.Uint64
+	ChainID      *hexutil.Big
+	GasTipCap    *hexutil.Big
 }
 
 // NewEthTransaction returns new ethereum-compatible transaction, which works as a intra-shard transaction
@@ -120,6 +135,30 @@ func newEthTransaction(nonce uint64, to *common.Address, amount *big.Int, gasLim
 	return &EthTransaction{data: d, time: time.Now()}
 }
 
+// NewAccessListEthTransaction returns new ethereum-compatible EIP-2930 transaction,
+// which works as a intra-shard transaction. A nil to is a contract creation.
+func NewAccessListEthTransaction(chainID *big.Int, nonce uint64, to *common.Address, amount *big.Int, gasLimit uint64, gasPrice *big.Int, data []byte, accessList AccessList) *EthTransaction {
+	tx := newEthTransaction(nonce, to, amount, gasLimit, gasPrice, data)
+	tx.data.Type = AccessListTxType
+	tx.data.ChainID = copyBig(chainID)
+	tx.data.AccessList = accessList.copy()
+	return tx
+}
+
+// NewDynamicFeeEthTransaction returns new ethereum-compatible EIP-1559 transaction,
+// which works as a intra-shard transaction. A nil to is a contract creation.
+func NewDynamicFeeEthTransaction(chainID *big.Int, nonce uint64, to *common.Address, amount *big.Int, gasLimit uint64, gasTipCap, gasFeeCap *big.Int, data []byte, accessList AccessList) *EthTransaction {
+	tx := newEthTransaction(nonce, to, amount, gasLimit, gasFeeCap, data)
+	tx.data.Type = DynamicFeeTxType
+	tx.data.ChainID = copyBig(chainID)
+	tx.data.GasTipCap = new(big.Int)
+	if gasTipCap != nil {
+		tx.data.GasTipCap.Set(gasTipCap)
+	}
+	tx.data.AccessList = accessList.copy()
+	return tx
+}
+
 // From returns the sender address of the transaction
 func (tx *EthTransaction) From() *atomic.Value {
 	return &tx.from
@@ -177,12 +216,42 @@ func (tx *EthTransaction) shardID() uint32 {
 
 // ChainID returns which chain id this transaction was signed for (if at all)
 func (tx *EthTransaction) ChainID() *big.Int {
+	if tx.data.Type != LegacyTxType {
+		return new(big.Int).Set(tx.data.ChainID)
+	}
 	return deriveChainID(tx.data.V)
 }
 
 // Protected returns whether the transaction is protected from replay protection.
+// The typed transactions are always protected.
 func (tx *EthTransaction) Protected() bool {
-	return isProtectedV(tx.data.V)
+	return tx.data.Type != LegacyTxType || isProtectedV(tx.data.V)
+}
+
+// Type returns the type of the transaction in the typed transaction envelope
+func (tx *EthTransaction) Type() uint8 {
+	return tx.data.Type
+}
+
+// GasTipCap returns the maximum priority fee per gas of the transaction, the
+// gas price of the transactions other than the dynamic fee ones
+func (tx *EthTransaction) GasTipCap() *big.Int {
+	if tx.data.Type != DynamicFeeTxType {
+		return new(big.Int).Set(tx.data.Price)
+	}
+	return new(big.Int).Set(tx.data.GasTipCap)
+}
+
+// GasFeeCap returns the maximum fee per gas of the transaction, the gas price
+// of the transactions other than the dynamic fee ones
+func (tx *EthTransaction) GasFeeCap() *big.Int {
+	return new(big.Int).Set(tx.data.Price)
+}
+
+// AccessList returns the access list of the transaction, nil for the legacy
+// transactions
+func (tx *EthTransaction) AccessList() AccessList {
+	return tx.data.AccessList
 }
 
 // Copy returns a copy of the transaction.
@@ -208,6 +277,10 @@ func (tx *EthTransaction) ConvertToHmy() *Transaction {
 	d2.V = new(big.Int).Set(d.V)
 	d2.R = new(big.Int).Set(d.R)
 	d2.S = new(big.Int).Set(d.S)
+	d2.Type = d.Type
+	d2.ChainID = copyBig(d.ChainID)
+	d2.GasTipCap = copyBig(d.GasTipCap)
+	d2.AccessList = d.AccessList.copy()
 
 	d2.ShardID = tx.ShardID()
 	d2.ToShardID = tx.ToShardID()
@@ -220,15 +293,33 @@ func (tx *EthTransaction) ConvertToHmy() *Transaction {
 	return &tx2
 }
 
-// EncodeRLP implements rlp.Encoder
+// EncodeRLP implements rlp.Encoder. The typed transactions are encoded as an
+// RLP string of the type byte followed by the RLP payload.
 func (tx *EthTransaction) EncodeRLP(w io.Writer) error {
-	return rlp.Encode(w, &tx.data)
+	if tx.data.Type == LegacyTxType {
+		return rlp.Encode(w, &tx.data)
+	}
+	enc, err := tx.data.encodeTyped()
+	if err != nil {
+		return err
+	}
+	return rlp.Encode(w, enc)
 }
 
 // DecodeRLP implements rlp.Decoder
 func (tx *EthTransaction) DecodeRLP(s *rlp.Stream) error {
-	_, size, _ := s.Kind()
-	err := s.Decode(&tx.data)
+	kind, size, err := s.Kind()
+	if err != nil {
+		return err
+	}
+	if kind == rlp.List {
+		err = s.Decode(&tx.data)
+	} else {
+		var b []byte
+		if b, err = s.Bytes(); err == nil {
+			err = tx.data.decodeTyped(b)
+		}
+	}
 	if err == nil {
 		tx.size.Store(common.StorageSize(rlp.ListSize(size)))
 		tx.time = time.Now()
@@ -237,6 +328,30 @@ func (tx *EthTransaction) DecodeRLP(s *rlp.Stream) error {
 	return err
 }
 
+// MarshalBinary returns the canonical encoding of the transaction, as sent by
+// the wallets: the RLP encoding of the legacy transactions, the type byte
+// followed by the RLP payload of the typed ones.
+func (tx *EthTransaction) MarshalBinary() ([]byte, error) {
+	if tx.data.Type == LegacyTxType {
+		return rlp.EncodeToBytes(&tx.data)
+	}
+	return tx.data.encodeTyped()
+}
+
+// UnmarshalBinary decodes the canonical encoding of the transaction.
+func (tx *EthTransaction) UnmarshalBinary(b []byte) error {
+	if len(b) > 0 && b[0] > 0x7f {
+		// The legacy transactions are RLP lists
+		return rlp.DecodeBytes(b, tx)
+	}
+	var data ethTxdata
+	if err := data.decodeTyped(b); err != nil {
+		return err
+	}
+	*tx = EthTransaction{data: data, time: time.Now()}
+	return nil
+}
+
 // MarshalJSON encodes the web3 RPC transaction format.
 func (tx *EthTransaction) MarshalJSON() ([]byte, error) {
 	hash := tx.Hash()
@@ -252,10 +367,15 @@ func (tx *EthTransaction) UnmarshalJSON(input []byte) error {
 		return err
 	}
 
+	if dec.Type != LegacyTxType && dec.ChainID == nil {
+		return errors.New("missing required field 'chainId' for typed transaction")
+	}
 	withSignature := dec.V.Sign() != 0 || dec.R.Sign() != 0 || dec.S.Sign() != 0
 	if withSignature {
 		var V byte
-		if isProtectedV(dec.V) {
+		if dec.Type != LegacyTxType {
+			V = byte(dec.V.Uint64())
+		} else if isProtectedV(dec.V) {
 			chainID := deriveChainID(dec.V).Uint64()
 			V = byte(dec.V.Uint64() - 35 - 2*chainID)
 		} else {
@@ -306,7 +426,13 @@ func (tx *EthTransaction) Hash() common.Hash {
 	if hash := tx.hash.Load(); hash != nil {
 		return hash.(common.Hash)
 	}
-	v := hash.FromRLP(tx)
+	var v common.Hash
+	if tx.data.Type == LegacyTxType {
+		v = hash.FromRLP(tx)
+	} else {
+		enc, _ := tx.data.encodeTyped()
+		v = crypto.Keccak256Hash(enc)
+	}
 	tx.hash.Store(v)
 	return v
 }
@@ -318,7 +444,7 @@ func (tx *EthTransaction) Size() common.StorageSize {
 		return size.(common.StorageSize)
 	}
 	c := writeCounter(0)
-	rlp.Encode(&c, &tx.data)
+	rlp.Encode(&c, tx)
 	tx.size.Store(common.StorageSize(c))
 	return common.StorageSize(c)
 }
@@ -361,9 +487,12 @@ func (tx *EthTransaction) AsMessage(s Signer) (Message, error) {
 		nonce:      tx.data.AccountNonce,
 		gasLimit:   tx.data.GasLimit,
 		gasPrice:   new(big.Int).Set(tx.data.Price),
+		gasFeeCap:  tx.GasFeeCap(),
+		gasTipCap:  tx.GasTipCap(),
 		to:         tx.data.Recipient,
 		amount:     tx.data.Amount,
 		data:       tx.data.Payload,
+		accessList: tx.data.AccessList,
 		checkNonce: true,
 	}
 
@@ -5,8 +5,10 @@ import (
 	"testing"
 
 	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/harmony-one/harmony/staking"
 )
 
@@ -112,3 +114,77 @@ func TestFindLogsWithTopic(t *testing.T) {
 		}
 	}
 }
+
+func TestTypedReceiptRLP(t *testing.T) {
+	for _, typ := range []uint8{LegacyTxType, AccessListTxType, DynamicFeeTxType} {
+		receipt := NewReceipt(nil, false, 21000)
+		receipt.Type = typ
+		receipt.Logs = []*Log{}
+		receipt.TxHash = crypto.Keccak256Hash([]byte("tx"))
+		receipt.GasUsed = 21000
+
+		b, err := rlp.EncodeToBytes(receipt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The consensus encoding matches the ethereum one
+		var ethReceipt ethtypes.Receipt
+		if err := rlp.DecodeBytes(b, &ethReceipt); err != nil {
+			t.Fatal(err)
+		}
+		if ethReceipt.Type != typ || ethReceipt.CumulativeGasUsed != 21000 {
+			t.Errorf("unexpected ethereum receipt type %d, gas %d", ethReceipt.Type, ethReceipt.CumulativeGasUsed)
+		}
+		var dec Receipt
+		if err := rlp.DecodeBytes(b, &dec); err != nil {
+			t.Fatal(err)
+		}
+		if dec.Type != typ || dec.Status != ReceiptStatusSuccessful {
+			t.Errorf("unexpected receipt type %d, status %d", dec.Type, dec.Status)
+		}
+
+		b, err = rlp.EncodeToBytes((*ReceiptForStorage)(receipt))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var stored ReceiptForStorage
+		if err := rlp.DecodeBytes(b, &stored); err != nil {
+			t.Fatal(err)
+		}
+		if stored.Type != typ || stored.TxHash != receipt.TxHash || stored.GasUsed != receipt.GasUsed {
+			t.Errorf("unexpected stored receipt %+v", stored)
+		}
+	}
+}
+
+func TestTypedReceiptRLP_Unsupported(t *testing.T) {
+	receipt := NewReceipt(nil, false, 21000)
+	receipt.Type = AccessListTxType
+	receipt.Logs = []*Log{}
+
+	b, err := rlp.EncodeToBytes(receipt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored, err := rlp.EncodeToBytes((*ReceiptForStorage)(receipt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// replace the type byte of the envelopes with an unknown type
+	for _, enc := range [][]byte{b, stored} {
+		_, content, _, err := rlp.Split(enc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		content[0] = 0x7f
+	}
+
+	var dec Receipt
+	if err := rlp.DecodeBytes(b, &dec); err != ErrTxTypeNotSupported {
+		t.Errorf("unexpected error %v / %v", err, ErrTxTypeNotSupported)
+	}
+	var decStored ReceiptForStorage
+	if err := rlp.DecodeBytes(stored, &decStored); err != ErrTxTypeNotSupported {
+		t.Errorf("unexpected error %v / %v", err, ErrTxTypeNotSupported)
+	}
+}
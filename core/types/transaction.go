@@ -57,12 +57,15 @@ const (
 	Delegate
 	Undelegate
 	CollectRewards
+	Redelegate
+	EditDelegation
 )
 
 // StakingTypeMap is the map from staking type to transactionType
 var StakingTypeMap = map[staking.Directive]TransactionType{staking.DirectiveCreateValidator: StakeCreateVal,
 	staking.DirectiveEditValidator: StakeEditVal, staking.DirectiveDelegate: Delegate,
-	staking.DirectiveUndelegate: Undelegate, staking.DirectiveCollectRewards: CollectRewards}
+	staking.DirectiveUndelegate: Undelegate, staking.DirectiveCollectRewards: CollectRewards,
+	staking.DirectiveRedelegate: Redelegate, staking.DirectiveEditDelegation: EditDelegation}
 
 // InternalTransaction defines the common interface for harmony and ethereum transactions.
 type InternalTransaction interface {
@@ -73,6 +76,12 @@ type InternalTransaction interface {
 	R() *big.Int
 	S() *big.Int
 
+	// Typed transaction values
+	Type() uint8
+	GasTipCap() *big.Int
+	GasFeeCap() *big.Int
+	AccessList() AccessList
+
 	IsEthCompatible() bool
 	AsMessage(s Signer) (Message, error)
 }
@@ -124,6 +133,10 @@ func (txType TransactionType) String() string {
 		return "Undelegate"
 	} else if txType == CollectRewards {
 		return "CollectRewards"
+	} else if txType == Redelegate {
+		return "Redelegate"
+	} else if txType == EditDelegation {
+		return "EditDelegation"
 	}
 	return "Unknown"
 }
@@ -143,6 +156,13 @@ type txdata struct {
 	R *big.Int `json:"r" gencodec:"required"`
 	S *big.Int `json:"s" gencodec:"required"`
 
+	// Fields of the typed transactions, not part of the legacy encoding. The
+	// Price is the fee cap of the dynamic fee transactions.
+	Type       uint8      `json:"type"                           rlp:"-"`
+	ChainID    *big.Int   `json:"chainId,omitempty"              rlp:"-"`
+	GasTipCap  *big.Int   `json:"maxPriorityFeePerGas,omitempty" rlp:"-"`
+	AccessList AccessList `json:"accessList,omitempty"           rlp:"-"`
+
 	// This is only used when marshaling to JSON.
 	Hash *common.Hash `json:"hash" rlp:"-"`
 }
@@ -175,6 +195,10 @@ func (d *txdata) CopyFrom(d2 *txdata) {
 	d.V = new(big.Int).Set(d2.V)
 	d.R = new(big.Int).Set(d2.R)
 	d.S = new(big.Int).Set(d2.S)
+	d.Type = d2.Type
+	d.ChainID = copyBig(d2.ChainID)
+	d.GasTipCap = copyBig(d2.GasTipCap)
+	d.AccessList = d2.AccessList.copy()
 	d.Hash = copyHash(d2.Hash)
 }
 
@@ -187,6 +211,9 @@ type txdataMarshaling struct {
 	V            *hexutil.Big
 	R            *hexutil.Big
 	S            *hexutil.Big
+	Type         hexutil.Uint64
+	ChainID      *hexutil.Big
+	GasTipCap    *hexutil.Big
 }
 
 // NewTransaction returns new transaction, this method is to create same shard transaction
@@ -288,11 +315,38 @@ func (tx *Transaction) GasLimit() uint64 {
 	return tx.data.GasLimit
 }
 
-// GasPrice is the gas price of the transaction
+// GasPrice is the gas price of the transaction, the fee cap of the dynamic fee
+// transactions
 func (tx *Transaction) GasPrice() *big.Int {
 	return tx.data.Price
 }
 
+// Type returns the type of the transaction in the typed transaction envelope
+func (tx *Transaction) Type() uint8 {
+	return tx.data.Type
+}
+
+// GasTipCap returns the maximum priority fee per gas of the transaction, the
+// gas price of the transactions other than the dynamic fee ones
+func (tx *Transaction) GasTipCap() *big.Int {
+	if tx.data.Type != DynamicFeeTxType {
+		return tx.data.Price
+	}
+	return tx.data.GasTipCap
+}
+
+// GasFeeCap returns the maximum fee per gas of the transaction, the gas price
+// of the transactions other than the dynamic fee ones
+func (tx *Transaction) GasFeeCap() *big.Int {
+	return tx.data.Price
+}
+
+// AccessList returns the access list of the transaction, nil for the legacy
+// transactions
+func (tx *Transaction) AccessList() AccessList {
+	return tx.data.AccessList
+}
+
 // Data returns data payload of Transaction.
 func (tx *Transaction) Data() []byte {
 	return common.CopyBytes(tx.data.Payload)
@@ -300,6 +354,9 @@ func (tx *Transaction) Data() []byte {
 
 // ChainID returns which chain id this transaction was signed for (if at all)
 func (tx *Transaction) ChainID() *big.Int {
+	if tx.data.Type != LegacyTxType {
+		return new(big.Int).Set(tx.data.ChainID)
+	}
 	return deriveChainID(tx.data.V)
 }
 
@@ -319,8 +376,9 @@ func (tx *Transaction) Time() time.Time {
 }
 
 // Protected returns whether the transaction is protected from replay protection.
+// The typed transactions are always protected.
 func (tx *Transaction) Protected() bool {
-	return isProtectedV(tx.data.V)
+	return tx.data.Type != LegacyTxType || isProtectedV(tx.data.V)
 }
 
 func isProtectedV(V *big.Int) bool {
@@ -332,15 +390,33 @@ func isProtectedV(V *big.Int) bool {
 	return true
 }
 
-// EncodeRLP implements rlp.Encoder
+// EncodeRLP implements rlp.Encoder. The typed transactions are encoded as an
+// RLP string of the type byte followed by the RLP payload.
 func (tx *Transaction) EncodeRLP(w io.Writer) error {
-	return rlp.Encode(w, &tx.data)
+	if tx.data.Type == LegacyTxType {
+		return rlp.Encode(w, &tx.data)
+	}
+	enc, err := tx.data.encodeTyped()
+	if err != nil {
+		return err
+	}
+	return rlp.Encode(w, enc)
 }
 
 // DecodeRLP implements rlp.Decoder
 func (tx *Transaction) DecodeRLP(s *rlp.Stream) error {
-	_, size, _ := s.Kind()
-	err := s.Decode(&tx.data)
+	kind, size, err := s.Kind()
+	if err != nil {
+		return err
+	}
+	if kind == rlp.List {
+		err = s.Decode(&tx.data)
+	} else {
+		var b []byte
+		if b, err = s.Bytes(); err == nil {
+			err = tx.data.decodeTyped(b)
+		}
+	}
 	if err == nil {
 		tx.size.Store(common.StorageSize(rlp.ListSize(size)))
 		tx.time = time.Now()
@@ -364,10 +440,15 @@ func (tx *Transaction) UnmarshalJSON(input []byte) error {
 		return err
 	}
 
+	if dec.Type != LegacyTxType && dec.ChainID == nil {
+		return errors.New("missing required field 'chainId' for typed transaction")
+	}
 	withSignature := dec.V.Sign() != 0 || dec.R.Sign() != 0 || dec.S.Sign() != 0
 	if withSignature {
 		var V byte
-		if isProtectedV(dec.V) {
+		if dec.Type != LegacyTxType {
+			V = byte(dec.V.Uint64())
+		} else if isProtectedV(dec.V) {
 			chainID := deriveChainID(dec.V).Uint64()
 			V = byte(dec.V.Uint64() - 35 - 2*chainID)
 		} else {
@@ -408,7 +489,13 @@ func (tx *Transaction) Hash() common.Hash {
 	if hash := tx.hash.Load(); hash != nil {
 		return hash.(common.Hash)
 	}
-	v := hash.FromRLP(tx)
+	var v common.Hash
+	if tx.data.Type == LegacyTxType {
+		v = hash.FromRLP(tx)
+	} else {
+		enc, _ := tx.data.encodeTyped()
+		v = crypto.Keccak256Hash(enc)
+	}
 	tx.hash.Store(v)
 	return v
 }
@@ -429,7 +516,7 @@ func (tx *Transaction) Size() common.StorageSize {
 		return size.(common.StorageSize)
 	}
 	c := writeCounter(0)
-	rlp.Encode(&c, &tx.data)
+	rlp.Encode(&c, tx)
 	tx.size.Store(common.StorageSize(c))
 	return common.StorageSize(c)
 }
@@ -454,6 +541,10 @@ func (tx *Transaction) ConvertToEth() *EthTransaction {
 	d2.V = new(big.Int).Set(d.V)
 	d2.R = new(big.Int).Set(d.R)
 	d2.S = new(big.Int).Set(d.S)
+	d2.Type = d.Type
+	d2.ChainID = copyBig(d.ChainID)
+	d2.GasTipCap = copyBig(d.GasTipCap)
+	d2.AccessList = d.AccessList.copy()
 
 	copy := tx2.Hash()
 	d2.Hash = &copy
@@ -473,9 +564,12 @@ func (tx *Transaction) AsMessage(s Signer) (Message, error) {
 		nonce:      tx.data.AccountNonce,
 		gasLimit:   tx.data.GasLimit,
 		gasPrice:   new(big.Int).Set(tx.data.Price),
+		gasFeeCap:  new(big.Int).Set(tx.GasFeeCap()),
+		gasTipCap:  new(big.Int).Set(tx.GasTipCap()),
 		to:         tx.data.Recipient,
 		amount:     tx.data.Amount,
 		data:       tx.data.Payload,
+		accessList: tx.data.AccessList,
 		checkNonce: true,
 	}
 
@@ -678,7 +772,10 @@ type Message struct {
 	amount     *big.Int
 	gasLimit   uint64
 	gasPrice   *big.Int
+	gasFeeCap  *big.Int
+	gasTipCap  *big.Int
 	data       []byte
+	accessList AccessList
 	checkNonce bool
 	blockNum   *big.Int
 	txType     TransactionType
@@ -693,6 +790,25 @@ func NewMessage(from common.Address, to *common.Address, nonce uint64, amount *b
 		amount:     amount,
 		gasLimit:   gasLimit,
 		gasPrice:   gasPrice,
+		gasFeeCap:  gasPrice,
+		gasTipCap:  gasPrice,
+		data:       data,
+		checkNonce: checkNonce,
+	}
+}
+
+// NewDynamicFeeMessage returns new message with the fee caps of a dynamic fee
+// transaction. The gas price is the fee cap.
+func NewDynamicFeeMessage(from common.Address, to *common.Address, nonce uint64, amount *big.Int, gasLimit uint64, gasFeeCap, gasTipCap *big.Int, data []byte, checkNonce bool) Message {
+	return Message{
+		from:       from,
+		to:         to,
+		nonce:      nonce,
+		amount:     amount,
+		gasLimit:   gasLimit,
+		gasPrice:   gasFeeCap,
+		gasFeeCap:  gasFeeCap,
+		gasTipCap:  gasTipCap,
 		data:       data,
 		checkNonce: checkNonce,
 	}
@@ -706,6 +822,8 @@ func NewStakingMessage(from common.Address, nonce uint64, gasLimit uint64, gasPr
 		nonce:      nonce,
 		gasLimit:   gasLimit,
 		gasPrice:   new(big.Int).Set(gasPrice),
+		gasFeeCap:  new(big.Int).Set(gasPrice),
+		gasTipCap:  new(big.Int).Set(gasPrice),
 		data:       data,
 		checkNonce: true,
 		blockNum:   blockNum,
@@ -727,6 +845,16 @@ func (m Message) GasPrice() *big.Int {
 	return m.gasPrice
 }
 
+// GasFeeCap returns the maximum fee per gas from Message.
+func (m Message) GasFeeCap() *big.Int {
+	return m.gasFeeCap
+}
+
+// GasTipCap returns the maximum priority fee per gas from Message.
+func (m Message) GasTipCap() *big.Int {
+	return m.gasTipCap
+}
+
 // Value returns the value amount from Message.
 func (m Message) Value() *big.Int {
 	return m.amount
@@ -747,6 +875,16 @@ func (m Message) Data() []byte {
 	return m.data
 }
 
+// AccessList returns the access list of the Message.
+func (m Message) AccessList() AccessList {
+	return m.accessList
+}
+
+// SetAccessList sets the access list of the Message.
+func (m *Message) SetAccessList(accessList AccessList) {
+	m.accessList = accessList
+}
+
 // CheckNonce returns checkNonce of Message.
 func (m Message) CheckNonce() bool {
 	return m.checkNonce
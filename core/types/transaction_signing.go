@@ -136,13 +136,24 @@ func (s EIP155Signer) Equal(s2 Signer) bool {
 	return ok && eip155.chainID.Cmp(s.chainID) == 0
 }
 
-var big8 = big.NewInt(8)
+var (
+	big8  = big.NewInt(8)
+	big27 = big.NewInt(27)
+)
 
 // Sender returns the sender address of the given signer.
 func (s EIP155Signer) Sender(tx InternalTransaction) (common.Address, error) {
 	if !tx.Protected() {
 		return HomesteadSigner{}.Sender(tx)
 	}
+	if tx.Type() != LegacyTxType {
+		// The typed transactions carry the chain id and a V of 0 or 1
+		if tx.ChainID().Cmp(s.chainID) != 0 {
+			return common.Address{}, ErrInvalidChainID
+		}
+		V := new(big.Int).Add(tx.V(), big27)
+		return recoverPlain(s.Hash(tx), tx.R(), tx.S(), V, true)
+	}
 
 	ethChainID := nodeconfig.GetDefaultConfig().GetNetworkType().ChainConfig().EthCompatibleChainID
 	if tx.ChainID().Cmp(ethChainID) != 0 && tx.ChainID().Cmp(s.chainID) != 0 {
@@ -160,6 +171,12 @@ func (s EIP155Signer) SignatureValues(tx InternalTransaction, sig []byte) (R, S,
 	if err != nil {
 		return nil, nil, nil, err
 	}
+	if tx.Type() != LegacyTxType {
+		if tx.ChainID().Cmp(s.chainID) != 0 {
+			return nil, nil, nil, ErrInvalidChainID
+		}
+		return R, S, big.NewInt(int64(sig[64])), nil
+	}
 	if s.chainID.Sign() != 0 {
 		V = big.NewInt(int64(sig[64] + 35))
 		V.Add(V, s.chainIDMul)
@@ -170,6 +187,32 @@ func (s EIP155Signer) SignatureValues(tx InternalTransaction, sig []byte) (R, S,
 // Hash returns the hash to be signed by the sender.
 // It does not uniquely identify the transaction.
 func (s EIP155Signer) Hash(tx InternalTransaction) common.Hash {
+	switch tx.Type() {
+	case AccessListTxType:
+		// The typed transactions are ethereum-compatible only
+		return hash.FromPrefixedRLP(AccessListTxType, []interface{}{
+			s.chainID,
+			tx.Nonce(),
+			tx.GasPrice(),
+			tx.GasLimit(),
+			tx.To(),
+			tx.Value(),
+			tx.Data(),
+			tx.AccessList(),
+		})
+	case DynamicFeeTxType:
+		return hash.FromPrefixedRLP(DynamicFeeTxType, []interface{}{
+			s.chainID,
+			tx.Nonce(),
+			tx.GasTipCap(),
+			tx.GasFeeCap(),
+			tx.GasLimit(),
+			tx.To(),
+			tx.Value(),
+			tx.Data(),
+			tx.AccessList(),
+		})
+	}
 	if params.IsEthCompatible(s.chainID) {
 		// following the same logic as in go-eth implementation
 		return hash.FromRLP([]interface{}{
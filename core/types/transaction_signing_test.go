@@ -18,10 +18,13 @@ package types
 
 import (
 	"math/big"
+	"reflect"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
 )
 
 func TestEIP155Signing(t *testing.T) {
@@ -96,3 +99,139 @@ func TestChainID(t *testing.T) {
 		t.Error("expected no error")
 	}
 }
+
+func TestDynamicFeeSigning(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	chainID := big.NewInt(2)
+
+	signer := NewEIP155Signer(chainID)
+	tx := NewDynamicFeeEthTransaction(chainID, 0, &addr, big.NewInt(1), 21000, big.NewInt(1e9), big.NewInt(200e9), nil, nil)
+	tx, err := SignEthTx(tx, signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := tx.V().Uint64(); v > 1 {
+		t.Errorf("expected V to be 0 or 1, got %d", v)
+	}
+	from, err := Sender(signer, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from != addr {
+		t.Errorf("exected from and address to be equal. Got %x want %x", from, addr)
+	}
+	if _, err := Sender(NewEIP155Signer(big.NewInt(3)), tx); err != ErrInvalidChainID {
+		t.Error("expected error:", ErrInvalidChainID)
+	}
+
+	// The encoding, hash and signature match the ethereum ones
+	enc, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ethTx ethtypes.Transaction
+	if err := ethTx.UnmarshalBinary(enc); err != nil {
+		t.Fatal(err)
+	}
+	if ethTx.Hash() != tx.Hash() {
+		t.Errorf("expected hash %x, got %x", ethTx.Hash(), tx.Hash())
+	}
+	ethFrom, err := ethtypes.Sender(ethtypes.NewLondonSigner(chainID), &ethTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ethFrom != addr {
+		t.Errorf("exected ethereum from and address to be equal. Got %x want %x", ethFrom, addr)
+	}
+	var decoded EthTransaction
+	if err := decoded.UnmarshalBinary(enc); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Hash() != tx.Hash() {
+		t.Errorf("expected hash %x, got %x", tx.Hash(), decoded.Hash())
+	}
+
+	// The harmony transaction keeps the type and the sender through RLP
+	b, err := rlp.EncodeToBytes(tx.ConvertToHmy())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var hmyTx Transaction
+	if err := rlp.DecodeBytes(b, &hmyTx); err != nil {
+		t.Fatal(err)
+	}
+	if hmyTx.Type() != DynamicFeeTxType {
+		t.Errorf("expected type %d, got %d", DynamicFeeTxType, hmyTx.Type())
+	}
+	if hmyTx.GasTipCap().Cmp(big.NewInt(1e9)) != 0 || hmyTx.GasFeeCap().Cmp(big.NewInt(200e9)) != 0 {
+		t.Errorf("unexpected fee caps %s, %s", hmyTx.GasTipCap(), hmyTx.GasFeeCap())
+	}
+	if hmyTx.ConvertToEth().Hash() != tx.Hash() {
+		t.Errorf("expected ethereum hash %x, got %x", tx.Hash(), hmyTx.ConvertToEth().Hash())
+	}
+	from, err = Sender(signer, &hmyTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from != addr {
+		t.Errorf("exected from and address to be equal. Got %x want %x", from, addr)
+	}
+}
+
+func TestAccessListSigning(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	chainID := big.NewInt(2)
+	accessList := AccessList{{Address: addr, StorageKeys: []common.Hash{{0x01}}}}
+
+	signer := NewEIP155Signer(chainID)
+	tx := NewAccessListEthTransaction(chainID, 0, &addr, big.NewInt(1), 50000, big.NewInt(1e9), nil, accessList)
+	tx, err := SignEthTx(tx, signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	from, err := Sender(signer, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from != addr {
+		t.Errorf("exected from and address to be equal. Got %x want %x", from, addr)
+	}
+
+	// The encoding, hash and signature match the ethereum ones
+	enc, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ethTx ethtypes.Transaction
+	if err := ethTx.UnmarshalBinary(enc); err != nil {
+		t.Fatal(err)
+	}
+	if ethTx.Type() != AccessListTxType || ethTx.Hash() != tx.Hash() {
+		t.Errorf("expected type %d hash %x, got %d %x", AccessListTxType, tx.Hash(), ethTx.Type(), ethTx.Hash())
+	}
+	ethFrom, err := ethtypes.Sender(ethtypes.NewEIP2930Signer(chainID), &ethTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ethFrom != addr {
+		t.Errorf("exected ethereum from and address to be equal. Got %x want %x", ethFrom, addr)
+	}
+
+	// The harmony transaction keeps the access list through RLP
+	b, err := rlp.EncodeToBytes(tx.ConvertToHmy())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var hmyTx Transaction
+	if err := rlp.DecodeBytes(b, &hmyTx); err != nil {
+		t.Fatal(err)
+	}
+	if hmyTx.Type() != AccessListTxType || !reflect.DeepEqual(hmyTx.AccessList(), accessList) {
+		t.Errorf("unexpected type %d or access list %v", hmyTx.Type(), hmyTx.AccessList())
+	}
+	if hmyTx.GasTipCap().Cmp(big.NewInt(1e9)) != 0 {
+		t.Errorf("expected tip cap to be the gas price, got %s", hmyTx.GasTipCap())
+	}
+}
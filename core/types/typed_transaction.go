@@ -0,0 +1,341 @@
+package types
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Transaction types of the typed transaction envelope (EIP-2718). The legacy
+// transactions are encoded as is, the typed ones as the type byte followed by
+// the RLP payload of the transaction.
+const (
+	LegacyTxType     = 0x00
+	AccessListTxType = 0x01
+	DynamicFeeTxType = 0x02
+)
+
+var (
+	// ErrTxTypeNotSupported is returned if a transaction is not supported in the
+	// current network configuration.
+	ErrTxTypeNotSupported = errors.New("transaction type not supported")
+
+	errEmptyTypedTx = errors.New("empty typed transaction bytes")
+)
+
+// AccessTuple is the element type of an access list.
+type AccessTuple struct {
+	Address     common.Address `json:"address"`
+	StorageKeys []common.Hash  `json:"storageKeys"`
+}
+
+// AccessList is an EIP-2930 access list, carried by the typed transactions.
+type AccessList []AccessTuple
+
+// StorageKeys returns the total number of storage keys in the access list.
+func (al AccessList) StorageKeys() int {
+	sum := 0
+	for _, tuple := range al {
+		sum += len(tuple.StorageKeys)
+	}
+	return sum
+}
+
+func (al AccessList) copy() AccessList {
+	if al == nil {
+		return nil
+	}
+	cpy := make(AccessList, len(al))
+	for i, tuple := range al {
+		cpy[i] = AccessTuple{
+			Address:     tuple.Address,
+			StorageKeys: append(tuple.StorageKeys[:0:0], tuple.StorageKeys...),
+		}
+	}
+	return cpy
+}
+
+func copyBig(v *big.Int) *big.Int {
+	if v == nil {
+		return nil
+	}
+	return new(big.Int).Set(v)
+}
+
+// accessListTxdata is the RLP payload of a harmony EIP-2930 transaction
+type accessListTxdata struct {
+	ChainID      *big.Int
+	AccountNonce uint64
+	Price        *big.Int
+	GasLimit     uint64
+	ShardID      uint32
+	ToShardID    uint32
+	Recipient    *common.Address `rlp:"nil"`
+	Amount       *big.Int
+	Payload      []byte
+	AccessList   AccessList
+
+	// Signature values
+	V *big.Int
+	R *big.Int
+	S *big.Int
+}
+
+// ethAccessListTxdata is the RLP payload of an ethereum EIP-2930 transaction
+type ethAccessListTxdata struct {
+	ChainID      *big.Int
+	AccountNonce uint64
+	Price        *big.Int
+	GasLimit     uint64
+	Recipient    *common.Address `rlp:"nil"`
+	Amount       *big.Int
+	Payload      []byte
+	AccessList   AccessList
+
+	// Signature values
+	V *big.Int
+	R *big.Int
+	S *big.Int
+}
+
+// dynamicFeeTxdata is the RLP payload of a harmony EIP-1559 transaction
+type dynamicFeeTxdata struct {
+	ChainID      *big.Int
+	AccountNonce uint64
+	GasTipCap    *big.Int
+	GasFeeCap    *big.Int
+	GasLimit     uint64
+	ShardID      uint32
+	ToShardID    uint32
+	Recipient    *common.Address `rlp:"nil"`
+	Amount       *big.Int
+	Payload      []byte
+	AccessList   AccessList
+
+	// Signature values
+	V *big.Int
+	R *big.Int
+	S *big.Int
+}
+
+// ethDynamicFeeTxdata is the RLP payload of an ethereum EIP-1559 transaction
+type ethDynamicFeeTxdata struct {
+	ChainID      *big.Int
+	AccountNonce uint64
+	GasTipCap    *big.Int
+	GasFeeCap    *big.Int
+	GasLimit     uint64
+	Recipient    *common.Address `rlp:"nil"`
+	Amount       *big.Int
+	Payload      []byte
+	AccessList   AccessList
+
+	// Signature values
+	V *big.Int
+	R *big.Int
+	S *big.Int
+}
+
+// encodeTyped returns the type byte followed by the RLP payload of the typed
+// transaction
+func (d *txdata) encodeTyped() ([]byte, error) {
+	var payload interface{}
+	switch d.Type {
+	case AccessListTxType:
+		payload = &accessListTxdata{
+			ChainID:      d.ChainID,
+			AccountNonce: d.AccountNonce,
+			Price:        d.Price,
+			GasLimit:     d.GasLimit,
+			ShardID:      d.ShardID,
+			ToShardID:    d.ToShardID,
+			Recipient:    d.Recipient,
+			Amount:       d.Amount,
+			Payload:      d.Payload,
+			AccessList:   d.AccessList,
+			V:            d.V,
+			R:            d.R,
+			S:            d.S,
+		}
+	case DynamicFeeTxType:
+		payload = &dynamicFeeTxdata{
+			ChainID:      d.ChainID,
+			AccountNonce: d.AccountNonce,
+			GasTipCap:    d.GasTipCap,
+			GasFeeCap:    d.Price,
+			GasLimit:     d.GasLimit,
+			ShardID:      d.ShardID,
+			ToShardID:    d.ToShardID,
+			Recipient:    d.Recipient,
+			Amount:       d.Amount,
+			Payload:      d.Payload,
+			AccessList:   d.AccessList,
+			V:            d.V,
+			R:            d.R,
+			S:            d.S,
+		}
+	default:
+		return nil, ErrTxTypeNotSupported
+	}
+	enc, err := rlp.EncodeToBytes(payload)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{d.Type}, enc...), nil
+}
+
+// decodeTyped decodes the type byte followed by the RLP payload of a typed
+// transaction
+func (d *txdata) decodeTyped(b []byte) error {
+	if len(b) == 0 {
+		return errEmptyTypedTx
+	}
+	switch b[0] {
+	case AccessListTxType:
+		var p accessListTxdata
+		if err := rlp.DecodeBytes(b[1:], &p); err != nil {
+			return err
+		}
+		*d = txdata{
+			AccountNonce: p.AccountNonce,
+			Price:        p.Price,
+			GasLimit:     p.GasLimit,
+			ShardID:      p.ShardID,
+			ToShardID:    p.ToShardID,
+			Recipient:    p.Recipient,
+			Amount:       p.Amount,
+			Payload:      p.Payload,
+			V:            p.V,
+			R:            p.R,
+			S:            p.S,
+			Type:         AccessListTxType,
+			ChainID:      p.ChainID,
+			AccessList:   p.AccessList,
+		}
+		return nil
+	case DynamicFeeTxType:
+		var p dynamicFeeTxdata
+		if err := rlp.DecodeBytes(b[1:], &p); err != nil {
+			return err
+		}
+		*d = txdata{
+			AccountNonce: p.AccountNonce,
+			Price:        p.GasFeeCap,
+			GasLimit:     p.GasLimit,
+			ShardID:      p.ShardID,
+			ToShardID:    p.ToShardID,
+			Recipient:    p.Recipient,
+			Amount:       p.Amount,
+			Payload:      p.Payload,
+			V:            p.V,
+			R:            p.R,
+			S:            p.S,
+			Type:         DynamicFeeTxType,
+			ChainID:      p.ChainID,
+			GasTipCap:    p.GasTipCap,
+			AccessList:   p.AccessList,
+		}
+		return nil
+	default:
+		return ErrTxTypeNotSupported
+	}
+}
+
+// encodeTyped returns the type byte followed by the RLP payload of the typed
+// transaction
+func (d *ethTxdata) encodeTyped() ([]byte, error) {
+	var payload interface{}
+	switch d.Type {
+	case AccessListTxType:
+		payload = &ethAccessListTxdata{
+			ChainID:      d.ChainID,
+			AccountNonce: d.AccountNonce,
+			Price:        d.Price,
+			GasLimit:     d.GasLimit,
+			Recipient:    d.Recipient,
+			Amount:       d.Amount,
+			Payload:      d.Payload,
+			AccessList:   d.AccessList,
+			V:            d.V,
+			R:            d.R,
+			S:            d.S,
+		}
+	case DynamicFeeTxType:
+		payload = &ethDynamicFeeTxdata{
+			ChainID:      d.ChainID,
+			AccountNonce: d.AccountNonce,
+			GasTipCap:    d.GasTipCap,
+			GasFeeCap:    d.Price,
+			GasLimit:     d.GasLimit,
+			Recipient:    d.Recipient,
+			Amount:       d.Amount,
+			Payload:      d.Payload,
+			AccessList:   d.AccessList,
+			V:            d.V,
+			R:            d.R,
+			S:            d.S,
+		}
+	default:
+		return nil, ErrTxTypeNotSupported
+	}
+	enc, err := rlp.EncodeToBytes(payload)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{d.Type}, enc...), nil
+}
+
+// decodeTyped decodes the type byte followed by the RLP payload of a typed
+// transaction
+func (d *ethTxdata) decodeTyped(b []byte) error {
+	if len(b) == 0 {
+		return errEmptyTypedTx
+	}
+	switch b[0] {
+	case AccessListTxType:
+		var p ethAccessListTxdata
+		if err := rlp.DecodeBytes(b[1:], &p); err != nil {
+			return err
+		}
+		*d = ethTxdata{
+			AccountNonce: p.AccountNonce,
+			Price:        p.Price,
+			GasLimit:     p.GasLimit,
+			Recipient:    p.Recipient,
+			Amount:       p.Amount,
+			Payload:      p.Payload,
+			V:            p.V,
+			R:            p.R,
+			S:            p.S,
+			Type:         AccessListTxType,
+			ChainID:      p.ChainID,
+			AccessList:   p.AccessList,
+		}
+		return nil
+	case DynamicFeeTxType:
+		var p ethDynamicFeeTxdata
+		if err := rlp.DecodeBytes(b[1:], &p); err != nil {
+			return err
+		}
+		*d = ethTxdata{
+			AccountNonce: p.AccountNonce,
+			Price:        p.GasFeeCap,
+			GasLimit:     p.GasLimit,
+			Recipient:    p.Recipient,
+			Amount:       p.Amount,
+			Payload:      p.Payload,
+			V:            p.V,
+			R:            p.R,
+			S:            p.S,
+			Type:         DynamicFeeTxType,
+			ChainID:      p.ChainID,
+			GasTipCap:    p.GasTipCap,
+			AccessList:   p.AccessList,
+		}
+		return nil
+	default:
+		return ErrTxTypeNotSupported
+	}
+}
@@ -0,0 +1,306 @@
+package vm
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/accounts/abi"
+	"github.com/harmony-one/harmony/internal/params"
+	"github.com/harmony-one/harmony/shard"
+	"github.com/harmony-one/harmony/staking/effective"
+	stakingTypes "github.com/harmony-one/harmony/staking/types"
+)
+
+// StatefulPrecompiledContractsStaking lists out the stateful precompiled contracts
+// which are available after the StakingQueryPrecompileEpoch
+// for now, we have only one contract at 250 or 0xfa - which is the read only staking precompile
+var StatefulPrecompiledContractsStaking = map[common.Address]StatefulPrecompiledContract{
+	common.BytesToAddress([]byte{250}): &stakingQueryPrecompile{},
+}
+
+// StatefulPrecompiledContract represents the interface for Native Go contracts
+// which are available as a precompile in the EVM
+// Unlike the (read-only) PrecompiledContracts, these can read the state
+// and unlike the WriteCapablePrecompiledContracts, these never alter it,
+// so they can be used within a static call
+type StatefulPrecompiledContract interface {
+	// RequiredGas calculates the contract gas use
+	RequiredGas(evm *EVM, contract *Contract, input []byte) (uint64, error)
+	// use a different name from the other contracts to be safe
+	RunStateful(evm *EVM, contract *Contract, input []byte) ([]byte, error)
+}
+
+// RunStatefulPrecompiledContract runs and evaluates the output of a stateful precompiled contract.
+func RunStatefulPrecompiledContract(
+	p StatefulPrecompiledContract,
+	evm *EVM,
+	contract *Contract,
+	input []byte,
+) ([]byte, error) {
+	gas, err := p.RequiredGas(evm, contract, input)
+	if err != nil {
+		return nil, err
+	}
+	if !contract.UseGas(gas) {
+		return nil, ErrOutOfGas
+	}
+	return p.RunStateful(evm, contract, input)
+}
+
+var abiStakingQuery abi.ABI
+
+func init() {
+	// every method takes the validator address first, and the delegator
+	// address for the delegation queries
+	// the status is the effective.Eligibility of the validator:
+	// 0 is not a validator, 1 active, 2 inactive and 3 banned
+	stakingQueryABIJSON := `
+	[
+	  {
+	    "inputs": [
+	      {
+	        "internalType": "address",
+	        "name": "validatorAddress",
+	        "type": "address"
+	      }
+	    ],
+	    "name": "getValidatorStatus",
+	    "outputs": [
+	      {
+	        "internalType": "uint8",
+	        "name": "status",
+	        "type": "uint8"
+	      }
+	    ],
+	    "stateMutability": "view",
+	    "type": "function"
+	  },
+	  {
+	    "inputs": [
+	      {
+	        "internalType": "address",
+	        "name": "validatorAddress",
+	        "type": "address"
+	      }
+	    ],
+	    "name": "getValidatorTotalDelegation",
+	    "outputs": [
+	      {
+	        "internalType": "uint256",
+	        "name": "amount",
+	        "type": "uint256"
+	      }
+	    ],
+	    "stateMutability": "view",
+	    "type": "function"
+	  },
+	  {
+	    "inputs": [
+	      {
+	        "internalType": "address",
+	        "name": "validatorAddress",
+	        "type": "address"
+	      },
+	      {
+	        "internalType": "address",
+	        "name": "delegatorAddress",
+	        "type": "address"
+	      }
+	    ],
+	    "name": "getDelegation",
+	    "outputs": [
+	      {
+	        "internalType": "uint256",
+	        "name": "amount",
+	        "type": "uint256"
+	      }
+	    ],
+	    "stateMutability": "view",
+	    "type": "function"
+	  },
+	  {
+	    "inputs": [
+	      {
+	        "internalType": "address",
+	        "name": "validatorAddress",
+	        "type": "address"
+	      },
+	      {
+	        "internalType": "address",
+	        "name": "delegatorAddress",
+	        "type": "address"
+	      }
+	    ],
+	    "name": "getPendingReward",
+	    "outputs": [
+	      {
+	        "internalType": "uint256",
+	        "name": "reward",
+	        "type": "uint256"
+	      }
+	    ],
+	    "stateMutability": "view",
+	    "type": "function"
+	  },
+	  {
+	    "inputs": [
+	      {
+	        "internalType": "address",
+	        "name": "validatorAddress",
+	        "type": "address"
+	      },
+	      {
+	        "internalType": "address",
+	        "name": "delegatorAddress",
+	        "type": "address"
+	      }
+	    ],
+	    "name": "getUndelegations",
+	    "outputs": [
+	      {
+	        "internalType": "uint256[]",
+	        "name": "amounts",
+	        "type": "uint256[]"
+	      },
+	      {
+	        "internalType": "uint256[]",
+	        "name": "epochs",
+	        "type": "uint256[]"
+	      }
+	    ],
+	    "stateMutability": "view",
+	    "type": "function"
+	  }
+	]`
+	var err error
+	abiStakingQuery, err = abi.JSON(strings.NewReader(stakingQueryABIJSON))
+	if err != nil {
+		// means an error in the code
+		panic("Invalid staking query ABI JSON")
+	}
+}
+
+type stakingQueryPrecompile struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+//
+// Every query reads the validator, whose cost grows with its delegations.
+// Only the base price is charged here, before the validator is read; the
+// price per delegation is charged by RunStateful once it is.
+func (c *stakingQueryPrecompile) RequiredGas(
+	evm *EVM,
+	contract *Contract,
+	input []byte,
+) (uint64, error) {
+	return params.StakingQueryGas, nil
+}
+
+// RunStateful runs the actual contract (that is it performs the query)
+// a query about an address which is not a validator, or about a delegator
+// which has no delegation with the validator, returns the zero values
+func (c *stakingQueryPrecompile) RunStateful(
+	evm *EVM,
+	contract *Contract,
+	input []byte,
+) ([]byte, error) {
+	if evm.Context.ShardID != shard.BeaconChainShardID {
+		return nil, errors.New("Staking not supported on this shard")
+	}
+	validatorAddress, delegatorAddress, method, err := parseStakingQuery(input)
+	if err != nil {
+		return nil, err
+	}
+	wrapper, err := stakingQueryValidator(evm, validatorAddress)
+	if err != nil {
+		return nil, err
+	}
+	if wrapper != nil {
+		if !contract.UseGas(uint64(len(wrapper.Delegations)) * params.StakingQueryPerDelegationGas) {
+			return nil, ErrOutOfGas
+		}
+	}
+	var delegation *stakingTypes.Delegation
+	if wrapper != nil && method.Name != "getValidatorStatus" && method.Name != "getValidatorTotalDelegation" {
+		for i := range wrapper.Delegations {
+			if wrapper.Delegations[i].DelegatorAddress == delegatorAddress {
+				delegation = &wrapper.Delegations[i]
+				break
+			}
+		}
+	}
+
+	switch method.Name {
+	case "getValidatorStatus":
+		status := effective.Nil
+		if wrapper != nil {
+			status = wrapper.Status
+		}
+		return method.Outputs.Pack(uint8(status))
+	case "getValidatorTotalDelegation":
+		total := big.NewInt(0)
+		if wrapper != nil {
+			total = wrapper.TotalDelegation()
+		}
+		return method.Outputs.Pack(total)
+	case "getDelegation":
+		amount := big.NewInt(0)
+		if delegation != nil {
+			amount = delegation.Amount
+		}
+		return method.Outputs.Pack(amount)
+	case "getPendingReward":
+		reward := big.NewInt(0)
+		if delegation != nil {
+			reward = delegation.Reward
+		}
+		return method.Outputs.Pack(reward)
+	case "getUndelegations":
+		amounts, epochs := []*big.Int{}, []*big.Int{}
+		if delegation != nil {
+			for _, undelegation := range delegation.Undelegations {
+				amounts = append(amounts, undelegation.Amount)
+				epochs = append(epochs, undelegation.Epoch)
+			}
+		}
+		return method.Outputs.Pack(amounts, epochs)
+	default:
+		return nil, errors.New("[StakingQueryPrecompile] Invalid method name from ABI selector")
+	}
+}
+
+// stakingQueryValidator returns the validator at the address, or nil if the
+// address is not a validator
+// the original is returned since it is only read, never altered
+func stakingQueryValidator(evm *EVM, address common.Address) (*stakingTypes.ValidatorWrapper, error) {
+	if !evm.StateDB.IsValidator(address) {
+		return nil, nil
+	}
+	return evm.StateDB.ValidatorWrapper(address, true, false)
+}
+
+// parseStakingQuery does a simple parse with only data types validation
+// the delegator address is left empty for the validator queries
+func parseStakingQuery(input []byte) (common.Address, common.Address, *abi.Method, error) {
+	method, err := abiStakingQuery.MethodById(input)
+	if err != nil {
+		return common.Address{}, common.Address{}, nil, err
+	}
+	input = input[4:]
+	args := map[string]interface{}{}
+	if err = method.Inputs.UnpackIntoMap(args, input); err != nil {
+		return common.Address{}, common.Address{}, nil, err
+	}
+	validatorAddress, err := abi.ParseAddressFromKey(args, "validatorAddress")
+	if err != nil {
+		return common.Address{}, common.Address{}, nil, err
+	}
+	var delegatorAddress common.Address
+	if _, ok := args["delegatorAddress"]; ok {
+		if delegatorAddress, err = abi.ParseAddressFromKey(args, "delegatorAddress"); err != nil {
+			return common.Address{}, common.Address{}, nil, err
+		}
+	}
+	return validatorAddress, delegatorAddress, method, nil
+}
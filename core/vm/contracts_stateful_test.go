@@ -0,0 +1,210 @@
+package vm
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/internal/params"
+	stakingTypes "github.com/harmony-one/harmony/staking/types"
+	staketest "github.com/harmony-one/harmony/staking/types/test"
+)
+
+type statefulPrecompileTest struct {
+	input, expected []byte
+	name            string
+	expectedError   error
+	shardID         uint32
+}
+
+var (
+	queryValidatorAddress = common.HexToAddress("0x1337")
+	queryDelegatorAddress = common.HexToAddress("0x1338")
+)
+
+func mustPackStakingQuery(name string, args ...interface{}) []byte {
+	input, err := abiStakingQuery.Pack(name, args...)
+	if err != nil {
+		panic(err)
+	}
+	return input
+}
+
+func mustPackStakingQueryOutputs(name string, values ...interface{}) []byte {
+	output, err := abiStakingQuery.Methods[name].Outputs.Pack(values...)
+	if err != nil {
+		panic(err)
+	}
+	return output
+}
+
+func testStakingQueryPrecompile(test statefulPrecompileTest, t *testing.T) {
+	t.Run(test.name, func(t *testing.T) {
+		db, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+		if err != nil {
+			t.Fatalf("Error while initializing state %s", err)
+		}
+		wrapper := staketest.GetDefaultValidatorWrapperWithAddr(queryValidatorAddress, nil)
+		wrapper.Delegations = append(wrapper.Delegations, stakingTypes.Delegation{
+			DelegatorAddress: queryDelegatorAddress,
+			Amount:           big.NewInt(1000),
+			Reward:           big.NewInt(10),
+			Undelegations: stakingTypes.Undelegations{
+				{Amount: big.NewInt(100), Epoch: big.NewInt(5)},
+				{Amount: big.NewInt(200), Epoch: big.NewInt(6)},
+			},
+		})
+		encoded, err := rlp.EncodeToBytes(wrapper)
+		if err != nil {
+			t.Fatal(err)
+		}
+		db.SetCode(queryValidatorAddress, encoded, true)
+		db.SetValidatorFlag(queryValidatorAddress)
+
+		env := NewEVM(Context{ShardID: test.shardID}, db, params.TestChainConfig, Config{})
+		p := &stakingQueryPrecompile{}
+		contract := NewContract(AccountRef(common.HexToAddress("1339")), AccountRef(common.HexToAddress("fa")), new(big.Int), 0)
+		gas, err := p.RequiredGas(env, contract, test.input)
+		if err != nil {
+			t.Error(err)
+		}
+		contract.Gas = gas + uint64(len(wrapper.Delegations))*params.StakingQueryPerDelegationGas
+		if res, err := RunStatefulPrecompiledContract(p, env, contract, test.input); err != nil {
+			if test.expectedError != nil {
+				if test.expectedError.Error() != err.Error() {
+					t.Errorf("Expected error %v, got %v", test.expectedError, err)
+				}
+			} else {
+				t.Error(err)
+			}
+		} else {
+			if test.expectedError != nil {
+				t.Errorf("Expected an error %v but instead got result %v", test.expectedError, res)
+			}
+			if !bytes.Equal(res, test.expected) {
+				t.Errorf("Expected %x, got %x", test.expected, res)
+			}
+		}
+	})
+}
+
+func TestStakingQueryPrecompile(t *testing.T) {
+	// built here since the ABI is only parsed by init
+	for _, test := range stakingQueryPrecompileTests() {
+		testStakingQueryPrecompile(test, t)
+	}
+}
+
+func TestStakingQueryPrecompileGas(t *testing.T) {
+	db, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	if err != nil {
+		t.Fatalf("Error while initializing state %s", err)
+	}
+	wrapper := staketest.GetDefaultValidatorWrapperWithAddr(queryValidatorAddress, nil)
+	for i := 0; i < 10; i++ {
+		wrapper.Delegations = append(wrapper.Delegations, stakingTypes.NewDelegation(queryDelegatorAddress, big.NewInt(1)))
+	}
+	encoded, err := rlp.EncodeToBytes(wrapper)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.SetCode(queryValidatorAddress, encoded, true)
+	db.SetValidatorFlag(queryValidatorAddress)
+	env := NewEVM(Context{}, db, params.TestChainConfig, Config{})
+	p := &stakingQueryPrecompile{}
+	input := mustPackStakingQuery("getValidatorTotalDelegation", queryValidatorAddress)
+
+	// the validator is only read once the base price is paid, and the price
+	// per delegation is charged after
+	gas, err := p.RequiredGas(env, nil, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gas != params.StakingQueryGas {
+		t.Errorf("Expected the base gas %d, got %d", params.StakingQueryGas, gas)
+	}
+	perDelegations := uint64(len(wrapper.Delegations)) * params.StakingQueryPerDelegationGas
+	contract := NewContract(AccountRef(common.HexToAddress("1339")), AccountRef(common.HexToAddress("fa")), new(big.Int), gas+perDelegations-1)
+	if _, err := RunStatefulPrecompiledContract(p, env, contract, input); err != ErrOutOfGas {
+		t.Errorf("Expected %v, got %v", ErrOutOfGas, err)
+	}
+	contract = NewContract(AccountRef(common.HexToAddress("1339")), AccountRef(common.HexToAddress("fa")), new(big.Int), gas+perDelegations)
+	if _, err := RunStatefulPrecompiledContract(p, env, contract, input); err != nil {
+		t.Fatal(err)
+	}
+	if contract.Gas != 0 {
+		t.Errorf("Expected all the gas used, %d left", contract.Gas)
+	}
+}
+
+func stakingQueryPrecompileTests() []statefulPrecompileTest {
+	return []statefulPrecompileTest{
+		{
+			input:         []byte{109, 107, 47, 120},
+			expectedError: errors.New("no method with id: 0x6d6b2f78"),
+			name:          "badQueryKind",
+		},
+		{
+			input:         []byte{0, 0},
+			expectedError: errors.New("data too short (2 bytes) for abi method lookup"),
+			name:          "malformedInput",
+		},
+		{
+			input:         mustPackStakingQuery("getValidatorStatus", queryValidatorAddress),
+			expectedError: errors.New("Staking not supported on this shard"),
+			name:          "wrongShard",
+			shardID:       1,
+		},
+		{
+			input:    mustPackStakingQuery("getValidatorStatus", queryValidatorAddress),
+			expected: mustPackStakingQueryOutputs("getValidatorStatus", uint8(1)),
+			name:     "validatorStatus",
+		},
+		{
+			input:    mustPackStakingQuery("getValidatorStatus", queryDelegatorAddress),
+			expected: mustPackStakingQueryOutputs("getValidatorStatus", uint8(0)),
+			name:     "notValidatorStatus",
+		},
+		{
+			input: mustPackStakingQuery("getValidatorTotalDelegation", queryValidatorAddress),
+			expected: mustPackStakingQueryOutputs("getValidatorTotalDelegation",
+				new(big.Int).Add(staketest.DefaultDelAmount, big.NewInt(1000))),
+			name: "validatorTotalDelegation",
+		},
+		{
+			input:    mustPackStakingQuery("getDelegation", queryValidatorAddress, queryDelegatorAddress),
+			expected: mustPackStakingQueryOutputs("getDelegation", big.NewInt(1000)),
+			name:     "delegation",
+		},
+		{
+			input:    mustPackStakingQuery("getDelegation", queryValidatorAddress, common.HexToAddress("0x1339")),
+			expected: mustPackStakingQueryOutputs("getDelegation", big.NewInt(0)),
+			name:     "noDelegation",
+		},
+		{
+			input:    mustPackStakingQuery("getPendingReward", queryValidatorAddress, queryDelegatorAddress),
+			expected: mustPackStakingQueryOutputs("getPendingReward", big.NewInt(10)),
+			name:     "pendingReward",
+		},
+		{
+			input: mustPackStakingQuery("getUndelegations", queryValidatorAddress, queryDelegatorAddress),
+			expected: mustPackStakingQueryOutputs("getUndelegations",
+				[]*big.Int{big.NewInt(100), big.NewInt(200)},
+				[]*big.Int{big.NewInt(5), big.NewInt(6)},
+			),
+			name: "undelegations",
+		},
+		{
+			input: mustPackStakingQuery("getUndelegations", queryDelegatorAddress, queryDelegatorAddress),
+			expected: mustPackStakingQueryOutputs("getUndelegations",
+				[]*big.Int{}, []*big.Int{},
+			),
+			name: "notValidatorUndelegations",
+		},
+	}
+}
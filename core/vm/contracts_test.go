@@ -18,12 +18,16 @@ package vm
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"math/big"
 	"reflect"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/internal/params"
 )
 
 // precompiledTest defines the input/output pairs for precompiled contract tests.
@@ -399,7 +403,7 @@ var blake2FTests = []precompiledTest{
 }
 
 func testPrecompiled(addr string, test precompiledTest, t *testing.T) {
-	p := PrecompiledContractsSHA3FIPS[common.HexToAddress(addr)]
+	p := PrecompiledContractsBLS[common.HexToAddress(addr)]
 	in := common.Hex2Bytes(test.input)
 	contract := NewContract(AccountRef(common.HexToAddress("1337")),
 		nil, new(big.Int), p.RequiredGas(in))
@@ -418,7 +422,7 @@ func testPrecompiled(addr string, test precompiledTest, t *testing.T) {
 }
 
 func testPrecompiledOOG(addr string, test precompiledTest, t *testing.T) {
-	p := PrecompiledContractsSHA3FIPS[common.HexToAddress(addr)]
+	p := PrecompiledContractsBLS[common.HexToAddress(addr)]
 	in := common.Hex2Bytes(test.input)
 	contract := NewContract(AccountRef(common.HexToAddress("1337")),
 		nil, new(big.Int), p.RequiredGas(in)-1)
@@ -436,7 +440,7 @@ func testPrecompiledOOG(addr string, test precompiledTest, t *testing.T) {
 }
 
 func testPrecompiledFailure(addr string, test precompiledFailureTest, t *testing.T) {
-	p := PrecompiledContractsSHA3FIPS[common.HexToAddress(addr)]
+	p := PrecompiledContractsBLS[common.HexToAddress(addr)]
 	in := common.Hex2Bytes(test.input)
 	contract := NewContract(AccountRef(common.HexToAddress("31337")),
 		nil, new(big.Int), p.RequiredGas(in))
@@ -458,7 +462,7 @@ func benchmarkPrecompiled(addr string, test precompiledTest, bench *testing.B) {
 	if test.noBenchmark {
 		return
 	}
-	p := PrecompiledContractsSHA3FIPS[common.HexToAddress(addr)]
+	p := PrecompiledContractsBLS[common.HexToAddress(addr)]
 	in := common.Hex2Bytes(test.input)
 	reqGas := p.RequiredGas(in)
 	contract := NewContract(AccountRef(common.HexToAddress("1337")),
@@ -610,6 +614,113 @@ func TestPrecompileBlake2FMalformedInput(t *testing.T) {
 	}
 }
 
+func TestPrecompiledBLS12381G1Add(t *testing.T)      { testJson("blsG1Add", "0b", t) }
+func TestPrecompiledBLS12381G1MultiExp(t *testing.T) { testJson("blsG1MultiExp", "0c", t) }
+func TestPrecompiledBLS12381G2Add(t *testing.T)      { testJson("blsG2Add", "0d", t) }
+func TestPrecompiledBLS12381G2MultiExp(t *testing.T) { testJson("blsG2MultiExp", "0e", t) }
+func TestPrecompiledBLS12381Pairing(t *testing.T)    { testJson("blsPairing", "0f", t) }
+func TestPrecompiledBLS12381MapG1(t *testing.T)      { testJson("blsMapG1", "10", t) }
+func TestPrecompiledBLS12381MapG2(t *testing.T)      { testJson("blsMapG2", "11", t) }
+
+func BenchmarkPrecompiledBLS12381G1Add(b *testing.B)      { benchJson("blsG1Add", "0b", b) }
+func BenchmarkPrecompiledBLS12381G1MultiExp(b *testing.B) { benchJson("blsG1MultiExp", "0c", b) }
+func BenchmarkPrecompiledBLS12381G2Add(b *testing.B)      { benchJson("blsG2Add", "0d", b) }
+func BenchmarkPrecompiledBLS12381G2MultiExp(b *testing.B) { benchJson("blsG2MultiExp", "0e", b) }
+func BenchmarkPrecompiledBLS12381Pairing(b *testing.B)    { benchJson("blsPairing", "0f", b) }
+func BenchmarkPrecompiledBLS12381MapG1(b *testing.B)      { benchJson("blsMapG1", "10", b) }
+func BenchmarkPrecompiledBLS12381MapG2(b *testing.B)      { benchJson("blsMapG2", "11", b) }
+
+// Failure tests
+func TestPrecompiledBLS12381G1AddFail(t *testing.T)      { testJsonFail("blsG1Add", "0b", t) }
+func TestPrecompiledBLS12381G1MultiExpFail(t *testing.T) { testJsonFail("blsG1MultiExp", "0c", t) }
+func TestPrecompiledBLS12381G2AddFail(t *testing.T)      { testJsonFail("blsG2Add", "0d", t) }
+func TestPrecompiledBLS12381G2MultiExpFail(t *testing.T) { testJsonFail("blsG2MultiExp", "0e", t) }
+func TestPrecompiledBLS12381PairingFail(t *testing.T)    { testJsonFail("blsPairing", "0f", t) }
+func TestPrecompiledBLS12381MapG1Fail(t *testing.T)      { testJsonFail("blsMapG1", "10", t) }
+func TestPrecompiledBLS12381MapG2Fail(t *testing.T)      { testJsonFail("blsMapG2", "11", t) }
+
+func testJson(name, addr string, t *testing.T) {
+	tests, err := loadJson(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range tests {
+		testPrecompiled(addr, test, t)
+	}
+}
+
+func testJsonFail(name, addr string, t *testing.T) {
+	tests, err := loadJsonFail(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range tests {
+		testPrecompiledFailure(addr, test, t)
+	}
+}
+
+func benchJson(name, addr string, b *testing.B) {
+	tests, err := loadJson(name)
+	if err != nil {
+		b.Fatal(err)
+	}
+	for _, test := range tests {
+		benchmarkPrecompiled(addr, test, b)
+	}
+}
+
+// loadJson loads the official test vectors of a precompile from testdata
+func loadJson(name string) ([]precompiledTest, error) {
+	data, err := ioutil.ReadFile(fmt.Sprintf("testdata/precompiles/%v.json", name))
+	if err != nil {
+		return nil, err
+	}
+	var testcases []struct {
+		Input, Expected string
+		Name            string
+		NoBenchmark     bool
+	}
+	if err := json.Unmarshal(data, &testcases); err != nil {
+		return nil, err
+	}
+	tests := make([]precompiledTest, len(testcases))
+	for i, tc := range testcases {
+		tests[i] = precompiledTest{
+			input:       tc.Input,
+			expected:    tc.Expected,
+			name:        tc.Name,
+			noBenchmark: tc.NoBenchmark,
+		}
+	}
+	return tests, nil
+}
+
+// loadJsonFail loads the official failure test vectors of a precompile from
+// testdata
+func loadJsonFail(name string) ([]precompiledFailureTest, error) {
+	data, err := ioutil.ReadFile(fmt.Sprintf("testdata/precompiles/fail-%v.json", name))
+	if err != nil {
+		return nil, err
+	}
+	var testcases []struct {
+		Input         string
+		ExpectedError string
+		Name          string
+	}
+	if err := json.Unmarshal(data, &testcases); err != nil {
+		return nil, err
+	}
+	tests := make([]precompiledFailureTest, len(testcases))
+	for i, tc := range testcases {
+		tests[i] = precompiledFailureTest{
+			input:         tc.Input,
+			expectedError: errors.New(tc.ExpectedError),
+			name:          tc.Name,
+		}
+	}
+	return tests, nil
+}
+
 // EcRecover test vectors
 var ecRecoverTests = []precompiledTest{
 	{
@@ -717,3 +828,48 @@ func TestPrecompiledEcrecoverPublicKey(t *testing.T) {
 	}
 
 }
+
+func TestActivePrecompiles(t *testing.T) {
+	tests := []struct {
+		rules  params.Rules
+		exp    []common.Address
+		notExp []common.Address
+	}{
+		{
+			rules:  params.Rules{},
+			exp:    []common.Address{common.BytesToAddress([]byte{1})},
+			notExp: []common.Address{common.BytesToAddress([]byte{250}), common.BytesToAddress([]byte{252})},
+		},
+		{
+			rules: params.Rules{
+				IsS3: true, IsIstanbul: true, IsVRF: true, IsSHA3: true,
+				IsStakingPrecompile: true, IsBLSPrecompile: true, IsStakingQueryPrecompile: true,
+			},
+			exp: []common.Address{
+				common.BytesToAddress([]byte{1}),
+				common.BytesToAddress([]byte{11}),
+				common.BytesToAddress([]byte{17}),
+				common.BytesToAddress([]byte{250}),
+				common.BytesToAddress([]byte{252}),
+				common.BytesToAddress([]byte{255}),
+			},
+			notExp: []common.Address{common.BytesToAddress([]byte{10}), common.BytesToAddress([]byte{18})},
+		},
+	}
+	for i, test := range tests {
+		active := make(map[common.Address]bool)
+		for _, addr := range ActivePrecompiles(test.rules) {
+			active[addr] = true
+		}
+		for _, addr := range test.exp {
+			if !active[addr] {
+				t.Errorf("Test %v: precompile %x not active", i, addr)
+			}
+		}
+		for _, addr := range test.notExp {
+			if active[addr] {
+				t.Errorf("Test %v: precompile %x active", i, addr)
+			}
+		}
+	}
+}
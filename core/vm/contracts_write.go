@@ -9,6 +9,7 @@ import (
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/harmony-one/harmony/accounts/abi"
 	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/internal/params"
 	"github.com/harmony-one/harmony/shard"
 	"github.com/harmony-one/harmony/staking"
 	stakingTypes "github.com/harmony-one/harmony/staking/types"
@@ -78,6 +79,7 @@ func (c *stakingPrecompile) RequiredGas(
 	// if invalid data or invalid shard
 	// set payload to blank and charge minimum gas
 	var payload []byte = make([]byte, 0)
+	isValidatorCreation := false
 	// availability of staking and precompile has already been checked
 	if evm.Context.ShardID == shard.BeaconChainShardID {
 		// check that input is well formed
@@ -85,6 +87,9 @@ func (c *stakingPrecompile) RequiredGas(
 		// and that we are only trying to perform staking tx
 		// on behalf of the correct entity
 		stakeMsg, err := staking.ParseStakeMsg(contract.Caller(), input)
+		if err == nil {
+			err = checkStakeMsgAvailable(evm, stakeMsg)
+		}
 		if err == nil {
 			// otherwise charge similar to a regular staking tx
 			if migrationMsg, ok := stakeMsg.(*stakingTypes.MigrationMsg); ok {
@@ -96,6 +101,7 @@ func (c *stakingPrecompile) RequiredGas(
 				)
 			} else if encoded, err := rlp.EncodeToBytes(stakeMsg); err == nil {
 				payload = encoded
+				_, isValidatorCreation = stakeMsg.(*stakingTypes.CreateValidator)
 			}
 		}
 	}
@@ -104,7 +110,8 @@ func (c *stakingPrecompile) RequiredGas(
 		false,                                   // contractCreation
 		evm.ChainConfig().IsS3(evm.EpochNumber), // homestead
 		evm.ChainConfig().IsIstanbul(evm.EpochNumber), // istanbul
-		false, // isValidatorCreation
+		isValidatorCreation,
+		false, // shanghai
 	); err != nil {
 		return 0, err // ErrOutOfGas occurs when gas payable > uint64
 	} else {
@@ -117,7 +124,7 @@ func (c *stakingPrecompile) RunWriteCapable(
 	evm *EVM,
 	contract *Contract,
 	input []byte,
-) ([]byte, error) {
+) (ret []byte, err error) {
 	if evm.Context.ShardID != shard.BeaconChainShardID {
 		return nil, errors.New("Staking not supported on this shard")
 	}
@@ -125,6 +132,23 @@ func (c *stakingPrecompile) RunWriteCapable(
 	if err != nil {
 		return nil, err
 	}
+	if err := checkStakeMsgAvailable(evm, stakeMsg); err != nil {
+		return nil, err
+	}
+
+	// the wrappers of the contract validators are kept in chunks of their
+	// storage, the chunks written are charged like the storage writes
+	set, reset := evm.StateDB.ContractValidatorChunkWrites()
+	defer func() {
+		if err != nil {
+			return
+		}
+		newSet, newReset := evm.StateDB.ContractValidatorChunkWrites()
+		gas := (newSet-set)*params.SstoreSetGas + (newReset-reset)*params.SstoreResetGas
+		if !contract.UseGas(gas) {
+			ret, err = nil, ErrOutOfGas
+		}
+	}()
 
 	var rosettaBlockTracer RosettaTracer
 	if tmpTracker, ok := evm.vmConfig.Tracer.(RosettaTracer); ok {
@@ -145,6 +169,34 @@ func (c *stakingPrecompile) RunWriteCapable(
 	if collectRewards, ok := stakeMsg.(*stakingTypes.CollectRewards); ok {
 		return nil, evm.CollectRewards(evm.StateDB, rosettaBlockTracer, collectRewards)
 	}
+	if redelegate, ok := stakeMsg.(*stakingTypes.Redelegate); ok {
+		if err := evm.Redelegate(evm.StateDB, rosettaBlockTracer, redelegate); err != nil {
+			return nil, err
+		} else {
+			evm.StakeMsgs = append(evm.StakeMsgs, redelegate)
+			return nil, nil
+		}
+	}
+	if editDelegation, ok := stakeMsg.(*stakingTypes.EditDelegation); ok {
+		return nil, evm.EditDelegation(evm.StateDB, rosettaBlockTracer, editDelegation)
+	}
+	if createValidator, ok := stakeMsg.(*stakingTypes.CreateValidator); ok {
+		// only transactions are sent by accounts without code, any other caller
+		// is a contract, even if its code is not set yet while it is deployed.
+		// The validator of a contract is kept apart from its code.
+		if contract.Caller() != evm.Origin && !evm.StateDB.IsValidator(createValidator.ValidatorAddress) {
+			evm.StateDB.SetContractValidatorFlag(createValidator.ValidatorAddress)
+		}
+		if err := evm.CreateValidator(evm.StateDB, rosettaBlockTracer, createValidator); err != nil {
+			return nil, err
+		} else {
+			evm.StakeMsgs = append(evm.StakeMsgs, createValidator)
+			return nil, nil
+		}
+	}
+	if editValidator, ok := stakeMsg.(*stakingTypes.EditValidator); ok {
+		return nil, evm.EditValidator(evm.StateDB, rosettaBlockTracer, editValidator)
+	}
 	// Migrate is not supported in precompile and will be done in a batch hard fork
 	//if migrationMsg, ok := stakeMsg.(*stakingTypes.MigrationMsg); ok {
 	//	stakeMsgs, err := evm.MigrateDelegations(evm.StateDB, migrationMsg)
@@ -164,6 +216,18 @@ func (c *stakingPrecompile) RunWriteCapable(
 	return nil, errors.New("[StakingPrecompile] Received incompatible stakeMsg from staking.ParseStakeMsg")
 }
 
+// checkStakeMsgAvailable returns an error if the stake msg is not yet
+// available through the precompile
+func checkStakeMsgAvailable(evm *EVM, stakeMsg interface{}) error {
+	switch stakeMsg.(type) {
+	case *stakingTypes.CreateValidator, *stakingTypes.EditValidator:
+		if !evm.chainRules.IsValidatorPrecompile {
+			return errors.New("[StakingPrecompile] Validator directives not supported before ValidatorPrecompileEpoch")
+		}
+	}
+	return nil
+}
+
 var abiCrossShardXfer abi.ABI
 
 func init() {
@@ -242,7 +306,8 @@ func (c *crossShardXferPrecompile) RunWriteCapable(
 		return nil, err
 	}
 	// validate not a contract (toAddress can still be a contract)
-	if len(evm.StateDB.GetCode(fromAddress)) > 0 && !evm.IsValidator(evm.StateDB, fromAddress) {
+	if len(evm.StateDB.GetCode(fromAddress)) > 0 &&
+		(!evm.IsValidator(evm.StateDB, fromAddress) || evm.StateDB.IsContractValidator(fromAddress)) {
 		return nil, errors.New("cross shard xfer not yet implemented for contracts")
 	}
 	// can't have too many shards
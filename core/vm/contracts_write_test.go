@@ -4,15 +4,21 @@ import (
 	"bytes"
 	"errors"
 	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/harmony-one/harmony/accounts/abi"
 	"github.com/harmony-one/harmony/core/rawdb"
 	"github.com/harmony-one/harmony/core/state"
 	"github.com/harmony-one/harmony/core/types"
 	"github.com/harmony-one/harmony/internal/params"
+	"github.com/harmony-one/harmony/staking"
 	stakingTypes "github.com/harmony-one/harmony/staking/types"
+	staketest "github.com/harmony-one/harmony/staking/types/test"
 )
 
 type writeCapablePrecompileTest struct {
@@ -52,6 +58,18 @@ func EditValidatorFn() EditValidatorFunc {
 	}
 }
 
+func RedelegateFn() RedelegateFunc {
+	return func(db StateDB, rosettaTracer RosettaTracer, redelegate *stakingTypes.Redelegate) error {
+		return nil
+	}
+}
+
+func EditDelegationFn() EditDelegationFunc {
+	return func(db StateDB, rosettaTracer RosettaTracer, editDelegation *stakingTypes.EditDelegation) error {
+		return nil
+	}
+}
+
 //func MigrateDelegationsFn() MigrateDelegationsFunc {
 //	return func(db StateDB, migrationMsg *stakingTypes.MigrationMsg) ([]interface{}, error) {
 //		return nil, nil
@@ -97,14 +115,23 @@ func testStakingPrecompile(test writeCapablePrecompileTest, t *testing.T) {
 		Undelegate:      UndelegateFn(),
 		CreateValidator: CreateValidatorFn(),
 		EditValidator:   EditValidatorFn(),
+		Redelegate:      RedelegateFn(),
+		EditDelegation:  EditDelegationFn(),
 		ShardID:         0,
 		//MigrateDelegations:    MigrateDelegationsFn(),
 		CalculateMigrationGas: CalculateMigrationGasFn(),
-	}, nil, params.TestChainConfig, Config{})
+	}, newStakingPrecompileTestState(), params.TestChainConfig, Config{})
 	p := &stakingPrecompile{}
 	testWriteCapablePrecompile(test, t, env, p)
 }
 
+// newStakingPrecompileTestState returns an empty state, the staking precompile
+// counts the writes of the contract validators in it
+func newStakingPrecompileTestState() StateDB {
+	db, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	return db
+}
+
 var StakingPrecompileTests = []writeCapablePrecompileTest{
 	{
 		input:         []byte{109, 107, 47, 120},
@@ -205,6 +232,427 @@ func TestStakingPrecompiles(t *testing.T) {
 	}
 }
 
+// mustPackStakingDirective packs the call to the staking precompile, the
+// selector is computed from the name and the argument types
+func mustPackStakingDirective(name string, argTypes []string, args ...interface{}) []byte {
+	arguments := abi.Arguments{}
+	for _, argType := range argTypes {
+		typ, err := abi.NewType(argType, "", nil)
+		if err != nil {
+			panic(err)
+		}
+		arguments = append(arguments, abi.Argument{Type: typ})
+	}
+	packed, err := arguments.Pack(args...)
+	if err != nil {
+		panic(err)
+	}
+	selector := crypto.Keccak256([]byte(name + "(" + strings.Join(argTypes, ",") + ")"))[:4]
+	return append(selector, packed...)
+}
+
+func TestStakingPrecompileValidatorDirectives(t *testing.T) {
+	validatorAddress := common.HexToAddress("1337")
+	createValidator := mustPackStakingDirective("CreateValidator",
+		[]string{"address", "string", "string", "string", "string", "string",
+			"string", "string", "string", "uint256", "uint256", "bytes[]", "bytes[]", "uint256"},
+		validatorAddress, "Alice", "alice", "alice.harmony.one", "Bob", "Don't mess with me!!!",
+		"0.1", "0.9", "0.05", big.NewInt(10000), big.NewInt(100000),
+		[][]byte{make([]byte, 48)}, [][]byte{make([]byte, 96)}, big.NewInt(10000),
+	)
+	editValidator := mustPackStakingDirective("EditValidator",
+		[]string{"address", "string", "string", "string", "string", "string",
+			"string", "uint256", "uint256", "bytes", "bytes", "bytes", "uint8"},
+		validatorAddress, "", "", "", "", "", "", big.NewInt(0), big.NewInt(0),
+		[]byte{}, []byte{}, []byte{}, uint8(0),
+	)
+	newEnv := func(validatorPrecompileEpoch *big.Int) *EVM {
+		db, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+		if err != nil {
+			t.Fatalf("Error while initializing state %s", err)
+		}
+		config := *params.TestChainConfig
+		config.ValidatorPrecompileEpoch = validatorPrecompileEpoch
+		return NewEVM(Context{
+			CreateValidator: CreateValidatorFn(),
+			EditValidator:   EditValidatorFn(),
+			Origin:          validatorAddress,
+			EpochNumber:     big.NewInt(0),
+			ShardID:         0,
+		}, db, &config, Config{})
+	}
+	p := &stakingPrecompile{}
+
+	testWriteCapablePrecompile(writeCapablePrecompileTest{
+		input:         editValidator,
+		expectedError: errors.New("[StakingPrecompile] Validator directives not supported before ValidatorPrecompileEpoch"),
+		name:          "editValidatorBeforeEpoch",
+	}, t, newEnv(params.EpochTBD), p)
+	testWriteCapablePrecompile(writeCapablePrecompileTest{
+		input: editValidator,
+		name:  "editValidatorSuccess",
+	}, t, newEnv(big.NewInt(0)), p)
+
+	env := newEnv(big.NewInt(0))
+	testWriteCapablePrecompile(writeCapablePrecompileTest{
+		input: createValidator,
+		name:  "createValidatorSuccess",
+	}, t, env, p)
+	if len(env.StakeMsgs) != 1 {
+		t.Errorf("Expected 1 stake msg, got %d", len(env.StakeMsgs))
+	}
+	if env.StateDB.IsContractValidator(validatorAddress) {
+		t.Errorf("Expected the validator of the transaction sender not to be a contract")
+	}
+	contract := NewContract(AccountRef(validatorAddress), AccountRef(common.HexToAddress("1338")), new(big.Int), 0)
+	if gas, err := p.RequiredGas(env, contract, createValidator); err != nil {
+		t.Error(err)
+	} else if gas < params.TxGasValidatorCreation {
+		t.Errorf("Expected at least the validator creation gas %d, got %d", params.TxGasValidatorCreation, gas)
+	}
+
+	testStakingPrecompileContractValidator(t)
+}
+
+// stakingForwarderCode forwards its call data to the staking precompile and
+// reverts if the precompile fails
+var stakingForwarderCode = common.FromHex("36600060003760006000366000600060fc5af115601857005b60006000fd")
+
+// newStakingForwarderInitCode returns the init code of the forwarder, whose
+// constructor sends the directive to the staking precompile
+func newStakingForwarderInitCode(directive []byte) []byte {
+	return newStakingInitCode(directive, stakingForwarderCode)
+}
+
+// newStakingInitCode returns the init code of a contract with the given code,
+// whose constructor sends the directive to the staking precompile
+func newStakingInitCode(directive []byte, runtimeCode []byte) []byte {
+	size := []byte{byte(len(directive) >> 8), byte(len(directive))}
+	ctorSize := byte(46)
+	offset := int(ctorSize) + len(runtimeCode)
+	code := []byte{
+		0x61, size[0], size[1], // directive size
+		0x61, byte(offset >> 8), byte(offset), // directive offset
+		0x60, 0x00, 0x39, // CODECOPY the directive to memory
+		0x60, 0x00, 0x60, 0x00, // no return data
+		0x61, size[0], size[1], 0x60, 0x00, // the directive as input
+		0x60, 0x00, 0x60, 0xfc, 0x5a, 0xf1, // CALL the staking precompile
+		0x15, 0x60, 0x28, 0x57, // revert if it fails
+		0x60, byte(len(runtimeCode)), 0x60, ctorSize, 0x60, 0x00, 0x39, // CODECOPY the code
+		0x60, byte(len(runtimeCode)), 0x60, 0x00, 0xf3, // RETURN it
+		0x5b, 0x60, 0x00, 0x60, 0x00, 0xfd,
+	}
+	code = append(code, runtimeCode...)
+	return append(code, directive...)
+}
+
+// packContractCreateValidator packs the directive creating the validator of
+// the contract
+func packContractCreateValidator(contractAddress common.Address) []byte {
+	return mustPackStakingDirective("CreateValidator",
+		[]string{"address", "string", "string", "string", "string", "string",
+			"string", "string", "string", "uint256", "uint256", "bytes[]", "bytes[]", "uint256"},
+		contractAddress, "Alice", "alice", "alice.harmony.one", "Bob", "Don't mess with me!!!",
+		"0.1", "0.9", "0.05", big.NewInt(10000), big.NewInt(100000),
+		[][]byte{make([]byte, 48)}, [][]byte{make([]byte, 96)}, big.NewInt(10000),
+	)
+}
+
+// newContractValidatorContext returns the context of the transactions of the
+// deployer, which stores the validators as core does
+func newContractValidatorContext(deployer common.Address) Context {
+	return Context{
+		CanTransfer: func(StateDB, common.Address, *big.Int) bool { return true },
+		Transfer:    transfer,
+		IsValidator: func(db StateDB, addr common.Address) bool { return db.IsValidator(addr) },
+		CreateValidator: func(db StateDB, _ RosettaTracer, msg *stakingTypes.CreateValidator) error {
+			wrapper := staketest.GetDefaultValidatorWrapperWithAddr(msg.ValidatorAddress, msg.SlotPubKeys)
+			wrapper.Description = msg.Description
+			if err := db.UpdateValidatorWrapperWithRevert(wrapper.Address, &wrapper); err != nil {
+				return err
+			}
+			db.SetValidatorFlag(msg.ValidatorAddress)
+			return nil
+		},
+		EditValidator: func(db StateDB, _ RosettaTracer, msg *stakingTypes.EditValidator) error {
+			wrapper, err := db.ValidatorWrapper(msg.ValidatorAddress, false, true)
+			if err != nil {
+				return err
+			}
+			wrapper.Description.Name = msg.Description.Name
+			return db.UpdateValidatorWrapperWithRevert(wrapper.Address, wrapper)
+		},
+		Origin:      deployer,
+		GasLimit:    params.TxGasValidatorCreation * 10,
+		BlockNumber: big.NewInt(0),
+		EpochNumber: big.NewInt(0),
+		Time:        big.NewInt(0),
+	}
+}
+
+// testStakingPrecompileContractValidator creates the validator of a contract
+// from its constructor, when its code is not set yet, and edits it afterwards
+func testStakingPrecompileContractValidator(t *testing.T) {
+	deployer := common.HexToAddress("1339")
+	contractAddress := crypto.CreateAddress(deployer, 0)
+	createValidator := packContractCreateValidator(contractAddress)
+	editValidator := mustPackStakingDirective("EditValidator",
+		[]string{"address", "string", "string", "string", "string", "string",
+			"string", "uint256", "uint256", "bytes", "bytes", "bytes", "uint8"},
+		contractAddress, "Carol", "", "", "", "", "", big.NewInt(0), big.NewInt(0),
+		[]byte{}, []byte{}, []byte{}, uint8(0),
+	)
+
+	database := state.NewDatabase(rawdb.NewMemoryDatabase())
+	db, err := state.New(common.Hash{}, database, nil)
+	if err != nil {
+		t.Fatalf("Error while initializing state %s", err)
+	}
+	config := *params.TestChainConfig
+	config.ValidatorPrecompileEpoch = big.NewInt(0)
+	env := NewEVM(newContractValidatorContext(deployer), db, &config, Config{})
+
+	checkValidator := func(db StateDB, name string) {
+		t.Helper()
+		if !db.IsValidator(contractAddress) || !db.IsContractValidator(contractAddress) {
+			t.Fatalf("Expected the contract to be a validator")
+		}
+		if code := db.GetCode(contractAddress); !bytes.Equal(code, stakingForwarderCode) {
+			t.Errorf("Expected the code of the contract %x, got %x", stakingForwarderCode, code)
+		}
+		wrapper, err := db.ValidatorWrapper(contractAddress, true, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if wrapper.Address != contractAddress || wrapper.Description.Name != name {
+			t.Errorf("Expected the validator %s of %x, got %s of %x",
+				name, contractAddress, wrapper.Description.Name, wrapper.Address)
+		}
+	}
+
+	_, address, leftOverGas, err := env.Create(AccountRef(deployer), newStakingForwarderInitCode(createValidator),
+		params.TxGasValidatorCreation*10, new(big.Int))
+	if err != nil {
+		t.Fatalf("Expected the contract to create its validator, got %v", err)
+	}
+	if address != contractAddress {
+		t.Fatalf("Expected the contract at %x, got %x", contractAddress, address)
+	}
+	checkValidator(db, "Alice")
+	// the chunks of the wrapper are charged
+	if set, _ := db.ContractValidatorChunkWrites(); set == 0 {
+		t.Errorf("Expected the wrapper chunks to be written")
+	} else if used := params.TxGasValidatorCreation*10 - leftOverGas; used < set*params.SstoreSetGas {
+		t.Errorf("Expected at least %d gas for %d chunks, used %d", set*params.SstoreSetGas, set, used)
+	}
+
+	// the contract can't write the staking data kept in its storage
+	writer := common.HexToAddress("133a")
+	for _, key := range []common.Hash{
+		staking.IsValidatorKey,
+		staking.IsContractValidatorKey,
+		staking.ContractValidatorWrapperKey,
+		staking.ContractValidatorWrapperChunkKey(1),
+	} {
+		// SSTORE 1 at the key
+		code := append(append([]byte{0x60, 0x01, 0x7f}, key.Bytes()...), 0x55, 0x00)
+		db.SetCode(writer, code, false)
+		if _, _, err := env.Call(AccountRef(deployer), writer, nil, 100000, new(big.Int)); err != errReservedStorageKey {
+			t.Errorf("Expected the write of the reserved key %x to fail, got %v", key, err)
+		}
+	}
+
+	// the contract runs its code although it is a validator
+	if _, _, err := env.Call(AccountRef(deployer), contractAddress, editValidator,
+		params.TxGasValidatorCreation, new(big.Int)); err != nil {
+		t.Fatalf("Expected the contract to edit its validator, got %v", err)
+	}
+	checkValidator(db, "Carol")
+
+	// the code and the validator are both kept in the state
+	root, err := db.Commit(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	committed, err := state.New(root, database, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkValidator(committed, "Carol")
+}
+
+// reservedWriterCode writes 1 at the storage key in its call data, and
+// self destructs when called without data
+var reservedWriterCode = common.FromHex("3615600c57600160003555005b6000ff")
+
+// TestContractValidatorSameBlock creates the validator of a contract whose
+// code writes the reserved storage keys and self destructs, and runs the code
+// in the block of the creation
+func TestContractValidatorSameBlock(t *testing.T) {
+	deployer := common.HexToAddress("133c")
+	contractAddress := crypto.CreateAddress(deployer, 0)
+
+	database := state.NewDatabase(rawdb.NewMemoryDatabase())
+	db, err := state.New(common.Hash{}, database, nil)
+	if err != nil {
+		t.Fatalf("Error while initializing state %s", err)
+	}
+	config := *params.TestChainConfig
+	config.ValidatorPrecompileEpoch = big.NewInt(0)
+	env := NewEVM(newContractValidatorContext(deployer), db, &config, Config{})
+
+	if _, _, _, err := env.Create(AccountRef(deployer),
+		newStakingInitCode(packContractCreateValidator(contractAddress), reservedWriterCode),
+		params.TxGasValidatorCreation*10, new(big.Int)); err != nil {
+		t.Fatalf("Expected the contract to create its validator, got %v", err)
+	}
+	// the next transactions of the block
+	db.Finalise(true)
+	wrapper, err := db.ValidatorWrapper(contractAddress, true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := rlp.EncodeToBytes(wrapper)
+	if err != nil {
+		t.Fatal(err)
+	}
+	size := db.GetState(contractAddress, staking.ContractValidatorWrapperKey).Big().Uint64()
+	chunks := (size + common.HashLength - 1) / common.HashLength
+	if chunks == 0 {
+		t.Fatalf("Expected the wrapper chunks to be written")
+	}
+
+	for _, key := range []common.Hash{
+		staking.IsValidatorKey,
+		staking.IsContractValidatorKey,
+		staking.FirstElectionEpochKey,
+		staking.ContractValidatorWrapperKey,
+		staking.ContractValidatorWrapperChunkKey(0),
+		staking.ContractValidatorWrapperChunkKey(chunks - 1),
+		// past the end of the wrapper
+		staking.ContractValidatorWrapperChunkKey(chunks),
+	} {
+		if _, _, err := env.Call(AccountRef(deployer), contractAddress, key.Bytes(), 100000, new(big.Int)); err != errReservedStorageKey {
+			t.Errorf("Expected the write of the reserved key %x to fail, got %v", key, err)
+		}
+	}
+	if _, _, err := env.Call(AccountRef(deployer), contractAddress, nil, 100000, new(big.Int)); err != errValidatorSuicide {
+		t.Errorf("Expected the validator not to self destruct, got %v", err)
+	}
+	if db.HasSuicided(contractAddress) {
+		t.Errorf("Expected the validator not to be destructed")
+	}
+
+	// the validator and the code are kept as created
+	check := func(db StateDB) {
+		t.Helper()
+		if !db.IsValidator(contractAddress) || !db.IsContractValidator(contractAddress) {
+			t.Fatalf("Expected the contract to be a validator")
+		}
+		if code := db.GetCode(contractAddress); !bytes.Equal(code, reservedWriterCode) {
+			t.Errorf("Expected the code of the contract %x, got %x", reservedWriterCode, code)
+		}
+		wrapper, err := db.ValidatorWrapper(contractAddress, true, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, err := rlp.EncodeToBytes(wrapper); err != nil || !bytes.Equal(got, encoded) {
+			t.Errorf("Expected the validator unchanged %x, got %x", encoded, got)
+		}
+	}
+	check(db)
+	root, err := db.Commit(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	committed, err := state.New(root, database, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check(committed)
+}
+
+func TestValidatorSuicide(t *testing.T) {
+	validatorAddress := common.HexToAddress("133b")
+	for _, forked := range []bool{false, true} {
+		db, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+		if err != nil {
+			t.Fatalf("Error while initializing state %s", err)
+		}
+		// a contract validator running SELFDESTRUCT
+		db.SetContractValidatorFlag(validatorAddress)
+		db.SetValidatorFlag(validatorAddress)
+		db.SetCode(validatorAddress, []byte{0x60, 0x00, 0xff}, false)
+
+		config := *params.TestChainConfig
+		if forked {
+			config.ValidatorPrecompileEpoch = big.NewInt(0)
+		}
+		env := NewEVM(Context{
+			CanTransfer: func(StateDB, common.Address, *big.Int) bool { return true },
+			Transfer:    transfer,
+			IsValidator: func(db StateDB, addr common.Address) bool { return db.IsValidator(addr) },
+			BlockNumber: big.NewInt(0),
+			EpochNumber: big.NewInt(0),
+			Time:        big.NewInt(0),
+		}, db, &config, Config{})
+		_, _, err = env.Call(AccountRef(common.HexToAddress("1337")), validatorAddress, nil, 100000, new(big.Int))
+		if forked {
+			if err != errValidatorSuicide {
+				t.Errorf("Expected the validator not to self destruct, got %v", err)
+			}
+			if db.HasSuicided(validatorAddress) {
+				t.Errorf("Expected the validator not to be destructed")
+			}
+			continue
+		}
+		// before the validator precompile, the suicide is unchanged
+		if err != nil || !db.HasSuicided(validatorAddress) {
+			t.Errorf("Expected the self destruct before the fork, got %v", err)
+		}
+	}
+}
+
+func TestStakingPrecompileRedelegate(t *testing.T) {
+	env := NewEVM(Context{
+		Redelegate: RedelegateFn(),
+		ShardID:    0,
+	}, newStakingPrecompileTestState(), params.TestChainConfig, Config{})
+	input := mustPackStakingDirective("Redelegate",
+		[]string{"address", "address", "address", "uint256"},
+		common.HexToAddress("1337"), common.HexToAddress("1338"), common.HexToAddress("1339"),
+		big.NewInt(100),
+	)
+	testWriteCapablePrecompile(writeCapablePrecompileTest{
+		input: input,
+		name:  "redelegateSuccess",
+	}, t, env, &stakingPrecompile{})
+	if len(env.StakeMsgs) != 1 {
+		t.Errorf("Expected 1 stake msg, got %d", len(env.StakeMsgs))
+	} else if _, ok := env.StakeMsgs[0].(*stakingTypes.Redelegate); !ok {
+		t.Errorf("Expected a redelegate stake msg, got %T", env.StakeMsgs[0])
+	}
+}
+
+func TestStakingPrecompileEditDelegation(t *testing.T) {
+	env := NewEVM(Context{
+		EditDelegation: EditDelegationFn(),
+		ShardID:        0,
+	}, newStakingPrecompileTestState(), params.TestChainConfig, Config{})
+	input := mustPackStakingDirective("EditDelegation",
+		[]string{"address", "address", "bool"},
+		common.HexToAddress("1337"), common.HexToAddress("1338"), true,
+	)
+	testWriteCapablePrecompile(writeCapablePrecompileTest{
+		input: input,
+		name:  "editDelegationSuccess",
+	}, t, env, &stakingPrecompile{})
+	// the delegation indexes are unchanged
+	if len(env.StakeMsgs) != 0 {
+		t.Errorf("Expected no stake msg, got %d", len(env.StakeMsgs))
+	}
+}
+
 func TestWriteCapablePrecompilesReadOnly(t *testing.T) {
 	p := &stakingPrecompile{}
 	expectedError := errWriteProtection
@@ -60,6 +60,8 @@ type (
 	DelegateFunc        func(db StateDB, rosettaTracer RosettaTracer, stakeMsg *stakingTypes.Delegate) error
 	UndelegateFunc      func(db StateDB, rosettaTracer RosettaTracer, stakeMsg *stakingTypes.Undelegate) error
 	CollectRewardsFunc  func(db StateDB, rosettaTracer RosettaTracer, stakeMsg *stakingTypes.CollectRewards) error
+	RedelegateFunc      func(db StateDB, rosettaTracer RosettaTracer, stakeMsg *stakingTypes.Redelegate) error
+	EditDelegationFunc  func(db StateDB, rosettaTracer RosettaTracer, stakeMsg *stakingTypes.EditDelegation) error
 	// Used for migrating delegations via the staking precompile
 	//MigrateDelegationsFunc    func(db StateDB, migrationMsg *stakingTypes.MigrationMsg) ([]interface{}, error)
 	CalculateMigrationGasFunc func(db StateDB, migrationMsg *stakingTypes.MigrationMsg, homestead bool, istanbul bool) (uint64, error)
@@ -68,28 +70,7 @@ type (
 // run runs the given contract and takes care of running precompiles with a fallback to the byte code interpreter.
 func run(evm *EVM, contract *Contract, input []byte, readOnly bool) ([]byte, error) {
 	if contract.CodeAddr != nil {
-		precompiles := PrecompiledContractsHomestead
-		// assign empty write capable precompiles till they are available in the fork
-		var writeCapablePrecompiles map[common.Address]WriteCapablePrecompiledContract
-		if evm.ChainConfig().IsS3(evm.EpochNumber) {
-			precompiles = PrecompiledContractsByzantium
-		}
-		if evm.chainRules.IsIstanbul {
-			precompiles = PrecompiledContractsIstanbul
-		}
-		if evm.chainRules.IsVRF {
-			precompiles = PrecompiledContractsVRF
-		}
-		if evm.chainRules.IsSHA3 {
-			precompiles = PrecompiledContractsSHA3FIPS
-		}
-		if evm.chainRules.IsStakingPrecompile {
-			precompiles = PrecompiledContractsStaking
-			writeCapablePrecompiles = WriteCapablePrecompiledContractsStaking
-		}
-		if evm.chainRules.IsCrossShardXferPrecompile {
-			writeCapablePrecompiles = WriteCapablePrecompiledContractsCrossXfer
-		}
+		precompiles, writeCapablePrecompiles, statefulPrecompiles := activePrecompiledContracts(evm.chainRules)
 		if p := precompiles[*contract.CodeAddr]; p != nil {
 			if _, ok := p.(*vrf); ok {
 				if evm.chainRules.IsPrevVRF {
@@ -114,6 +95,9 @@ func run(evm *EVM, contract *Contract, input []byte, readOnly bool) ([]byte, err
 			}
 			return RunPrecompiledContract(p, input, contract)
 		}
+		if p := statefulPrecompiles[*contract.CodeAddr]; p != nil {
+			return RunStatefulPrecompiledContract(p, evm, contract, input)
+		}
 		if len(writeCapablePrecompiles) > 0 {
 			if p := writeCapablePrecompiles[*contract.CodeAddr]; p != nil {
 				return RunWriteCapablePrecompiledContract(p, evm, contract, input, readOnly)
@@ -169,6 +153,7 @@ type Context struct {
 	EpochNumber *big.Int       // Provides information for EPOCH
 	Time        *big.Int       // Provides information for TIME
 	VRF         common.Hash    // Provides information for VRF
+	BaseFee     *big.Int       // Provides information for BASEFEE, nil before EIP-1559
 
 	TxType types.TransactionType
 
@@ -177,6 +162,8 @@ type Context struct {
 	Delegate              DelegateFunc
 	Undelegate            UndelegateFunc
 	CollectRewards        CollectRewardsFunc
+	Redelegate            RedelegateFunc
+	EditDelegation        EditDelegationFunc
 	CalculateMigrationGas CalculateMigrationGasFunc
 
 	ShardID   uint32 // Used by staking and cross shard transfer precompile
@@ -302,28 +289,8 @@ func (evm *EVM) Call(caller ContractRef, addr common.Address, input []byte, gas
 		snapshot = evm.StateDB.Snapshot()
 	)
 	if !evm.StateDB.Exist(addr) && txType != types.SubtractionOnly {
-		precompiles := PrecompiledContractsHomestead
-		var writeCapablePrecompiles map[common.Address]WriteCapablePrecompiledContract
-		if evm.ChainConfig().IsS3(evm.EpochNumber) {
-			precompiles = PrecompiledContractsByzantium
-		}
-		if evm.chainRules.IsIstanbul {
-			precompiles = PrecompiledContractsIstanbul
-		}
-		if evm.chainRules.IsVRF {
-			precompiles = PrecompiledContractsVRF
-		}
-		if evm.chainRules.IsSHA3 {
-			precompiles = PrecompiledContractsSHA3FIPS
-		}
-		if evm.chainRules.IsStakingPrecompile {
-			precompiles = PrecompiledContractsStaking
-			writeCapablePrecompiles = WriteCapablePrecompiledContractsStaking
-		}
-		if evm.chainRules.IsCrossShardXferPrecompile {
-			writeCapablePrecompiles = WriteCapablePrecompiledContractsCrossXfer
-		}
-		if (len(writeCapablePrecompiles) == 0 || writeCapablePrecompiles[addr] == nil) && precompiles[addr] == nil && evm.ChainConfig().IsS3(evm.EpochNumber) && value.Sign() == 0 {
+		precompiles, writeCapablePrecompiles, statefulPrecompiles := activePrecompiledContracts(evm.chainRules)
+		if (len(writeCapablePrecompiles) == 0 || writeCapablePrecompiles[addr] == nil) && statefulPrecompiles[addr] == nil && precompiles[addr] == nil && evm.ChainConfig().IsS3(evm.EpochNumber) && value.Sign() == 0 {
 			// Calling a non existing account, don't do anything, but ping the tracer
 			if evm.vmConfig.Debug && evm.depth == 0 {
 				evm.vmConfig.Tracer.CaptureStart(evm, caller.Address(), addr, false, input, gas, value)
@@ -338,8 +305,8 @@ func (evm *EVM) Call(caller ContractRef, addr common.Address, input []byte, gas
 	codeHash := evm.StateDB.GetCodeHash(addr)
 	code := evm.StateDB.GetCode(addr)
 	// If address is a validator address, then it's not a smart contract address
-	// we don't use its code and codeHash fields
-	if evm.Context.IsValidator(evm.StateDB, addr) {
+	// we don't use its code and codeHash fields, unless the validator is a contract
+	if evm.Context.IsValidator(evm.StateDB, addr) && !evm.StateDB.IsContractValidator(addr) {
 		codeHash = emptyCodeHash
 		code = nil
 	}
@@ -513,6 +480,11 @@ func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, gas uint64,
 	}
 	nonce := evm.StateDB.GetNonce(caller.Address())
 	evm.StateDB.SetNonce(caller.Address(), nonce+1)
+	// We add this to the access list _before_ taking a snapshot. Even if the creation fails,
+	// the access-list change should not be rolled back
+	if evm.chainRules.IsEIP2929 {
+		evm.StateDB.AddAddressToAccessList(address)
+	}
 
 	// Ensure there's no existing contract already at the designated address
 	contractHash := evm.StateDB.GetCodeHash(address)
@@ -597,3 +569,14 @@ func (evm *EVM) Create2(caller ContractRef, code []byte, gas uint64, endowment *
 
 // ChainConfig returns the environment's chain configuration
 func (evm *EVM) ChainConfig() *params.ChainConfig { return evm.chainConfig }
+
+// NoBaseFee returns whether the base fee checks of the messages are skipped
+func (evm *EVM) NoBaseFee() bool { return evm.vmConfig.NoBaseFee }
+
+// CaptureTxStart tells the tracer of the start of the transaction, if it is a
+// TxTracer
+func (evm *EVM) CaptureTxStart(gasLimit uint64) {
+	if tracer, ok := evm.vmConfig.Tracer.(TxTracer); ok && evm.vmConfig.Debug {
+		tracer.CaptureTxStart(gasLimit)
+	}
+}
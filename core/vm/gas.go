@@ -56,7 +56,7 @@ func callGas(isEip150 bool, availableGas, base uint64, callCost *big.Int) (uint6
 }
 
 // IntrinsicGas computes the 'intrinsic gas' for a message with the given data.
-func IntrinsicGas(data []byte, contractCreation, homestead, istanbul, isValidatorCreation bool) (uint64, error) {
+func IntrinsicGas(data []byte, contractCreation, homestead, istanbul, isValidatorCreation, shanghai bool) (uint64, error) {
 	// Set the starting gas for the raw transaction
 	var gas uint64
 	if contractCreation && homestead {
@@ -90,6 +90,15 @@ func IntrinsicGas(data []byte, contractCreation, homestead, istanbul, isValidato
 			return 0, ErrOutOfGas
 		}
 		gas += z * params.TxDataZeroGas
+
+		// The init code of the contract creations is metered (EIP-3860)
+		if contractCreation && shanghai {
+			lenWords := toWordSize(uint64(len(data)))
+			if (math.MaxUint64-gas)/params.InitCodeWordGas < lenWords {
+				return 0, ErrOutOfGas
+			}
+			gas += lenWords * params.InitCodeWordGas
+		}
 	}
 	return gas, nil
 }
@@ -108,3 +108,79 @@ func TestEIP2200(t *testing.T) {
 		}
 	}
 }
+
+func TestCreateGasEIP3860(t *testing.T) {
+	tests := []struct {
+		size     uint64
+		create   uint64
+		create2  uint64
+		overflow bool
+	}{
+		{0, 0, 0, false},
+		{32, params.InitCodeWordGas, params.InitCodeWordGas + params.Sha3WordGas, false},
+		{33, 2 * params.InitCodeWordGas, 2 * (params.InitCodeWordGas + params.Sha3WordGas), false},
+		{params.MaxInitCodeSize, 1536 * params.InitCodeWordGas, 1536 * (params.InitCodeWordGas + params.Sha3WordGas), false},
+		{params.MaxInitCodeSize + 1, 0, 0, true},
+	}
+	for i, tt := range tests {
+		stack := newstack()
+		stack.push(new(big.Int).SetUint64(tt.size)) // size
+		stack.push(new(big.Int))                    // offset
+		stack.push(new(big.Int))                    // value
+		gas, err := gasCreateEip3860(nil, nil, stack, NewMemory(), 0)
+		if (err == errGasUintOverflow) != tt.overflow {
+			t.Errorf("test %d: overflow mismatch: have %v, want %v", i, err == errGasUintOverflow, tt.overflow)
+		}
+		if gas != tt.create {
+			t.Errorf("test %d: create gas mismatch: have %v, want %v", i, gas, tt.create)
+		}
+		gas, err = gasCreate2Eip3860(nil, nil, stack, NewMemory(), 0)
+		if (err == errGasUintOverflow) != tt.overflow {
+			t.Errorf("test %d: overflow mismatch: have %v, want %v", i, err == errGasUintOverflow, tt.overflow)
+		}
+		if gas != tt.create2 {
+			t.Errorf("test %d: create2 gas mismatch: have %v, want %v", i, gas, tt.create2)
+		}
+	}
+}
+
+var eip2929Tests = []struct {
+	input      string
+	accessList types.AccessList
+	used       uint64
+}{
+	// SLOAD twice: cold then warm, or warm twice if listed
+	{"0x60005450600054", nil, 3 + 2100 + 2 + 3 + 100},
+	{"0x60005450600054", types.AccessList{{Address: common.BytesToAddress([]byte("contract")), StorageKeys: []common.Hash{{}}}}, 3 + 100 + 2 + 3 + 100},
+	// BALANCE twice: cold then warm, or warm twice if listed
+	{"0x60ff315060ff31", nil, 3 + 2600 + 2 + 3 + 100},
+	{"0x60ff315060ff31", types.AccessList{{Address: common.BytesToAddress([]byte{0xff})}}, 3 + 100 + 2 + 3 + 100},
+}
+
+func TestEIP2929(t *testing.T) {
+	for i, tt := range eip2929Tests {
+		address := common.BytesToAddress([]byte("contract"))
+
+		statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+		statedb.CreateAccount(address)
+		statedb.SetCode(address, hexutil.MustDecode(tt.input), false)
+		statedb.Finalise(true)
+		statedb.PrepareAccessList(common.Address{}, &address, nil, tt.accessList)
+
+		vmctx := Context{
+			CanTransfer: func(StateDB, common.Address, *big.Int) bool { return true },
+			Transfer:    func(StateDB, common.Address, common.Address, *big.Int, types.TransactionType) {},
+			IsValidator: func(StateDB, common.Address) bool { return false },
+			EpochNumber: big.NewInt(0),
+		}
+		vmenv := NewEVM(vmctx, statedb, params.AllProtocolChanges, Config{})
+
+		_, gas, err := vmenv.Call(AccountRef(common.Address{}), address, nil, math.MaxUint64, new(big.Int))
+		if err != nil {
+			t.Errorf("test %d: unexpected error: %v", i, err)
+		}
+		if used := math.MaxUint64 - gas; used != tt.used {
+			t.Errorf("test %d: gas used mismatch: have %v, want %v", i, used, tt.used)
+		}
+	}
+}
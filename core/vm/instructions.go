@@ -25,6 +25,7 @@ import (
 	"github.com/harmony-one/harmony/core/types"
 	"github.com/harmony-one/harmony/internal/params"
 	"github.com/harmony-one/harmony/shard"
+	"github.com/harmony-one/harmony/staking"
 	"golang.org/x/crypto/sha3"
 )
 
@@ -36,6 +37,8 @@ var (
 	ErrExecutionReverted     = errors.New("evm: execution reverted")
 	errMaxCodeSizeExceeded   = errors.New("evm: max code size exceeded")
 	errInvalidJump           = errors.New("evm: invalid jump destination")
+	errValidatorSuicide      = errors.New("evm: validator cannot self destruct")
+	errReservedStorageKey    = errors.New("evm: storage key reserved for staking")
 )
 
 func opAdd(pc *uint64, interpreter *EVMInterpreter, contract *Contract, memory *Memory, stack *Stack) ([]byte, error) {
@@ -481,7 +484,8 @@ func opExtCodeSize(pc *uint64, interpreter *EVMInterpreter, contract *Contract,
 	address := common.BigToAddress(slot)
 	fixValidatorCode := interpreter.evm.chainRules.IsValidatorCodeFix &&
 		interpreter.evm.ShardID == shard.BeaconChainShardID &&
-		interpreter.evm.StateDB.IsValidator(address)
+		interpreter.evm.StateDB.IsValidator(address) &&
+		!interpreter.evm.StateDB.IsContractValidator(address)
 	if fixValidatorCode {
 		// https://github.com/ethereum/solidity/blob/develop/Changelog.md#081-2021-01-27
 		// per this link, <address>.code.length calls extcodesize on the address so this fix will work
@@ -523,7 +527,8 @@ func opExtCodeCopy(pc *uint64, interpreter *EVMInterpreter, contract *Contract,
 	var code []byte
 	fixValidatorCode := interpreter.evm.chainRules.IsValidatorCodeFix &&
 		interpreter.evm.ShardID == shard.BeaconChainShardID &&
-		interpreter.evm.StateDB.IsValidator(addr)
+		interpreter.evm.StateDB.IsValidator(addr) &&
+		!interpreter.evm.StateDB.IsContractValidator(addr)
 	if fixValidatorCode {
 		// for EOAs that are not validators, statedb returns nil
 		code = nil
@@ -578,7 +583,8 @@ func opExtCodeHash(pc *uint64, interpreter *EVMInterpreter, contract *Contract,
 	} else {
 		fixValidatorCode := interpreter.evm.chainRules.IsValidatorCodeFix &&
 			interpreter.evm.ShardID == shard.BeaconChainShardID &&
-			interpreter.evm.StateDB.IsValidator(address)
+			interpreter.evm.StateDB.IsValidator(address) &&
+			!interpreter.evm.StateDB.IsContractValidator(address)
 		if fixValidatorCode {
 			slot.SetBytes(emptyCodeHash.Bytes())
 		} else {
@@ -669,6 +675,10 @@ func opSload(pc *uint64, interpreter *EVMInterpreter, contract *Contract, memory
 func opSstore(pc *uint64, interpreter *EVMInterpreter, contract *Contract, memory *Memory, stack *Stack) ([]byte, error) {
 	loc := common.BigToHash(stack.pop())
 	val := stack.pop()
+	// the staking data of a contract validator is kept in its storage
+	if interpreter.evm.chainRules.IsValidatorPrecompile && staking.IsReservedStorageKey(loc) {
+		return nil, errReservedStorageKey
+	}
 	interpreter.evm.StateDB.SetState(contract.Address(), loc, common.BigToHash(val))
 
 	interpreter.intPool.put(val)
@@ -922,6 +932,11 @@ func opStop(pc *uint64, interpreter *EVMInterpreter, contract *Contract, memory
 }
 
 func opSuicide(pc *uint64, interpreter *EVMInterpreter, contract *Contract, memory *Memory, stack *Stack) ([]byte, error) {
+	// the stake of a contract validator would be lost with its account
+	if interpreter.evm.chainRules.IsValidatorPrecompile &&
+		interpreter.evm.StateDB.IsValidator(contract.Address()) {
+		return nil, errValidatorSuicide
+	}
 	balance := interpreter.evm.StateDB.GetBalance(contract.Address())
 	interpreter.evm.StateDB.AddBalance(common.BigToAddress(stack.pop()), balance)
 
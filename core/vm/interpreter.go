@@ -50,6 +50,10 @@ type Config struct {
 
 	// ExtraEips the additional EIPS that are to be enabled
 	ExtraEips []int
+
+	// NoBaseFee skips the check of the fee caps against the base fee, for the
+	// calls with no gas price
+	NoBaseFee bool
 }
 
 // Interpreter is used to run Ethereum based contracts and will utilise the
@@ -104,6 +108,10 @@ func NewEVMInterpreter(evm *EVM, cfg Config) *EVMInterpreter {
 	if !cfg.JumpTable[STOP].valid {
 		var jt JumpTable
 		switch {
+		case evm.chainRules.IsCancun:
+			jt = cancunInstructionSet
+		case evm.chainRules.IsShanghai:
+			jt = shanghaiInstructionSet
 		case evm.chainRules.IsIstanbul:
 			jt = istanbulInstructionSet
 		case evm.chainRules.IsS3:
@@ -111,6 +119,10 @@ func NewEVMInterpreter(evm *EVM, cfg Config) *EVMInterpreter {
 		default:
 			jt = frontierInstructionSet
 		}
+		// The access costs apply on top of the instruction set
+		if evm.chainRules.IsEIP2929 {
+			enable2929(&jt)
+		}
 		for i, eip := range cfg.ExtraEips {
 			if err := EnableEIP(eip, &jt); err != nil {
 				// Disable it, so caller can check if it's activated or not
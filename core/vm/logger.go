@@ -114,6 +114,13 @@ type Tracer interface {
 	CaptureEnd(output []byte, gasUsed uint64, t time.Duration, err error) error
 }
 
+// TxTracer is a Tracer which is also told of the start of the transaction,
+// before the gas of the message is bought
+type TxTracer interface {
+	Tracer
+	CaptureTxStart(gasLimit uint64)
+}
+
 // StructLogger is an EVM state logger and implements Tracer.
 //
 // StructLogger can capture state based on the given Log configuration and also keeps
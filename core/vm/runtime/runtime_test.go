@@ -118,6 +118,50 @@ func TestCall(t *testing.T) {
 	}
 }
 
+func TestCancunOpcodes(t *testing.T) {
+	// tstore(0, 0x2a); mstore(0x20, tload(0)); mcopy(0, 0x20, 0x20); return(0, 0x20)
+	code := []byte{
+		byte(vm.PUSH1), 0x2a,
+		byte(vm.PUSH0),
+		byte(vm.TSTORE),
+		byte(vm.PUSH0),
+		byte(vm.TLOAD),
+		byte(vm.PUSH1), 0x20,
+		byte(vm.MSTORE),
+		byte(vm.PUSH1), 0x20,
+		byte(vm.PUSH1), 0x20,
+		byte(vm.PUSH0),
+		byte(vm.MCOPY),
+		byte(vm.PUSH1), 0x20,
+		byte(vm.PUSH0),
+		byte(vm.RETURN),
+	}
+	newConfig := func(cancun *big.Int) *Config {
+		return &Config{ChainConfig: &params.ChainConfig{
+			ChainID:              big.NewInt(1),
+			EthCompatibleChainID: params.EthMainnetShard0ChainID,
+			EIP155Epoch:          new(big.Int),
+			S3Epoch:              new(big.Int),
+			IstanbulEpoch:        new(big.Int),
+			ShanghaiEpoch:        cancun,
+			CancunEpoch:          cancun,
+		}}
+	}
+
+	ret, _, err := Execute(code, nil, newConfig(new(big.Int)))
+	if err != nil {
+		t.Fatal("didn't expect error", err)
+	}
+	if num := new(big.Int).SetBytes(ret); num.Cmp(big.NewInt(0x2a)) != 0 {
+		t.Error("Expected 42, got", num)
+	}
+
+	// The opcodes are invalid before the forks
+	if _, _, err := Execute(code, nil, newConfig(big.NewInt(1))); err == nil {
+		t.Error("expected invalid opcode error before the forks")
+	}
+}
+
 func BenchmarkCall(b *testing.B) {
 	var definition = `[{"constant":true,"inputs":[],"name":"seller","outputs":[{"name":"","type":"address"}],"type":"function"},{"constant":false,"inputs":[],"name":"abort","outputs":[],"type":"function"},{"constant":true,"inputs":[],"name":"value","outputs":[{"name":"","type":"uint256"}],"type":"function"},{"constant":false,"inputs":[],"name":"refund","outputs":[],"type":"function"},{"constant":true,"inputs":[],"name":"buyer","outputs":[{"name":"","type":"address"}],"type":"function"},{"constant":false,"inputs":[],"name":"confirmReceived","outputs":[],"type":"function"},{"constant":true,"inputs":[],"name":"state","outputs":[{"name":"","type":"uint8"}],"type":"function"},{"constant":false,"inputs":[],"name":"confirmPurchase","outputs":[],"type":"function"},{"inputs":[],"type":"constructor"},{"anonymous":false,"inputs":[],"name":"Aborted","type":"event"},{"anonymous":false,"inputs":[],"name":"PurchaseConfirmed","type":"event"},{"anonymous":false,"inputs":[],"name":"ItemReceived","type":"event"},{"anonymous":false,"inputs":[],"name":"Refunded","type":"event"}]`
 
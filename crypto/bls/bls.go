@@ -19,9 +19,17 @@ const (
 	BLSSignatureSizeInBytes = 96
 )
 
+// Signer signs hashes with a bls private key. A *bls.SecretKey held in memory is
+// a Signer, so is a key held by a remote signer. SignHash returns nil if the
+// hash could not be signed.
+type Signer interface {
+	SignHash(hash []byte) *bls.Sign
+	GetPublicKey() *bls.PublicKey
+}
+
 // PrivateKeyWrapper combines the bls private key and the corresponding public key
 type PrivateKeyWrapper struct {
-	Pri *bls.SecretKey
+	Pri Signer
 	Pub *PublicKeyWrapper
 }
 
@@ -34,10 +42,15 @@ type PublicKeyWrapper struct {
 
 // WrapperFromPrivateKey makes a PrivateKeyWrapper from bls secret key
 func WrapperFromPrivateKey(pri *bls.SecretKey) PrivateKeyWrapper {
-	pub := pri.GetPublicKey()
+	return WrapperFromSigner(pri)
+}
+
+// WrapperFromSigner makes a PrivateKeyWrapper from a bls signer
+func WrapperFromSigner(signer Signer) PrivateKeyWrapper {
+	pub := signer.GetPublicKey()
 	pubBytes := FromLibBLSPublicKeyUnsafe(pub)
 	return PrivateKeyWrapper{
-		Pri: pri,
+		Pri: signer,
 		Pub: &PublicKeyWrapper{
 			Bytes:  *pubBytes,
 			Object: pub,
@@ -0,0 +1,154 @@
+package remotesigner
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	bls_core "github.com/harmony-one/bls/ffi/go/bls"
+	"github.com/pkg/errors"
+
+	"github.com/harmony-one/harmony/crypto/bls"
+	"github.com/harmony-one/harmony/internal/utils"
+)
+
+// maxResponseSize is the limit of the response body read from the signer
+const maxResponseSize = 1 << 20
+
+// Client is the http client of a remote signer
+type Client struct {
+	url    string
+	client *http.Client
+}
+
+// NewClient creates a client of the remote signer at the given http(s) url
+func NewClient(rawURL string, timeout time.Duration) (*Client, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid remote signer url")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported remote signer url scheme [%v]", u.Scheme)
+	}
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Client{
+		url:    strings.TrimSuffix(rawURL, "/"),
+		client: &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// Upcheck returns an error if the remote signer is not reachable
+func (c *Client) Upcheck() error {
+	resp, err := c.client.Get(c.url + UpcheckPath)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return checkStatus(resp)
+}
+
+// PublicKeys returns the public keys held by the remote signer
+func (c *Client) PublicKeys() ([]bls.SerializedPublicKey, error) {
+	resp, err := c.client.Get(c.url + PublicKeysPath)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp); err != nil {
+		return nil, err
+	}
+	var hexKeys []string
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&hexKeys); err != nil {
+		return nil, errors.Wrap(err, "cannot decode public keys")
+	}
+	keys := make([]bls.SerializedPublicKey, 0, len(hexKeys))
+	for _, hexKey := range hexKeys {
+		key, err := bls.WrapperPublicKeyFromString(strings.TrimPrefix(hexKey, "0x"))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid public key %v", hexKey)
+		}
+		keys = append(keys, key.Bytes)
+	}
+	return keys, nil
+}
+
+// Sign asks the remote signer to sign the hash with the key. The returned
+// signature is verified against the key.
+func (c *Client) Sign(key *bls.PublicKeyWrapper, hash []byte) (*bls_core.Sign, error) {
+	body, err := json.Marshal(SignRequest{SigningRoot: hexutil.Encode(hash)})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Post(c.url+SignPath+key.Bytes.Hex(), "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp); err != nil {
+		return nil, err
+	}
+	var signResp SignResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&signResp); err != nil {
+		return nil, errors.Wrap(err, "cannot decode sign response")
+	}
+	sigBytes, err := hexutil.Decode(signResp.Signature)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot decode signature")
+	}
+	sig := &bls_core.Sign{}
+	if err := sig.Deserialize(sigBytes); err != nil {
+		return nil, errors.Wrap(err, "cannot deserialize signature")
+	}
+	if !sig.VerifyHash(key.Object, hash) {
+		return nil, ErrInvalidSignature
+	}
+	return sig, nil
+}
+
+func checkStatus(resp *http.Response) error {
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+		return ErrUnknownKey
+	default:
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("remote signer returned %v: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+}
+
+// Signer is a bls key held by a remote signer. It implements bls.Signer.
+type Signer struct {
+	client *Client
+	key    *bls.PublicKeyWrapper
+}
+
+// NewSigner creates the signer of the key held by the remote signer
+func NewSigner(client *Client, key *bls.PublicKeyWrapper) *Signer {
+	return &Signer{client: client, key: key}
+}
+
+// SignHash signs the hash with the remote signer. It returns nil if signing failed.
+func (s *Signer) SignHash(hash []byte) *bls_core.Sign {
+	sig, err := s.client.Sign(s.key, hash)
+	if err != nil {
+		utils.Logger().Error().Err(err).
+			Str("key", s.key.Bytes.Hex()).
+			Msg("[RemoteSigner] failed to sign")
+		return nil
+	}
+	return sig
+}
+
+// GetPublicKey returns the public key of the signer
+func (s *Signer) GetPublicKey() *bls_core.PublicKey {
+	return s.key.Object
+}
@@ -0,0 +1,47 @@
+// Package remotesigner implements a Web3Signer style http api to sign with bls
+// keys held outside of the node process, with both the client used by the node
+// and a reference server.
+//
+// The api has three endpoints:
+//
+//	GET  /upcheck                     - returns 200 if the signer is up
+//	GET  /api/v1/harmony/publicKeys   - returns the hex encoded public keys the signer holds
+//	POST /api/v1/harmony/sign/{key}   - signs the signing root in the request body with the key
+//
+// The sign request body is {"signingRoot": "0x..."} and the response body is
+// {"signature": "0x..."}, both hex encoded.
+package remotesigner
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Paths of the api endpoints
+const (
+	UpcheckPath    = "/upcheck"
+	PublicKeysPath = "/api/v1/harmony/publicKeys"
+	SignPath       = "/api/v1/harmony/sign/"
+)
+
+// DefaultTimeout is the default timeout of a request to the remote signer. Signing
+// is on the critical path of consensus, so it is kept well below the phase timeouts.
+const DefaultTimeout = 2 * time.Second
+
+var (
+	// ErrUnknownKey is returned when the signer does not hold the requested key
+	ErrUnknownKey = errors.New("key not held by the remote signer")
+	// ErrInvalidSignature is returned when the signature from the signer does not verify
+	ErrInvalidSignature = errors.New("invalid signature from the remote signer")
+)
+
+// SignRequest is the body of a sign request
+type SignRequest struct {
+	SigningRoot string `json:"signingRoot"`
+}
+
+// SignResponse is the body of a sign response
+type SignResponse struct {
+	Signature string `json:"signature"`
+}
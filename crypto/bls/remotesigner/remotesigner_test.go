@@ -0,0 +1,53 @@
+package remotesigner
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/harmony-one/harmony/crypto/bls"
+	"github.com/harmony-one/harmony/multibls"
+)
+
+func TestRemoteSigner(t *testing.T) {
+	local := multibls.GetPrivateKeys(bls.RandPrivateKey(), bls.RandPrivateKey())
+	srv := httptest.NewServer(NewServer(local))
+	defer srv.Close()
+
+	client, err := NewClient(srv.URL, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Upcheck(); err != nil {
+		t.Fatal(err)
+	}
+	keys, err := client.PublicKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != len(local) {
+		t.Fatalf("unexpected number of keys: %v / %v", len(keys), len(local))
+	}
+
+	hash := []byte("0123456789abcdef0123456789abcdef")
+	for _, key := range local {
+		signer := NewSigner(client, key.Pub)
+		sig := signer.SignHash(hash)
+		if sig == nil {
+			t.Fatal("failed to sign")
+		}
+		// bls signatures are deterministic
+		if expected := key.Pri.SignHash(hash); !sig.IsEqual(expected) {
+			t.Errorf("unexpected signature for key %v", key.Pub.Bytes.Hex())
+		}
+	}
+
+	unknown := bls.WrapperFromPrivateKey(bls.RandPrivateKey())
+	if _, err := client.Sign(unknown.Pub, hash); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if sig := NewSigner(client, unknown.Pub).SignHash(hash); sig != nil {
+		t.Errorf("signed with unknown key")
+	}
+}
@@ -0,0 +1,91 @@
+package remotesigner
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/harmony-one/harmony/crypto/bls"
+	"github.com/harmony-one/harmony/internal/utils"
+	"github.com/harmony-one/harmony/multibls"
+)
+
+// maxRequestSize is the limit of the request body read by the server
+const maxRequestSize = 1 << 12
+
+// Server serves the remote signer api for the bls keys it holds
+type Server struct {
+	keys    map[bls.SerializedPublicKey]bls.PrivateKeyWrapper
+	pubKeys []string
+}
+
+// NewServer creates a server signing with the given keys
+func NewServer(keys multibls.PrivateKeys) *Server {
+	s := &Server{
+		keys:    make(map[bls.SerializedPublicKey]bls.PrivateKeyWrapper, len(keys)),
+		pubKeys: make([]string, 0, len(keys)),
+	}
+	for _, key := range keys.Dedup() {
+		s.keys[key.Pub.Bytes] = key
+		s.pubKeys = append(s.pubKeys, key.Pub.Bytes.Hex())
+	}
+	return s
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == UpcheckPath && r.Method == http.MethodGet:
+		w.WriteHeader(http.StatusOK)
+	case r.URL.Path == PublicKeysPath && r.Method == http.MethodGet:
+		writeJSON(w, s.pubKeys)
+	case strings.HasPrefix(r.URL.Path, SignPath) && r.Method == http.MethodPost:
+		s.handleSign(w, r)
+	default:
+		http.Error(w, "unknown endpoint", http.StatusBadRequest)
+	}
+}
+
+func (s *Server) handleSign(w http.ResponseWriter, r *http.Request) {
+	hexKey := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, SignPath), "0x")
+	pub, err := bls.WrapperPublicKeyFromString(hexKey)
+	if err != nil {
+		http.Error(w, "invalid public key", http.StatusBadRequest)
+		return
+	}
+	key, ok := s.keys[pub.Bytes]
+	if !ok {
+		http.Error(w, "unknown public key", http.StatusNotFound)
+		return
+	}
+	var req SignRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxRequestSize)).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	root, err := hexutil.Decode(req.SigningRoot)
+	if err != nil || len(root) == 0 {
+		http.Error(w, "invalid signing root", http.StatusBadRequest)
+		return
+	}
+	sig := key.Pri.SignHash(root)
+	if sig == nil {
+		http.Error(w, "failed to sign", http.StatusInternalServerError)
+		return
+	}
+	utils.Logger().Debug().
+		Str("key", key.Pub.Bytes.Hex()).
+		Str("signingRoot", req.SigningRoot).
+		Msg("[RemoteSigner] signed")
+	writeJSON(w, SignResponse{Signature: hexutil.Encode(sig.Serialize())})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		utils.Logger().Warn().Err(err).Msg("[RemoteSigner] failed to write response")
+	}
+}
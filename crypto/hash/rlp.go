@@ -28,6 +28,21 @@ func FromRLP(x interface{}) (h common.Hash) {
 	return h
 }
 
+// FromPrefixedRLP hashes the prefix followed by the RLP representation of the
+// given object, as the typed transactions are hashed.
+func FromPrefixedRLP(prefix byte, x interface{}) (h common.Hash) {
+	hw := kec256Pool.Get().(hash.Hash)
+	defer func() {
+		hw.Reset()
+		kec256Pool.Put(hw)
+	}()
+
+	hw.Write([]byte{prefix})
+	rlp.Encode(hw, x)
+	hw.Sum(h[:0])
+	return h
+}
+
 var sha256Pool = sync.Pool{
 	New: func() interface{} {
 		return sha3.New256()
@@ -19,9 +19,16 @@ type PublicKey struct {
 	*bls.PublicKey
 }
 
+// SecretKey is the bls key used to evaluate the VRF. Besides *bls.SecretKey,
+// it can be a key held by a remote signer.
+type SecretKey interface {
+	SignHash(hash []byte) *bls.Sign
+	GetPublicKey() *bls.PublicKey
+}
+
 // PrivateKey holds a private VRF key.
 type PrivateKey struct {
-	*bls.SecretKey
+	SecretKey
 }
 
 func init() {
@@ -49,7 +56,7 @@ func NewVRFVerifier(pubkey *bls.PublicKey) vrf.PublicKey {
 }
 
 // NewVRFSigner creates a signer object from a private key.
-func NewVRFSigner(seck *bls.SecretKey) vrf.PrivateKey {
+func NewVRFSigner(seck SecretKey) vrf.PrivateKey {
 	return &PrivateKey{seck}
 }
 
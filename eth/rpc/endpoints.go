@@ -18,12 +18,15 @@ package rpc
 
 import (
 	"net"
+	"net/http"
 
 	"github.com/ethereum/go-ethereum/log"
 )
 
-// StartHTTPEndpoint starts the HTTP RPC endpoint, configured with cors/vhosts/modules
-func StartHTTPEndpoint(endpoint string, apis []API, modules []string, rmf *RpcMethodFilter, cors []string, vhosts []string, timeouts HTTPTimeouts) (net.Listener, *Server, error) {
+// StartHTTPEndpoint starts the HTTP RPC endpoint, configured with cors/vhosts/modules.
+// If jwtSecret is set, the requests must be authenticated with a jwt signed with it.
+// If limiter is set, the requests of each client are rate limited.
+func StartHTTPEndpoint(endpoint string, apis []API, modules []string, rmf *RpcMethodFilter, cors []string, vhosts []string, timeouts HTTPTimeouts, jwtSecret []byte, limiter *ClientLimiter) (net.Listener, *Server, error) {
 	// Generate the whitelist based on the allowed modules
 	whitelist := make(map[string]bool)
 	for _, module := range modules {
@@ -31,6 +34,7 @@ func StartHTTPEndpoint(endpoint string, apis []API, modules []string, rmf *RpcMe
 	}
 	// Register all the APIs exposed by the services
 	handler := NewServer()
+	handler.SetClientLimiter(limiter)
 	for _, api := range apis {
 		if whitelist[api.Namespace] || (len(whitelist) == 0 && api.Public) {
 			if err := handler.RegisterName(api.Namespace, api.Service, rmf); err != nil {
@@ -47,12 +51,18 @@ func StartHTTPEndpoint(endpoint string, apis []API, modules []string, rmf *RpcMe
 	if listener, err = net.Listen("tcp", endpoint); err != nil {
 		return nil, nil, err
 	}
-	go NewHTTPServer(cors, vhosts, timeouts, handler).Serve(listener)
+	var srv http.Handler = handler
+	if len(jwtSecret) != 0 {
+		srv = newJWTHandler(jwtSecret, srv)
+	}
+	go NewHTTPServer(cors, vhosts, timeouts, srv).Serve(listener)
 	return listener, handler, err
 }
 
-// StartWSEndpoint starts a websocket endpoint
-func StartWSEndpoint(endpoint string, apis []API, modules []string, rmf *RpcMethodFilter, wsOrigins []string, exposeAll bool) (net.Listener, *Server, error) {
+// StartWSEndpoint starts a websocket endpoint.
+// If jwtSecret is set, the connections must be authenticated with a jwt signed with it.
+// If limiter is set, the requests of each client are rate limited.
+func StartWSEndpoint(endpoint string, apis []API, modules []string, rmf *RpcMethodFilter, wsOrigins []string, exposeAll bool, jwtSecret []byte, limiter *ClientLimiter) (net.Listener, *Server, error) {
 
 	// Generate the whitelist based on the allowed modules
 	whitelist := make(map[string]bool)
@@ -61,6 +71,7 @@ func StartWSEndpoint(endpoint string, apis []API, modules []string, rmf *RpcMeth
 	}
 	// Register all the APIs exposed by the services
 	handler := NewServer()
+	handler.SetClientLimiter(limiter)
 	for _, api := range apis {
 		if exposeAll || whitelist[api.Namespace] || (len(whitelist) == 0 && api.Public) {
 			if err := handler.RegisterName(api.Namespace, api.Service, rmf); err != nil {
@@ -77,7 +88,11 @@ func StartWSEndpoint(endpoint string, apis []API, modules []string, rmf *RpcMeth
 	if listener, err = net.Listen("tcp", endpoint); err != nil {
 		return nil, nil, err
 	}
-	go NewWSServer(wsOrigins, handler).Serve(listener)
+	srv := NewWSServer(wsOrigins, handler)
+	if len(jwtSecret) != 0 {
+		srv.Handler = newJWTHandler(jwtSecret, srv.Handler)
+	}
+	go srv.Serve(listener)
 	return listener, handler, err
 
 }
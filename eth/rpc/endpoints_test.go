@@ -0,0 +1,38 @@
+package rpc
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+func TestStartIPCEndpoint(t *testing.T) {
+	if runtime.GOOS == "windows" || runtime.GOOS == "js" {
+		t.Skip("the IPC endpoint is a unix socket")
+	}
+	endpoint := filepath.Join(t.TempDir(), "harmony.ipc")
+	apis := []API{{Namespace: "test", Service: new(testService), Public: true}}
+
+	listener, handler, err := StartIPCEndpoint(endpoint, apis, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer handler.Stop()
+	defer listener.Close()
+
+	client, err := DialIPC(context.Background(), endpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	var resp echoResult
+	if err := client.Call(&resp, "test_echo", "hello", 10, &echoArgs{"world"}); err != nil {
+		t.Fatal(err)
+	}
+	if want := (echoResult{"hello", 10, &echoArgs{"world"}}); !reflect.DeepEqual(resp, want) {
+		t.Errorf("unexpected result %#v / %#v", resp, want)
+	}
+}
@@ -60,6 +60,8 @@ type handler struct {
 	conn           jsonWriter                     // where responses will be sent
 	log            log.Logger
 	allowSubscribe bool
+	limiter        *ClientLimiter // per-client rate limiter, may be nil
+	clientID       string         // id of the client for the limiter
 
 	subLock    sync.Mutex
 	serverSubs map[ID]*Subscription
@@ -87,6 +89,9 @@ func newHandler(connCtx context.Context, conn jsonWriter, idgen func() ID, reg *
 	if conn.remoteAddr() != "" {
 		h.log = h.log.New("conn", conn.remoteAddr())
 	}
+	if lc, ok := conn.(*limitedCodec); ok {
+		h.limiter, h.clientID = lc.limiter, lc.clientID
+	}
 	h.unsubscribeCb = newCallback(reflect.Value{}, reflect.ValueOf(h.unsubscribe))
 	return h
 }
@@ -310,6 +315,10 @@ func (h *handler) handleCallMsg(ctx *callProc, msg *jsonrpcMessage) *jsonrpcMess
 
 // handleCall processes method calls.
 func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage {
+	if h.limiter != nil && !h.limiter.allow(h.clientID, msg.Method) {
+		doMetricRateLimitedRequest(msg.Method)
+		return msg.errorResponse(&limitExceededError{method: msg.Method})
+	}
 	if msg.isSubscribe() {
 		return h.handleSubscribe(cp, msg)
 	}
@@ -264,7 +264,7 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("content-type", contentType)
-	codec := newHTTPServerConn(r, w)
+	codec := withClientLimiter(newHTTPServerConn(r, w), s.limiter, r)
 	defer codec.close()
 	s.serveSingleRequest(ctx, codec)
 }
@@ -0,0 +1,106 @@
+package rpc
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	// jwtSecretLength is the length of the HS256 shared secret in bytes
+	jwtSecretLength = 32
+	// jwtIatSkew is the maximum difference between the issued-at time of a
+	// token and the local clock
+	jwtIatSkew = 60 * time.Second
+)
+
+// ObtainJWTSecret loads the hex encoded jwt secret from the file. If the file
+// doesn't exist, a random secret is generated and written to it, so that the
+// clients of the auth port can read it.
+func ObtainJWTSecret(fileName string) ([]byte, error) {
+	if data, err := os.ReadFile(fileName); err == nil {
+		secret := common.FromHex(strings.TrimSpace(string(data)))
+		if len(secret) != jwtSecretLength {
+			return nil, fmt.Errorf("invalid jwt secret in %v: need %d hex encoded bytes", fileName, jwtSecretLength)
+		}
+		return secret, nil
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	secret := make([]byte, jwtSecretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(fileName), 0700); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(fileName, []byte(hexutil.Encode(secret)), 0600); err != nil {
+		return nil, err
+	}
+	log.Info("Generated jwt secret", "path", fileName)
+	return secret, nil
+}
+
+// jwtSubjectKey is the context key of the subject of the jwt a request was
+// authenticated with
+type jwtSubjectKey struct{}
+
+// jwtHandler rejects the requests without a valid HS256 token signed with the
+// shared secret in the Authorization header
+type jwtHandler struct {
+	keyFunc func(token *jwt.Token) (interface{}, error)
+	next    http.Handler
+}
+
+// newJWTHandler wraps next with the jwt authentication
+func newJWTHandler(secret []byte, next http.Handler) http.Handler {
+	return &jwtHandler{
+		keyFunc: func(token *jwt.Token) (interface{}, error) {
+			return secret, nil
+		},
+		next: next,
+	}
+}
+
+// ServeHTTP implements http.Handler
+func (handler *jwtHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "Bearer ") {
+		http.Error(w, "missing bearer token", http.StatusUnauthorized)
+		return
+	}
+	var claims jwt.RegisteredClaims
+	// Only HS256 is allowed. The claims are checked below, as the default
+	// validation rejects an issued-at time later than now, with no clock skew.
+	token, err := jwt.ParseWithClaims(strings.TrimPrefix(auth, "Bearer "), &claims, handler.keyFunc,
+		jwt.WithValidMethods([]string{"HS256"}),
+		jwt.WithoutClaimsValidation())
+
+	switch {
+	case err != nil:
+		http.Error(w, fmt.Sprintf("invalid token: %v", err), http.StatusUnauthorized)
+	case !token.Valid:
+		http.Error(w, "invalid token", http.StatusUnauthorized)
+	case !claims.VerifyExpiresAt(time.Now(), false):
+		http.Error(w, "token is expired", http.StatusUnauthorized)
+	case claims.IssuedAt == nil:
+		http.Error(w, "missing issued-at (iat) claim", http.StatusUnauthorized)
+	case time.Since(claims.IssuedAt.Time) > jwtIatSkew:
+		http.Error(w, "stale token: issued-at (iat) too far in the past", http.StatusUnauthorized)
+	case time.Until(claims.IssuedAt.Time) > jwtIatSkew:
+		http.Error(w, "future token: issued-at (iat) too far in the future", http.StatusUnauthorized)
+	default:
+		ctx := context.WithValue(r.Context(), jwtSubjectKey{}, claims.Subject)
+		handler.next.ServeHTTP(w, r.WithContext(ctx))
+	}
+}
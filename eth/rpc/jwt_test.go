@@ -0,0 +1,73 @@
+package rpc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+func TestJWTHandler(t *testing.T) {
+	secret, err := ObtainJWTSecret(filepath.Join(t.TempDir(), "jwtsecret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := newJWTHandler(secret, ok)
+
+	token := func(method jwt.SigningMethod, key interface{}, iat time.Time) string {
+		claims := jwt.RegisteredClaims{IssuedAt: jwt.NewNumericDate(iat)}
+		str, err := jwt.NewWithClaims(method, claims).SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return "Bearer " + str
+	}
+	tests := []struct {
+		auth   string
+		status int
+	}{
+		{"", http.StatusUnauthorized},
+		{token(jwt.SigningMethodHS256, secret, time.Now()), http.StatusOK},
+		{token(jwt.SigningMethodHS256, secret, time.Now().Add(30*time.Second)), http.StatusOK},
+		{token(jwt.SigningMethodHS256, secret, time.Now().Add(-2*jwtIatSkew)), http.StatusUnauthorized},
+		{token(jwt.SigningMethodHS256, secret, time.Now().Add(2*jwtIatSkew)), http.StatusUnauthorized},
+		{token(jwt.SigningMethodHS256, []byte("wrong secret"), time.Now()), http.StatusUnauthorized},
+		{token(jwt.SigningMethodHS512, secret, time.Now()), http.StatusUnauthorized},
+	}
+	for i, test := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		if test.auth != "" {
+			req.Header.Set("Authorization", test.auth)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != test.status {
+			t.Errorf("test %d: unexpected status %d, expected %d: %s", i, rec.Code, test.status, rec.Body.String())
+		}
+	}
+}
+
+func TestObtainJWTSecret(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "jwtsecret")
+	secret, err := ObtainJWTSecret(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(secret) != jwtSecretLength {
+		t.Fatalf("unexpected secret length %d", len(secret))
+	}
+	// the generated secret is loaded again
+	loaded, err := ObtainJWTSecret(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(loaded) != string(secret) {
+		t.Errorf("loaded secret differs from generated one")
+	}
+}
@@ -0,0 +1,138 @@
+package rpc
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/harmony-one/harmony/internal/rate"
+	xrate "golang.org/x/time/rate"
+)
+
+// DefaultMethodCosts are the default costs, in requests, of the expensive
+// methods for the per-client rate limiter. The other methods cost one request.
+var DefaultMethodCosts = map[string]int{
+	"hmy_getSuperCommittees":            10,
+	"hmyv2_getSuperCommittees":          10,
+	"hmy_getStakingNetworkInfo":         10,
+	"hmyv2_getStakingNetworkInfo":       10,
+	"hmy_getAllValidatorInformation":    5,
+	"hmyv2_getAllValidatorInformation":  5,
+	"hmy_getAllDelegationInformation":   5,
+	"hmyv2_getAllDelegationInformation": 5,
+	"txpool_content":                    10,
+	"txpool_inspect":                    10,
+	"txpool_nonceGaps":                  10,
+	"txpool_contentFrom":                2,
+}
+
+// ClientLimiterConfig is the config of the per-client rate limiter
+type ClientLimiterConfig struct {
+	// RequestsPerSecond is the number of requests per second of each client
+	RequestsPerSecond int
+	// Burst is the maximum number of requests of a client at once, defaults
+	// to RequestsPerSecond
+	Burst int
+	// KeyHeader is the HTTP header of the API key identifying the clients. If
+	// not set, missing in a request or not one of Keys, the clients are
+	// identified by the subject of their jwt or by IP.
+	KeyHeader string
+	// Keys are the API keys honored in KeyHeader. The header is not honored
+	// without keys, so that a client can't get new limits by changing its key.
+	Keys []string
+	// Whitelist are the IPs, API keys and jwt subjects that are not limited.
+	// The whitelisted API keys must be in Keys too.
+	Whitelist []string
+	// MethodCosts are the costs, in requests, of the methods. The methods not
+	// listed cost one request.
+	MethodCosts map[string]int
+}
+
+// ClientLimiter limits the requests of each client, identified by API key, jwt
+// subject or IP
+type ClientLimiter struct {
+	limiter   rate.IDLimiter
+	keyHeader string
+	keys      map[string]struct{}
+	costs     map[string]int
+	burst     int
+}
+
+// NewClientLimiter creates a per-client rate limiter
+func NewClientLimiter(config ClientLimiterConfig) *ClientLimiter {
+	burst := config.Burst
+	if burst <= 0 {
+		burst = config.RequestsPerSecond
+	}
+	costs := config.MethodCosts
+	if costs == nil {
+		costs = DefaultMethodCosts
+	}
+	keys := make(map[string]struct{}, len(config.Keys))
+	for _, key := range config.Keys {
+		keys[key] = struct{}{}
+	}
+	limiter := rate.NewLimiterPerID(xrate.Limit(config.RequestsPerSecond), burst, &rate.Config{
+		Whitelist: config.Whitelist,
+	})
+	return &ClientLimiter{
+		limiter:   limiter,
+		keyHeader: config.KeyHeader,
+		keys:      keys,
+		costs:     costs,
+		burst:     burst,
+	}
+}
+
+// clientID returns the API key of the request if it is a known one, else the
+// subject of the jwt the request was authenticated with, else the IP of the client
+func (l *ClientLimiter) clientID(r *http.Request) string {
+	if l.keyHeader != "" {
+		key := strings.TrimSpace(r.Header.Get(l.keyHeader))
+		if _, ok := l.keys[key]; ok {
+			return key
+		}
+	}
+	if sub, ok := r.Context().Value(jwtSubjectKey{}).(string); ok && sub != "" {
+		return sub
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// allow returns whether the client may call the method. A cost above the
+// burst is capped, so that the method can still be called at all.
+func (l *ClientLimiter) allow(id string, method string) bool {
+	cost, ok := l.costs[method]
+	if !ok || cost <= 0 {
+		cost = 1
+	}
+	if cost > l.burst {
+		cost = l.burst
+	}
+	return l.limiter.AllowN(id, cost)
+}
+
+// limitedCodec is the codec of a connection of a client limited by a
+// ClientLimiter
+type limitedCodec struct {
+	ServerCodec
+	limiter  *ClientLimiter
+	clientID string
+}
+
+// withClientLimiter wraps the codec of the request with the per-client rate
+// limiter, if set
+func withClientLimiter(codec ServerCodec, limiter *ClientLimiter, r *http.Request) ServerCodec {
+	if limiter == nil {
+		return codec
+	}
+	return &limitedCodec{
+		ServerCodec: codec,
+		limiter:     limiter,
+		clientID:    limiter.clientID(r),
+	}
+}
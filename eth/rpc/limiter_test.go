@@ -0,0 +1,97 @@
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClientLimiter(t *testing.T) {
+	server := newTestServer()
+	defer server.Stop()
+	server.SetClientLimiter(NewClientLimiter(ClientLimiterConfig{
+		RequestsPerSecond: 1,
+		Burst:             2,
+		KeyHeader:         "X-Api-Key",
+		Keys:              []string{"key"},
+		Whitelist:         []string{"10.0.0.3"},
+		MethodCosts:       map[string]int{"test_rets": 2},
+	}))
+
+	call := func(method, ip, key string) bool {
+		body := `{"jsonrpc":"2.0","id":1,"method":"` + method + `"}`
+		req := httptest.NewRequest(http.MethodPost, "http://url.com", strings.NewReader(body))
+		req.Header.Set("content-type", contentType)
+		req.RemoteAddr = ip + ":1234"
+		if key != "" {
+			req.Header.Set("X-Api-Key", key)
+		}
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, req)
+		return !strings.Contains(rec.Body.String(), "rate limit exceeded")
+	}
+	tests := []struct {
+		method, ip, key string
+		allowed         bool
+	}{
+		{"test_noArgsRets", "10.0.0.1", "", true},
+		{"test_noArgsRets", "10.0.0.1", "", true},
+		{"test_noArgsRets", "10.0.0.1", "", false},
+		// other clients are limited separately
+		{"test_rets", "10.0.0.2", "", true},
+		{"test_noArgsRets", "10.0.0.2", "", false},
+		// the api key identifies the client instead of the ip
+		{"test_noArgsRets", "10.0.0.1", "key", true},
+		{"test_rets", "10.0.0.2", "key", false},
+		// unknown api keys are ignored, the client is identified by ip
+		{"test_noArgsRets", "10.0.0.1", "other", false},
+		{"test_noArgsRets", "10.0.0.4", "other", true},
+		{"test_noArgsRets", "10.0.0.4", "another", true},
+		{"test_noArgsRets", "10.0.0.4", "yet another", false},
+		// whitelisted clients are never limited
+		{"test_rets", "10.0.0.3", "", true},
+		{"test_rets", "10.0.0.3", "", true},
+		{"test_rets", "10.0.0.3", "", true},
+	}
+	for i, test := range tests {
+		if allowed := call(test.method, test.ip, test.key); allowed != test.allowed {
+			t.Errorf("test %d: unexpected allowed %v, expected %v", i, allowed, test.allowed)
+		}
+	}
+}
+
+func TestClientLimiter_clientID(t *testing.T) {
+	limiter := NewClientLimiter(ClientLimiterConfig{
+		RequestsPerSecond: 1,
+		KeyHeader:         "X-Api-Key",
+		Keys:              []string{"key"},
+	})
+	request := func(key, sub string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "http://url.com", nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		if key != "" {
+			req.Header.Set("X-Api-Key", key)
+		}
+		if sub != "" {
+			req = req.WithContext(context.WithValue(req.Context(), jwtSubjectKey{}, sub))
+		}
+		return req
+	}
+	tests := []struct {
+		key, sub, exp string
+	}{
+		{"", "", "10.0.0.1"},
+		{"unknown", "", "10.0.0.1"},
+		{"key", "", "key"},
+		{"", "node", "node"},
+		{"unknown", "node", "node"},
+		{"key", "node", "key"},
+	}
+	for i, test := range tests {
+		if id := limiter.clientID(request(test.key, test.sub)); id != test.exp {
+			t.Errorf("test %d: unexpected client id %v, expected %v", i, id, test.exp)
+		}
+	}
+}
@@ -10,6 +10,7 @@ func init() {
 		requestCounterVec,
 		requestErroredCounterVec,
 		requestDurationHistVec,
+		requestRateLimitedCounterVec,
 	)
 }
 
@@ -34,6 +35,16 @@ var (
 		[]string{"method"},
 	)
 
+	requestRateLimitedCounterVec = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "hmy",
+			Subsystem: "rpc2",
+			Name:      "rate_limited_count",
+			Help:      "counters of RPC requests rejected by the per-client rate limiter",
+		},
+		[]string{"method"},
+	)
+
 	requestDurationHistVec = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Namespace: "hmy",
@@ -63,6 +74,13 @@ func doMetricErroredRequest(method string) {
 	requestErroredCounterVec.With(pLabel).Inc()
 }
 
+func doMetricRateLimitedRequest(method string) {
+	pLabel := prometheus.Labels{
+		"method": method,
+	}
+	requestRateLimitedCounterVec.With(pLabel).Inc()
+}
+
 func doMetricDelayHist(timer *prometheus.Timer) {
 	timer.ObserveDuration()
 }
@@ -46,6 +46,7 @@ type Server struct {
 	idgen    func() ID
 	run      int32
 	codecs   mapset.Set
+	limiter  *ClientLimiter
 }
 
 // NewServer creates a new server instance with no registered handlers.
@@ -58,6 +59,12 @@ func NewServer() *Server {
 	return server
 }
 
+// SetClientLimiter sets the per-client rate limiter of the HTTP and websocket
+// requests. It must be called before serving.
+func (s *Server) SetClientLimiter(limiter *ClientLimiter) {
+	s.limiter = limiter
+}
+
 // RegisterName creates a service for the given receiver type under the given name. When no
 // methods on the given receiver match the criteria to be either a RPC method or a
 // subscription an error is returned. Otherwise a new service is created and added to the
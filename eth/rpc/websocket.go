@@ -62,7 +62,7 @@ func (s *Server) WebsocketHandler(allowedOrigins []string) http.Handler {
 			log.Debug("WebSocket upgrade failed", "err", err)
 			return
 		}
-		codec := newWebsocketCodec(conn)
+		codec := withClientLimiter(newWebsocketCodec(conn), s.limiter, r)
 		s.ServeCodec(codec, 0)
 	})
 }
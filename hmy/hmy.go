@@ -237,11 +237,20 @@ func (hmy *Harmony) GetNodeMetadata() commonRPC.NodeMetadata {
 	}
 }
 
-// GetEVM returns a new EVM entity
+// GetEVM returns a new EVM entity, with the sender of msg given the max balance
 func (hmy *Harmony) GetEVM(ctx context.Context, msg core.Message, state *state.DB, header *block.Header) (*vm.EVM, error) {
 	state.SetBalance(msg.From(), math.MaxBig256)
+	return hmy.GetEVMOnState(ctx, msg, state, header)
+}
+
+// GetEVMOnState returns a new EVM entity on the state as is, the balance of
+// the sender of msg is left untouched
+func (hmy *Harmony) GetEVMOnState(ctx context.Context, msg core.Message, state *state.DB, header *block.Header) (*vm.EVM, error) {
 	vmCtx := core.NewEVMContext(msg, header, hmy.BlockChain, nil)
-	return vm.NewEVM(vmCtx, state, hmy.BlockChain.Config(), *hmy.BlockChain.GetVMConfig()), nil
+	vmConfig := *hmy.BlockChain.GetVMConfig()
+	// The calls may have no gas price, whatever the base fee
+	vmConfig.NoBaseFee = true
+	return vm.NewEVM(vmCtx, state, hmy.BlockChain.Config(), vmConfig), nil
 }
 
 // ChainDb ..
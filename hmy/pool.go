@@ -48,6 +48,18 @@ func (hmy *Harmony) GetPoolTransactions() (types.PoolTransactions, error) {
 	return txs, nil
 }
 
+// GetPoolContent returns the pending and queued pool transactions, grouped by
+// sender and sorted by nonce.
+func (hmy *Harmony) GetPoolContent() (map[common.Address]types.PoolTransactions, map[common.Address]types.PoolTransactions) {
+	return hmy.TxPool.Content()
+}
+
+// GetPoolContentFrom returns the pending and queued pool transactions of the
+// sender, sorted by nonce.
+func (hmy *Harmony) GetPoolContentFrom(addr common.Address) (types.PoolTransactions, types.PoolTransactions) {
+	return hmy.TxPool.ContentFrom(addr)
+}
+
 func (hmy *Harmony) SuggestPrice(ctx context.Context) (*big.Int, error) {
 	return hmy.gpo.SuggestPrice(ctx)
 }
@@ -20,6 +20,7 @@ import (
 	"bufio"
 	"context"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
@@ -57,9 +58,10 @@ const (
 // TraceConfig holds extra parameters to trace functions.
 type TraceConfig struct {
 	*vm.LogConfig
-	Tracer  *string
-	Timeout *string
-	Reexec  *uint64
+	Tracer       *string
+	TracerConfig json.RawMessage // Config of the native tracers
+	Timeout      *string
+	Reexec       *uint64
 }
 
 // StdTraceConfig holds extra parameters to standard-json trace functions.
@@ -709,6 +711,14 @@ func (hmy *Harmony) ComputeStateDB(block *types.Block, reexec uint64) (*state.DB
 // be tracer dependent.
 // NOTE: Only support default StructLogger tracer
 func (hmy *Harmony) TraceTx(ctx context.Context, message core.Message, vmctx vm.Context, statedb *state.DB, config *TraceConfig) (interface{}, error) {
+	trace, _, err := hmy.TraceMessage(ctx, message, vmctx, statedb, config, false)
+	return trace, err
+}
+
+// TraceMessage is TraceTx also returning the execution result of the message.
+// noBaseFee skips the base fee check of the message, as for the calls with no
+// gas price; it is off when replaying the transactions of a block.
+func (hmy *Harmony) TraceMessage(ctx context.Context, message core.Message, vmctx vm.Context, statedb *state.DB, config *TraceConfig, noBaseFee bool) (interface{}, *core.ExecutionResult, error) {
 	// Assemble the structured logger or the JavaScript tracer
 	var (
 		tracer vm.Tracer
@@ -727,18 +737,29 @@ func (hmy *Harmony) TraceTx(ctx context.Context, message core.Message, vmctx vm.
 		timeout := defaultTraceTimeout
 		if config.Timeout != nil {
 			if timeout, err = time.ParseDuration(*config.Timeout); err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 		}
-		// Constuct the JavaScript tracer to execute with
-		if tracer, err = tracers.New(*config.Tracer); err != nil {
-			return nil, err
+		// Prefer the native tracer, construct the JavaScript tracer otherwise
+		native, ok, err := tracers.NewNative(*config.Tracer, config.TracerConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+		var stopper interface{ Stop(err error) }
+		if ok {
+			tracer, stopper = native, native
+		} else {
+			jsTracer, err := tracers.New(*config.Tracer)
+			if err != nil {
+				return nil, nil, err
+			}
+			tracer, stopper = jsTracer, jsTracer
 		}
 		// Handle timeouts and RPC cancellations
 		deadlineCtx, cancel := context.WithTimeout(ctx, timeout)
 		go func() {
 			<-deadlineCtx.Done()
-			tracer.(*tracers.Tracer).Stop(errors.New("execution timeout"))
+			stopper.Stop(errors.New("execution timeout"))
 		}()
 		defer cancel()
 
@@ -749,32 +770,48 @@ func (hmy *Harmony) TraceTx(ctx context.Context, message core.Message, vmctx vm.
 		tracer = vm.NewStructLogger(config.LogConfig)
 	}
 	// Run the transaction with tracing enabled.
-	vmenv := vm.NewEVM(vmctx, statedb, hmy.BlockChain.Config(), vm.Config{Debug: true, Tracer: tracer})
+	vmenv := vm.NewEVM(vmctx, statedb, hmy.BlockChain.Config(), vm.Config{Debug: true, Tracer: tracer, NoBaseFee: noBaseFee})
+
+	// Cancel the execution once the context is done, e.g. on the timeout of the
+	// caller
+	execCtx, cancelExec := context.WithCancel(ctx)
+	defer cancelExec()
+	go func() {
+		<-execCtx.Done()
+		vmenv.Cancel()
+	}()
 
 	result, err := core.ApplyMessage(vmenv, message, new(core.GasPool).AddGas(message.Gas()))
 	if err != nil {
-		return nil, fmt.Errorf("tracing failed: %v", err)
+		return nil, nil, fmt.Errorf("tracing failed: %v", err)
 	}
 	// Depending on the tracer type, format and return the output
+	var trace interface{}
 	switch tracer := tracer.(type) {
 	case *vm.StructLogger:
-		return &ExecutionResult{
+		trace = &ExecutionResult{
 			Gas:         result.UsedGas,
 			Failed:      result.VMErr != nil,
 			ReturnValue: fmt.Sprintf("%x", result.ReturnData),
 			StructLogs:  FormatLogs(tracer.StructLogs(), config),
-		}, nil
+		}
 
 	case *tracers.Tracer:
-		return tracer.GetResult()
+		trace, err = tracer.GetResult()
 	case *tracers.ParityBlockTracer:
-		return tracer.GetResult()
+		trace, err = tracer.GetResult()
 	case *tracers.RosettaBlockTracer:
-		return tracer.GetResult()
+		trace, err = tracer.GetResult()
+	case tracers.NativeTracer:
+		trace, err = tracer.GetResult()
 
 	default:
 		panic(fmt.Sprintf("bad tracer type %T", tracer))
 	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return trace, &result, nil
 }
 
 // ComputeTxEnv returns the execution environment of a certain transaction.
@@ -0,0 +1,78 @@
+package hmy
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/core/vm"
+	"github.com/harmony-one/harmony/internal/params"
+)
+
+// configChain is a chain which only has a config
+type configChain struct {
+	core.BlockChain
+	config *params.ChainConfig
+}
+
+func (c configChain) Config() *params.ChainConfig { return c.config }
+
+func TestTraceMessage_NoGasPrice(t *testing.T) {
+	var (
+		from = common.HexToAddress("0x1000000000000000000000000000000000000001")
+		to   = common.HexToAddress("0x2000000000000000000000000000000000000002")
+		hmy  = &Harmony{BlockChain: configChain{config: params.TestChainConfig}}
+	)
+	// a call with no gas price, after the EIP-1559 epoch
+	msg := types.NewMessage(from, &to, 0, new(big.Int), 100000, new(big.Int), nil, false)
+	newContext := func() (vm.Context, *state.DB) {
+		db, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		db.SetCode(to, common.FromHex("600160005260206000f3"), false)
+		return vm.Context{
+			CanTransfer: core.CanTransfer,
+			Transfer:    core.Transfer,
+			IsValidator: core.IsValidator,
+			GetHash:     func(uint64) common.Hash { return common.Hash{} },
+			GetVRF:      func(uint64) common.Hash { return common.Hash{} },
+			Origin:      from,
+			GasPrice:    new(big.Int),
+			GasLimit:    math.MaxUint64,
+			BlockNumber: big.NewInt(1),
+			EpochNumber: big.NewInt(1),
+			Time:        big.NewInt(0),
+			BaseFee:     new(big.Int).Set(params.InitialBaseFee),
+		}, db
+	}
+	if !params.TestChainConfig.IsEIP1559(big.NewInt(1)) {
+		t.Fatal("the test chain has no EIP-1559")
+	}
+
+	// the call is traced as eth_call runs it
+	vmctx, db := newContext()
+	trace, result, err := hmy.TraceMessage(context.Background(), msg, vmctx, db, nil, true)
+	if err != nil {
+		t.Fatalf("traceCall failed: %v", err)
+	}
+	if result.Failed() {
+		t.Fatalf("the call failed: %v", result.VMErr)
+	}
+	if res := trace.(*ExecutionResult); res.Failed || res.ReturnValue != common.Bytes2Hex(common.LeftPadBytes([]byte{1}, 32)) {
+		t.Errorf("unexpected trace %+v", res)
+	}
+
+	// the transactions of a block are still held to the base fee
+	vmctx, db = newContext()
+	if _, _, err := hmy.TraceMessage(context.Background(), msg, vmctx, db, nil, false); err == nil || !strings.Contains(err.Error(), core.ErrFeeCapTooLow.Error()) {
+		t.Errorf("want %v, got %v", core.ErrFeeCapTooLow, err)
+	}
+}
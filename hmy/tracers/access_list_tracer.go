@@ -0,0 +1,146 @@
+package tracers
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/core/vm"
+)
+
+// accessList is the set of the addresses and storage slots accessed by a call
+type accessList map[common.Address]map[common.Hash]struct{}
+
+// addAddress adds the address to the list if it isn't yet
+func (al accessList) addAddress(addr common.Address) {
+	if _, ok := al[addr]; !ok {
+		al[addr] = make(map[common.Hash]struct{})
+	}
+}
+
+// addSlot adds the storage slot of the address to the list
+func (al accessList) addSlot(addr common.Address, slot common.Hash) {
+	al.addAddress(addr)
+	al[addr][slot] = struct{}{}
+}
+
+// equal returns whether the two lists hold the same addresses and slots
+func (al accessList) equal(other accessList) bool {
+	if len(al) != len(other) {
+		return false
+	}
+	for addr, slots := range al {
+		otherSlots, ok := other[addr]
+		if !ok || len(slots) != len(otherSlots) {
+			return false
+		}
+		for slot := range slots {
+			if _, ok := otherSlots[slot]; !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// accessList converts the list to the EIP-2930 access list, sorted by address
+// and slot so that the result is deterministic
+func (al accessList) accessList() types.AccessList {
+	acl := make(types.AccessList, 0, len(al))
+	for addr, slots := range al {
+		tuple := types.AccessTuple{Address: addr, StorageKeys: make([]common.Hash, 0, len(slots))}
+		for slot := range slots {
+			tuple.StorageKeys = append(tuple.StorageKeys, slot)
+		}
+		sort.Slice(tuple.StorageKeys, func(i, j int) bool {
+			return bytes.Compare(tuple.StorageKeys[i][:], tuple.StorageKeys[j][:]) < 0
+		})
+		acl = append(acl, tuple)
+	}
+	sort.Slice(acl, func(i, j int) bool {
+		return bytes.Compare(acl[i].Address[:], acl[j].Address[:]) < 0
+	})
+	return acl
+}
+
+// AccessListTracer collects the addresses and storage slots accessed by a
+// call, to build the EIP-2930 access list of the transaction. The sender, the
+// recipient and the precompiled contracts are accessed by every call, they are
+// left out of the list unless their storage is accessed.
+type AccessListTracer struct {
+	excl map[common.Address]struct{}
+	list accessList
+}
+
+// NewAccessListTracer returns a tracer starting with the given access list,
+// leaving the from and to addresses and the precompiled contracts out of it.
+func NewAccessListTracer(acl types.AccessList, from, to common.Address, precompiles []common.Address) *AccessListTracer {
+	excl := map[common.Address]struct{}{
+		from: {},
+		to:   {},
+	}
+	for _, addr := range precompiles {
+		excl[addr] = struct{}{}
+	}
+	t := &AccessListTracer{
+		excl: excl,
+		list: make(accessList),
+	}
+	for _, tuple := range acl {
+		t.addAddress(tuple.Address)
+		for _, slot := range tuple.StorageKeys {
+			t.list.addSlot(tuple.Address, slot)
+		}
+	}
+	return t
+}
+
+// addAddress records the address unless it is left out of the list
+func (t *AccessListTracer) addAddress(addr common.Address) {
+	if _, ok := t.excl[addr]; ok {
+		return
+	}
+	t.list.addAddress(addr)
+}
+
+// CaptureStart implements the Tracer interface to initialize the tracing operation.
+func (t *AccessListTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	return nil
+}
+
+// CaptureState implements the Tracer interface to trace a single step of VM execution.
+func (t *AccessListTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) (vm.HookAfter, error) {
+	switch op {
+	case vm.SLOAD, vm.SSTORE:
+		t.list.addSlot(contract.Address(), common.BigToHash(stackBack(stack, 0)))
+	case vm.EXTCODECOPY, vm.EXTCODESIZE, vm.EXTCODEHASH, vm.BALANCE, vm.SELFDESTRUCT:
+		t.addAddress(common.BigToAddress(stackBack(stack, 0)))
+	case vm.CALL, vm.CALLCODE, vm.DELEGATECALL, vm.STATICCALL:
+		t.addAddress(common.BigToAddress(stackBack(stack, 1)))
+	}
+	return nil, nil
+}
+
+// CaptureFault implements the Tracer interface to trace an execution fault
+// while running an opcode.
+func (t *AccessListTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	return nil
+}
+
+// CaptureEnd is called after the call finishes to finalize the tracing.
+func (t *AccessListTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) error {
+	return nil
+}
+
+// AccessList returns the access list collected so far
+func (t *AccessListTracer) AccessList() types.AccessList {
+	return t.list.accessList()
+}
+
+// Equal returns whether the two tracers collected the same access list
+func (t *AccessListTracer) Equal(other *AccessListTracer) bool {
+	return t.list.equal(other.list)
+}
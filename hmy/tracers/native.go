@@ -0,0 +1,127 @@
+package tracers
+
+import (
+	"encoding/json"
+	"math/big"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/harmony-one/harmony/core/vm"
+)
+
+// NativeTracer is a tracer implemented in Go. It produces the same output as the
+// JavaScript tracer with the same name, without the cost of the JS engine.
+type NativeTracer interface {
+	vm.Tracer
+	// GetResult returns the json encoded result of the tracer, or any error
+	// accumulated while tracing
+	GetResult() (json.RawMessage, error)
+	// Stop terminates the tracing at the first opportune moment
+	Stop(err error)
+}
+
+// nativeTracers contains all the native tracers by name
+var nativeTracers = map[string]func(cfg json.RawMessage) (NativeTracer, error){
+	"callTracer":     newCallTracer,
+	"prestateTracer": newPrestateTracer,
+	"4byteTracer":    newFourByteTracer,
+}
+
+// NewNative returns the native tracer with the given name configured with cfg.
+// It returns false if there is no native tracer with the name.
+func NewNative(name string, cfg json.RawMessage) (NativeTracer, bool, error) {
+	newTracer, ok := nativeTracers[name]
+	if !ok {
+		return nil, false, nil
+	}
+	tracer, err := newTracer(cfg)
+	if err != nil {
+		return nil, true, err
+	}
+	return tracer, true, nil
+}
+
+// parseNativeConfig decodes the tracer config into cfg, an empty config keeps
+// the defaults
+func parseNativeConfig(raw json.RawMessage, cfg interface{}) error {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	return json.Unmarshal(raw, cfg)
+}
+
+// interrupter implements the interruption of the native tracers
+type interrupter struct {
+	interrupt uint32     // Atomic flag to signal execution interruption
+	reason    error      // Textual reason for the interruption
+	lock      sync.Mutex // Guards the reason, set and read by different goroutines
+}
+
+// Stop terminates the tracing at the first opportune moment
+func (i *interrupter) Stop(err error) {
+	i.lock.Lock()
+	i.reason = err
+	i.lock.Unlock()
+	atomic.StoreUint32(&i.interrupt, 1)
+}
+
+// interrupted returns whether the tracing was stopped
+func (i *interrupter) interrupted() bool {
+	return atomic.LoadUint32(&i.interrupt) > 0
+}
+
+// stopReason returns the reason the tracing was stopped with
+func (i *interrupter) stopReason() error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	return i.reason
+}
+
+// precompileSet is the set of the precompiled contracts enabled at the epoch of
+// the traced block
+type precompileSet map[common.Address]struct{}
+
+// activePrecompiles returns the precompiled contracts enabled in the evm
+func activePrecompiles(env *vm.EVM) precompileSet {
+	set := make(precompileSet)
+	for _, addr := range vm.ActivePrecompiles(env.ChainConfig().Rules(env.EpochNumber)) {
+		set[addr] = struct{}{}
+	}
+	return set
+}
+
+// contains returns whether the address is a precompiled contract
+func (set precompileSet) contains(addr common.Address) bool {
+	_, ok := set[addr]
+	return ok
+}
+
+// stackBack returns the n-th item from the top of the stack, or zero if the
+// stack is too short
+func stackBack(stack *vm.Stack, n int) *big.Int {
+	if n >= len(stack.Data()) {
+		return new(big.Int)
+	}
+	return stack.Back(n)
+}
+
+// memoryCopy returns a copy of the memory slice, or nothing if it is out of
+// the bounds of the memory
+func memoryCopy(memory *vm.Memory, off, size *big.Int) []byte {
+	if !off.IsInt64() || !size.IsInt64() || off.Int64()+size.Int64() > int64(memory.Len()) {
+		return nil
+	}
+	return memory.GetCopy(off.Int64(), size.Int64())
+}
+
+// hexBytes returns the bytes as a non nil hex value, so that empty bytes are
+// encoded as "0x"
+func hexBytes(b []byte) *hexutil.Bytes {
+	hb := hexutil.Bytes(common.CopyBytes(b))
+	if hb == nil {
+		hb = hexutil.Bytes{}
+	}
+	return &hb
+}
@@ -0,0 +1,85 @@
+package tracers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/core/vm"
+)
+
+// fourByteTracer is the native version of 4byte_tracer.js. It counts the 4 byte
+// method identifiers of the calls along with the size of their arguments, the
+// keys of the result are of the form "0x<selector>-<argument size>".
+type fourByteTracer struct {
+	interrupter
+	ids         map[string]int
+	precompiles precompileSet
+}
+
+func newFourByteTracer(cfg json.RawMessage) (NativeTracer, error) {
+	return &fourByteTracer{ids: make(map[string]int)}, nil
+}
+
+// store counts the method identifier with the size of the call arguments
+func (t *fourByteTracer) store(id []byte, size int) {
+	t.ids[fmt.Sprintf("0x%x-%d", id, size)]++
+}
+
+// CaptureStart implements the Tracer interface to initialize the tracing operation.
+func (t *fourByteTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	t.precompiles = activePrecompiles(env)
+	if len(input) >= 4 {
+		t.store(input[:4], len(input)-4)
+	}
+	return nil
+}
+
+// CaptureState implements the Tracer interface to trace a single step of VM execution.
+func (t *fourByteTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) (vm.HookAfter, error) {
+	if t.interrupted() {
+		return nil, nil
+	}
+	// position of the input offset on the stack
+	var inPos int
+	switch op {
+	case vm.CALL, vm.CALLCODE:
+		inPos = 3
+	case vm.DELEGATECALL, vm.STATICCALL:
+		inPos = 2
+	default:
+		return nil, nil
+	}
+	if t.precompiles.contains(common.BigToAddress(stackBack(stack, 1))) {
+		return nil, nil
+	}
+	inSize := stackBack(stack, inPos+1)
+	if !inSize.IsInt64() || inSize.Int64() < 4 {
+		return nil, nil
+	}
+	if id := memoryCopy(memory, stackBack(stack, inPos), big.NewInt(4)); len(id) == 4 {
+		t.store(id, int(inSize.Int64())-4)
+	}
+	return nil, nil
+}
+
+// CaptureFault implements the Tracer interface to trace an execution fault
+// while running an opcode.
+func (t *fourByteTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	return nil
+}
+
+// CaptureEnd is called after the call finishes to finalize the tracing.
+func (t *fourByteTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) error {
+	return nil
+}
+
+// GetResult returns the json encoded counts of the method identifiers
+func (t *fourByteTracer) GetResult() (json.RawMessage, error) {
+	if t.interrupted() {
+		return nil, t.stopReason()
+	}
+	return json.Marshal(t.ids)
+}
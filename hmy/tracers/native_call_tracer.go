@@ -0,0 +1,320 @@
+package tracers
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/harmony-one/harmony/core/vm"
+)
+
+// callTracerConfig is the config of the native callTracer
+type callTracerConfig struct {
+	OnlyTopCall bool `json:"onlyTopCall"` // If true, call tracer won't collect any subcalls
+	WithLog     bool `json:"withLog"`     // If true, call tracer will collect event logs
+}
+
+// callLog is an event log emitted by a call frame
+type callLog struct {
+	Address common.Address `json:"address"`
+	Topics  []common.Hash  `json:"topics"`
+	Data    hexutil.Bytes  `json:"data"`
+}
+
+// callFrame is a call of the callTracer result. The fields are in the order of
+// the output of call_tracer.js.
+type callFrame struct {
+	Type    string          `json:"type"`
+	From    common.Address  `json:"from"`
+	To      *common.Address `json:"to,omitempty"`
+	Value   *hexutil.Big    `json:"value,omitempty"`
+	Gas     *hexutil.Uint64 `json:"gas,omitempty"`
+	GasUsed *hexutil.Uint64 `json:"gasUsed,omitempty"`
+	Input   *hexutil.Bytes  `json:"input,omitempty"`
+	Output  *hexutil.Bytes  `json:"output,omitempty"`
+	Error   string          `json:"error,omitempty"`
+	Time    string          `json:"time,omitempty"`
+	Calls   []*callFrame    `json:"calls,omitempty"`
+	Logs    []callLog       `json:"logs,omitempty"`
+
+	// bookkeeping while the call runs
+	gasIn   uint64
+	gasCost uint64
+	outOff  *big.Int
+	outLen  *big.Int
+}
+
+// callTracer is the native version of call_tracer.js
+type callTracer struct {
+	interrupter
+	config callTracerConfig
+
+	callstack   []*callFrame
+	precompiles precompileSet
+	// descended is set when a call was entered, the gas of the call is only
+	// known at its first step
+	descended bool
+
+	// context of the transaction
+	typ     string
+	from    common.Address
+	to      common.Address
+	input   []byte
+	gas     uint64
+	value   *big.Int
+	output  []byte
+	gasUsed uint64
+	time    time.Duration
+	err     error
+}
+
+func newCallTracer(cfg json.RawMessage) (NativeTracer, error) {
+	t := &callTracer{callstack: []*callFrame{{}}}
+	if err := parseNativeConfig(cfg, &t.config); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// CaptureStart implements the Tracer interface to initialize the tracing operation.
+func (t *callTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	t.precompiles = activePrecompiles(env)
+	t.typ = "CALL"
+	if create {
+		t.typ = "CREATE"
+	}
+	t.from = from
+	t.to = to
+	t.input = common.CopyBytes(input)
+	t.gas = gas
+	t.value = new(big.Int).Set(value)
+	return nil
+}
+
+// CaptureState implements the Tracer interface to trace a single step of VM execution.
+func (t *callTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) (vm.HookAfter, error) {
+	if t.interrupted() || (t.config.OnlyTopCall && depth > 1) {
+		return nil, nil
+	}
+	if err != nil {
+		t.fault(gas, err)
+		return nil, nil
+	}
+
+	switch op {
+	case vm.CREATE, vm.CREATE2:
+		if t.config.OnlyTopCall {
+			return nil, nil
+		}
+		t.callstack = append(t.callstack, &callFrame{
+			Type:    op.String(),
+			From:    contract.Address(),
+			Input:   hexBytes(memoryCopy(memory, stackBack(stack, 1), stackBack(stack, 2))),
+			Value:   (*hexutil.Big)(new(big.Int).Set(stackBack(stack, 0))),
+			gasIn:   gas,
+			gasCost: cost,
+		})
+		t.descended = true
+		return nil, nil
+
+	case vm.SELFDESTRUCT:
+		if t.config.OnlyTopCall {
+			return nil, nil
+		}
+		to := common.BigToAddress(stackBack(stack, 0))
+		parent := t.callstack[len(t.callstack)-1]
+		parent.Calls = append(parent.Calls, &callFrame{
+			Type:  op.String(),
+			From:  contract.Address(),
+			To:    &to,
+			Value: (*hexutil.Big)(new(big.Int).Set(env.StateDB.GetBalance(contract.Address()))),
+		})
+		return nil, nil
+
+	case vm.CALL, vm.CALLCODE, vm.DELEGATECALL, vm.STATICCALL:
+		to := common.BigToAddress(stackBack(stack, 1))
+		if t.config.OnlyTopCall || t.precompiles.contains(to) {
+			return nil, nil
+		}
+		off := 1
+		if op == vm.DELEGATECALL || op == vm.STATICCALL {
+			off = 0
+		}
+		call := &callFrame{
+			Type:    op.String(),
+			From:    contract.Address(),
+			To:      &to,
+			Input:   hexBytes(memoryCopy(memory, stackBack(stack, 2+off), stackBack(stack, 3+off))),
+			gasIn:   gas,
+			gasCost: cost,
+			outOff:  new(big.Int).Set(stackBack(stack, 4+off)),
+			outLen:  new(big.Int).Set(stackBack(stack, 5+off)),
+		}
+		if op != vm.DELEGATECALL && op != vm.STATICCALL {
+			call.Value = (*hexutil.Big)(new(big.Int).Set(stackBack(stack, 2)))
+		}
+		t.callstack = append(t.callstack, call)
+		t.descended = true
+		return nil, nil
+	}
+
+	// If we've just descended into an inner call, retrieve its true allowance
+	if t.descended {
+		if depth >= len(t.callstack) {
+			callGas := hexutil.Uint64(gas)
+			t.callstack[len(t.callstack)-1].Gas = &callGas
+		}
+		t.descended = false
+	}
+	if op == vm.REVERT {
+		t.callstack[len(t.callstack)-1].Error = "execution reverted"
+		return nil, nil
+	}
+	// If we've just returned from a call, pop it and add it to its parent
+	if depth == len(t.callstack)-1 {
+		call := t.callstack[len(t.callstack)-1]
+		t.callstack = t.callstack[:len(t.callstack)-1]
+
+		ret := stackBack(stack, 0)
+		if call.Type == vm.CREATE.String() || call.Type == vm.CREATE2.String() {
+			gasUsed := hexutil.Uint64(call.gasIn - call.gasCost - gas)
+			call.GasUsed = &gasUsed
+			if ret.Sign() != 0 {
+				to := common.BigToAddress(ret)
+				call.To = &to
+				call.Output = hexBytes(env.StateDB.GetCode(to))
+			} else if call.Error == "" {
+				call.Error = "internal failure"
+			}
+		} else {
+			if call.Gas != nil {
+				gasUsed := hexutil.Uint64(call.gasIn - call.gasCost + uint64(*call.Gas) - gas)
+				call.GasUsed = &gasUsed
+			}
+			if ret.Sign() != 0 {
+				call.Output = hexBytes(memoryCopy(memory, call.outOff, call.outLen))
+			} else if call.Error == "" {
+				call.Error = "internal failure"
+			}
+		}
+		parent := t.callstack[len(t.callstack)-1]
+		parent.Calls = append(parent.Calls, call)
+	}
+	if t.config.WithLog && op >= vm.LOG0 && op <= vm.LOG4 {
+		t.captureLog(op, memory, stack, contract)
+	}
+	return nil, nil
+}
+
+// captureLog adds the log emitted by the op to the current call
+func (t *callTracer) captureLog(op vm.OpCode, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract) {
+	size := int(op - vm.LOG0)
+	topics := make([]common.Hash, size)
+	for i := 0; i < size; i++ {
+		topics[i] = common.BigToHash(stackBack(stack, 2+i))
+	}
+	data := memoryCopy(memory, stackBack(stack, 0), stackBack(stack, 1))
+	if data == nil {
+		data = []byte{}
+	}
+	call := t.callstack[len(t.callstack)-1]
+	call.Logs = append(call.Logs, callLog{
+		Address: contract.Address(),
+		Topics:  topics,
+		Data:    data,
+	})
+}
+
+// CaptureFault implements the Tracer interface to trace an execution fault
+// while running an opcode.
+func (t *callTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	if t.interrupted() || (t.config.OnlyTopCall && depth > 1) {
+		return nil
+	}
+	t.fault(gas, err)
+	return nil
+}
+
+// fault marks the current call as failed and flattens it into its parent
+func (t *callTracer) fault(gas uint64, err error) {
+	// If the topmost call already reverted, don't handle the additional fault again
+	if t.callstack[len(t.callstack)-1].Error != "" {
+		return
+	}
+	call := t.callstack[len(t.callstack)-1]
+	t.callstack = t.callstack[:len(t.callstack)-1]
+	call.Error = err.Error()
+
+	// Consume all available gas and clean any leftovers
+	if call.Gas != nil {
+		call.GasUsed = call.Gas
+	}
+	if len(t.callstack) > 0 {
+		parent := t.callstack[len(t.callstack)-1]
+		parent.Calls = append(parent.Calls, call)
+		return
+	}
+	t.callstack = append(t.callstack, call)
+}
+
+// CaptureEnd is called after the call finishes to finalize the tracing.
+func (t *callTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) error {
+	t.output = common.CopyBytes(output)
+	t.gasUsed = gasUsed
+	t.time = d
+	t.err = err
+	return nil
+}
+
+// GetResult returns the json encoded top call with its subcalls
+func (t *callTracer) GetResult() (json.RawMessage, error) {
+	if t.interrupted() {
+		return nil, t.stopReason()
+	}
+	if len(t.callstack) != 1 {
+		return nil, errors.New("incorrect number of top-level calls")
+	}
+	root := t.callstack[0]
+	to := t.to
+	gas, gasUsed := hexutil.Uint64(t.gas), hexutil.Uint64(t.gasUsed)
+	result := &callFrame{
+		Type:    t.typ,
+		From:    t.from,
+		To:      &to,
+		Value:   (*hexutil.Big)(t.value),
+		Gas:     &gas,
+		GasUsed: &gasUsed,
+		Input:   hexBytes(t.input),
+		Output:  hexBytes(t.output),
+		Time:    t.time.String(),
+		Calls:   root.Calls,
+		Logs:    root.Logs,
+	}
+	if root.Error != "" {
+		result.Error = root.Error
+	} else if t.err != nil {
+		result.Error = t.err.Error()
+	}
+	if result.Error != "" && (result.Error != "execution reverted" || len(t.output) == 0) {
+		result.Output = nil
+	}
+	if t.config.WithLog {
+		clearFailedLogs(result, false)
+	}
+	return json.Marshal(result)
+}
+
+// clearFailedLogs drops the logs of the failed calls and of their subcalls, as
+// they were reverted
+func clearFailedLogs(call *callFrame, parentFailed bool) {
+	failed := parentFailed || call.Error != ""
+	if failed {
+		call.Logs = nil
+	}
+	for _, sub := range call.Calls {
+		clearFailedLogs(sub, failed)
+	}
+}
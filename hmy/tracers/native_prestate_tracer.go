@@ -0,0 +1,264 @@
+package tracers
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/harmony-one/harmony/core/vm"
+)
+
+// prestateTracerConfig is the config of the native prestateTracer
+type prestateTracerConfig struct {
+	DiffMode bool `json:"diffMode"` // If true, this tracer will return state modifications
+}
+
+// prestateAccount is an account of the prestateTracer result, in the format of
+// prestate_tracer.js
+type prestateAccount struct {
+	Balance *hexutil.Big                `json:"balance"`
+	Nonce   uint64                      `json:"nonce"`
+	Code    hexutil.Bytes               `json:"code"`
+	Storage map[common.Hash]common.Hash `json:"storage"`
+}
+
+// diffAccount is an account of the diffMode result, only the fields changed by
+// the transaction are set
+type diffAccount struct {
+	Balance *hexutil.Big                `json:"balance,omitempty"`
+	Nonce   uint64                      `json:"nonce,omitempty"`
+	Code    hexutil.Bytes               `json:"code,omitempty"`
+	Storage map[common.Hash]common.Hash `json:"storage,omitempty"`
+}
+
+// stateDiff is the result of the prestateTracer in diffMode
+type stateDiff struct {
+	Pre  map[common.Address]*diffAccount `json:"pre"`
+	Post map[common.Address]*diffAccount `json:"post"`
+}
+
+// prestateTracer is the native version of prestate_tracer.js. It collects the
+// accounts and storage slots touched by the transaction as they were before it.
+type prestateTracer struct {
+	interrupter
+	config prestateTracerConfig
+
+	env         *vm.EVM
+	precompiles precompileSet
+	prestate    map[common.Address]*prestateAccount
+	created     map[common.Address]bool
+	deleted     map[common.Address]bool
+
+	// context of the transaction
+	create   bool
+	from     common.Address
+	to       common.Address
+	value    *big.Int
+	gasLimit uint64
+}
+
+func newPrestateTracer(cfg json.RawMessage) (NativeTracer, error) {
+	t := &prestateTracer{
+		prestate: make(map[common.Address]*prestateAccount),
+		created:  make(map[common.Address]bool),
+		deleted:  make(map[common.Address]bool),
+	}
+	if err := parseNativeConfig(cfg, &t.config); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// lookupAccount records the account if it wasn't yet
+func (t *prestateTracer) lookupAccount(addr common.Address) {
+	if _, ok := t.prestate[addr]; ok {
+		return
+	}
+	db := t.env.StateDB
+	t.prestate[addr] = &prestateAccount{
+		Balance: (*hexutil.Big)(new(big.Int).Set(db.GetBalance(addr))),
+		Nonce:   db.GetNonce(addr),
+		Code:    common.CopyBytes(db.GetCode(addr)),
+		Storage: make(map[common.Hash]common.Hash),
+	}
+}
+
+// lookupStorage records the storage slot of the account if it wasn't yet
+func (t *prestateTracer) lookupStorage(addr common.Address, key common.Hash) {
+	t.lookupAccount(addr)
+	if _, ok := t.prestate[addr].Storage[key]; ok {
+		return
+	}
+	t.prestate[addr].Storage[key] = t.env.StateDB.GetState(addr, key)
+}
+
+// CaptureTxStart implements the TxTracer interface, it is called before the gas
+// of the transaction is bought.
+func (t *prestateTracer) CaptureTxStart(gasLimit uint64) {
+	t.gasLimit = gasLimit
+}
+
+// CaptureStart implements the Tracer interface to initialize the tracing operation.
+func (t *prestateTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	t.env = env
+	t.precompiles = activePrecompiles(env)
+	t.create = create
+	t.from = from
+	t.to = to
+	t.value = new(big.Int).Set(value)
+
+	t.lookupAccount(from)
+	t.lookupAccount(to)
+	if create {
+		t.created[to] = true
+	}
+	return nil
+}
+
+// CaptureState implements the Tracer interface to trace a single step of VM execution.
+func (t *prestateTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) (vm.HookAfter, error) {
+	if t.interrupted() {
+		return nil, nil
+	}
+	switch op {
+	case vm.EXTCODECOPY, vm.EXTCODESIZE, vm.EXTCODEHASH, vm.BALANCE:
+		t.lookupAccount(common.BigToAddress(stackBack(stack, 0)))
+	case vm.CREATE:
+		from := contract.Address()
+		addr := crypto.CreateAddress(from, env.StateDB.GetNonce(from))
+		t.lookupAccount(addr)
+		t.created[addr] = true
+	case vm.CREATE2:
+		from := contract.Address()
+		initCode := memoryCopy(memory, stackBack(stack, 1), stackBack(stack, 2))
+		salt := common.BigToHash(stackBack(stack, 3))
+		addr := crypto.CreateAddress2(from, salt, crypto.Keccak256(initCode))
+		t.lookupAccount(addr)
+		t.created[addr] = true
+	case vm.CALL, vm.CALLCODE, vm.DELEGATECALL, vm.STATICCALL:
+		if addr := common.BigToAddress(stackBack(stack, 1)); !t.precompiles.contains(addr) {
+			t.lookupAccount(addr)
+		}
+	case vm.SSTORE, vm.SLOAD:
+		t.lookupStorage(contract.Address(), common.BigToHash(stackBack(stack, 0)))
+	case vm.SELFDESTRUCT:
+		t.lookupAccount(common.BigToAddress(stackBack(stack, 0)))
+		t.deleted[contract.Address()] = true
+	}
+	return nil, nil
+}
+
+// CaptureFault implements the Tracer interface to trace an execution fault
+// while running an opcode.
+func (t *prestateTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	return nil
+}
+
+// CaptureEnd is called after the call finishes to finalize the tracing.
+func (t *prestateTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) error {
+	if t.env == nil {
+		return nil
+	}
+	// The value was transferred, the gas bought and the nonce of the sender
+	// increased before the tracing started, undo it
+	from, to := t.prestate[t.from], t.prestate[t.to]
+	to.Balance = (*hexutil.Big)(new(big.Int).Sub(to.Balance.ToInt(), t.value))
+	fromBalance := new(big.Int).Add(from.Balance.ToInt(), t.value)
+	if gasPrice := t.env.Context.GasPrice; gasPrice != nil {
+		fromBalance.Add(fromBalance, new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(t.gasLimit)))
+	}
+	from.Balance = (*hexutil.Big)(fromBalance)
+	from.Nonce--
+	return nil
+}
+
+// GetResult returns the json encoded prestate of the accounts, or the changes
+// of the accounts in diffMode
+func (t *prestateTracer) GetResult() (json.RawMessage, error) {
+	if t.interrupted() {
+		return nil, t.stopReason()
+	}
+	if t.config.DiffMode {
+		return json.Marshal(t.diff())
+	}
+	if t.create {
+		delete(t.prestate, t.to)
+	}
+	return json.Marshal(t.prestate)
+}
+
+// diff compares the prestate of the accounts with their state after the
+// transaction. Only the accounts and fields that changed are kept.
+func (t *prestateTracer) diff() *stateDiff {
+	result := &stateDiff{
+		Pre:  make(map[common.Address]*diffAccount),
+		Post: make(map[common.Address]*diffAccount),
+	}
+	if t.env == nil {
+		return result
+	}
+	db := t.env.StateDB
+	for addr, pre := range t.prestate {
+		preAcc := &diffAccount{
+			Balance: pre.Balance,
+			Nonce:   pre.Nonce,
+			Code:    pre.Code,
+			Storage: make(map[common.Hash]common.Hash),
+		}
+		// accounts created by the transaction have no prestate, all their
+		// fields are in the poststate
+		base := pre
+		if t.created[addr] {
+			preAcc = nil
+			base = &prestateAccount{Balance: new(hexutil.Big), Storage: pre.Storage}
+		}
+		// accounts deleted by the transaction have no poststate
+		if t.deleted[addr] {
+			if preAcc != nil {
+				preAcc.Storage = pre.Storage
+				result.Pre[addr] = preAcc
+			}
+			continue
+		}
+
+		postAcc := &diffAccount{Storage: make(map[common.Hash]common.Hash)}
+		modified := false
+		if balance := db.GetBalance(addr); balance.Cmp(base.Balance.ToInt()) != 0 {
+			postAcc.Balance = (*hexutil.Big)(new(big.Int).Set(balance))
+			modified = true
+		}
+		if nonce := db.GetNonce(addr); nonce != base.Nonce {
+			postAcc.Nonce = nonce
+			modified = true
+		}
+		if code := db.GetCode(addr); !bytes.Equal(code, base.Code) {
+			postAcc.Code = common.CopyBytes(code)
+			modified = true
+		}
+		for key, val := range pre.Storage {
+			newVal := db.GetState(addr, key)
+			if newVal == val {
+				continue
+			}
+			modified = true
+			if val != (common.Hash{}) && preAcc != nil {
+				preAcc.Storage[key] = val
+			}
+			if newVal != (common.Hash{}) {
+				postAcc.Storage[key] = newVal
+			}
+		}
+		if !modified {
+			continue
+		}
+		if preAcc != nil {
+			result.Pre[addr] = preAcc
+		}
+		result.Post[addr] = postAcc
+	}
+	return result
+}
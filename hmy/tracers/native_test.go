@@ -0,0 +1,228 @@
+package tracers
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/vm"
+	"github.com/harmony-one/harmony/core/vm/runtime"
+)
+
+var (
+	nativeTestOrigin = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	nativeTestCaller = common.HexToAddress("0x2000000000000000000000000000000000000002")
+	nativeTestCallee = common.HexToAddress("0x3000000000000000000000000000000000000003")
+)
+
+// runNativeTracer calls a contract calling the method 0x12345678 of another
+// contract, which stores 0x2a in the slot 1 and emits a log with the topic 1
+func runNativeTracer(t *testing.T, name string, cfg string) json.RawMessage {
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	statedb.SetNonce(nativeTestOrigin, 1)
+	statedb.SetCode(nativeTestCallee, common.FromHex("602a600155600160006000a100"), false)
+	statedb.SetCode(nativeTestCaller, common.FromHex(
+		"6312345678600052600060006004601c600073"+nativeTestCallee.Hex()[2:]+"5af15000"), false)
+
+	tracer, ok, err := NewNative(name, json.RawMessage(cfg))
+	if err != nil || !ok {
+		t.Fatalf("failed to create tracer %v: %v", name, err)
+	}
+	_, _, err = runtime.Call(nativeTestCaller, nil, &runtime.Config{
+		Origin:    nativeTestOrigin,
+		GasLimit:  1000000,
+		State:     statedb,
+		EVMConfig: vm.Config{Debug: true, Tracer: tracer},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := tracer.GetResult()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+func TestNativeCallTracer(t *testing.T) {
+	var res callFrame
+	if err := json.Unmarshal(runNativeTracer(t, "callTracer", `{"withLog":true}`), &res); err != nil {
+		t.Fatal(err)
+	}
+	if res.Type != "CALL" || res.From != nativeTestOrigin || *res.To != nativeTestCaller {
+		t.Errorf("unexpected top call: %+v", res)
+	}
+	if len(res.Calls) != 1 {
+		t.Fatalf("unexpected number of subcalls: %v", len(res.Calls))
+	}
+	sub := res.Calls[0]
+	if sub.Type != "CALL" || sub.From != nativeTestCaller || *sub.To != nativeTestCallee {
+		t.Errorf("unexpected subcall: %+v", sub)
+	}
+	if sub.Input.String() != "0x12345678" {
+		t.Errorf("unexpected subcall input: %v", sub.Input)
+	}
+	if sub.Gas == nil || sub.GasUsed == nil || *sub.GasUsed == 0 {
+		t.Errorf("missing subcall gas: %+v", sub)
+	}
+	if len(sub.Logs) != 1 || sub.Logs[0].Address != nativeTestCallee ||
+		sub.Logs[0].Topics[0] != common.BigToHash(common.Big1) {
+		t.Errorf("unexpected subcall logs: %+v", sub.Logs)
+	}
+
+	res = callFrame{}
+	if err := json.Unmarshal(runNativeTracer(t, "callTracer", `{"onlyTopCall":true}`), &res); err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Calls) != 0 {
+		t.Errorf("unexpected subcalls with onlyTopCall: %v", len(res.Calls))
+	}
+}
+
+func TestNativePrestateTracer(t *testing.T) {
+	var res map[common.Address]*prestateAccount
+	if err := json.Unmarshal(runNativeTracer(t, "prestateTracer", ""), &res); err != nil {
+		t.Fatal(err)
+	}
+	for _, addr := range []common.Address{nativeTestOrigin, nativeTestCaller, nativeTestCallee} {
+		if _, ok := res[addr]; !ok {
+			t.Errorf("missing account %v", addr.Hex())
+		}
+	}
+	if acc := res[nativeTestOrigin]; acc != nil && acc.Nonce != 0 {
+		t.Errorf("unexpected origin nonce: %v", acc.Nonce)
+	}
+	slot := common.BigToHash(common.Big1)
+	if val, ok := res[nativeTestCallee].Storage[slot]; !ok || val != (common.Hash{}) {
+		t.Errorf("unexpected callee storage: %v", res[nativeTestCallee].Storage)
+	}
+
+	var diff stateDiff
+	if err := json.Unmarshal(runNativeTracer(t, "prestateTracer", `{"diffMode":true}`), &diff); err != nil {
+		t.Fatal(err)
+	}
+	if post, ok := diff.Post[nativeTestCallee]; !ok || post.Storage[slot] != common.HexToHash("0x2a") {
+		t.Errorf("unexpected callee poststate: %+v", post)
+	}
+	if _, ok := diff.Post[nativeTestCaller]; ok {
+		t.Errorf("unmodified caller in poststate")
+	}
+}
+
+func TestNativePrestateTracerDiffSender(t *testing.T) {
+	var (
+		balance  = big.NewInt(1e18)
+		gasPrice = big.NewInt(2)
+		gasLimit = uint64(1000000)
+		bought   = new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gasLimit))
+	)
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	statedb.SetNonce(nativeTestOrigin, 1)
+	statedb.SetCode(nativeTestCallee, common.FromHex("602a600155600160006000a100"), false)
+
+	tracer, _, err := NewNative("prestateTracer", json.RawMessage(`{"diffMode":true}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the gas is bought after the start of the transaction, and the gas left
+	// refunded after the call, as by the state transition
+	tracer.(vm.TxTracer).CaptureTxStart(gasLimit)
+	statedb.AddBalance(nativeTestOrigin, new(big.Int).Sub(balance, bought))
+	_, left, err := runtime.Call(nativeTestCallee, nil, &runtime.Config{
+		Origin:    nativeTestOrigin,
+		GasLimit:  gasLimit,
+		GasPrice:  gasPrice,
+		State:     statedb,
+		EVMConfig: vm.Config{Debug: true, Tracer: tracer},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	statedb.AddBalance(nativeTestOrigin, new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(left)))
+	res, err := tracer.GetResult()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var diff stateDiff
+	if err := json.Unmarshal(res, &diff); err != nil {
+		t.Fatal(err)
+	}
+	pre, post := diff.Pre[nativeTestOrigin], diff.Post[nativeTestOrigin]
+	if pre == nil || post == nil || pre.Balance == nil || post.Balance == nil {
+		t.Fatalf("missing sender balance: %+v / %+v", pre, post)
+	}
+	if pre.Balance.ToInt().Cmp(balance) != 0 {
+		t.Errorf("unexpected sender prestate balance %v / %v", pre.Balance.ToInt(), balance)
+	}
+	// the sender paid the gas used only
+	paid := new(big.Int).Sub(pre.Balance.ToInt(), post.Balance.ToInt())
+	if used := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gasLimit-left)); paid.Cmp(used) != 0 {
+		t.Errorf("unexpected sender balance delta %v / %v", paid, used)
+	}
+}
+
+func TestNative4ByteTracer(t *testing.T) {
+	var res map[string]int
+	if err := json.Unmarshal(runNativeTracer(t, "4byteTracer", ""), &res); err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 1 || res["0x12345678-0"] != 1 {
+		t.Errorf("unexpected method ids: %v", res)
+	}
+}
+
+func TestNativeTracerStop(t *testing.T) {
+	reason := errors.New("execution timeout")
+	for name := range nativeTracers {
+		tracer, _, err := NewNative(name, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// the tracer is stopped from the goroutine of the timeout
+		done := make(chan struct{})
+		go func() {
+			tracer.Stop(reason)
+			close(done)
+		}()
+		<-done
+		if _, err := tracer.GetResult(); err != reason {
+			t.Errorf("%v: unexpected error %v / %v", name, err, reason)
+		}
+	}
+}
+
+func TestAccessListTracer(t *testing.T) {
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	statedb.SetCode(nativeTestCallee, common.FromHex("602a600155600160006000a100"), false)
+	statedb.SetCode(nativeTestCaller, common.FromHex(
+		"6312345678600052600060006004601c600073"+nativeTestCallee.Hex()[2:]+"5af15000"), false)
+
+	tracer := NewAccessListTracer(nil, nativeTestOrigin, nativeTestCaller, nil)
+	_, _, err := runtime.Call(nativeTestCaller, nil, &runtime.Config{
+		Origin:    nativeTestOrigin,
+		GasLimit:  1000000,
+		State:     statedb,
+		EVMConfig: vm.Config{Debug: true, Tracer: tracer},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	acl := tracer.AccessList()
+	if len(acl) != 1 || acl[0].Address != nativeTestCallee {
+		t.Fatalf("unexpected access list: %+v", acl)
+	}
+	if keys := acl[0].StorageKeys; len(keys) != 1 || keys[0] != common.BigToHash(common.Big1) {
+		t.Errorf("unexpected storage keys: %v", keys)
+	}
+	if !tracer.Equal(NewAccessListTracer(acl, nativeTestOrigin, nativeTestCaller, nil)) {
+		t.Errorf("tracer not equal to its own access list")
+	}
+	if tracer.Equal(NewAccessListTracer(nil, nativeTestOrigin, nativeTestCaller, nil)) {
+		t.Errorf("tracer equal to an empty access list")
+	}
+}
@@ -3,6 +3,7 @@ package blsgen
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	bls_core "github.com/harmony-one/bls/ffi/go/bls"
 	"github.com/harmony-one/harmony/multibls"
@@ -11,6 +12,9 @@ import (
 // LoadKeys load all BLS keys with the given config. If loading keys from files, the
 // file extension will decide which decryption algorithm to use.
 func LoadKeys(cfg Config) (multibls.PrivateKeys, error) {
+	if cfg.RemoteSignerURL != "" {
+		return loadRemoteKeys(cfg)
+	}
 	decrypters, err := getKeyDecrypters(cfg)
 	if err != nil {
 		return nil, err
@@ -59,6 +63,17 @@ type Config struct {
 	AwsCfgSrcType AwsCfgSrcType
 	// AwsConfigFile set the json file to load aws config.
 	AwsConfigFile *string
+
+	// Remote signer related settings. If RemoteSignerURL is set, the keys are held
+	// by the remote signer and no key file is loaded or decrypted.
+	//
+	// RemoteSignerURL is the http(s) url of the remote signer.
+	RemoteSignerURL string
+	// RemoteSignerKeys are the hex public keys to sign with. If empty, all keys held
+	// by the remote signer are used.
+	RemoteSignerKeys []string
+	// RemoteSignerTimeout is the timeout of a request to the remote signer.
+	RemoteSignerTimeout time.Duration
 }
 
 func (cfg *Config) getPassProviderConfig() passDecrypterConfig {
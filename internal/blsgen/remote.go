@@ -0,0 +1,55 @@
+package blsgen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/harmony-one/harmony/crypto/bls"
+	"github.com/harmony-one/harmony/crypto/bls/remotesigner"
+	"github.com/harmony-one/harmony/multibls"
+)
+
+// loadRemoteKeys loads the keys held by the remote signer. The secret keys never
+// enter the process, every signature is requested from the remote signer.
+func loadRemoteKeys(cfg Config) (multibls.PrivateKeys, error) {
+	client, err := remotesigner.NewClient(cfg.RemoteSignerURL, cfg.RemoteSignerTimeout)
+	if err != nil {
+		return nil, err
+	}
+	held, err := client.PublicKeys()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get public keys from remote signer: %v", err)
+	}
+	heldSet := make(map[bls.SerializedPublicKey]struct{}, len(held))
+	for _, key := range held {
+		heldSet[key] = struct{}{}
+	}
+
+	var pubKeys []*bls.PublicKeyWrapper
+	if len(cfg.RemoteSignerKeys) == 0 {
+		for _, key := range held {
+			pub, err := bls.WrapperPublicKeyFromString(key.Hex())
+			if err != nil {
+				return nil, err
+			}
+			pubKeys = append(pubKeys, pub)
+		}
+	} else {
+		for _, hexKey := range cfg.RemoteSignerKeys {
+			pub, err := bls.WrapperPublicKeyFromString(strings.TrimPrefix(hexKey, "0x"))
+			if err != nil {
+				return nil, fmt.Errorf("invalid remote signer key %v: %v", hexKey, err)
+			}
+			if _, ok := heldSet[pub.Bytes]; !ok {
+				return nil, fmt.Errorf("key %v not held by the remote signer", hexKey)
+			}
+			pubKeys = append(pubKeys, pub)
+		}
+	}
+
+	signers := make([]bls.Signer, 0, len(pubKeys))
+	for _, pub := range pubKeys {
+		signers = append(signers, remotesigner.NewSigner(client, pub))
+	}
+	return multibls.GetPrivateKeysFromSigners(signers...), nil
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/harmony-one/harmony/block"
 	"github.com/harmony-one/harmony/consensus/engine"
+	"github.com/harmony-one/harmony/consensus/misc"
 	"github.com/harmony-one/harmony/consensus/quorum"
 	"github.com/harmony-one/harmony/consensus/reward"
 	"github.com/harmony-one/harmony/consensus/signature"
@@ -64,6 +65,11 @@ func (e *engineImpl) VerifyHeader(chain engine.ChainReader, header *block.Header
 	if parentHeader == nil {
 		return engine.ErrUnknownAncestor
 	}
+	if chain.Config().IsEIP1559(header.Epoch()) {
+		if err := misc.VerifyEIP1559Header(chain.Config(), parentHeader, header); err != nil {
+			return err
+		}
+	}
 	if seal {
 		if err := e.VerifySeal(chain, header); err != nil {
 			return err
@@ -394,6 +400,11 @@ func payoutUndelegations(
 			if totalWithdraw.Sign() != 0 {
 				state.AddBalance(delegation.DelegatorAddress, totalWithdraw)
 			}
+			// the redelegated stake is no longer liable for the
+			// double signs of the former validator after the cooldown
+			delegation.RemoveExpiredRedelegations(
+				header.Epoch(), staking.RedelegationCooldownInEpoch,
+			)
 		}
 		countTrack[validator] = len(wrapper.Delegations)
 	}
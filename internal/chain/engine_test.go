@@ -103,6 +103,49 @@ func TestApplySlashing(t *testing.T) {
 	}
 }
 
+func TestVerifyHeaderBaseFee(t *testing.T) {
+	epoch := params.LocalnetChainConfig.EIP1559Epoch
+	parent := blockfactory.ForTest.NewHeader(epoch)
+	parent.SetNumber(big.NewInt(doubleSignBlockNumber))
+	parent.SetGasLimit(80_000_000)
+	parent.SetGasUsed(40_000_000) // the gas target, the base fee stays the same
+	parent.SetBaseFee(params.InitialBaseFee)
+	chain := &headerChain{fakeBlockChain: makeFakeBlockChain(), parent: parent}
+
+	tests := []struct {
+		baseFee *big.Int
+		wantErr bool
+	}{
+		{new(big.Int).Set(params.InitialBaseFee), false},
+		{new(big.Int).Add(params.InitialBaseFee, common.Big1), true},
+		{new(big.Int).Sub(params.InitialBaseFee, common.Big1), true},
+	}
+	for i, test := range tests {
+		header := blockfactory.ForTest.NewHeader(epoch)
+		header.SetParentHash(parent.Hash())
+		header.SetNumber(big.NewInt(doubleSignBlockNumber + 1))
+		header.SetBaseFee(test.baseFee)
+
+		err := NewEngine().VerifyHeader(chain, header, false)
+		if (err != nil) != test.wantErr {
+			t.Errorf("Test %v: VerifyHeader() error = %v, wantErr %v", i, err, test.wantErr)
+		}
+	}
+}
+
+// headerChain serves the parent header to the header verification
+type headerChain struct {
+	*fakeBlockChain
+	parent *block.Header
+}
+
+func (bc *headerChain) GetHeader(hash common.Hash, number uint64) *block.Header {
+	if hash == bc.parent.Hash() && number == bc.parent.Number().Uint64() {
+		return bc.parent
+	}
+	return nil
+}
+
 //
 // Make slash record for testing
 //
@@ -2,6 +2,7 @@ package harmony
 
 import (
 	"fmt"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"time"
@@ -37,6 +38,7 @@ type HarmonyConfig struct {
 	ShardData  ShardDataConfig
 	GPO        GasPriceOracleConfig
 	Preimage   *PreimageConfig
+	IPC        *IpcConfig `toml:",omitempty"`
 }
 
 func (hc HarmonyConfig) ToRPCServerConfig() nodeconfig.RPCServerConfig {
@@ -72,11 +74,19 @@ func (hc HarmonyConfig) ToRPCServerConfig() nodeconfig.RPCServerConfig {
 			Dur("updated", evmCallTimeout).
 			Msg("Sanitizing invalid evm_call timeout")
 	}
+	var ipcPath string
+	if hc.IPC != nil && hc.IPC.Enabled {
+		ipcPath = hc.IPC.Path
+		if !filepath.IsAbs(ipcPath) {
+			ipcPath = filepath.Join(hc.General.DataDir, ipcPath)
+		}
+	}
 	return nodeconfig.RPCServerConfig{
 		HTTPEnabled:        hc.HTTP.Enabled,
 		HTTPIp:             hc.HTTP.IP,
 		HTTPPort:           hc.HTTP.Port,
 		HTTPAuthPort:       hc.HTTP.AuthPort,
+		HTTPAuthJWTSecret:  hc.HTTP.AuthJWTSecret,
 		HTTPTimeoutRead:    readTimeout,
 		HTTPTimeoutWrite:   writeTimeout,
 		HTTPTimeoutIdle:    idleTimeout,
@@ -84,6 +94,9 @@ func (hc HarmonyConfig) ToRPCServerConfig() nodeconfig.RPCServerConfig {
 		WSIp:               hc.WS.IP,
 		WSPort:             hc.WS.Port,
 		WSAuthPort:         hc.WS.AuthPort,
+		WSAuthJWTSecret:    hc.WS.AuthJWTSecret,
+		IPCEnabled:         ipcPath != "",
+		IPCPath:            ipcPath,
 		DebugEnabled:       hc.RPCOpt.DebugEnabled,
 		PreimagesEnabled:   hc.RPCOpt.PreimagesEnabled,
 		EthRPCsEnabled:     hc.RPCOpt.EthRPCsEnabled,
@@ -180,8 +193,10 @@ type GasPriceOracleConfig struct {
 }
 
 type ConsensusConfig struct {
-	MinPeers     int
-	AggregateSig bool
+	MinPeers           int
+	AggregateSig       bool
+	SlashingProtection bool   // check prepare/commit votes against the local signing history before signing
+	DoppelgangerBlocks uint64 // number of blocks to watch for the bls keys signing elsewhere before signing, 0 to disable
 }
 
 type BlsConfig struct {
@@ -197,6 +212,10 @@ type BlsConfig struct {
 	KMSEnabled       bool
 	KMSConfigSrcType string
 	KMSConfigFile    string
+
+	RemoteSignerURL     string        `toml:",omitempty"` // sign with the keys held by the remote signer instead of loading key files
+	RemoteSignerKeys    []string      `toml:",omitempty"` // keys of the remote signer to use, all keys if empty
+	RemoteSignerTimeout time.Duration `toml:",omitempty"` // timeout of a request to the remote signer
 }
 
 type TxPoolConfig struct {
@@ -211,6 +230,7 @@ type TxPoolConfig struct {
 	Lifetime          time.Duration
 	PriceLimit        PriceLimit
 	PriceBump         uint64
+	AnnounceTxs       bool // gossip the hashes of the transactions, to be fetched over the txfetch stream protocol
 }
 
 type PprofConfig struct {
@@ -271,13 +291,20 @@ type HttpConfig struct {
 	ReadTimeout    string
 	WriteTimeout   string
 	IdleTimeout    string
+	AuthJWTSecret  string `toml:",omitempty"` // hex secret file for the jwt auth of the auth port
 }
 
 type WsConfig struct {
-	Enabled  bool
-	IP       string
-	Port     int
-	AuthPort int
+	Enabled       bool
+	IP            string
+	Port          int
+	AuthPort      int
+	AuthJWTSecret string `toml:",omitempty"` // hex secret file for the jwt auth of the auth port
+}
+
+type IpcConfig struct {
+	Enabled bool
+	Path    string // path of the unix socket, relative to the data dir if not absolute
 }
 
 type RpcOptConfig struct {
@@ -290,6 +317,14 @@ type RpcOptConfig struct {
 	RequestsPerSecond  int    // for RPC rate limiter
 	EvmCallTimeout     string // Timeout for eth_call
 	PreimagesEnabled   bool   // Expose preimage API
+
+	ClientRateLimiterEnabled bool           `toml:",omitempty"` // Enable the per-client rate limiter for RPC
+	ClientRequestsPerSecond  int            `toml:",omitempty"` // for per-client RPC rate limiter
+	ClientBurst              int            `toml:",omitempty"` // max requests of a client at once, defaults to ClientRequestsPerSecond
+	ClientKeyHeader          string         `toml:",omitempty"` // header of the API key identifying the clients, by IP if not set
+	ClientKeys               []string       `toml:",omitempty"` // API keys honored in ClientKeyHeader
+	ClientWhitelist          []string       `toml:",omitempty"` // IPs, API keys and jwt subjects not limited
+	MethodCosts              map[string]int `toml:",omitempty"` // costs of the expensive methods in requests
 }
 
 type DevnetConfig struct {
@@ -340,6 +375,7 @@ type SyncConfig struct {
 	DiscHardLowCap       int              // when removing stream, num is below this value, spin discovery immediately
 	DiscHighCap          int              // upper limit of streams in one sync protocol
 	DiscBatch            int              // size of each discovery
+	Checkpoint           string           `toml:",omitempty"` // file of the trusted epoch block to bootstrap the stream sync from
 }
 
 type StagedSyncConfig struct {
@@ -353,6 +389,7 @@ type StagedSyncConfig struct {
 	VerifyHeaderBatchSize  uint64 // batch size to verify header before insert to chain
 	UseMemDB               bool   // it uses memory by default. set it to false to use disk
 	LogProgress            bool   // log the full sync progress in console
+	SnapSync               bool   // download the state of a recent block instead of executing all the blocks (shard chains only)
 	DebugMode              bool   // log every single process and error to help to debug syncing issues (DebugMode is not accessible to the end user and is only an aid for development)
 }
 
@@ -68,6 +68,31 @@ func TestToRPCServerConfig(t *testing.T) {
 				EvmCallTimeout:     5 * time.Second,
 			},
 		},
+		{
+			input: HarmonyConfig{
+				General: GeneralConfig{DataDir: "./data"},
+				HTTP: HttpConfig{
+					ReadTimeout:  "1s",
+					WriteTimeout: "1s",
+					IdleTimeout:  "1s",
+				},
+				RPCOpt: RpcOptConfig{
+					EvmCallTimeout: "1s",
+				},
+				IPC: &IpcConfig{
+					Enabled: true,
+					Path:    "harmony.ipc",
+				},
+			},
+			output: nodeconfig.RPCServerConfig{
+				HTTPTimeoutRead:  time.Second,
+				HTTPTimeoutWrite: time.Second,
+				HTTPTimeoutIdle:  time.Second,
+				IPCEnabled:       true,
+				IPCPath:          "data/harmony.ipc",
+				EvmCallTimeout:   time.Second,
+			},
+		},
 	}
 	for i, tt := range tests {
 		assertObject := assert.New(t)
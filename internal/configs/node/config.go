@@ -141,6 +141,9 @@ type RPCServerConfig struct {
 	HTTPIp       string
 	HTTPPort     int
 	HTTPAuthPort int
+	// HTTPAuthJWTSecret is the secret file of the jwt auth of the auth port,
+	// the auth port has no auth if empty
+	HTTPAuthJWTSecret string
 
 	HTTPTimeoutRead  time.Duration
 	HTTPTimeoutWrite time.Duration
@@ -150,6 +153,14 @@ type RPCServerConfig struct {
 	WSIp       string
 	WSPort     int
 	WSAuthPort int
+	// WSAuthJWTSecret is the secret file of the jwt auth of the auth port,
+	// the auth port has no auth if empty
+	WSAuthJWTSecret string
+
+	// IPCEnabled enables the IPC endpoint serving the public and auth apis
+	// on the unix socket IPCPath
+	IPCEnabled bool
+	IPCPath    string
 
 	DebugEnabled bool
 
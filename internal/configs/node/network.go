@@ -82,6 +82,9 @@ const (
 const (
 	// DefaultRateLimit for RPC, the number of requests per second
 	DefaultRPCRateLimit = 1000
+	// DefaultRPCClientRateLimit for the per-client RPC rate limiter, the number
+	// of requests per second of each client
+	DefaultRPCClientRateLimit = 100
 )
 
 const (
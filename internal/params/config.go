@@ -76,6 +76,14 @@ var (
 		HIP30Epoch:                            big.NewInt(1673), // 2023-11-02 17:30:00+00:00
 		BlockGas30MEpoch:                      big.NewInt(1673), // 2023-11-02 17:30:00+00:00
 		MaxRateEpoch:                          big.NewInt(1733), // 2023-12-17 12:20:15+00:00
+		EIP1559Epoch:                          EpochTBD,
+		ShanghaiEpoch:                         EpochTBD,
+		CancunEpoch:                           EpochTBD,
+		BLSPrecompileEpoch:                    EpochTBD,
+		StakingQueryPrecompileEpoch:           EpochTBD,
+		ValidatorPrecompileEpoch:              EpochTBD,
+		AtomicRedelegationEpoch:               EpochTBD,
+		AutoCompoundEpoch:                     EpochTBD,
 	}
 
 	// TestnetChainConfig contains the chain parameters to run a node on the harmony test network.
@@ -120,6 +128,14 @@ var (
 		HIP30Epoch:                            big.NewInt(2176), // 2023-10-12 10:00:00+00:00
 		BlockGas30MEpoch:                      big.NewInt(2176), // 2023-10-12 10:00:00+00:00
 		MaxRateEpoch:                          big.NewInt(2520), // 2023-12-16 12:17:14+00:00
+		EIP1559Epoch:                          EpochTBD,
+		ShanghaiEpoch:                         EpochTBD,
+		CancunEpoch:                           EpochTBD,
+		BLSPrecompileEpoch:                    EpochTBD,
+		StakingQueryPrecompileEpoch:           EpochTBD,
+		ValidatorPrecompileEpoch:              EpochTBD,
+		AtomicRedelegationEpoch:               EpochTBD,
+		AutoCompoundEpoch:                     EpochTBD,
 	}
 	// PangaeaChainConfig contains the chain parameters for the Pangaea network.
 	// All features except for CrossLink are enabled at launch.
@@ -164,6 +180,14 @@ var (
 		HIP30Epoch:                            EpochTBD,
 		BlockGas30MEpoch:                      big.NewInt(0),
 		MaxRateEpoch:                          EpochTBD,
+		EIP1559Epoch:                          EpochTBD,
+		ShanghaiEpoch:                         EpochTBD,
+		CancunEpoch:                           EpochTBD,
+		BLSPrecompileEpoch:                    EpochTBD,
+		StakingQueryPrecompileEpoch:           EpochTBD,
+		ValidatorPrecompileEpoch:              EpochTBD,
+		AtomicRedelegationEpoch:               EpochTBD,
+		AutoCompoundEpoch:                     EpochTBD,
 	}
 
 	// PartnerChainConfig contains the chain parameters for the Partner network.
@@ -209,6 +233,14 @@ var (
 		HIP30Epoch:                            big.NewInt(7),
 		BlockGas30MEpoch:                      big.NewInt(7),
 		MaxRateEpoch:                          EpochTBD,
+		EIP1559Epoch:                          EpochTBD,
+		ShanghaiEpoch:                         EpochTBD,
+		CancunEpoch:                           EpochTBD,
+		BLSPrecompileEpoch:                    EpochTBD,
+		StakingQueryPrecompileEpoch:           EpochTBD,
+		ValidatorPrecompileEpoch:              EpochTBD,
+		AtomicRedelegationEpoch:               EpochTBD,
+		AutoCompoundEpoch:                     EpochTBD,
 	}
 
 	// StressnetChainConfig contains the chain parameters for the Stress test network.
@@ -254,6 +286,14 @@ var (
 		HIP30Epoch:                            EpochTBD,
 		BlockGas30MEpoch:                      big.NewInt(0),
 		MaxRateEpoch:                          EpochTBD,
+		EIP1559Epoch:                          EpochTBD,
+		ShanghaiEpoch:                         EpochTBD,
+		CancunEpoch:                           EpochTBD,
+		BLSPrecompileEpoch:                    EpochTBD,
+		StakingQueryPrecompileEpoch:           EpochTBD,
+		ValidatorPrecompileEpoch:              EpochTBD,
+		AtomicRedelegationEpoch:               EpochTBD,
+		AutoCompoundEpoch:                     EpochTBD,
 	}
 
 	// LocalnetChainConfig contains the chain parameters to run for local development.
@@ -298,6 +338,14 @@ var (
 		HIP30Epoch:                            EpochTBD,
 		BlockGas30MEpoch:                      big.NewInt(0),
 		MaxRateEpoch:                          EpochTBD,
+		EIP1559Epoch:                          big.NewInt(2),
+		ShanghaiEpoch:                         big.NewInt(0),
+		CancunEpoch:                           big.NewInt(0),
+		BLSPrecompileEpoch:                    big.NewInt(2),
+		StakingQueryPrecompileEpoch:           big.NewInt(2),
+		ValidatorPrecompileEpoch:              big.NewInt(2),
+		AtomicRedelegationEpoch:               big.NewInt(2),
+		AutoCompoundEpoch:                     big.NewInt(2),
 	}
 
 	// AllProtocolChanges ...
@@ -344,6 +392,14 @@ var (
 		big.NewInt(0),                      // BlockGas30M
 		big.NewInt(0),                      // BlockGas30M
 		big.NewInt(0),                      // MaxRateEpoch
+		big.NewInt(0),                      // EIP1559Epoch
+		big.NewInt(0),                      // ShanghaiEpoch
+		big.NewInt(0),                      // CancunEpoch
+		big.NewInt(0),                      // BLSPrecompileEpoch
+		big.NewInt(0),                      // StakingQueryPrecompileEpoch
+		big.NewInt(0),                      // ValidatorPrecompileEpoch
+		big.NewInt(0),                      // AtomicRedelegationEpoch
+		big.NewInt(0),                      // AutoCompoundEpoch
 	}
 
 	// TestChainConfig ...
@@ -390,6 +446,14 @@ var (
 		big.NewInt(0),        // HIP30Epoch
 		big.NewInt(0),        // BlockGas30M
 		big.NewInt(0),        // MaxRateEpoch
+		big.NewInt(0),        // EIP1559Epoch
+		big.NewInt(0),        // ShanghaiEpoch
+		big.NewInt(0),        // CancunEpoch
+		big.NewInt(0),        // BLSPrecompileEpoch
+		big.NewInt(0),        // StakingQueryPrecompileEpoch
+		big.NewInt(0),        // ValidatorPrecompileEpoch
+		big.NewInt(0),        // AtomicRedelegationEpoch
+		big.NewInt(0),        // AutoCompoundEpoch
 	}
 
 	// TestRules ...
@@ -558,6 +622,38 @@ type ChainConfig struct {
 
 	// MaxRateEpoch will make sure the validator max-rate is at least equal to the minRate + the validator max-rate-increase
 	MaxRateEpoch *big.Int `json:"max-rate-epoch,omitempty"`
+
+	// EIP1559Epoch is the epoch when the EIP-1559 fee market starts: the
+	// dynamic fee transactions are accepted and the blocks have a base fee
+	EIP1559Epoch *big.Int `json:"eip1559-epoch,omitempty"`
+
+	// ShanghaiEpoch is the epoch when the PUSH0 opcode (EIP-3855) is added and
+	// the size of the initcode is limited and metered (EIP-3860)
+	ShanghaiEpoch *big.Int `json:"shanghai-epoch,omitempty"`
+
+	// CancunEpoch is the epoch when the transient storage opcodes TLOAD and
+	// TSTORE (EIP-1153) and the MCOPY opcode (EIP-5656) are added
+	CancunEpoch *big.Int `json:"cancun-epoch,omitempty"`
+
+	// BLSPrecompileEpoch is the first epoch to support the BLS12-381
+	// precompiles (EIP-2537)
+	BLSPrecompileEpoch *big.Int `json:"bls-precompile-epoch,omitempty"`
+
+	// StakingQueryPrecompileEpoch is the first epoch to support the read-only
+	// staking precompile, which lets the contracts query the staking state
+	StakingQueryPrecompileEpoch *big.Int `json:"staking-query-precompile-epoch,omitempty"`
+
+	// ValidatorPrecompileEpoch is the first epoch to support creating and
+	// editing validators through the staking precompile
+	ValidatorPrecompileEpoch *big.Int `json:"validator-precompile-epoch,omitempty"`
+
+	// AtomicRedelegationEpoch is the first epoch to support the Redelegate
+	// directive, which moves the stake from a validator to another at once
+	AtomicRedelegationEpoch *big.Int `json:"atomic-redelegation-epoch,omitempty"`
+
+	// AutoCompoundEpoch is the first epoch to support the EditDelegation
+	// directive, which lets the rewards of a delegation be added to its amount
+	AutoCompoundEpoch *big.Int `json:"auto-compound-epoch,omitempty"`
 }
 
 // String implements the fmt.Stringer interface.
@@ -626,6 +722,28 @@ func (c *ChainConfig) mustValid() {
 	// max rate (7%) fix is applied on or after hip30
 	require(c.MaxRateEpoch.Cmp(c.HIP30Epoch) >= 0,
 		"must satisfy: MaxRateEpoch >= HIP30Epoch")
+	// the dynamic fee transactions are ethereum compatible, and the base fee
+	// is burned or sent to the fee collectors like the post-staking fees
+	require(c.EIP1559Epoch.Cmp(c.EthCompatibleEpoch) >= 0,
+		"must satisfy: EIP1559Epoch >= EthCompatibleEpoch")
+	require(c.EIP1559Epoch.Cmp(c.StakingEpoch) >= 0,
+		"must satisfy: EIP1559Epoch >= StakingEpoch")
+	// the instruction sets build on each other
+	require(c.ShanghaiEpoch.Cmp(c.IstanbulEpoch) >= 0,
+		"must satisfy: ShanghaiEpoch >= IstanbulEpoch")
+	require(c.CancunEpoch.Cmp(c.ShanghaiEpoch) >= 0,
+		"must satisfy: CancunEpoch >= ShanghaiEpoch")
+	// the precompile maps build on each other
+	require(c.BLSPrecompileEpoch.Cmp(c.StakingPrecompileEpoch) >= 0,
+		"must satisfy: BLSPrecompileEpoch >= StakingPrecompileEpoch")
+	require(c.StakingQueryPrecompileEpoch.Cmp(c.StakingPrecompileEpoch) >= 0,
+		"must satisfy: StakingQueryPrecompileEpoch >= StakingPrecompileEpoch")
+	require(c.ValidatorPrecompileEpoch.Cmp(c.StakingPrecompileEpoch) >= 0,
+		"must satisfy: ValidatorPrecompileEpoch >= StakingPrecompileEpoch")
+	require(c.AtomicRedelegationEpoch.Cmp(c.RedelegationEpoch) >= 0,
+		"must satisfy: AtomicRedelegationEpoch >= RedelegationEpoch")
+	require(c.AutoCompoundEpoch.Cmp(c.StakingEpoch) >= 0,
+		"must satisfy: AutoCompoundEpoch >= StakingEpoch")
 }
 
 // IsEIP155 returns whether epoch is either equal to the EIP155 fork epoch or greater.
@@ -821,6 +939,53 @@ func (c *ChainConfig) IsMaxRate(epoch *big.Int) bool {
 	return isForked(c.MaxRateEpoch, epoch)
 }
 
+// IsEIP1559 returns whether epoch is either equal to the EIP-1559 fork epoch or greater.
+func (c *ChainConfig) IsEIP1559(epoch *big.Int) bool {
+	return isForked(c.EIP1559Epoch, epoch)
+}
+
+// IsEIP2929 returns whether the warm/cold access costs of EIP-2929 apply in
+// the epoch. They come with the typed transactions, so that the access lists
+// of EIP-2930 pre-warm the accesses they list.
+func (c *ChainConfig) IsEIP2929(epoch *big.Int) bool {
+	return c.IsEIP1559(epoch)
+}
+
+// IsShanghai returns whether epoch is either equal to the Shanghai fork epoch or greater.
+func (c *ChainConfig) IsShanghai(epoch *big.Int) bool {
+	return isForked(c.ShanghaiEpoch, epoch)
+}
+
+// IsCancun returns whether epoch is either equal to the Cancun fork epoch or greater.
+func (c *ChainConfig) IsCancun(epoch *big.Int) bool {
+	return isForked(c.CancunEpoch, epoch)
+}
+
+// IsBLSPrecompile returns whether epoch is either equal to the BLS12-381 precompile fork epoch or greater.
+func (c *ChainConfig) IsBLSPrecompile(epoch *big.Int) bool {
+	return isForked(c.BLSPrecompileEpoch, epoch)
+}
+
+// IsStakingQueryPrecompile determines whether the read-only staking precompile is available in the EVM
+func (c *ChainConfig) IsStakingQueryPrecompile(epoch *big.Int) bool {
+	return isForked(c.StakingQueryPrecompileEpoch, epoch)
+}
+
+// IsValidatorPrecompile determines whether the validators can be created and edited through the staking precompile
+func (c *ChainConfig) IsValidatorPrecompile(epoch *big.Int) bool {
+	return isForked(c.ValidatorPrecompileEpoch, epoch)
+}
+
+// IsAtomicRedelegation determines whether the stake can be redelegated from a validator to another at once
+func (c *ChainConfig) IsAtomicRedelegation(epoch *big.Int) bool {
+	return isForked(c.AtomicRedelegationEpoch, epoch)
+}
+
+// IsAutoCompound determines whether the delegations can opt in to compound their rewards
+func (c *ChainConfig) IsAutoCompound(epoch *big.Int) bool {
+	return isForked(c.AutoCompoundEpoch, epoch)
+}
+
 // During this epoch, shards 2 and 3 will start sending
 // their balances over to shard 0 or 1.
 func (c *ChainConfig) IsOneEpochBeforeHIP30(epoch *big.Int) bool {
@@ -881,10 +1046,15 @@ type Rules struct {
 	IsS3,
 	// precompiles
 	IsIstanbul, IsVRF, IsPrevVRF, IsSHA3,
-	IsStakingPrecompile, IsCrossShardXferPrecompile,
+	IsStakingPrecompile, IsCrossShardXferPrecompile, IsBLSPrecompile,
+	IsStakingQueryPrecompile, IsValidatorPrecompile,
 	// eip-155 chain id fix
 	IsChainIdFix bool
 	IsValidatorCodeFix bool
+	// instruction sets
+	IsShanghai, IsCancun bool
+	// access costs
+	IsEIP2929 bool
 }
 
 // Rules ensures c's ChainID is not nil.
@@ -910,5 +1080,11 @@ func (c *ChainConfig) Rules(epoch *big.Int) Rules {
 		IsCrossShardXferPrecompile: c.IsCrossShardXferPrecompile(epoch),
 		IsChainIdFix:               c.IsChainIdFix(epoch),
 		IsValidatorCodeFix:         c.IsValidatorCodeFix(epoch),
+		IsShanghai:                 c.IsShanghai(epoch),
+		IsCancun:                   c.IsCancun(epoch),
+		IsBLSPrecompile:            c.IsBLSPrecompile(epoch),
+		IsStakingQueryPrecompile:   c.IsStakingQueryPrecompile(epoch),
+		IsValidatorPrecompile:      c.IsValidatorPrecompile(epoch),
+		IsEIP2929:                  c.IsEIP2929(epoch),
 	}
 }
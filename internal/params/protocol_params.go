@@ -86,6 +86,13 @@ const (
 	// SstoreClearRefundEIP2200 ...
 	SstoreClearRefundEIP2200 uint64 = 15000 // Once per SSTORE operation for clearing an originally existing storage slot
 
+	// ColdAccountAccessCostEIP2929 ...
+	ColdAccountAccessCostEIP2929 uint64 = 2600 // COLD_ACCOUNT_ACCESS_COST
+	// ColdSloadCostEIP2929 ...
+	ColdSloadCostEIP2929 uint64 = 2100 // COLD_SLOAD_COST
+	// WarmStorageReadCostEIP2929 ...
+	WarmStorageReadCostEIP2929 uint64 = 100 // WARM_STORAGE_READ_COST
+
 	// JumpdestGas ...
 	JumpdestGas uint64 = 1 // Refunded gas, once per SSTORE operation if the zeroness changes to zero.
 	// EpochDuration ...
@@ -120,6 +127,10 @@ const (
 	TxDataNonZeroGasFrontier uint64 = 68 // Per byte of data attached to a transaction that is not equal to zero. NOTE: Not payable on data of calls between transactions.
 	// TxDataNonZeroGasEIP2028 ...
 	TxDataNonZeroGasEIP2028 uint64 = 16 // Per byte of non zero data attached to a transaction after EIP 2028 (part in Istanbul)
+	// TxAccessListAddressGas ...
+	TxAccessListAddressGas uint64 = 2400 // Per address specified in EIP 2930 access list
+	// TxAccessListStorageKeyGas ...
+	TxAccessListStorageKeyGas uint64 = 1900 // Per storage key specified in EIP 2930 access list
 
 	// These have been changed during the course of the chain
 	CallGasFrontier              uint64 = 40  // Once per CALL operation & message call transaction.
@@ -152,6 +163,12 @@ const (
 
 	// MaxCodeSize ...
 	MaxCodeSize = 24576 // Maximum bytecode to permit for a contract
+	// MaxInitCodeSize ...
+	MaxInitCodeSize = 2 * MaxCodeSize // Maximum initcode to permit in a creation transaction and create instructions (EIP-3860)
+	// InitCodeWordGas ...
+	InitCodeWordGas uint64 = 2 // Once per word of the init code when creating a contract (EIP-3860)
+	// TransientStorageGas ...
+	TransientStorageGas uint64 = 100 // Cost of TLOAD and TSTORE (EIP-1153)
 
 	// Precompiled contract gas prices
 
@@ -177,6 +194,22 @@ const (
 	Sha3FipsGas     uint64 = 30 // Once per SHA3-256 operation.
 	Sha3FipsWordGas uint64 = 6  // Once per word of the SHA3-256 operation's data.
 
+	// BLS12-381 precompiled contracts gas prices as per EIP-2537
+	Bls12381G1AddGas          uint64 = 375   // Price for BLS12-381 elliptic curve G1 point addition
+	Bls12381G1MulGas          uint64 = 12000 // Price for BLS12-381 elliptic curve G1 point scalar multiplication
+	Bls12381G2AddGas          uint64 = 600   // Price for BLS12-381 elliptic curve G2 point addition
+	Bls12381G2MulGas          uint64 = 22500 // Price for BLS12-381 elliptic curve G2 point scalar multiplication
+	Bls12381PairingBaseGas    uint64 = 37700 // Base gas price for BLS12-381 elliptic curve pairing check
+	Bls12381PairingPerPairGas uint64 = 32600 // Per-point pair gas price for BLS12-381 elliptic curve pairing check
+	Bls12381MapG1Gas          uint64 = 5500  // Gas price for BLS12-381 mapping field element to G1 operation
+	Bls12381MapG2Gas          uint64 = 23800 // Gas price for BLS12-381 mapping field element to G2 operation
+
+	StakingQueryGas              uint64 = 2000 // Base price for a query of the read-only staking precompile
+	StakingQueryPerDelegationGas uint64 = 20   // Per-delegation price of the validator read by a staking query
+
+	BaseFeeChangeDenominator uint64 = 8 // Bounds the amount the base fee can change between blocks.
+	ElasticityMultiplier     uint64 = 2 // Bounds the maximum gas limit an EIP-1559 block may have.
+
 )
 
 // nolint
@@ -185,4 +218,17 @@ var (
 	GenesisDifficulty      = big.NewInt(131072) // Difficulty of the Genesis block.
 	MinimumDifficulty      = big.NewInt(131072) // The minimum that the difficulty may ever be.
 	DurationLimit          = big.NewInt(13)     // The decision boundary on the blocktime duration used to determine whether difficulty should go up or not.
+
+	// InitialBaseFee is the base fee of the first EIP-1559 block, same as the
+	// minimum gas price of the transaction pool
+	InitialBaseFee = big.NewInt(100e9)
+	// MinimumBaseFee is the floor of the base fee, so that the fees of a
+	// spam transaction never become negligible
+	MinimumBaseFee = big.NewInt(100e9)
 )
+
+// Bls12381G1MultiExpDiscountTable is the gas discount table for BLS12-381 G1 multi exponentiation operation
+var Bls12381G1MultiExpDiscountTable = [128]uint64{1000, 949, 848, 797, 764, 750, 738, 728, 719, 712, 705, 698, 692, 687, 682, 677, 673, 669, 665, 661, 658, 654, 651, 648, 645, 642, 640, 637, 635, 632, 630, 627, 625, 623, 621, 619, 617, 615, 613, 611, 609, 608, 606, 604, 603, 601, 599, 598, 596, 595, 593, 592, 591, 589, 588, 586, 585, 584, 582, 581, 580, 579, 577, 576, 575, 574, 573, 572, 570, 569, 568, 567, 566, 565, 564, 563, 562, 561, 560, 559, 558, 557, 556, 555, 554, 553, 552, 551, 550, 549, 548, 547, 547, 546, 545, 544, 543, 542, 541, 540, 540, 539, 538, 537, 536, 536, 535, 534, 533, 532, 532, 531, 530, 529, 528, 528, 527, 526, 525, 525, 524, 523, 522, 522, 521, 520, 520, 519}
+
+// Bls12381G2MultiExpDiscountTable is the gas discount table for BLS12-381 G2 multi exponentiation operation
+var Bls12381G2MultiExpDiscountTable = [128]uint64{1000, 1000, 923, 884, 855, 832, 812, 796, 782, 770, 759, 749, 740, 732, 724, 717, 711, 704, 699, 693, 688, 683, 679, 674, 670, 666, 663, 659, 655, 652, 649, 646, 643, 640, 637, 634, 632, 629, 627, 624, 622, 620, 618, 615, 613, 611, 609, 607, 606, 604, 602, 600, 598, 597, 595, 593, 592, 590, 589, 587, 586, 584, 583, 582, 580, 579, 578, 576, 575, 574, 573, 571, 570, 569, 568, 567, 566, 565, 563, 562, 561, 560, 559, 558, 557, 556, 555, 554, 553, 552, 552, 551, 550, 549, 548, 547, 546, 545, 545, 544, 543, 542, 541, 541, 540, 539, 538, 537, 537, 536, 535, 535, 534, 533, 532, 532, 531, 530, 530, 529, 528, 528, 527, 526, 526, 525, 524, 524}
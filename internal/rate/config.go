@@ -53,5 +53,8 @@ func toConfigInt(limit rate.Limit, burst int, c *Config) configInt {
 	if c.MinEvictDur != nil {
 		ci.minEvictDur = *c.MinEvictDur
 	}
+	for _, id := range c.Whitelist {
+		ci.whitelist[id] = struct{}{}
+	}
 	return ci
 }
@@ -59,6 +59,20 @@ func TestLimiterPerID_AllowN(t *testing.T) {
 	}
 }
 
+func TestLimiterPerID_whitelist(t *testing.T) {
+	lpi := newTestLimiter()
+	lpi.c = toConfigInt(testConfig.limit, testConfig.burst, &Config{Whitelist: []string{id1}})
+
+	for i := 0; i != 5; i++ {
+		if !lpi.AllowN(id1, 2) {
+			t.Fatalf("whitelisted id rejected")
+		}
+	}
+	if !lpi.AllowN(id2, 2) || lpi.AllowN(id2, 1) {
+		t.Errorf("unexpected limit of not whitelisted id")
+	}
+}
+
 func TestLimiterPerID_maintain(t *testing.T) {
 	lpi := newTestLimiter()
 	lpi.c.capacity = 0
@@ -4,6 +4,7 @@ import (
 	"sync"
 
 	"github.com/harmony-one/harmony/consensus/engine"
+	"github.com/harmony-one/harmony/consensus/slashprotect"
 	"github.com/harmony-one/harmony/core"
 	"github.com/harmony-one/harmony/internal/shardchain"
 	"github.com/harmony-one/harmony/webhooks"
@@ -20,6 +21,8 @@ type Registry struct {
 	isBackup    bool
 	engine      engine.Engine
 	collection  *shardchain.CollectionImpl
+
+	slashProtect *slashprotect.DB
 }
 
 // New creates a new registry.
@@ -160,3 +163,20 @@ func (r *Registry) GetShardChainCollection() *shardchain.CollectionImpl {
 
 	return r.collection
 }
+
+// SetSlashingProtection sets the slashing protection db to registry.
+func (r *Registry) SetSlashingProtection(db *slashprotect.DB) *Registry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.slashProtect = db
+	return r
+}
+
+// GetSlashingProtection gets the slashing protection db from registry.
+func (r *Registry) GetSlashingProtection() *slashprotect.DB {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.slashProtect
+}
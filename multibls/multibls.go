@@ -72,3 +72,12 @@ func GetPrivateKeys(secretKeys ...*bls_core.SecretKey) PrivateKeys {
 	}
 	return keys
 }
+
+// GetPrivateKeysFromSigners creates a multibls PrivateKeys using bls signers
+func GetPrivateKeysFromSigners(signers ...bls.Signer) PrivateKeys {
+	keys := make(PrivateKeys, 0, len(signers))
+	for _, signer := range signers {
+		keys = append(keys, bls.WrapperFromSigner(signer))
+	}
+	return keys
+}
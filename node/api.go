@@ -1,6 +1,7 @@
 package node
 
 import (
+	"github.com/harmony-one/harmony/api/service/lightnode"
 	"github.com/harmony-one/harmony/consensus/quorum"
 	"github.com/harmony-one/harmony/core/types"
 	"github.com/harmony-one/harmony/eth/rpc"
@@ -74,6 +75,12 @@ func (node *Node) StartRPC() error {
 	return hmy_rpc.StartServers(harmony, apis, node.NodeConfig.RPCServer, node.HarmonyConfig.RPCOpt)
 }
 
+// StartLightRPC starts the RPC service of a light node, serving the data verified
+// by the light node backend
+func (node *Node) StartLightRPC(backend *lightnode.Backend) error {
+	return hmy_rpc.StartLightServers(backend, node.NodeConfig.RPCServer, node.HarmonyConfig.RPCOpt)
+}
+
 // StopRPC stop RPC service
 func (node *Node) StopRPC() error {
 	return hmy_rpc.StopServers()
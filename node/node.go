@@ -51,6 +51,7 @@ import (
 	"github.com/harmony-one/harmony/internal/utils"
 	"github.com/harmony-one/harmony/node/worker"
 	"github.com/harmony-one/harmony/p2p"
+	"github.com/harmony-one/harmony/p2p/stream/protocols/txfetch"
 	"github.com/harmony-one/harmony/shard"
 	"github.com/harmony-one/harmony/shard/committee"
 	"github.com/harmony-one/harmony/staking/reward"
@@ -114,6 +115,7 @@ type Node struct {
 	stateSync              *legacysync.StateSync
 	epochSync              *legacysync.EpochSync
 	stateStagedSync        *stagedsync.StagedSync
+	txFetcher              *txfetch.Fetcher       // fetch the transactions announced by the peers
 	peerRegistrationRecord map[string]*syncConfig // record registration time (unixtime) of peers begin in syncing
 	SyncingPeerProvider    SyncingPeerProvider
 	// The p2p host used to send/receive p2p messages
@@ -218,7 +220,14 @@ func (node *Node) EpochChain() core.BlockChain {
 
 // TODO: make this batch more transactions
 func (node *Node) tryBroadcast(tx *types.Transaction) {
-	msg := proto_node.ConstructTransactionListMessageAccount(types.Transactions{tx})
+	var msg []byte
+	if node.announceTx(tx) {
+		msg = proto_node.ConstructTransactionAnnouncementMessage(
+			[]proto_node.TxAnnouncement{proto_node.NewTxAnnouncement(tx)},
+		)
+	} else {
+		msg = proto_node.ConstructTransactionListMessageAccount(types.Transactions{tx})
+	}
 
 	shardGroupID := nodeconfig.NewGroupIDByShardID(nodeconfig.ShardID(tx.ShardID()))
 	utils.Logger().Info().Str("shardGroupID", string(shardGroupID)).Msg("tryBroadcast")
@@ -234,7 +243,14 @@ func (node *Node) tryBroadcast(tx *types.Transaction) {
 }
 
 func (node *Node) tryBroadcastStaking(stakingTx *staking.StakingTransaction) {
-	msg := proto_node.ConstructStakingTransactionListMessageAccount(staking.StakingTransactions{stakingTx})
+	var msg []byte
+	if node.announceTx(stakingTx) {
+		msg = proto_node.ConstructStakingTransactionAnnouncementMessage(
+			[]proto_node.TxAnnouncement{proto_node.NewTxAnnouncement(stakingTx)},
+		)
+	} else {
+		msg = proto_node.ConstructStakingTransactionListMessageAccount(staking.StakingTransactions{stakingTx})
+	}
 
 	shardGroupID := nodeconfig.NewGroupIDByShardID(
 		nodeconfig.ShardID(shard.BeaconChainShardID),
@@ -251,6 +267,43 @@ func (node *Node) tryBroadcastStaking(stakingTx *staking.StakingTransaction) {
 	}
 }
 
+// announceTx returns whether the hash of the transaction is gossiped instead of
+// the transaction, which is only possible if the peers can fetch it from the pool
+func (node *Node) announceTx(tx types.PoolTransaction) bool {
+	if node.HarmonyConfig == nil || !node.HarmonyConfig.TxPool.AnnounceTxs {
+		return false
+	}
+	return node.TxPool.Get(tx.Hash()) != nil
+}
+
+// SetTxFetcher sets the fetcher of the transactions announced by the peers
+func (node *Node) SetTxFetcher(fetcher *txfetch.Fetcher) {
+	node.txFetcher = fetcher
+}
+
+// AddFetchedTransactions adds the transactions fetched from the peers after
+// their announcement to the pending transaction list.
+func (node *Node) AddFetchedTransactions(txs types.PoolTransactions) {
+	var (
+		plainTxs   types.Transactions
+		stakingTxs staking.StakingTransactions
+	)
+	for _, tx := range txs {
+		switch tx := tx.(type) {
+		case *types.Transaction:
+			plainTxs = append(plainTxs, tx)
+		case *staking.StakingTransaction:
+			stakingTxs = append(stakingTxs, tx)
+		}
+	}
+	if len(plainTxs) > 0 {
+		addPendingTransactions(node.registry, plainTxs)
+	}
+	if len(stakingTxs) > 0 {
+		node.addPendingStakingTransactions(stakingTxs)
+	}
+}
+
 // Add new transactions to the pending transaction list.
 func addPendingTransactions(registry *registry.Registry, newTxs types.Transactions) []error {
 	var (
@@ -476,10 +529,18 @@ func (node *Node) validateNodeMessage(ctx context.Context, payload []byte) (
 	switch msgType {
 	case proto_node.Transaction:
 		// nothing much to validate transaction message unless decode the RLP
-		nodeNodeMessageCounterVec.With(prometheus.Labels{"type": "tx"}).Inc()
+		if proto_node.TransactionMessageType(payload[p2pNodeMsgPrefixSize]) == proto_node.Announce {
+			nodeNodeMessageCounterVec.With(prometheus.Labels{"type": "tx_announce"}).Inc()
+		} else {
+			nodeNodeMessageCounterVec.With(prometheus.Labels{"type": "tx"}).Inc()
+		}
 	case proto_node.Staking:
 		// nothing much to validate staking message unless decode the RLP
-		nodeNodeMessageCounterVec.With(prometheus.Labels{"type": "staking_tx"}).Inc()
+		if proto_node.TransactionMessageType(payload[p2pNodeMsgPrefixSize]) == proto_node.Announce {
+			nodeNodeMessageCounterVec.With(prometheus.Labels{"type": "staking_tx_announce"}).Inc()
+		} else {
+			nodeNodeMessageCounterVec.With(prometheus.Labels{"type": "staking_tx"}).Inc()
+		}
 	case proto_node.Block:
 		switch proto_node.BlockMessageType(payload[p2pNodeMsgPrefixSize]) {
 		case proto_node.Sync:
@@ -580,6 +641,9 @@ func validateShardBoundMessage(consensus *consensus.Consensus, nodeConfig *nodec
 		}
 	}
 
+	// look for the bls keys of the node signing elsewhere before any message is dropped
+	consensus.ObserveDoppelganger(&m)
+
 	// when node is in ViewChanging mode, it still accepts normal messages into FBFTLog
 	// in order to avoid possible trap forever but drop PREPARE and COMMIT
 	// which are message types specifically for a node acting as leader
@@ -745,6 +809,7 @@ func (node *Node) StartPubSub() error {
 		ctx context.Context,
 		rlpPayload []byte,
 		actionType proto_node.MessageType,
+		sender libp2p_peer.ID,
 	) error
 
 	// interface pass to p2p message validator
@@ -756,6 +821,7 @@ func (node *Node) StartPubSub() error {
 		handleEArg     []byte
 		senderPubKey   *bls.SerializedPublicKey
 		actionType     proto_node.MessageType
+		sender         libp2p_peer.ID
 	}
 
 	isThisNodeAnExplorerNode := node.NodeConfig.Role() == nodeconfig.ExplorerNode
@@ -854,6 +920,9 @@ func (node *Node) StartPubSub() error {
 						handleE:        node.HandleNodeMessage,
 						handleEArg:     validMsg,
 						actionType:     actionType,
+						// the peer which relayed the message rather than its origin,
+						// the announced transactions are fetched from a direct stream
+						sender: msg.ReceivedFrom,
 					}
 					return libp2p_pubsub.ValidationAccept
 				default:
@@ -939,7 +1008,7 @@ func (node *Node) StartPubSub() error {
 						if semNode.TryAcquire(1) {
 							defer semNode.Release(1)
 
-							if err := msg.handleE(ctx, msg.handleEArg, msg.actionType); err != nil {
+							if err := msg.handleE(ctx, msg.handleEArg, msg.actionType, msg.sender); err != nil {
 								errChan <- withError{err, nil}
 							}
 						}
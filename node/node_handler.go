@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/rlp"
+	libp2p_peer "github.com/libp2p/go-libp2p/core/peer"
+
 	"github.com/harmony-one/harmony/crypto/bls"
 	"github.com/harmony-one/harmony/internal/utils/crosslinks"
 
@@ -20,6 +22,7 @@ import (
 	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
 	"github.com/harmony-one/harmony/internal/utils"
 	"github.com/harmony-one/harmony/p2p"
+	sttypes "github.com/harmony-one/harmony/p2p/stream/types"
 	"github.com/harmony-one/harmony/shard"
 	"github.com/harmony-one/harmony/staking/availability"
 	"github.com/harmony-one/harmony/staking/slash"
@@ -57,12 +60,13 @@ func (node *Node) HandleNodeMessage(
 	ctx context.Context,
 	msgPayload []byte,
 	actionType proto_node.MessageType,
+	sender libp2p_peer.ID,
 ) error {
 	switch actionType {
 	case proto_node.Transaction:
-		node.transactionMessageHandler(msgPayload)
+		node.transactionMessageHandler(msgPayload, sender)
 	case proto_node.Staking:
-		node.stakingMessageHandler(msgPayload)
+		node.stakingMessageHandler(msgPayload, sender)
 	case proto_node.Block:
 		switch blockMsgType := proto_node.BlockMessageType(msgPayload[0]); blockMsgType {
 		case proto_node.Sync:
@@ -102,7 +106,7 @@ func (node *Node) HandleNodeMessage(
 	return nil
 }
 
-func (node *Node) transactionMessageHandler(msgPayload []byte) {
+func (node *Node) transactionMessageHandler(msgPayload []byte, sender libp2p_peer.ID) {
 	txMessageType := proto_node.TransactionMessageType(msgPayload[0])
 
 	switch txMessageType {
@@ -116,10 +120,12 @@ func (node *Node) transactionMessageHandler(msgPayload []byte) {
 			return
 		}
 		addPendingTransactions(node.registry, txs)
+	case proto_node.Announce:
+		node.txAnnouncementHandler(msgPayload[1:], sender, false) // skip the Announce message type
 	}
 }
 
-func (node *Node) stakingMessageHandler(msgPayload []byte) {
+func (node *Node) stakingMessageHandler(msgPayload []byte, sender libp2p_peer.ID) {
 	txMessageType := proto_node.TransactionMessageType(msgPayload[0])
 
 	switch txMessageType {
@@ -133,7 +139,26 @@ func (node *Node) stakingMessageHandler(msgPayload []byte) {
 			return
 		}
 		node.addPendingStakingTransactions(txs)
+	case proto_node.Announce:
+		node.txAnnouncementHandler(msgPayload[1:], sender, true) // skip the Announce message type
+	}
+}
+
+// txAnnouncementHandler hands the announced transactions to the fetcher, which
+// fetches the ones not in the pool from the announcer
+func (node *Node) txAnnouncementHandler(payload []byte, sender libp2p_peer.ID, isStaking bool) {
+	if node.txFetcher == nil {
+		return
 	}
+	anns := []proto_node.TxAnnouncement{}
+	if err := rlp.DecodeBytes(payload, &anns); err != nil {
+		utils.Logger().Error().
+			Err(err).
+			Bool("staking", isStaking).
+			Msg("Failed to deserialize transaction announcements")
+		return
+	}
+	node.txFetcher.Notify(sttypes.StreamID(sender.String()), isStaking, anns)
 }
 
 // BroadcastNewBlock is called by consensus leader to sync new blocks with other clients/nodes.
@@ -270,7 +295,12 @@ func (node *Node) BroadcastCrosslinkHeartbeatSignalFromBeaconToShards() { // lea
 			utils.Logger().Error().Err(err).Msg("[BroadcastCrossLinkSignal] failed to encode signal")
 			continue
 		}
-		hb.Signature = privToSing.Pri.SignHash(rs).Serialize()
+		sig := privToSing.Pri.SignHash(rs)
+		if sig == nil {
+			utils.Logger().Error().Msg("[BroadcastCrossLinkSignal] failed to sign signal")
+			continue
+		}
+		hb.Signature = sig.Serialize()
 		bts := proto_node.ConstructCrossLinkHeartBeatMessage(hb)
 		node.host.SendMessageToGroups(
 			[]nodeconfig.GroupID{nodeconfig.NewGroupIDByShardID(nodeconfig.ShardID(shardID))},
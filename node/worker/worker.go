@@ -17,6 +17,7 @@ import (
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/harmony-one/harmony/block"
 	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/consensus/misc"
 	"github.com/harmony-one/harmony/core"
 	"github.com/harmony-one/harmony/core/state"
 	"github.com/harmony-one/harmony/core/types"
@@ -82,6 +83,9 @@ func New(
 		Time(big.NewInt(timestamp)).
 		ShardID(chain.ShardID()).
 		Header()
+	if chain.Config().IsEIP1559(epoch) {
+		header.SetBaseFee(misc.CalcBaseFee(chain.Config(), parent))
+	}
 	worker.makeCurrent(parent, header)
 
 	return worker
@@ -135,6 +139,14 @@ func (w *Worker) CommitSortedTransactions(
 			continue
 		}
 
+		// The fee cap must cover the base fee, which does not go down within the
+		// block, so the next transactions of the account are skipped as well
+		if baseFee := w.current.header.BaseFee(); baseFee != nil && tx.GasFeeCap().Cmp(baseFee) < 0 {
+			utils.Logger().Info().Str("hash", tx.Hash().Hex()).Str("baseFee", baseFee.String()).Msg("Skipping transaction with fee cap below base fee")
+			txs.Pop()
+			continue
+		}
+
 		// Start executing the transaction
 		w.current.state.Prepare(tx.Hash(), common.Hash{}, len(w.current.txs))
 		err := w.commitTransaction(tx, coinbase)
@@ -371,6 +383,9 @@ func (w *Worker) UpdateCurrent() (Environment, error) {
 		Time(big.NewInt(timestamp)).
 		ShardID(w.chain.ShardID()).
 		Header()
+	if w.config.IsEIP1559(epoch) {
+		header.SetBaseFee(misc.CalcBaseFee(w.config, parent))
+	}
 	return w.makeCurrent(parent, header)
 }
 
@@ -625,6 +640,10 @@ func (w *Worker) FinalizeNewBlock(
 	if err != nil {
 		return nil, errors.Wrapf(err, "cannot finalize block")
 	}
+	// a state write failed, e.g. a validator wrapper over its size cap
+	if err := w.current.state.Error(); err != nil {
+		return nil, errors.Wrapf(err, "cannot finalize block")
+	}
 	w.current.reward = payout
 	return block, nil
 }
@@ -81,7 +81,8 @@ func TestCommitTransactions(t *testing.T) {
 	// Generate a test tx
 	baseNonce := worker.GetCurrentState().GetNonce(crypto.PubkeyToAddress(testBankKey.PublicKey))
 	randAmount := rand.Float32()
-	tx, _ := types.SignTx(types.NewTransaction(baseNonce, testBankAddress, uint32(0), big.NewInt(int64(denominations.One*randAmount)), params.TxGas, nil, nil), types.HomesteadSigner{}, testBankKey)
+	gasPrice := worker.current.header.BaseFee()
+	tx, _ := types.SignTx(types.NewTransaction(baseNonce, testBankAddress, uint32(0), big.NewInt(int64(denominations.One*randAmount)), params.TxGas, gasPrice, nil), types.HomesteadSigner{}, testBankKey)
 
 	// Commit the tx to the worker
 	txs := make(map[common.Address]types.Transactions)
@@ -102,6 +103,53 @@ func TestCommitTransactions(t *testing.T) {
 	}
 }
 
+func TestCommitTransactionsBelowBaseFee(t *testing.T) {
+	otherKey, _ := crypto.GenerateKey()
+	otherAddress := crypto.PubkeyToAddress(otherKey.PublicKey)
+	var (
+		database = rawdb.NewMemoryDatabase()
+		gspec    = core.Genesis{
+			Config:  chainConfig,
+			Factory: blockFactory,
+			Alloc: core.GenesisAlloc{
+				testBankAddress: {Balance: testBankFunds},
+				otherAddress:    {Balance: testBankFunds},
+			},
+			ShardID: 0,
+		}
+		engine = chain2.NewEngine()
+	)
+
+	gspec.MustCommit(database)
+	cacheConfig := &core.CacheConfig{SnapshotLimit: 0}
+	chain, _ := core.NewBlockChain(database, nil, nil, cacheConfig, gspec.Config, engine, vm.Config{})
+
+	worker := New(chain, nil)
+
+	// the fee cap of the first tx is below the base fee, it is skipped
+	baseFee := worker.current.header.BaseFee()
+	lowPrice := new(big.Int).Sub(baseFee, common.Big1)
+	low, _ := types.SignTx(types.NewTransaction(0, otherAddress, uint32(0), big.NewInt(1), params.TxGas, lowPrice, nil), types.HomesteadSigner{}, testBankKey)
+	ok, _ := types.SignTx(types.NewTransaction(0, testBankAddress, uint32(0), big.NewInt(1), params.TxGas, baseFee, nil), types.HomesteadSigner{}, otherKey)
+
+	txs := make(map[common.Address]types.Transactions)
+	txs[testBankAddress] = types.Transactions{low}
+	txs[otherAddress] = types.Transactions{ok}
+	if err := worker.CommitTransactions(txs, nil, testBankAddress); err != nil {
+		t.Error(err)
+	}
+
+	if len(worker.current.txs) != 1 {
+		t.Fatalf("Unexpected committed transactions: got %v, want 1", len(worker.current.txs))
+	}
+	if worker.current.txs[0].Hash() != ok.Hash() {
+		t.Error("Transaction below the base fee is committed")
+	}
+	if len(worker.GetCurrentReceipts()) != 1 {
+		t.Errorf("Unexpected receipts: got %v, want 1", len(worker.GetCurrentReceipts()))
+	}
+}
+
 func TestGasLimit(t *testing.T) {
 	w := newWorker(
 		&params.ChainConfig{
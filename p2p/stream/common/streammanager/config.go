@@ -1,6 +1,10 @@
 package streammanager
 
-import "time"
+import (
+	"time"
+
+	"github.com/hashicorp/go-version"
+)
 
 const (
 	// checkInterval is the default interval for checking stream number. If the stream
@@ -22,4 +26,7 @@ type Config struct {
 	HiCap int
 	// DiscBatch is the size of each discovery
 	DiscBatch int
+	// Versions are the protocol versions supported when dialing a peer. If empty,
+	// only the version of the protocol ID of the stream manager is supported.
+	Versions []*version.Version
 }
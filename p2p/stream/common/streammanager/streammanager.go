@@ -3,6 +3,7 @@ package streammanager
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/harmony-one/harmony/internal/utils"
 	sttypes "github.com/harmony-one/harmony/p2p/stream/types"
 	"github.com/harmony-one/harmony/shard"
+	"github.com/hashicorp/go-version"
 	"github.com/libp2p/go-libp2p/core/network"
 	libp2p_peer "github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
@@ -336,8 +338,10 @@ func (sm *streamManager) discoverAndSetupStream(discCtx context.Context) (int, e
 	return connecting, nil
 }
 
+// discover finds the peers under every target protocol ID, so that the peers
+// which only advertise the older versions are found as well. The results are
+// merged into one channel of at most discBatch distinct peers.
 func (sm *streamManager) discover(ctx context.Context) (<-chan libp2p_peer.AddrInfo, error) {
-	protoID := sm.targetProtoID()
 	discBatch := sm.config.DiscBatch
 	if sm.config.HiCap-sm.streams.size() < sm.config.DiscBatch {
 		discBatch = sm.config.HiCap - sm.streams.size()
@@ -351,15 +355,89 @@ func (sm *streamManager) discover(ctx context.Context) (<-chan libp2p_peer.AddrI
 		<-time.After(discTimeout)
 		cancel()
 	}()
-	return sm.pf.FindPeers(ctx2, protoID, discBatch)
+
+	var (
+		found   []<-chan libp2p_peer.AddrInfo
+		lastErr error
+	)
+	for _, protoID := range sm.targetProtoIDs() {
+		peers, err := sm.pf.FindPeers(ctx2, string(protoID), discBatch)
+		if err != nil {
+			sm.logger.Warn().Err(err).Str("protocol", string(protoID)).
+				Msg("failed to find peers")
+			lastErr = err
+			continue
+		}
+		found = append(found, peers)
+	}
+	if len(found) == 0 {
+		return nil, lastErr
+	}
+	return mergePeers(found, discBatch), nil
 }
 
-func (sm *streamManager) targetProtoID() string {
+// mergePeers merges the peers found into one channel, skipping the duplicates,
+// up to limit peers. The channel is closed once all the inputs are closed.
+func mergePeers(found []<-chan libp2p_peer.AddrInfo, limit int) <-chan libp2p_peer.AddrInfo {
+	var (
+		merged = make(chan libp2p_peer.AddrInfo)
+		seen   = make(map[libp2p_peer.ID]struct{})
+		lock   sync.Mutex
+		wg     sync.WaitGroup
+	)
+	wg.Add(len(found))
+	for _, peers := range found {
+		go func(peers <-chan libp2p_peer.AddrInfo) {
+			defer wg.Done()
+			// drain the input even past the limit, so that its finder is not blocked
+			for peer := range peers {
+				lock.Lock()
+				_, dup := seen[peer.ID]
+				full := len(seen) >= limit
+				if !dup && !full {
+					seen[peer.ID] = struct{}{}
+				}
+				lock.Unlock()
+				if !dup && !full {
+					merged <- peer
+				}
+			}
+		}(peers)
+	}
+	go func() {
+		wg.Wait()
+		close(merged)
+	}()
+	return merged
+}
+
+func (sm *streamManager) targetProtoSpec() sttypes.ProtoSpec {
 	targetSpec := sm.myProtoSpec
 	if targetSpec.ShardID == shard.BeaconChainShardID { // for beacon chain, only connect to beacon nodes
 		targetSpec.BeaconNode = true
 	}
-	return string(targetSpec.ToProtoID())
+	return targetSpec
+}
+
+// targetProtoIDs returns the protocol IDs of the supported versions from the newest
+// one. The peer is dialed with all of them, so that the stream runs at the highest
+// version both nodes support rather than at the version of the local node, which
+// the nodes of older versions would accept as well.
+func (sm *streamManager) targetProtoIDs() []protocol.ID {
+	versions := make([]*version.Version, 0, len(sm.config.Versions))
+	versions = append(versions, sm.config.Versions...)
+	if len(versions) == 0 {
+		versions = append(versions, sm.myProtoSpec.Version)
+	}
+	sort.Sort(sort.Reverse(version.Collection(versions)))
+
+	pids := make([]protocol.ID, 0, len(versions))
+	for _, v := range versions {
+		targetSpec := sm.targetProtoSpec()
+		targetSpec.Version = v
+		pids = append(pids, protocol.ID(targetSpec.ToProtoID()))
+	}
+	return pids
 }
 
 func (sm *streamManager) setupStreamWithPeer(ctx context.Context, pid libp2p_peer.ID) error {
@@ -369,7 +447,7 @@ func (sm *streamManager) setupStreamWithPeer(ctx context.Context, pid libp2p_pee
 	nCtx, cancel := context.WithTimeout(ctx, connectTimeout)
 	defer cancel()
 
-	st, err := sm.host.NewStream(nCtx, pid, protocol.ID(sm.targetProtoID()))
+	st, err := sm.host.NewStream(nCtx, pid, sm.targetProtoIDs()...)
 	if err != nil {
 		return err
 	}
@@ -1,6 +1,7 @@
 package streammanager
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -9,7 +10,9 @@ import (
 	"time"
 
 	sttypes "github.com/harmony-one/harmony/p2p/stream/types"
+	"github.com/hashicorp/go-version"
 	libp2p_peer "github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
 )
 
 const (
@@ -204,6 +207,118 @@ func TestStreamManager_HandleRemoveStream_Disc(t *testing.T) {
 	}
 }
 
+func TestStreamManager_targetProtoIDs(t *testing.T) {
+	var (
+		v100, _ = version.NewVersion("1.0.0")
+		v110, _ = version.NewVersion("1.1.0")
+		v120, _ = version.NewVersion("1.2.0")
+	)
+	tests := []struct {
+		pid      sttypes.ProtoID
+		versions []*version.Version
+		exp      []protocol.ID
+	}{
+		{
+			pid: testProtoID,
+			exp: []protocol.ID{"harmony/sync/unitest/0/1.0.0/1"},
+		},
+		{
+			pid:      "harmony/sync/unitest/1/1.2.0/0",
+			versions: []*version.Version{v100, v110, v120},
+			exp: []protocol.ID{
+				"harmony/sync/unitest/1/1.2.0/0",
+				"harmony/sync/unitest/1/1.1.0/0",
+				"harmony/sync/unitest/1/1.0.0/0",
+			},
+		},
+		{
+			// only the beacon nodes are dialed for the beacon chain
+			pid:      "harmony/sync/unitest/0/1.2.0/0",
+			versions: []*version.Version{v120, v100},
+			exp: []protocol.ID{
+				"harmony/sync/unitest/0/1.2.0/1",
+				"harmony/sync/unitest/0/1.0.0/1",
+			},
+		},
+	}
+	for i, test := range tests {
+		sm := newStreamManager(test.pid, newTestHost(), nil, nil, Config{Versions: test.versions})
+		pids := sm.targetProtoIDs()
+		if len(pids) != len(test.exp) {
+			t.Fatalf("Test %v: unexpected protocol IDs %v / %v", i, pids, test.exp)
+		}
+		for j := range pids {
+			if pids[j] != test.exp[j] {
+				t.Errorf("Test %v: unexpected protocol ID %v / %v", i, pids[j], test.exp[j])
+			}
+		}
+	}
+}
+
+func TestStreamManager_discoverAllVersions(t *testing.T) {
+	var (
+		v100, _ = version.NewVersion("1.0.0")
+		v120, _ = version.NewVersion("1.2.0")
+	)
+	// the old nodes only advertise 1.0.0, the new ones both versions
+	pf := &nsPeerFinder{peers: map[string][]libp2p_peer.ID{
+		"harmony/sync/unitest/1/1.2.0/0": {makePeerID(1), makePeerID(2)},
+		"harmony/sync/unitest/1/1.0.0/0": {makePeerID(1), makePeerID(2), makePeerID(3)},
+	}}
+	config := defConfig
+	config.Versions = []*version.Version{v100, v120}
+	sm := newStreamManager("harmony/sync/unitest/1/1.2.0/0", newTestHost(), pf, nil, config)
+
+	peers, err := sm.discover(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[libp2p_peer.ID]int)
+	for peer := range peers {
+		got[peer.ID]++
+	}
+	if len(got) != 3 {
+		t.Errorf("unexpected peers found %v / 3", len(got))
+	}
+	for id, n := range got {
+		if n != 1 {
+			t.Errorf("peer %v found %v times", id, n)
+		}
+	}
+	if len(pf.queried) != 2 {
+		t.Errorf("unexpected protocol IDs queried %v", pf.queried)
+	}
+}
+
+// nsPeerFinder finds the peers by the namespace they advertised under
+type nsPeerFinder struct {
+	peers   map[string][]libp2p_peer.ID
+	queried []string
+	lock    sync.Mutex
+}
+
+func (pf *nsPeerFinder) FindPeers(ctx context.Context, ns string, peerLimit int) (<-chan libp2p_peer.AddrInfo, error) {
+	pf.lock.Lock()
+	pf.queried = append(pf.queried, ns)
+	pf.lock.Unlock()
+
+	resC := make(chan libp2p_peer.AddrInfo)
+	go func() {
+		defer close(resC)
+		for i, id := range pf.peers[ns] {
+			if i == peerLimit {
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case resC <- libp2p_peer.AddrInfo{ID: id}:
+			}
+		}
+	}()
+	return resC, nil
+}
+
 func TestStreamSet_numStreamsWithMinProtoID(t *testing.T) {
 	var (
 		pid1    = testProtoID
@@ -1,12 +1,20 @@
 package sync
 
 import (
+	"bytes"
+	"time"
+
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/trie"
 	"github.com/harmony-one/harmony/block"
 	"github.com/harmony-one/harmony/consensus/engine"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/state/snapshot"
 	"github.com/harmony-one/harmony/core/types"
 	shardingconfig "github.com/harmony-one/harmony/internal/configs/sharding"
 	"github.com/harmony-one/harmony/internal/utils/keylocker"
+	syncpb "github.com/harmony-one/harmony/p2p/stream/protocols/sync/message"
 	"github.com/pkg/errors"
 )
 
@@ -18,6 +26,20 @@ type chainHelper interface {
 	getBlocksByHashes(hs []common.Hash) ([]*types.Block, error)
 	getNodeData(hs []common.Hash) ([][]byte, error)
 	getReceipts(hs []common.Hash) ([]types.Receipts, error)
+	getBlocksWithReceiptsByNumber(bns []uint64) ([]*types.Block, []types.Receipts, error)
+	getAccountRange(root, origin, limit common.Hash, bytes uint64) ([]*syncpb.AccountData, [][]byte, error)
+	getStorageRanges(root common.Hash, accounts []common.Hash, origin, limit []byte, bytes uint64) ([]*syncpb.StoragesData, [][]byte, error)
+	getByteCodes(hs []common.Hash, bytes uint64) ([][]byte, error)
+	getTrieNodes(root common.Hash, paths [][][]byte, bytes uint64) ([][]byte, error)
+}
+
+// maxHash is the end of the hash space
+var maxHash = common.HexToHash("0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+
+// stateChain is the chain which serves the state ranges out of its snapshots
+type stateChain interface {
+	GetSnapshotTrie() *snapshot.Tree
+	GetStateCache() state.Database
 }
 
 type chainHelperImpl struct {
@@ -182,3 +204,324 @@ func (ch *chainHelperImpl) getReceipts(hs []common.Hash) ([]types.Receipts, erro
 	}
 	return receipts, nil
 }
+
+// getBlocksWithReceiptsByNumber returns the blocks of the numbers along with their
+// receipts. It stops at the first block not found, or with the receipts not found,
+// so that the result is a prefix of the blocks requested.
+func (ch *chainHelperImpl) getBlocksWithReceiptsByNumber(bns []uint64) ([]*types.Block, []types.Receipts, error) {
+	curBlock := ch.chain.CurrentHeader().Number().Uint64()
+	var (
+		blocks   = make([]*types.Block, 0, len(bns))
+		receipts = make([]types.Receipts, 0, len(bns))
+	)
+	for _, bn := range bns {
+		if curBlock < bn {
+			break
+		}
+		header := ch.chain.GetHeaderByNumber(bn)
+		if header == nil {
+			break
+		}
+		rs := ch.chain.GetReceiptsByHash(header.Hash())
+		if rs == nil && header.ReceiptHash() != types.EmptyRootHash {
+			break
+		}
+		block, err := ch.getBlockWithSigByHeader(header)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "get block %v at %v", header.Hash().String(), header.Number())
+		}
+		blocks = append(blocks, block)
+		receipts = append(receipts, rs)
+	}
+	return blocks, receipts, nil
+}
+
+// stateSnapshots returns the snapshots and the trie database the state ranges
+// are served from, or nil if the chain does not keep state snapshots
+func (ch *chainHelperImpl) stateSnapshots() (*snapshot.Tree, *trie.Database) {
+	sc, ok := ch.chain.(stateChain)
+	if !ok {
+		return nil, nil
+	}
+	snaps := sc.GetSnapshotTrie()
+	if snaps == nil {
+		return nil, nil
+	}
+	return snaps, sc.GetStateCache().TrieDB()
+}
+
+// getAccountRange assembles the response to an account range query. The accounts
+// are in the slim snapshot format, starting at origin and ending at the first
+// account at or after limit, or when the response reaches the bytes target.
+// An empty response is returned if the state of root is not available.
+func (ch *chainHelperImpl) getAccountRange(root, origin, limit common.Hash, bytesTarget uint64) ([]*syncpb.AccountData, [][]byte, error) {
+	if bytesTarget > SoftResponseLimit {
+		bytesTarget = SoftResponseLimit
+	}
+	snaps, triedb := ch.stateSnapshots()
+	if snaps == nil {
+		return nil, nil, nil
+	}
+	tr, err := trie.New(trie.StateTrieID(root), triedb)
+	if err != nil {
+		return nil, nil, nil
+	}
+	it, err := snaps.AccountIterator(root, origin)
+	if err != nil {
+		return nil, nil, nil
+	}
+	var (
+		accounts []*syncpb.AccountData
+		size     uint64
+		last     common.Hash
+	)
+	for it.Next() {
+		hash, account := it.Hash(), common.CopyBytes(it.Account())
+		last = hash
+		size += uint64(common.HashLength + len(account))
+		accounts = append(accounts, &syncpb.AccountData{
+			Hash: hash.Bytes(),
+			Body: account,
+		})
+		if bytes.Compare(hash[:], limit[:]) >= 0 || size > bytesTarget {
+			break
+		}
+	}
+	it.Release()
+
+	// prove the first and the last account of the range
+	proof := memorydb.New()
+	if err := tr.Prove(origin[:], 0, proof); err != nil {
+		return nil, nil, nil
+	}
+	if last != (common.Hash{}) {
+		if err := tr.Prove(last[:], 0, proof); err != nil {
+			return nil, nil, nil
+		}
+	}
+	return accounts, proofNodes(proof), nil
+}
+
+// getStorageRanges assembles the response to a storage ranges query. The slots
+// of the accounts are returned in order, the first one from origin and the last
+// one up to limit. Only the last storage range is proved, if it is incomplete or
+// does not start at the beginning of the storage.
+func (ch *chainHelperImpl) getStorageRanges(root common.Hash, accounts []common.Hash, origin, limit []byte, bytesTarget uint64) ([]*syncpb.StoragesData, [][]byte, error) {
+	if bytesTarget > SoftResponseLimit {
+		bytesTarget = SoftResponseLimit
+	}
+	snaps, triedb := ch.stateSnapshots()
+	if snaps == nil {
+		return nil, nil, nil
+	}
+	// the hard limit allows to return a whole storage rather than proving it
+	hardLimit := bytesTarget + bytesTarget/10
+
+	var (
+		slots  []*syncpb.StoragesData
+		proofs [][]byte
+		size   uint64
+	)
+	for _, account := range accounts {
+		if size >= bytesTarget {
+			break
+		}
+		// the first account may start at origin, the last may end at limit
+		var (
+			start = common.Hash{}
+			end   = maxHash
+		)
+		if len(origin) > 0 {
+			start, origin = common.BytesToHash(origin), nil
+		}
+		if len(limit) > 0 {
+			end, limit = common.BytesToHash(limit), nil
+		}
+		it, err := snaps.StorageIterator(root, account, start)
+		if err != nil {
+			return nil, nil, nil
+		}
+		var (
+			storage []*syncpb.StorageData
+			last    common.Hash
+			abort   bool
+		)
+		for it.Next() {
+			if size >= hardLimit {
+				abort = true
+				break
+			}
+			hash, slot := it.Hash(), common.CopyBytes(it.Slot())
+			last = hash
+			size += uint64(common.HashLength + len(slot))
+			storage = append(storage, &syncpb.StorageData{
+				Hash: hash.Bytes(),
+				Body: slot,
+			})
+			if bytes.Compare(hash[:], end[:]) >= 0 {
+				break
+			}
+		}
+		it.Release()
+		if len(storage) > 0 {
+			slots = append(slots, &syncpb.StoragesData{Data: storage})
+		}
+		if start == (common.Hash{}) && !(abort && len(storage) > 0) {
+			continue
+		}
+		// the storage range is partial, prove its first and last slot and
+		// end the response with it
+		accTrie, err := trie.NewStateTrie(trie.StateTrieID(root), triedb)
+		if err != nil {
+			return nil, nil, nil
+		}
+		acc, err := accTrie.TryGetAccountByHash(account)
+		if err != nil || acc == nil {
+			return nil, nil, nil
+		}
+		stTrie, err := trie.NewStateTrie(trie.StorageTrieID(root, account, acc.Root), triedb)
+		if err != nil {
+			return nil, nil, nil
+		}
+		proof := memorydb.New()
+		if err := stTrie.Prove(start[:], 0, proof); err != nil {
+			return nil, nil, nil
+		}
+		if last != (common.Hash{}) {
+			if err := stTrie.Prove(last[:], 0, proof); err != nil {
+				return nil, nil, nil
+			}
+		}
+		proofs = proofNodes(proof)
+		break
+	}
+	return slots, proofs, nil
+}
+
+// getByteCodes assembles the response to a byte codes query, the contract and
+// the validator codes are served alike
+func (ch *chainHelperImpl) getByteCodes(hs []common.Hash, bytesTarget uint64) ([][]byte, error) {
+	if bytesTarget > SoftResponseLimit {
+		bytesTarget = SoftResponseLimit
+	}
+	var (
+		codes [][]byte
+		size  uint64
+	)
+	for _, hash := range hs {
+		if hash == types.EmptyCodeHash {
+			codes = append(codes, []byte{})
+			continue
+		}
+		code, err := ch.chain.ContractCode(hash)
+		if len(code) == 0 || err != nil {
+			code, err = ch.chain.ValidatorCode(hash)
+		}
+		if err != nil || len(code) == 0 {
+			continue
+		}
+		codes = append(codes, code)
+		size += uint64(len(code))
+		if size > bytesTarget {
+			break
+		}
+	}
+	return codes, nil
+}
+
+// getTrieNodes assembles the response to a trie nodes query. A path set is
+// either a single account trie path, or an account hash followed by the paths
+// in its storage trie.
+func (ch *chainHelperImpl) getTrieNodes(root common.Hash, paths [][][]byte, bytesTarget uint64) ([][]byte, error) {
+	if bytesTarget > SoftResponseLimit {
+		bytesTarget = SoftResponseLimit
+	}
+	snaps, triedb := ch.stateSnapshots()
+	if snaps == nil {
+		return nil, nil
+	}
+	accTrie, err := trie.NewStateTrie(trie.StateTrieID(root), triedb)
+	if err != nil {
+		return nil, nil
+	}
+	// the snapshot may not cover root, the accounts are looked up in the trie then
+	snap := snaps.Snapshot(root)
+
+	var (
+		nodes [][]byte
+		size  uint64
+		loads int
+		start = time.Now()
+	)
+	exceeded := func() bool {
+		return size > bytesTarget || loads > maxTrieNodeLookups || time.Since(start) > maxTrieNodeTimeSpent
+	}
+	for _, pathset := range paths {
+		switch len(pathset) {
+		case 0:
+			return nil, errors.New("zero-item pathset requested")
+
+		case 1:
+			blob, resolved, err := accTrie.TryGetNode(pathset[0])
+			loads += resolved
+			if err != nil {
+				break
+			}
+			nodes = append(nodes, blob)
+			size += uint64(len(blob))
+
+		default:
+			accHash := common.BytesToHash(pathset[0])
+			var stRoot common.Hash
+			if snap == nil {
+				account, err := accTrie.TryGetAccountByHash(accHash)
+				loads += 8
+				if err != nil || account == nil {
+					break
+				}
+				stRoot = account.Root
+			} else {
+				account, err := snap.Account(accHash)
+				loads++
+				if err != nil || account == nil {
+					break
+				}
+				stRoot = types.EmptyRootHash
+				if len(account.Root) > 0 {
+					stRoot = common.BytesToHash(account.Root)
+				}
+			}
+			stTrie, err := trie.NewStateTrie(trie.StorageTrieID(root, accHash, stRoot), triedb)
+			loads++
+			if err != nil {
+				break
+			}
+			for _, path := range pathset[1:] {
+				blob, resolved, err := stTrie.TryGetNode(path)
+				loads += resolved
+				if err != nil {
+					break
+				}
+				nodes = append(nodes, blob)
+				size += uint64(len(blob))
+				if exceeded() {
+					break
+				}
+			}
+		}
+		if exceeded() {
+			break
+		}
+	}
+	return nodes, nil
+}
+
+func proofNodes(proof *memorydb.Database) [][]byte {
+	var nodes [][]byte
+	it := proof.NewIterator(nil, nil)
+	defer it.Release()
+	for it.Next() {
+		nodes = append(nodes, common.CopyBytes(it.Value()))
+	}
+	return nodes
+}
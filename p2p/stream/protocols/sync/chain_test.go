@@ -60,6 +60,37 @@ func (tch *testChainHelper) getReceipts(hs []common.Hash) ([]types.Receipts, err
 	return receipts, nil
 }
 
+func (tch *testChainHelper) getBlocksWithReceiptsByNumber(bns []uint64) ([]*types.Block, []types.Receipts, error) {
+	var (
+		blocks   = make([]*types.Block, 0, len(bns))
+		receipts = make([]types.Receipts, 0, len(bns))
+	)
+	for _, bn := range bns {
+		if bn > tch.getCurrentBlockNumber() {
+			break
+		}
+		blocks = append(blocks, makeTestBlock(bn))
+		receipts = append(receipts, types.Receipts{})
+	}
+	return blocks, receipts, nil
+}
+
+func (tch *testChainHelper) getAccountRange(root, origin, limit common.Hash, bytes uint64) ([]*syncpb.AccountData, [][]byte, error) {
+	return nil, nil, nil
+}
+
+func (tch *testChainHelper) getStorageRanges(root common.Hash, accounts []common.Hash, origin, limit []byte, bytes uint64) ([]*syncpb.StoragesData, [][]byte, error) {
+	return nil, nil, nil
+}
+
+func (tch *testChainHelper) getByteCodes(hs []common.Hash, bytes uint64) ([][]byte, error) {
+	return makeTestCodes(len(hs)), nil
+}
+
+func (tch *testChainHelper) getTrieNodes(root common.Hash, paths [][][]byte, bytes uint64) ([][]byte, error) {
+	return nil, nil
+}
+
 func checkGetReceiptsResult(b []byte, hs []common.Hash) error {
 	var msg = &syncpb.Message{}
 	if err := protobuf.Unmarshal(b, msg); err != nil {
@@ -75,6 +106,30 @@ func checkGetReceiptsResult(b []byte, hs []common.Hash) error {
 	return nil
 }
 
+func checkBlocksWithReceiptsResult(b []byte, bns []uint64) error {
+	var msg = &syncpb.Message{}
+	if err := protobuf.Unmarshal(b, msg); err != nil {
+		return err
+	}
+	brResp, err := msg.GetBlocksWithReceiptsByNumResponse()
+	if err != nil {
+		return err
+	}
+	if len(bns) != len(brResp.BlocksBytes) || len(bns) != len(brResp.Receipts) {
+		return errors.New("unexpected size")
+	}
+	blocks, err := decodeBlocksBytes(brResp.BlocksBytes)
+	if err != nil {
+		return err
+	}
+	for i, block := range blocks {
+		if block.NumberU64() != bns[i] {
+			return fmt.Errorf("unexpected number %v / %v", block.NumberU64(), bns[i])
+		}
+	}
+	return nil
+}
+
 func checkGetNodeDataResult(b []byte, hs []common.Hash) error {
 	var msg = &syncpb.Message{}
 	if err := protobuf.Unmarshal(b, msg); err != nil {
@@ -230,3 +285,26 @@ func checkBlocksByHashesResult(b []byte, hs []common.Hash) error {
 	}
 	return nil
 }
+
+func makeTestCodes(size int) [][]byte {
+	codes := make([][]byte, 0, size)
+	for i := 0; i != size; i++ {
+		codes = append(codes, []byte(fmt.Sprintf("test code %v", i)))
+	}
+	return codes
+}
+
+func checkGetByteCodesResult(b []byte, hs []common.Hash) error {
+	var msg = &syncpb.Message{}
+	if err := protobuf.Unmarshal(b, msg); err != nil {
+		return err
+	}
+	bcResp, err := msg.GetByteCodesResponse()
+	if err != nil {
+		return err
+	}
+	if len(hs) != len(bcResp.Codes) {
+		return errors.New("unexpected size")
+	}
+	return nil
+}
@@ -7,8 +7,13 @@ import (
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
 	protobuf "github.com/golang/protobuf/proto"
+	"github.com/harmony-one/harmony/core/state/snapshot"
 	"github.com/harmony-one/harmony/core/types"
 	syncpb "github.com/harmony-one/harmony/p2p/stream/protocols/sync/message"
 	sttypes "github.com/harmony-one/harmony/p2p/stream/types"
@@ -181,6 +186,167 @@ func (p *Protocol) GetNodeData(ctx context.Context, hs []common.Hash, opts ...Op
 	return
 }
 
+// GetAccountRange do getAccountRangeRequest through sync stream protocol.
+// Return the accounts of the state of root from origin, in the slim snapshot format,
+// whether the state has more accounts after the range, target stream id, and error.
+// The range is verified against root with its proof.
+func (p *Protocol) GetAccountRange(ctx context.Context, root, origin, limit common.Hash, bytes uint64, opts ...Option) (hashes []common.Hash, accounts [][]byte, more bool, stid sttypes.StreamID, err error) {
+	timer := p.doMetricClientRequest("getAccountRange")
+	defer p.doMetricPostClientRequest("getAccountRange", err, timer)
+
+	req := newGetAccountRangeRequest(root, origin, limit, bytes)
+	resp, stid, err := p.rm.DoRequest(ctx, req, opts...)
+	if err != nil {
+		return
+	}
+	hashes, accounts, more, err = req.getAccountRangeFromResponse(resp)
+	return
+}
+
+// GetAccountProof do getAccountRangeRequest of a single account through sync stream
+// protocol. Return the account in the slim snapshot format, nil if the state does not
+// have it, the merkle proof of the account, target stream id, and error. The account,
+// or its absence, is verified against root.
+func (p *Protocol) GetAccountProof(ctx context.Context, root, account common.Hash, opts ...Option) (body []byte, proof [][]byte, stid sttypes.StreamID, err error) {
+	timer := p.doMetricClientRequest("getAccountProof")
+	defer p.doMetricPostClientRequest("getAccountProof", err, timer)
+
+	req := newGetAccountRangeRequest(root, account, account, 0)
+	resp, stid, err := p.rm.DoRequest(ctx, req, opts...)
+	if err != nil {
+		return
+	}
+	body, proof, err = req.getAccountProofFromResponse(resp)
+	return
+}
+
+// GetStorageRanges do getStorageRangesRequest through sync stream protocol.
+// Return the storage slots of the accounts, the first one from origin and the last
+// one up to limit, whether the last storage has more slots after the range, target
+// stream id, and error. The ranges are verified against the storage roots of the accounts.
+func (p *Protocol) GetStorageRanges(ctx context.Context, root common.Hash, accounts []common.Hash, roots []common.Hash, origin, limit []byte, bytes uint64, opts ...Option) (hashes [][]common.Hash, slots [][][]byte, more bool, stid sttypes.StreamID, err error) {
+	timer := p.doMetricClientRequest("getStorageRanges")
+	defer p.doMetricPostClientRequest("getStorageRanges", err, timer)
+
+	if len(accounts) == 0 {
+		err = fmt.Errorf("zero accounts requested")
+		return
+	}
+	if len(accounts) > GetStorageRangesCap {
+		err = fmt.Errorf("number of requested accounts exceed limit")
+		return
+	}
+	if len(accounts) != len(roots) {
+		err = fmt.Errorf("storage roots size not expected: %v / %v", len(roots), len(accounts))
+		return
+	}
+	req := newGetStorageRangesRequest(root, accounts, roots, origin, limit, bytes)
+	resp, stid, err := p.rm.DoRequest(ctx, req, opts...)
+	if err != nil {
+		return
+	}
+	hashes, slots, more, err = req.getStorageRangesFromResponse(resp)
+	return
+}
+
+// GetStorageProof do getStorageRangesRequest of a single storage slot through sync
+// stream protocol. Return the slot value, nil if the storage does not have it, the
+// merkle proof of the slot, target stream id, and error. The slot, or its absence,
+// is verified against the storage root of the account.
+func (p *Protocol) GetStorageProof(ctx context.Context, root, account, storageRoot, slot common.Hash, opts ...Option) (value []byte, proof [][]byte, stid sttypes.StreamID, err error) {
+	timer := p.doMetricClientRequest("getStorageProof")
+	defer p.doMetricPostClientRequest("getStorageProof", err, timer)
+
+	if storageRoot == types.EmptyRootHash {
+		// nothing to request, the storage is empty
+		return
+	}
+	req := newGetStorageRangesRequest(root, []common.Hash{account}, []common.Hash{storageRoot},
+		slot[:], slot[:], 0)
+	resp, stid, err := p.rm.DoRequest(ctx, req, opts...)
+	if err != nil {
+		return
+	}
+	value, proof, err = req.getStorageProofFromResponse(resp, slot)
+	return
+}
+
+// GetByteCodes do getByteCodesRequest through sync stream protocol.
+// Return the codes in the order of the hashes, nil for the ones not delivered,
+// target stream id, and error
+func (p *Protocol) GetByteCodes(ctx context.Context, hs []common.Hash, bytes uint64, opts ...Option) (codes [][]byte, stid sttypes.StreamID, err error) {
+	timer := p.doMetricClientRequest("getByteCodes")
+	defer p.doMetricPostClientRequest("getByteCodes", err, timer)
+
+	if len(hs) == 0 {
+		err = fmt.Errorf("zero code hashes requested")
+		return
+	}
+	if len(hs) > GetByteCodesCap {
+		err = fmt.Errorf("number of requested hashes exceed limit")
+		return
+	}
+	req := newGetByteCodesRequest(hs, bytes)
+	resp, stid, err := p.rm.DoRequest(ctx, req, opts...)
+	if err != nil {
+		return
+	}
+	codes, err = req.getByteCodesFromResponse(resp)
+	return
+}
+
+// GetTrieNodes do getTrieNodesRequest through sync stream protocol.
+// A path set is either a single account trie path, or an account hash followed by
+// paths in its storage trie, and hs are the hashes of the nodes of all the paths in
+// order. Return the trie nodes in the order of the hashes, nil for the ones not
+// delivered, target stream id, and error
+func (p *Protocol) GetTrieNodes(ctx context.Context, root common.Hash, paths [][][]byte, hs []common.Hash, bytes uint64, opts ...Option) (nodes [][]byte, stid sttypes.StreamID, err error) {
+	timer := p.doMetricClientRequest("getTrieNodes")
+	defer p.doMetricPostClientRequest("getTrieNodes", err, timer)
+
+	if len(hs) == 0 {
+		err = fmt.Errorf("zero trie nodes requested")
+		return
+	}
+	if len(hs) > GetTrieNodesCap {
+		err = fmt.Errorf("number of requested trie nodes exceed limit")
+		return
+	}
+	req := newGetTrieNodesRequest(root, paths, hs, bytes)
+	resp, stid, err := p.rm.DoRequest(ctx, req, opts...)
+	if err != nil {
+		return
+	}
+	nodes, err = req.getTrieNodesFromResponse(resp)
+	return
+}
+
+// GetBlocksWithReceiptsByNumber do getBlocksWithReceiptsByNumberRequest through sync stream
+// protocol, for the syncing nodes trusting the commit signatures to skip executing the blocks.
+// The result is a prefix of the blocks requested, capped in size by the remote node, with the
+// receipts verified against the block headers. Return the blocks and receipts as result,
+// target stream id, and error
+func (p *Protocol) GetBlocksWithReceiptsByNumber(ctx context.Context, bns []uint64, opts ...Option) (blocks []*types.Block, receipts []types.Receipts, stid sttypes.StreamID, err error) {
+	timer := p.doMetricClientRequest("getBlocksWithReceiptsByNumber")
+	defer p.doMetricPostClientRequest("getBlocksWithReceiptsByNumber", err, timer)
+
+	if len(bns) == 0 {
+		err = fmt.Errorf("zero block numbers requested")
+		return
+	}
+	if len(bns) > GetBlocksWithReceiptsByNumAmountCap {
+		err = fmt.Errorf("number of blocks exceed cap of %v", GetBlocksWithReceiptsByNumAmountCap)
+		return
+	}
+	req := newGetBlocksWithReceiptsByNumberRequest(bns)
+	resp, stid, err := p.rm.DoRequest(ctx, req, opts...)
+	if err != nil {
+		return
+	}
+	blocks, receipts, err = req.getBlocksWithReceiptsFromResponse(resp)
+	return
+}
+
 // getBlocksByNumberRequest is the request for get block by numbers which implements
 // sttypes.Request interface
 type getBlocksByNumberRequest struct {
@@ -571,3 +737,518 @@ func (req *getReceiptsRequest) parseGetReceiptsBytes(resp *syncResponse) ([]type
 	}
 	return receipts, nil
 }
+
+// getAccountRangeRequest is the request for get account range which implements
+// sttypes.Request interface
+type getAccountRangeRequest struct {
+	root   common.Hash
+	origin common.Hash
+	limit  common.Hash
+	pbReq  *syncpb.Request
+}
+
+func newGetAccountRangeRequest(root, origin, limit common.Hash, bytes uint64) *getAccountRangeRequest {
+	pbReq := syncpb.MakeGetAccountRangeRequest(root, origin, limit, bytes)
+	return &getAccountRangeRequest{
+		root:   root,
+		origin: origin,
+		limit:  limit,
+		pbReq:  pbReq,
+	}
+}
+
+func (req *getAccountRangeRequest) ReqID() uint64 {
+	return req.pbReq.GetReqId()
+}
+
+func (req *getAccountRangeRequest) SetReqID(val uint64) {
+	req.pbReq.ReqId = val
+}
+
+func (req *getAccountRangeRequest) String() string {
+	return fmt.Sprintf("REQUEST [GetAccountRange: %s %s-%s]", req.root.String(),
+		req.origin.String(), req.limit.String())
+}
+
+func (req *getAccountRangeRequest) IsSupportedByProto(target sttypes.ProtoSpec) bool {
+	return target.Version.GreaterThanOrEqual(version110)
+}
+
+func (req *getAccountRangeRequest) Encode() ([]byte, error) {
+	msg := syncpb.MakeMessageFromRequest(req.pbReq)
+	return protobuf.Marshal(msg)
+}
+
+func (req *getAccountRangeRequest) getAccountRangeFromResponse(resp sttypes.Response) ([]common.Hash, [][]byte, bool, error) {
+	sResp, ok := resp.(*syncResponse)
+	if !ok || sResp == nil {
+		return nil, nil, false, errors.New("not sync response")
+	}
+	if errResp := sResp.pb.GetErrorResponse(); errResp != nil {
+		return nil, nil, false, errors.New(errResp.Error)
+	}
+	arResp := sResp.pb.GetGetAccountRangeResponse()
+	if arResp == nil {
+		return nil, nil, false, errors.New("response not GetAccountRange")
+	}
+	if len(arResp.Accounts) == 0 && len(arResp.Proof) == 0 {
+		return nil, nil, false, ErrStateUnavailable
+	}
+	var (
+		hashes   = make([]common.Hash, 0, len(arResp.Accounts))
+		keys     = make([][]byte, 0, len(arResp.Accounts))
+		accounts = make([][]byte, 0, len(arResp.Accounts))
+		values   = make([][]byte, 0, len(arResp.Accounts))
+	)
+	for _, account := range arResp.Accounts {
+		full, err := snapshot.FullAccountRLP(account.Body)
+		if err != nil {
+			return nil, nil, false, errors.Wrap(err, "[GetAccountRangeResponse]")
+		}
+		hash := common.BytesToHash(account.Hash)
+		hashes = append(hashes, hash)
+		keys = append(keys, hash.Bytes())
+		accounts = append(accounts, account.Body)
+		values = append(values, full)
+	}
+	var end []byte
+	if len(keys) > 0 {
+		end = keys[len(keys)-1]
+	}
+	more, err := trie.VerifyRangeProof(req.root, req.origin[:], end, keys, values, proofDB(arResp.Proof))
+	if err != nil {
+		return nil, nil, false, errors.Wrap(err, "[GetAccountRangeResponse]")
+	}
+	return hashes, accounts, more, nil
+}
+
+// getAccountProofFromResponse returns the requested account of the single account
+// range, and its proof ordered from the root
+func (req *getAccountRangeRequest) getAccountProofFromResponse(resp sttypes.Response) ([]byte, [][]byte, error) {
+	hashes, accounts, _, err := req.getAccountRangeFromResponse(resp)
+	if err != nil {
+		return nil, nil, err
+	}
+	proof, err := proofPath(req.root, req.origin[:], resp.(*syncResponse).pb.GetGetAccountRangeResponse().Proof)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "[GetAccountRangeResponse]")
+	}
+	if len(hashes) == 0 || hashes[0] != req.origin {
+		// the range proves the account does not exist
+		return nil, proof, nil
+	}
+	return accounts[0], proof, nil
+}
+
+// getStorageRangesRequest is the request for get storage ranges which implements
+// sttypes.Request interface
+type getStorageRangesRequest struct {
+	root     common.Hash
+	accounts []common.Hash
+	roots    []common.Hash
+	origin   common.Hash
+	pbReq    *syncpb.Request
+}
+
+func newGetStorageRangesRequest(root common.Hash, accounts, roots []common.Hash, origin, limit []byte, bytes uint64) *getStorageRangesRequest {
+	pbReq := syncpb.MakeGetStorageRangesRequest(root, accounts, origin, limit, bytes)
+	return &getStorageRangesRequest{
+		root:     root,
+		accounts: accounts,
+		roots:    roots,
+		origin:   common.BytesToHash(origin),
+		pbReq:    pbReq,
+	}
+}
+
+func (req *getStorageRangesRequest) ReqID() uint64 {
+	return req.pbReq.GetReqId()
+}
+
+func (req *getStorageRangesRequest) SetReqID(val uint64) {
+	req.pbReq.ReqId = val
+}
+
+func (req *getStorageRangesRequest) String() string {
+	ss := make([]string, 0, len(req.accounts))
+	for _, h := range req.accounts {
+		ss = append(ss, h.String())
+	}
+	hsStr := strings.Join(ss, ",")
+	return fmt.Sprintf("REQUEST [GetStorageRanges: %s %s]", req.root.String(), hsStr)
+}
+
+func (req *getStorageRangesRequest) IsSupportedByProto(target sttypes.ProtoSpec) bool {
+	return target.Version.GreaterThanOrEqual(version110)
+}
+
+func (req *getStorageRangesRequest) Encode() ([]byte, error) {
+	msg := syncpb.MakeMessageFromRequest(req.pbReq)
+	return protobuf.Marshal(msg)
+}
+
+func (req *getStorageRangesRequest) getStorageRangesFromResponse(resp sttypes.Response) ([][]common.Hash, [][][]byte, bool, error) {
+	sResp, ok := resp.(*syncResponse)
+	if !ok || sResp == nil {
+		return nil, nil, false, errors.New("not sync response")
+	}
+	if errResp := sResp.pb.GetErrorResponse(); errResp != nil {
+		return nil, nil, false, errors.New(errResp.Error)
+	}
+	srResp := sResp.pb.GetGetStorageRangesResponse()
+	if srResp == nil {
+		return nil, nil, false, errors.New("response not GetStorageRanges")
+	}
+	if len(srResp.Slots) == 0 && len(srResp.Proof) == 0 {
+		return nil, nil, false, ErrStateUnavailable
+	}
+	if len(srResp.Slots) > len(req.accounts) {
+		return nil, nil, false, fmt.Errorf("storage ranges size not expected: %v / %v",
+			len(srResp.Slots), len(req.accounts))
+	}
+	var (
+		hashes = make([][]common.Hash, 0, len(srResp.Slots))
+		slots  = make([][][]byte, 0, len(srResp.Slots))
+		more   bool
+	)
+	for i, storage := range srResp.Slots {
+		var (
+			keys   = make([][]byte, 0, len(storage.Data))
+			hs     = make([]common.Hash, 0, len(storage.Data))
+			values = make([][]byte, 0, len(storage.Data))
+		)
+		for _, slot := range storage.Data {
+			hash := common.BytesToHash(slot.Hash)
+			hs = append(hs, hash)
+			keys = append(keys, hash.Bytes())
+			values = append(values, slot.Body)
+		}
+		if i < len(srResp.Slots)-1 || len(srResp.Proof) == 0 {
+			// no proof, the range must be the whole storage
+			if _, err := trie.VerifyRangeProof(req.roots[i], nil, nil, keys, values, nil); err != nil {
+				return nil, nil, false, errors.Wrap(err, "[GetStorageRangesResponse]")
+			}
+		} else {
+			var origin common.Hash
+			if i == 0 {
+				origin = req.origin
+			}
+			var end []byte
+			if len(keys) > 0 {
+				end = keys[len(keys)-1]
+			}
+			cont, err := trie.VerifyRangeProof(req.roots[i], origin[:], end, keys, values, proofDB(srResp.Proof))
+			if err != nil {
+				return nil, nil, false, errors.Wrap(err, "[GetStorageRangesResponse]")
+			}
+			more = cont
+		}
+		hashes = append(hashes, hs)
+		slots = append(slots, values)
+	}
+	return hashes, slots, more, nil
+}
+
+// getStorageProofFromResponse returns the requested slot of the single slot range,
+// and its proof ordered from the storage root
+func (req *getStorageRangesRequest) getStorageProofFromResponse(resp sttypes.Response, slot common.Hash) ([]byte, [][]byte, error) {
+	hashes, slots, _, err := req.getStorageRangesFromResponse(resp)
+	if err != nil {
+		return nil, nil, err
+	}
+	rawProof := resp.(*syncResponse).pb.GetGetStorageRangesResponse().Proof
+	if len(hashes) == 0 {
+		// the range is empty, which is only verified by the proof of the slot
+		if _, err := trie.VerifyRangeProof(req.roots[0], slot[:], nil, nil, nil, proofDB(rawProof)); err != nil {
+			return nil, nil, errors.Wrap(err, "[GetStorageRangesResponse]")
+		}
+	}
+	proof, err := proofPath(req.roots[0], slot[:], rawProof)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "[GetStorageRangesResponse]")
+	}
+	if len(hashes) == 0 || len(hashes[0]) == 0 || hashes[0][0] != slot {
+		// the range proves the slot does not exist
+		return nil, proof, nil
+	}
+	return slots[0][0], proof, nil
+}
+
+// getByteCodesRequest is the request for get byte codes which implements
+// sttypes.Request interface
+type getByteCodesRequest struct {
+	hashes []common.Hash
+	pbReq  *syncpb.Request
+}
+
+func newGetByteCodesRequest(hashes []common.Hash, bytes uint64) *getByteCodesRequest {
+	pbReq := syncpb.MakeGetByteCodesRequest(hashes, bytes)
+	return &getByteCodesRequest{
+		hashes: hashes,
+		pbReq:  pbReq,
+	}
+}
+
+func (req *getByteCodesRequest) ReqID() uint64 {
+	return req.pbReq.GetReqId()
+}
+
+func (req *getByteCodesRequest) SetReqID(val uint64) {
+	req.pbReq.ReqId = val
+}
+
+func (req *getByteCodesRequest) String() string {
+	ss := make([]string, 0, len(req.hashes))
+	for _, h := range req.hashes {
+		ss = append(ss, h.String())
+	}
+	hsStr := strings.Join(ss, ",")
+	return fmt.Sprintf("REQUEST [GetByteCodes: %s]", hsStr)
+}
+
+func (req *getByteCodesRequest) IsSupportedByProto(target sttypes.ProtoSpec) bool {
+	return target.Version.GreaterThanOrEqual(version110)
+}
+
+func (req *getByteCodesRequest) Encode() ([]byte, error) {
+	msg := syncpb.MakeMessageFromRequest(req.pbReq)
+	return protobuf.Marshal(msg)
+}
+
+func (req *getByteCodesRequest) getByteCodesFromResponse(resp sttypes.Response) ([][]byte, error) {
+	sResp, ok := resp.(*syncResponse)
+	if !ok || sResp == nil {
+		return nil, errors.New("not sync response")
+	}
+	if errResp := sResp.pb.GetErrorResponse(); errResp != nil {
+		return nil, errors.New(errResp.Error)
+	}
+	bcResp := sResp.pb.GetGetByteCodesResponse()
+	if bcResp == nil {
+		return nil, errors.New("response not GetByteCodes")
+	}
+	codes, err := alignByHash(bcResp.Codes, req.hashes)
+	if err != nil {
+		return nil, errors.Wrap(err, "[GetByteCodesResponse]")
+	}
+	return codes, nil
+}
+
+// getTrieNodesRequest is the request for get trie nodes which implements
+// sttypes.Request interface
+type getTrieNodesRequest struct {
+	root   common.Hash
+	hashes []common.Hash
+	pbReq  *syncpb.Request
+}
+
+func newGetTrieNodesRequest(root common.Hash, paths [][][]byte, hashes []common.Hash, bytes uint64) *getTrieNodesRequest {
+	pbReq := syncpb.MakeGetTrieNodesRequest(root, paths, bytes)
+	return &getTrieNodesRequest{
+		root:   root,
+		hashes: hashes,
+		pbReq:  pbReq,
+	}
+}
+
+func (req *getTrieNodesRequest) ReqID() uint64 {
+	return req.pbReq.GetReqId()
+}
+
+func (req *getTrieNodesRequest) SetReqID(val uint64) {
+	req.pbReq.ReqId = val
+}
+
+func (req *getTrieNodesRequest) String() string {
+	ss := make([]string, 0, len(req.hashes))
+	for _, h := range req.hashes {
+		ss = append(ss, h.String())
+	}
+	hsStr := strings.Join(ss, ",")
+	return fmt.Sprintf("REQUEST [GetTrieNodes: %s %s]", req.root.String(), hsStr)
+}
+
+func (req *getTrieNodesRequest) IsSupportedByProto(target sttypes.ProtoSpec) bool {
+	return target.Version.GreaterThanOrEqual(version110)
+}
+
+func (req *getTrieNodesRequest) Encode() ([]byte, error) {
+	msg := syncpb.MakeMessageFromRequest(req.pbReq)
+	return protobuf.Marshal(msg)
+}
+
+func (req *getTrieNodesRequest) getTrieNodesFromResponse(resp sttypes.Response) ([][]byte, error) {
+	sResp, ok := resp.(*syncResponse)
+	if !ok || sResp == nil {
+		return nil, errors.New("not sync response")
+	}
+	if errResp := sResp.pb.GetErrorResponse(); errResp != nil {
+		return nil, errors.New(errResp.Error)
+	}
+	tnResp := sResp.pb.GetGetTrieNodesResponse()
+	if tnResp == nil {
+		return nil, errors.New("response not GetTrieNodes")
+	}
+	nodes, err := alignByHash(tnResp.Nodes, req.hashes)
+	if err != nil {
+		return nil, errors.Wrap(err, "[GetTrieNodesResponse]")
+	}
+	return nodes, nil
+}
+
+// getBlocksWithReceiptsByNumberRequest is the request for get blocks with receipts by
+// numbers which implements sttypes.Request interface
+type getBlocksWithReceiptsByNumberRequest struct {
+	bns   []uint64
+	pbReq *syncpb.Request
+}
+
+func newGetBlocksWithReceiptsByNumberRequest(bns []uint64) *getBlocksWithReceiptsByNumberRequest {
+	pbReq := syncpb.MakeGetBlocksWithReceiptsByNumRequest(bns)
+	return &getBlocksWithReceiptsByNumberRequest{
+		bns:   bns,
+		pbReq: pbReq,
+	}
+}
+
+func (req *getBlocksWithReceiptsByNumberRequest) ReqID() uint64 {
+	return req.pbReq.GetReqId()
+}
+
+func (req *getBlocksWithReceiptsByNumberRequest) SetReqID(val uint64) {
+	req.pbReq.ReqId = val
+}
+
+func (req *getBlocksWithReceiptsByNumberRequest) String() string {
+	ss := make([]string, 0, len(req.bns))
+	for _, bn := range req.bns {
+		ss = append(ss, strconv.Itoa(int(bn)))
+	}
+	bnsStr := strings.Join(ss, ",")
+	return fmt.Sprintf("REQUEST [GetBlocksWithReceiptsByNumber: %s]", bnsStr)
+}
+
+func (req *getBlocksWithReceiptsByNumberRequest) IsSupportedByProto(target sttypes.ProtoSpec) bool {
+	return target.Version.GreaterThanOrEqual(version120)
+}
+
+func (req *getBlocksWithReceiptsByNumberRequest) Encode() ([]byte, error) {
+	msg := syncpb.MakeMessageFromRequest(req.pbReq)
+	return protobuf.Marshal(msg)
+}
+
+func (req *getBlocksWithReceiptsByNumberRequest) getBlocksWithReceiptsFromResponse(resp sttypes.Response) ([]*types.Block, []types.Receipts, error) {
+	sResp, ok := resp.(*syncResponse)
+	if !ok || sResp == nil {
+		return nil, nil, errors.New("not sync response")
+	}
+	if errResp := sResp.pb.GetErrorResponse(); errResp != nil {
+		return nil, nil, errors.New(errResp.Error)
+	}
+	brResp := sResp.pb.GetGetBlocksWithReceiptsByNumResponse()
+	if brResp == nil {
+		return nil, nil, errors.New("response not GetBlocksWithReceiptsByNum")
+	}
+	if len(brResp.BlocksBytes) != len(brResp.CommitSig) || len(brResp.BlocksBytes) != len(brResp.Receipts) {
+		return nil, nil, fmt.Errorf("commit sigs and receipts size not expected: %v, %v / %v",
+			len(brResp.CommitSig), len(brResp.Receipts), len(brResp.BlocksBytes))
+	}
+	if len(brResp.BlocksBytes) > len(req.bns) {
+		return nil, nil, fmt.Errorf("more blocks delivered than requested: %v / %v",
+			len(brResp.BlocksBytes), len(req.bns))
+	}
+	var (
+		blocks   = make([]*types.Block, 0, len(brResp.BlocksBytes))
+		receipts = make([]types.Receipts, 0, len(brResp.BlocksBytes))
+	)
+	for i, bb := range brResp.BlocksBytes {
+		var block *types.Block
+		if err := rlp.DecodeBytes(bb, &block); err != nil {
+			return nil, nil, errors.Wrap(err, "[GetBlocksWithReceiptsByNumResponse]")
+		}
+		if block == nil || block.NumberU64() != req.bns[i] {
+			return nil, nil, fmt.Errorf("unexpected block delivered for number %v", req.bns[i])
+		}
+		block.SetCurrentCommitSig(brResp.CommitSig[i])
+
+		blkReceipts := make(types.Receipts, 0, len(brResp.Receipts[i].GetReceiptBytes()))
+		for _, rcptBytes := range brResp.Receipts[i].GetReceiptBytes() {
+			var receipt *types.Receipt
+			if err := rlp.DecodeBytes(rcptBytes, &receipt); err != nil {
+				return nil, nil, errors.Wrap(err, "[GetBlocksWithReceiptsByNumResponse]")
+			}
+			blkReceipts = append(blkReceipts, receipt)
+		}
+		if hash := types.DeriveSha(blkReceipts); hash != block.Header().ReceiptHash() {
+			return nil, nil, fmt.Errorf("receipt root of block %v not match: %x / %x",
+				block.NumberU64(), hash, block.Header().ReceiptHash())
+		}
+		blocks = append(blocks, block)
+		receipts = append(receipts, blkReceipts)
+	}
+	return blocks, receipts, nil
+}
+
+// alignByHash places the delivered blobs at the index of their hash in the
+// requested hashes. The blobs are delivered in the requested order, but some
+// may be skipped.
+func alignByHash(blobs [][]byte, hashes []common.Hash) ([][]byte, error) {
+	aligned := make([][]byte, len(hashes))
+	j := 0
+	for _, blob := range blobs {
+		hash := crypto.Keccak256Hash(blob)
+		for j < len(hashes) && hash != hashes[j] {
+			j++
+		}
+		if j == len(hashes) {
+			return nil, errors.New("unexpected data delivered")
+		}
+		aligned[j] = blob
+		j++
+	}
+	return aligned, nil
+}
+
+// proofDB returns the database of the proof nodes keyed by their hash, or nil
+// if there is no proof, that is the range is the whole trie
+func proofDB(proof [][]byte) ethdb.KeyValueReader {
+	if len(proof) == 0 {
+		return nil
+	}
+	db := memorydb.New()
+	for _, node := range proof {
+		db.Put(crypto.Keccak256(node), node)
+	}
+	return db
+}
+
+// proofPath returns the nodes of the proof on the path from root to key, in order,
+// as expected by the merkle proof consumers
+func proofPath(root common.Hash, key []byte, proof [][]byte) ([][]byte, error) {
+	if len(proof) == 0 {
+		return nil, errors.New("missing proof")
+	}
+	rec := &proofRecorder{db: proofDB(proof)}
+	if _, err := trie.VerifyProof(root, key, rec); err != nil {
+		return nil, err
+	}
+	return rec.nodes, nil
+}
+
+// proofRecorder records the proof nodes in the order they are read
+type proofRecorder struct {
+	db    ethdb.KeyValueReader
+	nodes [][]byte
+}
+
+func (rec *proofRecorder) Has(key []byte) (bool, error) {
+	return rec.db.Has(key)
+}
+
+func (rec *proofRecorder) Get(key []byte) ([]byte, error) {
+	node, err := rec.db.Get(key)
+	if err == nil {
+		rec.nodes = append(rec.nodes, node)
+	}
+	return node, err
+}
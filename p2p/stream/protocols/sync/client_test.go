@@ -1,17 +1,25 @@
 package sync
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
+	"sort"
 	"strings"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
 	"github.com/harmony-one/harmony/block"
 	headerV3 "github.com/harmony-one/harmony/block/v3"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/state/snapshot"
 	"github.com/harmony-one/harmony/core/types"
 	"github.com/harmony-one/harmony/p2p/stream/common/ratelimiter"
 	"github.com/harmony-one/harmony/p2p/stream/common/streammanager"
@@ -24,6 +32,11 @@ var (
 	_ sttypes.Request  = &getBlocksByNumberRequest{}
 	_ sttypes.Request  = &getBlockNumberRequest{}
 	_ sttypes.Request  = &getReceiptsRequest{}
+	_ sttypes.Request  = &getAccountRangeRequest{}
+	_ sttypes.Request  = &getStorageRangesRequest{}
+	_ sttypes.Request  = &getByteCodesRequest{}
+	_ sttypes.Request  = &getTrieNodesRequest{}
+	_ sttypes.Request  = &getBlocksWithReceiptsByNumberRequest{}
 	_ sttypes.Response = &syncResponse{&syncpb.Response{}}
 )
 
@@ -67,6 +80,18 @@ var (
 
 	testNodeDataResponse = syncpb.MakeGetNodeDataResponse(0, [][]byte{testNodeDataBytes})
 
+	testCodes             = makeTestCodes(2)
+	testByteCodesResponse = syncpb.MakeGetByteCodesResponse(0, [][]byte{testCodes[1]})
+
+	testBlockWithReceipts          = makeTestBlockWithReceipts(0, types.Receipts{testReceipt})
+	testBlockWithReceiptsBytes, _  = rlp.EncodeToBytes(testBlockWithReceipts)
+	testBlocksWithReceiptsResponse = syncpb.MakeGetBlocksWithReceiptsByNumResponse(0,
+		[][]byte{testBlockWithReceiptsBytes}, make([][]byte, 1),
+		[]*message.Receipts{{ReceiptBytes: [][]byte{testReceiptBytes}}})
+	testBlocksWithBadReceiptsResponse = syncpb.MakeGetBlocksWithReceiptsByNumResponse(0,
+		[][]byte{testBlockWithReceiptsBytes}, make([][]byte, 1),
+		[]*message.Receipts{{ReceiptBytes: [][]byte{testReceiptBytes, testReceiptBytes}}})
+
 	testErrorResponse = syncpb.MakeErrorResponse(0, errors.New("test error"))
 )
 
@@ -369,6 +394,82 @@ func TestProtocol_GetReceipts(t *testing.T) {
 	}
 }
 
+func TestProtocol_GetBlocksWithReceiptsByNumber(t *testing.T) {
+	tests := []struct {
+		getResponse getResponseFn
+		expErr      error
+		expStID     sttypes.StreamID
+	}{
+		{
+			getResponse: func(request sttypes.Request) (sttypes.Response, sttypes.StreamID) {
+				return &syncResponse{
+					pb: testBlocksWithReceiptsResponse,
+				}, makeTestStreamID(0)
+			},
+			expErr:  nil,
+			expStID: makeTestStreamID(0),
+		},
+		{
+			getResponse: func(request sttypes.Request) (sttypes.Response, sttypes.StreamID) {
+				return &syncResponse{
+					pb: testBlocksWithBadReceiptsResponse,
+				}, makeTestStreamID(0)
+			},
+			expErr: fmt.Errorf("receipt root of block 0 not match: %x / %x",
+				types.DeriveSha(types.Receipts{testReceipt, testReceipt}),
+				testBlockWithReceipts.Header().ReceiptHash()),
+			expStID: makeTestStreamID(0),
+		},
+		{
+			getResponse: func(request sttypes.Request) (sttypes.Response, sttypes.StreamID) {
+				return &syncResponse{
+					pb: testBlockResponse,
+				}, makeTestStreamID(0)
+			},
+			expErr:  errors.New("response not GetBlocksWithReceiptsByNum"),
+			expStID: makeTestStreamID(0),
+		},
+		{
+			getResponse: nil,
+			expErr:      errors.New("get response error"),
+			expStID:     "",
+		},
+		{
+			getResponse: func(request sttypes.Request) (sttypes.Response, sttypes.StreamID) {
+				return &syncResponse{
+					pb: testErrorResponse,
+				}, makeTestStreamID(0)
+			},
+			expErr:  errors.New("test error"),
+			expStID: makeTestStreamID(0),
+		},
+	}
+
+	for i, test := range tests {
+		protocol := makeTestProtocol(test.getResponse)
+		blocks, receipts, stid, err := protocol.GetBlocksWithReceiptsByNumber(context.Background(), []uint64{0})
+
+		if assErr := assertError(err, test.expErr); assErr != nil {
+			t.Errorf("Test %v: %v", i, assErr)
+			continue
+		}
+		if stid != test.expStID {
+			t.Errorf("Test %v: unexpected st id: %v / %v", i, stid, test.expStID)
+		}
+		if test.expErr == nil {
+			if len(blocks) != 1 || len(receipts) != 1 {
+				t.Errorf("Test %v: size not 1", i)
+			}
+			if len(receipts[0]) != 1 {
+				t.Errorf("Test %v: block receipts size not 1", i)
+			}
+			if blocks[0].Hash() != testBlockWithReceipts.Hash() {
+				t.Errorf("Test %v: unexpected block %x", i, blocks[0].Hash())
+			}
+		}
+	}
+}
+
 func TestProtocol_GetNodeData(t *testing.T) {
 	tests := []struct {
 		getResponse getResponseFn
@@ -428,12 +529,322 @@ func TestProtocol_GetNodeData(t *testing.T) {
 	}
 }
 
+func TestProtocol_GetByteCodes(t *testing.T) {
+	hs := []common.Hash{crypto.Keccak256Hash(testCodes[0]), crypto.Keccak256Hash(testCodes[1])}
+	tests := []struct {
+		getResponse getResponseFn
+		expErr      error
+		expStID     sttypes.StreamID
+	}{
+		{
+			getResponse: func(request sttypes.Request) (sttypes.Response, sttypes.StreamID) {
+				return &syncResponse{
+					pb: testByteCodesResponse,
+				}, makeTestStreamID(0)
+			},
+			expErr:  nil,
+			expStID: makeTestStreamID(0),
+		},
+		{
+			getResponse: func(request sttypes.Request) (sttypes.Response, sttypes.StreamID) {
+				return &syncResponse{
+					pb: syncpb.MakeGetByteCodesResponse(0, [][]byte{[]byte("unexpected code")}),
+				}, makeTestStreamID(0)
+			},
+			expErr:  errors.New("[GetByteCodesResponse]: unexpected data delivered"),
+			expStID: makeTestStreamID(0),
+		},
+		{
+			getResponse: func(request sttypes.Request) (sttypes.Response, sttypes.StreamID) {
+				return &syncResponse{
+					pb: testNodeDataResponse,
+				}, makeTestStreamID(0)
+			},
+			expErr:  errors.New("response not GetByteCodes"),
+			expStID: makeTestStreamID(0),
+		},
+		{
+			getResponse: nil,
+			expErr:      errors.New("get response error"),
+			expStID:     "",
+		},
+	}
+
+	for i, test := range tests {
+		protocol := makeTestProtocol(test.getResponse)
+		codes, stid, err := protocol.GetByteCodes(context.Background(), hs, SoftResponseLimit)
+
+		if assErr := assertError(err, test.expErr); assErr != nil {
+			t.Errorf("Test %v: %v", i, assErr)
+			continue
+		}
+		if stid != test.expStID {
+			t.Errorf("Test %v: unexpected st id: %v / %v", i, stid, test.expStID)
+		}
+		if test.expErr == nil {
+			if len(codes) != len(hs) {
+				t.Errorf("Test %v: unexpected size %v / %v", i, len(codes), len(hs))
+			}
+			// the codes not delivered are nil
+			if codes[0] != nil || !bytes.Equal(codes[1], testCodes[1]) {
+				t.Errorf("Test %v: codes not aligned to the hashes", i)
+			}
+		}
+	}
+}
+
+func TestProtocol_GetAccountRange(t *testing.T) {
+	var (
+		tr       = trie.NewEmpty(trie.NewDatabase(rawdb.NewMemoryDatabase()))
+		accounts []*syncpb.AccountData
+	)
+	for i := 0; i != 10; i++ {
+		hash := crypto.Keccak256Hash([]byte{byte(i)})
+		slim := snapshot.SlimAccountRLP(uint64(i), big.NewInt(int64(i)), types.EmptyRootHash, crypto.Keccak256(nil))
+		full, _ := snapshot.FullAccountRLP(slim)
+		tr.Update(hash[:], full)
+		accounts = append(accounts, &syncpb.AccountData{Hash: hash[:], Body: slim})
+	}
+	sort.Slice(accounts, func(i, j int) bool {
+		return bytes.Compare(accounts[i].Hash, accounts[j].Hash) < 0
+	})
+	root := tr.Hash()
+
+	// the response of the first half of the accounts
+	proofOf := func(keys ...[]byte) [][]byte {
+		proof := memorydb.New()
+		for _, key := range keys {
+			tr.Prove(key, 0, proof)
+		}
+		return proofNodes(proof)
+	}
+	half := accounts[:5]
+	tests := []struct {
+		resp     *syncpb.Response
+		expErr   error
+		expSize  int
+		expMore  bool
+		expStale bool
+	}{
+		{
+			resp:    syncpb.MakeGetAccountRangeResponse(0, half, proofOf(common.Hash{}.Bytes(), half[4].Hash)),
+			expSize: 5,
+			expMore: true,
+		},
+		{
+			// the whole state needs no proof
+			resp:    syncpb.MakeGetAccountRangeResponse(0, accounts, nil),
+			expSize: 10,
+			expMore: false,
+		},
+		{
+			// accounts missing in the range
+			resp:   syncpb.MakeGetAccountRangeResponse(0, []*syncpb.AccountData{half[0], half[2]}, proofOf(common.Hash{}.Bytes(), half[2].Hash)),
+			expErr: errors.New("[GetAccountRangeResponse]: invalid range"),
+		},
+		{
+			resp:   syncpb.MakeGetAccountRangeResponse(0, nil, nil),
+			expErr: ErrStateUnavailable,
+		},
+	}
+
+	for i, test := range tests {
+		resp := test.resp
+		protocol := makeTestProtocol(func(request sttypes.Request) (sttypes.Response, sttypes.StreamID) {
+			return &syncResponse{pb: resp}, makeTestStreamID(0)
+		})
+		hashes, slims, more, _, err := protocol.GetAccountRange(context.Background(), root, common.Hash{}, maxHash, SoftResponseLimit)
+
+		if test.expErr != nil {
+			if err == nil {
+				t.Errorf("Test %v: expect error %v", i, test.expErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Test %v: unexpected error %v", i, err)
+			continue
+		}
+		if len(hashes) != test.expSize || len(slims) != test.expSize {
+			t.Errorf("Test %v: unexpected size %v / %v", i, len(hashes), test.expSize)
+		}
+		if more != test.expMore {
+			t.Errorf("Test %v: unexpected more %v / %v", i, more, test.expMore)
+		}
+	}
+}
+
+func TestProtocol_GetAccountProof(t *testing.T) {
+	var (
+		tr       = trie.NewEmpty(trie.NewDatabase(rawdb.NewMemoryDatabase()))
+		accounts []*syncpb.AccountData
+	)
+	for i := 0; i != 10; i++ {
+		hash := crypto.Keccak256Hash([]byte{byte(i)})
+		slim := snapshot.SlimAccountRLP(uint64(i), big.NewInt(int64(i)), types.EmptyRootHash, crypto.Keccak256(nil))
+		full, _ := snapshot.FullAccountRLP(slim)
+		tr.Update(hash[:], full)
+		accounts = append(accounts, &syncpb.AccountData{Hash: hash[:], Body: slim})
+	}
+	sort.Slice(accounts, func(i, j int) bool {
+		return bytes.Compare(accounts[i].Hash, accounts[j].Hash) < 0
+	})
+	root := tr.Hash()
+
+	proofOf := func(keys ...[]byte) [][]byte {
+		proof := memorydb.New()
+		for _, key := range keys {
+			tr.Prove(key, 0, proof)
+		}
+		return proofNodes(proof)
+	}
+	// a key between the third and the fourth account
+	existing := common.BytesToHash(accounts[3].Hash)
+	absent := common.BigToHash(new(big.Int).Add(existing.Big(), common.Big1))
+	tests := []struct {
+		account common.Hash
+		resp    *syncpb.Response
+		expErr  error
+		expBody []byte
+	}{
+		{
+			account: existing,
+			resp:    syncpb.MakeGetAccountRangeResponse(0, accounts[3:4], proofOf(existing[:])),
+			expBody: accounts[3].Body,
+		},
+		{
+			// the next account proves the absence
+			account: absent,
+			resp:    syncpb.MakeGetAccountRangeResponse(0, accounts[4:5], proofOf(absent[:], accounts[4].Hash)),
+			expBody: nil,
+		},
+		{
+			// the account skipped
+			account: existing,
+			resp:    syncpb.MakeGetAccountRangeResponse(0, accounts[4:5], proofOf(existing[:], accounts[4].Hash)),
+			expErr:  errors.New("[GetAccountRangeResponse]: invalid range"),
+		},
+	}
+
+	for i, test := range tests {
+		resp := test.resp
+		protocol := makeTestProtocol(func(request sttypes.Request) (sttypes.Response, sttypes.StreamID) {
+			return &syncResponse{pb: resp}, makeTestStreamID(0)
+		})
+		body, proof, _, err := protocol.GetAccountProof(context.Background(), root, test.account)
+
+		if test.expErr != nil {
+			if err == nil {
+				t.Errorf("Test %v: expect error %v", i, test.expErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Test %v: unexpected error %v", i, err)
+			continue
+		}
+		if !bytes.Equal(body, test.expBody) {
+			t.Errorf("Test %v: unexpected account %x / %x", i, body, test.expBody)
+		}
+		// the proof is the path from the root
+		if len(proof) == 0 || crypto.Keccak256Hash(proof[0]) != root {
+			t.Errorf("Test %v: proof not ordered from the root", i)
+		}
+	}
+}
+
+func TestProtocol_GetStorageProof(t *testing.T) {
+	var (
+		tr    = trie.NewEmpty(trie.NewDatabase(rawdb.NewMemoryDatabase()))
+		slots []*syncpb.StorageData
+	)
+	for i := 0; i != 10; i++ {
+		hash := crypto.Keccak256Hash([]byte{byte(i)})
+		value, _ := rlp.EncodeToBytes(uint64(i + 1))
+		tr.Update(hash[:], value)
+		slots = append(slots, &syncpb.StorageData{Hash: hash[:], Body: value})
+	}
+	sort.Slice(slots, func(i, j int) bool {
+		return bytes.Compare(slots[i].Hash, slots[j].Hash) < 0
+	})
+	storageRoot := tr.Hash()
+
+	proofOf := func(keys ...[]byte) [][]byte {
+		proof := memorydb.New()
+		for _, key := range keys {
+			tr.Prove(key, 0, proof)
+		}
+		return proofNodes(proof)
+	}
+	var (
+		existing = common.BytesToHash(slots[3].Hash)
+		// a key after the last slot
+		absent = common.BigToHash(new(big.Int).Add(common.BytesToHash(slots[9].Hash).Big(), common.Big1))
+	)
+	tests := []struct {
+		slot     common.Hash
+		resp     *syncpb.Response
+		expErr   error
+		expValue []byte
+	}{
+		{
+			slot:     existing,
+			resp:     syncpb.MakeGetStorageRangesResponse(0, []*syncpb.StoragesData{{Data: slots[3:4]}}, proofOf(existing[:])),
+			expValue: slots[3].Body,
+		},
+		{
+			// no slot after the requested one, the proof alone proves the absence
+			slot:     absent,
+			resp:     syncpb.MakeGetStorageRangesResponse(0, nil, proofOf(absent[:])),
+			expValue: nil,
+		},
+		{
+			// the existing slot hidden
+			slot:   existing,
+			resp:   syncpb.MakeGetStorageRangesResponse(0, nil, proofOf(existing[:])),
+			expErr: errors.New("[GetStorageRangesResponse]: more entries available"),
+		},
+	}
+
+	for i, test := range tests {
+		resp := test.resp
+		protocol := makeTestProtocol(func(request sttypes.Request) (sttypes.Response, sttypes.StreamID) {
+			return &syncResponse{pb: resp}, makeTestStreamID(0)
+		})
+		value, proof, _, err := protocol.GetStorageProof(context.Background(), common.Hash{}, common.Hash{}, storageRoot, test.slot)
+
+		if test.expErr != nil {
+			if err == nil {
+				t.Errorf("Test %v: expect error %v", i, test.expErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Test %v: unexpected error %v", i, err)
+			continue
+		}
+		if !bytes.Equal(value, test.expValue) {
+			t.Errorf("Test %v: unexpected value %x / %x", i, value, test.expValue)
+		}
+		if len(proof) == 0 || crypto.Keccak256Hash(proof[0]) != storageRoot {
+			t.Errorf("Test %v: proof not ordered from the root", i)
+		}
+	}
+}
+
 type getResponseFn func(request sttypes.Request) (sttypes.Response, sttypes.StreamID)
 
 type testHostRequestManager struct {
 	getResponse getResponseFn
 }
 
+func makeTestBlockWithReceipts(bn uint64, receipts types.Receipts) *types.Block {
+	header := testHeader.Copy()
+	header.SetNumber(new(big.Int).SetUint64(bn))
+	header.SetReceiptHash(types.DeriveSha(receipts))
+	return types.NewBlockWithHeader(&block.Header{Header: header})
+}
+
 func makeTestProtocol(f getResponseFn) *Protocol {
 	rm := &testHostRequestManager{f}
 
@@ -25,6 +25,44 @@ const (
 	// This number has an effect on maxMsgBytes as 20MB defined in github.com/harmony-one/harmony/p2p/stream/types.
 	GetReceiptsCap = 128
 
+	// GetBlocksWithReceiptsByNumAmountCap is the cap of request of a single GetBlocksWithReceiptsByNum
+	// request. The response is capped in size by BlocksResponseSoftLimit as well.
+	GetBlocksWithReceiptsByNumAmountCap = 128
+
+	// GetStorageRangesCap is the cap of the accounts of a single GetStorageRanges request
+	GetStorageRangesCap = 128
+
+	// GetByteCodesCap is the cap of request of single GetByteCodes request
+	GetByteCodesCap = 1024
+
+	// GetTrieNodesCap is the cap of the paths of a single GetTrieNodes request
+	GetTrieNodesCap = 1024
+
+	// SoftResponseLimit is the target maximum size of the state range, byte code and
+	// trie node responses. The requested size is capped at this limit.
+	SoftResponseLimit = 2 * 1024 * 1024
+
+	// BlocksResponseSoftLimit is the target maximum size of the GetBlocksWithReceiptsByNum responses
+	// before compression. The blocks after the one crossing the limit are left out of the response.
+	// Together with the 2MB size assumption of a block, it keeps the messages under the 20MB maxMsgBytes
+	// defined in github.com/harmony-one/harmony/p2p/stream/types.
+	BlocksResponseSoftLimit = 8 * 1024 * 1024
+
+	// minCompressMsgBytes is the minimum size of the messages compressed over the streams
+	// supporting compression. The smaller messages are sent as is.
+	minCompressMsgBytes = 1024
+
+	// maxDecompressedMsgBytes is the maximum size of a decompressed message
+	maxDecompressedMsgBytes = 20 * 1024 * 1024
+
+	// maxTrieNodeLookups is the maximum number of trie nodes looked up for a single
+	// GetTrieNodes request
+	maxTrieNodeLookups = 1024
+
+	// maxTrieNodeTimeSpent is the maximum time spent on looking up the trie nodes
+	// of a single GetTrieNodes request, to reply before the requester times out
+	maxTrieNodeTimeSpent = 5 * time.Second
+
 	// MaxStreamFailures is the maximum allowed failures before stream gets removed
 	MaxStreamFailures = 5
 
@@ -68,6 +68,77 @@ func MakeGetReceiptsRequest(hashes []common.Hash) *Request {
 	}
 }
 
+// MakeGetAccountRangeRequest makes the GetAccountRange request
+func MakeGetAccountRangeRequest(root, origin, limit common.Hash, bytes uint64) *Request {
+	return &Request{
+		Request: &Request_GetAccountRangeRequest{
+			GetAccountRangeRequest: &GetAccountRangeRequest{
+				Root:   root[:],
+				Origin: origin[:],
+				Limit:  limit[:],
+				Bytes:  bytes,
+			},
+		},
+	}
+}
+
+// MakeGetStorageRangesRequest makes the GetStorageRanges request
+func MakeGetStorageRangesRequest(root common.Hash, accounts []common.Hash, origin, limit []byte, bytes uint64) *Request {
+	return &Request{
+		Request: &Request_GetStorageRangesRequest{
+			GetStorageRangesRequest: &GetStorageRangesRequest{
+				Root:     root[:],
+				Accounts: hashesToBytes(accounts),
+				Origin:   origin,
+				Limit:    limit,
+				Bytes:    bytes,
+			},
+		},
+	}
+}
+
+// MakeGetByteCodesRequest makes the GetByteCodes request
+func MakeGetByteCodesRequest(hashes []common.Hash, bytes uint64) *Request {
+	return &Request{
+		Request: &Request_GetByteCodesRequest{
+			GetByteCodesRequest: &GetByteCodesRequest{
+				Hashes: hashesToBytes(hashes),
+				Bytes:  bytes,
+			},
+		},
+	}
+}
+
+// MakeGetTrieNodesRequest makes the GetTrieNodes request
+func MakeGetTrieNodesRequest(root common.Hash, paths [][][]byte, bytes uint64) *Request {
+	pathsets := make([]*TrieNodePathSet, 0, len(paths))
+	for _, p := range paths {
+		pathsets = append(pathsets, &TrieNodePathSet{
+			Pathset: p,
+		})
+	}
+	return &Request{
+		Request: &Request_GetTrieNodesRequest{
+			GetTrieNodesRequest: &GetTrieNodesRequest{
+				Root:  root[:],
+				Paths: pathsets,
+				Bytes: bytes,
+			},
+		},
+	}
+}
+
+// MakeGetBlocksWithReceiptsByNumRequest makes the GetBlocksWithReceiptsByNum request
+func MakeGetBlocksWithReceiptsByNumRequest(bns []uint64) *Request {
+	return &Request{
+		Request: &Request_GetBlocksWithReceiptsByNumRequest{
+			GetBlocksWithReceiptsByNumRequest: &GetBlocksWithReceiptsByNumRequest{
+				Nums: bns,
+			},
+		},
+	}
+}
+
 // MakeErrorResponse makes the error response
 func MakeErrorResponseMessage(rid uint64, err error) *Message {
 	resp := MakeErrorResponse(rid, err)
@@ -196,6 +267,100 @@ func MakeGetReceiptsResponse(rid uint64, receipts map[uint64]*Receipts) *Respons
 	}
 }
 
+// MakeGetAccountRangeResponseMessage makes the GetAccountRangeResponse of Message type
+func MakeGetAccountRangeResponseMessage(rid uint64, accounts []*AccountData, proof [][]byte) *Message {
+	resp := MakeGetAccountRangeResponse(rid, accounts, proof)
+	return makeMessageFromResponse(resp)
+}
+
+// MakeGetAccountRangeResponse make the GetAccountRangeResponse of Response type
+func MakeGetAccountRangeResponse(rid uint64, accounts []*AccountData, proof [][]byte) *Response {
+	return &Response{
+		ReqId: rid,
+		Response: &Response_GetAccountRangeResponse{
+			GetAccountRangeResponse: &GetAccountRangeResponse{
+				Accounts: accounts,
+				Proof:    proof,
+			},
+		},
+	}
+}
+
+// MakeGetStorageRangesResponseMessage makes the GetStorageRangesResponse of Message type
+func MakeGetStorageRangesResponseMessage(rid uint64, slots []*StoragesData, proof [][]byte) *Message {
+	resp := MakeGetStorageRangesResponse(rid, slots, proof)
+	return makeMessageFromResponse(resp)
+}
+
+// MakeGetStorageRangesResponse make the GetStorageRangesResponse of Response type
+func MakeGetStorageRangesResponse(rid uint64, slots []*StoragesData, proof [][]byte) *Response {
+	return &Response{
+		ReqId: rid,
+		Response: &Response_GetStorageRangesResponse{
+			GetStorageRangesResponse: &GetStorageRangesResponse{
+				Slots: slots,
+				Proof: proof,
+			},
+		},
+	}
+}
+
+// MakeGetByteCodesResponseMessage makes the GetByteCodesResponse of Message type
+func MakeGetByteCodesResponseMessage(rid uint64, codes [][]byte) *Message {
+	resp := MakeGetByteCodesResponse(rid, codes)
+	return makeMessageFromResponse(resp)
+}
+
+// MakeGetByteCodesResponse make the GetByteCodesResponse of Response type
+func MakeGetByteCodesResponse(rid uint64, codes [][]byte) *Response {
+	return &Response{
+		ReqId: rid,
+		Response: &Response_GetByteCodesResponse{
+			GetByteCodesResponse: &GetByteCodesResponse{
+				Codes: codes,
+			},
+		},
+	}
+}
+
+// MakeGetTrieNodesResponseMessage makes the GetTrieNodesResponse of Message type
+func MakeGetTrieNodesResponseMessage(rid uint64, nodes [][]byte) *Message {
+	resp := MakeGetTrieNodesResponse(rid, nodes)
+	return makeMessageFromResponse(resp)
+}
+
+// MakeGetTrieNodesResponse make the GetTrieNodesResponse of Response type
+func MakeGetTrieNodesResponse(rid uint64, nodes [][]byte) *Response {
+	return &Response{
+		ReqId: rid,
+		Response: &Response_GetTrieNodesResponse{
+			GetTrieNodesResponse: &GetTrieNodesResponse{
+				Nodes: nodes,
+			},
+		},
+	}
+}
+
+// MakeGetBlocksWithReceiptsByNumResponseMessage makes the GetBlocksWithReceiptsByNumResponse of Message type
+func MakeGetBlocksWithReceiptsByNumResponseMessage(rid uint64, blocksBytes, sigs [][]byte, receipts []*Receipts) *Message {
+	resp := MakeGetBlocksWithReceiptsByNumResponse(rid, blocksBytes, sigs, receipts)
+	return makeMessageFromResponse(resp)
+}
+
+// MakeGetBlocksWithReceiptsByNumResponse makes the GetBlocksWithReceiptsByNumResponse of Response type
+func MakeGetBlocksWithReceiptsByNumResponse(rid uint64, blocksBytes, sigs [][]byte, receipts []*Receipts) *Response {
+	return &Response{
+		ReqId: rid,
+		Response: &Response_GetBlocksWithReceiptsByNumResponse{
+			GetBlocksWithReceiptsByNumResponse: &GetBlocksWithReceiptsByNumResponse{
+				BlocksBytes: blocksBytes,
+				CommitSig:   sigs,
+				Receipts:    receipts,
+			},
+		},
+	}
+}
+
 // MakeMessageFromRequest makes a message from the request
 func MakeMessageFromRequest(req *Request) *Message {
 	return &Message{
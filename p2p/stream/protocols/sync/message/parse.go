@@ -111,3 +111,83 @@ func (msg *Message) GetNodeDataResponse() (*GetNodeDataResponse, error) {
 	}
 	return gnResp, nil
 }
+
+// GetAccountRangeResponse parse the message to GetAccountRangeResponse
+func (msg *Message) GetAccountRangeResponse() (*GetAccountRangeResponse, error) {
+	resp := msg.GetResp()
+	if resp == nil {
+		return nil, errors.New("not response message")
+	}
+	if errResp := resp.GetErrorResponse(); errResp != nil {
+		return nil, &ResponseError{errResp.Error}
+	}
+	sResp := resp.GetGetAccountRangeResponse()
+	if sResp == nil {
+		return nil, errors.New("not GetAccountRangeResponse")
+	}
+	return sResp, nil
+}
+
+// GetStorageRangesResponse parse the message to GetStorageRangesResponse
+func (msg *Message) GetStorageRangesResponse() (*GetStorageRangesResponse, error) {
+	resp := msg.GetResp()
+	if resp == nil {
+		return nil, errors.New("not response message")
+	}
+	if errResp := resp.GetErrorResponse(); errResp != nil {
+		return nil, &ResponseError{errResp.Error}
+	}
+	sResp := resp.GetGetStorageRangesResponse()
+	if sResp == nil {
+		return nil, errors.New("not GetStorageRangesResponse")
+	}
+	return sResp, nil
+}
+
+// GetByteCodesResponse parse the message to GetByteCodesResponse
+func (msg *Message) GetByteCodesResponse() (*GetByteCodesResponse, error) {
+	resp := msg.GetResp()
+	if resp == nil {
+		return nil, errors.New("not response message")
+	}
+	if errResp := resp.GetErrorResponse(); errResp != nil {
+		return nil, &ResponseError{errResp.Error}
+	}
+	sResp := resp.GetGetByteCodesResponse()
+	if sResp == nil {
+		return nil, errors.New("not GetByteCodesResponse")
+	}
+	return sResp, nil
+}
+
+// GetTrieNodesResponse parse the message to GetTrieNodesResponse
+func (msg *Message) GetTrieNodesResponse() (*GetTrieNodesResponse, error) {
+	resp := msg.GetResp()
+	if resp == nil {
+		return nil, errors.New("not response message")
+	}
+	if errResp := resp.GetErrorResponse(); errResp != nil {
+		return nil, &ResponseError{errResp.Error}
+	}
+	sResp := resp.GetGetTrieNodesResponse()
+	if sResp == nil {
+		return nil, errors.New("not GetTrieNodesResponse")
+	}
+	return sResp, nil
+}
+
+// GetBlocksWithReceiptsByNumResponse parse the message to GetBlocksWithReceiptsByNumResponse
+func (msg *Message) GetBlocksWithReceiptsByNumResponse() (*GetBlocksWithReceiptsByNumResponse, error) {
+	resp := msg.GetResp()
+	if resp == nil {
+		return nil, errors.New("not response message")
+	}
+	if errResp := resp.GetErrorResponse(); errResp != nil {
+		return nil, &ResponseError{errResp.Error}
+	}
+	gbResp := resp.GetGetBlocksWithReceiptsByNumResponse()
+	if gbResp == nil {
+		return nil, errors.New("not GetBlocksWithReceiptsByNumResponse")
+	}
+	return gbResp, nil
+}
@@ -30,9 +30,13 @@ const (
 
 var (
 	version100, _ = version.NewVersion("1.0.0")
+	// version110 serves the state ranges, byte codes and trie nodes for state sync
+	version110, _ = version.NewVersion("1.1.0")
+	// version120 compresses the messages and serves the blocks along with their receipts
+	version120, _ = version.NewVersion("1.2.0")
 
 	// MyVersion is the version of sync protocol of the local node
-	MyVersion = version100
+	MyVersion = version120
 
 	// MinVersion is the minimum version for matching function
 	MinVersion = version100
@@ -65,6 +69,7 @@ type (
 		ShardID              nodeconfig.ShardID
 		Network              nodeconfig.NetworkType
 		BeaconNode           bool
+		ClientOnly           bool // do not advertise, for the nodes only requesting data (light nodes)
 		MaxAdvertiseWaitTime int
 		// stream manager config
 		SmSoftLowCap int
@@ -92,6 +97,7 @@ func NewProtocol(config Config) *Protocol {
 		HardLoCap: config.SmHardLowCap,
 		HiCap:     config.SmHiCap,
 		DiscBatch: config.DiscBatch,
+		Versions:  sp.supportedVersions(),
 	}
 	sp.sm = streammanager.NewStreamManager(sp.ProtoID(), config.Host, config.Discovery,
 		sp.HandleStream, smConfig)
@@ -110,7 +116,7 @@ func (p *Protocol) Start() {
 	p.rm.Start()
 	p.rl.Start()
 	// If it's not EpochChain, advertise
-	if p.beaconNode || p.chain.ShardID() != shard.BeaconChainShardID {
+	if !p.config.ClientOnly && (p.beaconNode || p.chain.ShardID() != shard.BeaconChainShardID) {
 		go p.advertiseLoop()
 	}
 }
@@ -167,6 +173,10 @@ func (p *Protocol) Match(targetID protocol.ID) bool {
 	if target.Version.LessThan(MinVersion) {
 		return false
 	}
+	// the stream runs at the version of the dialing node, which shall be known
+	if target.Version.GreaterThan(MyVersion) {
+		return false
+	}
 	return true
 }
 
@@ -239,7 +249,7 @@ func (p *Protocol) supportedProtoIDs() []sttypes.ProtoID {
 }
 
 func (p *Protocol) supportedVersions() []*version.Version {
-	return []*version.Version{version100}
+	return []*version.Version{version100, version110, version120}
 }
 
 func (p *Protocol) protoIDByVersion(v *version.Version) sttypes.ProtoID {
@@ -6,9 +6,15 @@ import (
 	"testing"
 	"time"
 
+	"github.com/harmony-one/harmony/p2p/stream/common/streammanager"
+	sttypes "github.com/harmony-one/harmony/p2p/stream/types"
+	"github.com/hashicorp/go-version"
 	"github.com/libp2p/go-libp2p/core/discovery"
+	libp2p_host "github.com/libp2p/go-libp2p/core/host"
+	libp2p_network "github.com/libp2p/go-libp2p/core/network"
 	libp2p_peer "github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
 )
 
 func TestProtocol_Match(t *testing.T) {
@@ -18,6 +24,8 @@ func TestProtocol_Match(t *testing.T) {
 	}{
 		{"harmony/sync/unitest/0/1.0.1/1", true},
 		{"harmony/sync/unitest/0/1.0.1/0", true},
+		{"harmony/sync/unitest/0/1.2.0/1", true},
+		{"harmony/sync/unitest/0/2.0.0/1", false},
 		{"h123456", false},
 		{"harmony/sync/unitest/0/0.9.9/1", false},
 		{"harmony/epoch/unitest/0/1.0.1/1", false},
@@ -42,6 +50,73 @@ func TestProtocol_Match(t *testing.T) {
 	}
 }
 
+func TestProtocol_DialVersion(t *testing.T) {
+	mn := mocknet.New()
+	defer mn.Close()
+
+	hosts := make([]libp2p_host.Host, 0, 3)
+	for i := 0; i != 3; i++ {
+		h, err := mn.GenPeer()
+		if err != nil {
+			t.Fatal(err)
+		}
+		hosts = append(hosts, h)
+	}
+	dialer, oldNode, newNode := hosts[0], hosts[1], hosts[2]
+	config := Config{Network: "unitest", ShardID: 1}
+
+	// a node of version 1.0.0 registers the protocol ID of its version, and accepts
+	// the higher versions as well
+	old := &Protocol{config: config}
+	oldNode.SetStreamHandlerMatch(protocol.ID(old.protoIDByVersion(version100)), func(pid protocol.ID) bool {
+		spec, err := sttypes.ProtoIDToProtoSpec(sttypes.ProtoID(pid))
+		return err == nil && !spec.Version.LessThan(version100)
+	}, func(libp2p_network.Stream) {})
+	current := &Protocol{config: config}
+	newNode.SetStreamHandlerMatch(protocol.ID(current.ProtoID()), current.Match, func(libp2p_network.Stream) {})
+
+	// the peers identify the protocols of each other once connected
+	if err := mn.LinkAll(); err != nil {
+		t.Fatal(err)
+	}
+	if err := mn.ConnectAllButSelf(); err != nil {
+		t.Fatal(err)
+	}
+
+	p := &Protocol{config: config}
+	p.config.Host = dialer
+	stC := make(chan *syncStream, 2)
+	pf := &testPeerFinder{peers: []libp2p_peer.ID{oldNode.ID(), newNode.ID()}}
+	sm := streammanager.NewStreamManager(p.ProtoID(), dialer, pf, func(raw libp2p_network.Stream) {
+		stC <- p.wrapStream(raw)
+	}, streammanager.Config{HardLoCap: 2, SoftLoCap: 2, HiCap: 2, DiscBatch: 2, Versions: p.supportedVersions()})
+	sm.Start()
+	defer sm.Close()
+
+	exp := map[sttypes.StreamID]*version.Version{
+		sttypes.StreamID(oldNode.ID().String()): version100,
+		sttypes.StreamID(newNode.ID().String()): version120,
+	}
+	for range exp {
+		select {
+		case st := <-stC:
+			spec, err := st.BaseStream.ProtoSpec()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if v := exp[st.ID()]; !spec.Version.Equal(v) {
+				t.Errorf("unexpected version with %v: %v / %v", st.ID(), spec.Version, v)
+			}
+			// only the streams of version 1.2.0 are compressed
+			if st.compress != spec.Version.Equal(version120) {
+				t.Errorf("unexpected compression with %v: %v", st.ID(), st.compress)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatal("streams not set up")
+		}
+	}
+}
+
 func TestProtocol_advertiseLoop(t *testing.T) {
 	disc := newTestDiscovery(100 * time.Millisecond)
 	p := &Protocol{
@@ -109,3 +184,16 @@ func (disc *testDiscovery) FindPeers(ctx context.Context, ns string, peerLimit i
 func (disc *testDiscovery) GetRawDiscovery() discovery.Discovery {
 	return nil
 }
+
+type testPeerFinder struct {
+	peers []libp2p_peer.ID
+}
+
+func (pf *testPeerFinder) FindPeers(ctx context.Context, ns string, peerLimit int) (<-chan libp2p_peer.AddrInfo, error) {
+	peerC := make(chan libp2p_peer.AddrInfo, len(pf.peers))
+	for _, peer := range pf.peers {
+		peerC <- libp2p_peer.AddrInfo{ID: peer}
+	}
+	close(peerC)
+	return peerC, nil
+}
@@ -10,8 +10,10 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/rlp"
 	protobuf "github.com/golang/protobuf/proto"
+	"github.com/golang/snappy"
 	syncpb "github.com/harmony-one/harmony/p2p/stream/protocols/sync/message"
 	sttypes "github.com/harmony-one/harmony/p2p/stream/types"
+	"github.com/hashicorp/go-version"
 	libp2p_network "github.com/libp2p/go-libp2p/core/network"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
@@ -24,6 +26,8 @@ type syncStream struct {
 
 	protocol *Protocol
 	chain    chainHelper
+	version  *version.Version // version negotiated with the remote peer
+	compress bool             // whether the messages are compressed, negotiated by the stream version
 
 	// pipeline channels
 	reqC  chan *syncpb.Request
@@ -44,16 +48,67 @@ func (p *Protocol) wrapStream(raw libp2p_network.Stream) *syncStream {
 		Str("Remote Protocol", string(bs.ProtoID())).
 		Logger()
 
-	return &syncStream{
+	st := &syncStream{
 		BaseStream: bs,
 		protocol:   p,
 		chain:      newChainHelper(p.chain, p.schedule),
+		version:    p.negotiatedVersion(raw, bs),
 		reqC:       make(chan *syncpb.Request, 100),
 		respC:      make(chan *syncpb.Response, 100),
 		closeC:     make(chan struct{}),
 		closeStat:  0,
 		logger:     logger,
 	}
+	st.compress = isCompressedStream(st)
+	return st
+}
+
+// negotiatedVersion returns the version the remote peer runs the stream at. The
+// protocol ID of a dialed stream is the one proposed by the local node, which the
+// nodes of older versions accept whatever its version. So the version of a dialed
+// stream is capped by the highest sync version the remote peer advertises.
+func (p *Protocol) negotiatedVersion(raw libp2p_network.Stream, bs *sttypes.BaseStream) *version.Version {
+	spec, err := bs.ProtoSpec()
+	if err != nil {
+		return nil
+	}
+	if raw.Stat().Direction != libp2p_network.DirOutbound || p.config.Host == nil {
+		return spec.Version
+	}
+	pids, err := p.config.Host.Peerstore().GetProtocols(raw.Conn().RemotePeer())
+	if err != nil {
+		return spec.Version
+	}
+	var advertised *version.Version
+	for _, pid := range pids {
+		target, err := sttypes.ProtoIDToProtoSpec(sttypes.ProtoID(pid))
+		if err != nil || target.Service != spec.Service ||
+			target.NetworkType != spec.NetworkType || target.ShardID != spec.ShardID {
+			continue
+		}
+		if target.Version.GreaterThan(spec.Version) {
+			continue
+		}
+		if advertised == nil || target.Version.GreaterThan(advertised) {
+			advertised = target.Version
+		}
+	}
+	// the peer advertising no sync protocol is not known to run an older version
+	if advertised == nil {
+		return spec.Version
+	}
+	return advertised
+}
+
+// ProtoSpec returns the protocol specifier of the stream at the version negotiated
+// with the remote peer, which gates the requests sent over the stream.
+func (st *syncStream) ProtoSpec() (sttypes.ProtoSpec, error) {
+	spec, err := st.BaseStream.ProtoSpec()
+	if err != nil || st.version == nil {
+		return spec, err
+	}
+	spec.Version = st.version
+	return spec, nil
 }
 
 func (st *syncStream) run() {
@@ -188,6 +243,21 @@ func (st *syncStream) handleReq(req *syncpb.Request) error {
 	if rReq := req.GetGetReceiptsRequest(); rReq != nil {
 		return st.handleGetReceiptsRequest(req.ReqId, rReq)
 	}
+	if arReq := req.GetGetAccountRangeRequest(); arReq != nil {
+		return st.handleGetAccountRangeRequest(req.ReqId, arReq)
+	}
+	if srReq := req.GetGetStorageRangesRequest(); srReq != nil {
+		return st.handleGetStorageRangesRequest(req.ReqId, srReq)
+	}
+	if bcReq := req.GetGetByteCodesRequest(); bcReq != nil {
+		return st.handleGetByteCodesRequest(req.ReqId, bcReq)
+	}
+	if tnReq := req.GetGetTrieNodesRequest(); tnReq != nil {
+		return st.handleGetTrieNodesRequest(req.ReqId, tnReq)
+	}
+	if brReq := req.GetGetBlocksWithReceiptsByNumRequest(); brReq != nil {
+		return st.handleGetBlocksWithReceiptsByNumRequest(req.ReqId, brReq)
+	}
 	// unsupported request type
 	return st.handleUnknownRequest(req.ReqId)
 }
@@ -308,6 +378,106 @@ func (st *syncStream) handleGetReceiptsRequest(rid uint64, req *syncpb.GetReceip
 	return errors.Wrap(err, "[GetReceipts]")
 }
 
+func (st *syncStream) handleGetAccountRangeRequest(rid uint64, req *syncpb.GetAccountRangeRequest) error {
+	serverRequestCounterVec.With(prometheus.Labels{
+		"topic":        string(st.ProtoID()),
+		"request_type": "getAccountRange",
+	}).Inc()
+
+	resp, err := st.computeGetAccountRange(rid, req)
+	if resp == nil && err != nil {
+		resp = syncpb.MakeErrorResponseMessage(rid, err)
+	}
+	if writeErr := st.writeMsg(resp); writeErr != nil {
+		if err == nil {
+			err = writeErr
+		} else {
+			err = fmt.Errorf("%v; [writeMsg] %v", err.Error(), writeErr)
+		}
+	}
+	return errors.Wrap(err, "[GetAccountRange]")
+}
+
+func (st *syncStream) handleGetStorageRangesRequest(rid uint64, req *syncpb.GetStorageRangesRequest) error {
+	serverRequestCounterVec.With(prometheus.Labels{
+		"topic":        string(st.ProtoID()),
+		"request_type": "getStorageRanges",
+	}).Inc()
+
+	resp, err := st.computeGetStorageRanges(rid, req)
+	if resp == nil && err != nil {
+		resp = syncpb.MakeErrorResponseMessage(rid, err)
+	}
+	if writeErr := st.writeMsg(resp); writeErr != nil {
+		if err == nil {
+			err = writeErr
+		} else {
+			err = fmt.Errorf("%v; [writeMsg] %v", err.Error(), writeErr)
+		}
+	}
+	return errors.Wrap(err, "[GetStorageRanges]")
+}
+
+func (st *syncStream) handleGetByteCodesRequest(rid uint64, req *syncpb.GetByteCodesRequest) error {
+	serverRequestCounterVec.With(prometheus.Labels{
+		"topic":        string(st.ProtoID()),
+		"request_type": "getByteCodes",
+	}).Inc()
+
+	resp, err := st.computeGetByteCodes(rid, req)
+	if resp == nil && err != nil {
+		resp = syncpb.MakeErrorResponseMessage(rid, err)
+	}
+	if writeErr := st.writeMsg(resp); writeErr != nil {
+		if err == nil {
+			err = writeErr
+		} else {
+			err = fmt.Errorf("%v; [writeMsg] %v", err.Error(), writeErr)
+		}
+	}
+	return errors.Wrap(err, "[GetByteCodes]")
+}
+
+func (st *syncStream) handleGetTrieNodesRequest(rid uint64, req *syncpb.GetTrieNodesRequest) error {
+	serverRequestCounterVec.With(prometheus.Labels{
+		"topic":        string(st.ProtoID()),
+		"request_type": "getTrieNodes",
+	}).Inc()
+
+	resp, err := st.computeGetTrieNodes(rid, req)
+	if resp == nil && err != nil {
+		resp = syncpb.MakeErrorResponseMessage(rid, err)
+	}
+	if writeErr := st.writeMsg(resp); writeErr != nil {
+		if err == nil {
+			err = writeErr
+		} else {
+			err = fmt.Errorf("%v; [writeMsg] %v", err.Error(), writeErr)
+		}
+	}
+	return errors.Wrap(err, "[GetTrieNodes]")
+}
+
+func (st *syncStream) handleGetBlocksWithReceiptsByNumRequest(rid uint64, req *syncpb.GetBlocksWithReceiptsByNumRequest) error {
+	serverRequestCounterVec.With(prometheus.Labels{
+		"topic":        string(st.ProtoID()),
+		"request_type": "getBlocksWithReceiptsByNumber",
+	}).Inc()
+
+	resp, err := st.computeGetBlocksWithReceiptsByNum(rid, req.Nums)
+	if resp == nil && err != nil {
+		resp = syncpb.MakeErrorResponseMessage(rid, err)
+	}
+	if writeErr := st.writeMsg(resp); writeErr != nil {
+		if err == nil {
+			err = writeErr
+		} else {
+			err = fmt.Errorf("%v; [writeMsg] %v", err.Error(), writeErr)
+		}
+	}
+	return errors.Wrap(err, "[GetBlocksWithReceiptsByNumber]")
+}
+
 func (st *syncStream) handleUnknownRequest(rid uint64) error {
 	serverRequestCounterVec.With(prometheus.Labels{
 		"topic":        string(st.ProtoID()),
@@ -341,6 +511,77 @@ func (st *syncStream) writeMsg(msg *syncpb.Message) error {
 	return st.WriteBytes(b)
 }
 
+// isCompressedStream returns whether the messages over the stream are compressed,
+// which is the case for the streams running at version 1.2.0 or above. Both sides
+// agree on it, as the version of a dialed stream is capped by the version of the
+// listening node.
+func isCompressedStream(st sttypes.Stream) bool {
+	spec, err := st.ProtoSpec()
+	if err != nil {
+		return false
+	}
+	return spec.Version.GreaterThanOrEqual(version120)
+}
+
+// WriteBytes writes the message to the stream, compressed if the stream supports it.
+// The compressed messages are prefixed with a byte of the codec.
+func (st *syncStream) WriteBytes(b []byte) error {
+	if st.compress {
+		b = encodeMsgBytes(b)
+	}
+	return st.BaseStream.WriteBytes(b)
+}
+
+// ReadBytes reads a message from the stream, decompressing it if the stream supports
+// compression
+func (st *syncStream) ReadBytes() ([]byte, error) {
+	b, err := st.BaseStream.ReadBytes()
+	if err != nil || !st.compress {
+		return b, err
+	}
+	return decodeMsgBytes(b)
+}
+
+const (
+	// msgCodecNone is the codec of the messages sent as is
+	msgCodecNone byte = iota
+	// msgCodecSnappy is the codec of the messages compressed with snappy
+	msgCodecSnappy
+)
+
+// encodeMsgBytes prefixes the message with its codec, compressing the large ones
+func encodeMsgBytes(b []byte) []byte {
+	if len(b) < minCompressMsgBytes {
+		return append([]byte{msgCodecNone}, b...)
+	}
+	enc := make([]byte, 1+snappy.MaxEncodedLen(len(b)))
+	enc[0] = msgCodecSnappy
+	n := len(snappy.Encode(enc[1:], b))
+	return enc[:1+n]
+}
+
+// decodeMsgBytes decodes the message prefixed with its codec
+func decodeMsgBytes(b []byte) ([]byte, error) {
+	if len(b) == 0 {
+		return nil, errors.New("empty message")
+	}
+	switch b[0] {
+	case msgCodecNone:
+		return b[1:], nil
+	case msgCodecSnappy:
+		size, err := snappy.DecodedLen(b[1:])
+		if err != nil {
+			return nil, errors.Wrap(err, "snappy decoded length")
+		}
+		if size > maxDecompressedMsgBytes {
+			return nil, fmt.Errorf("decompressed message too large: %v", size)
+		}
+		return snappy.Decode(nil, b[1:])
+	default:
+		return nil, fmt.Errorf("unknown message codec %v", b[0])
+	}
+}
+
 func (st *syncStream) computeBlockNumberResp(rid uint64) *syncpb.Message {
 	bn := st.chain.getCurrentBlockNumber()
 	return syncpb.MakeGetBlockNumberResponseMessage(rid, bn)
@@ -453,6 +694,111 @@ func (st *syncStream) computeGetReceipts(rid uint64, hs []common.Hash) (*syncpb.
 	return syncpb.MakeGetReceiptsResponseMessage(rid, normalizedReceipts), nil
 }
 
+func (st *syncStream) computeGetAccountRange(rid uint64, req *syncpb.GetAccountRangeRequest) (*syncpb.Message, error) {
+	var (
+		root   = common.BytesToHash(req.Root)
+		origin = common.BytesToHash(req.Origin)
+		limit  = common.BytesToHash(req.Limit)
+	)
+	accounts, proof, err := st.chain.getAccountRange(root, origin, limit, req.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	return syncpb.MakeGetAccountRangeResponseMessage(rid, accounts, proof), nil
+}
+
+func (st *syncStream) computeGetStorageRanges(rid uint64, req *syncpb.GetStorageRangesRequest) (*syncpb.Message, error) {
+	if len(req.Accounts) > GetStorageRangesCap {
+		err := fmt.Errorf("GetStorageRanges amount exceed cap: %v > %v", len(req.Accounts), GetStorageRangesCap)
+		return nil, err
+	}
+	root := common.BytesToHash(req.Root)
+	accounts := bytesToHashes(req.Accounts)
+	slots, proof, err := st.chain.getStorageRanges(root, accounts, req.Origin, req.Limit, req.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	return syncpb.MakeGetStorageRangesResponseMessage(rid, slots, proof), nil
+}
+
+func (st *syncStream) computeGetByteCodes(rid uint64, req *syncpb.GetByteCodesRequest) (*syncpb.Message, error) {
+	if len(req.Hashes) > GetByteCodesCap {
+		err := fmt.Errorf("GetByteCodes amount exceed cap: %v > %v", len(req.Hashes), GetByteCodesCap)
+		return nil, err
+	}
+	codes, err := st.chain.getByteCodes(bytesToHashes(req.Hashes), req.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	return syncpb.MakeGetByteCodesResponseMessage(rid, codes), nil
+}
+
+func (st *syncStream) computeGetTrieNodes(rid uint64, req *syncpb.GetTrieNodesRequest) (*syncpb.Message, error) {
+	var (
+		paths = make([][][]byte, 0, len(req.Paths))
+		count int
+	)
+	for _, pathset := range req.Paths {
+		paths = append(paths, pathset.Pathset)
+		count += len(pathset.Pathset)
+	}
+	if count > GetTrieNodesCap {
+		err := fmt.Errorf("GetTrieNodes amount exceed cap: %v > %v", count, GetTrieNodesCap)
+		return nil, err
+	}
+	nodes, err := st.chain.getTrieNodes(common.BytesToHash(req.Root), paths, req.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	return syncpb.MakeGetTrieNodesResponseMessage(rid, nodes), nil
+}
+
+func (st *syncStream) computeGetBlocksWithReceiptsByNum(rid uint64, bns []uint64) (*syncpb.Message, error) {
+	if len(bns) > GetBlocksWithReceiptsByNumAmountCap {
+		err := fmt.Errorf("GetBlocksWithReceiptsByNum amount exceed cap: %v>%v", len(bns), GetBlocksWithReceiptsByNumAmountCap)
+		return nil, err
+	}
+	blocks, receipts, err := st.chain.getBlocksWithReceiptsByNumber(bns)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		blocksBytes = make([][]byte, 0, len(blocks))
+		sigs        = make([][]byte, 0, len(blocks))
+		rs          = make([]*syncpb.Receipts, 0, len(blocks))
+		size        int
+	)
+	for i, block := range blocks {
+		bb, err := rlp.EncodeToBytes(block)
+		if err != nil {
+			return nil, err
+		}
+		sig := block.GetCurrentCommitSig()
+		size += len(bb) + len(sig)
+
+		blkReceipts := &syncpb.Receipts{
+			ReceiptBytes: make([][]byte, 0, len(receipts[i])),
+		}
+		for _, receipt := range receipts[i] {
+			receiptBytes, err := rlp.EncodeToBytes(receipt)
+			if err != nil {
+				return nil, err
+			}
+			blkReceipts.ReceiptBytes = append(blkReceipts.ReceiptBytes, receiptBytes)
+			size += len(receiptBytes)
+		}
+		blocksBytes = append(blocksBytes, bb)
+		sigs = append(sigs, sig)
+		rs = append(rs, blkReceipts)
+
+		if size >= BlocksResponseSoftLimit {
+			break
+		}
+	}
+	return syncpb.MakeGetBlocksWithReceiptsByNumResponseMessage(rid, blocksBytes, sigs, rs), nil
+}
+
 func bytesToHashes(bs [][]byte) []common.Hash {
 	hs := make([]common.Hash, 0, len(bs))
 	for _, b := range bs {
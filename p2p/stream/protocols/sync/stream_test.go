@@ -10,10 +10,14 @@ import (
 	protobuf "github.com/golang/protobuf/proto"
 	syncpb "github.com/harmony-one/harmony/p2p/stream/protocols/sync/message"
 	sttypes "github.com/harmony-one/harmony/p2p/stream/types"
+	"github.com/hashicorp/go-version"
 	ic "github.com/libp2p/go-libp2p/core/crypto"
+	libp2p_host "github.com/libp2p/go-libp2p/core/host"
 	libp2p_network "github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
 	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/libp2p/go-libp2p/p2p/host/peerstore/pstoremem"
 	ma "github.com/multiformats/go-multiaddr"
 )
 
@@ -60,6 +64,18 @@ var (
 	}
 	testGetNodeDataRequest    = syncpb.MakeGetNodeDataRequest(testGetNodeData)
 	testGetNodeDataRequestMsg = syncpb.MakeMessageFromRequest(testGetNodeDataRequest)
+
+	testGetByteCodes = []common.Hash{
+		numberToHash(1),
+		numberToHash(2),
+		numberToHash(3),
+	}
+	testGetByteCodesRequest    = syncpb.MakeGetByteCodesRequest(testGetByteCodes, SoftResponseLimit)
+	testGetByteCodesRequestMsg = syncpb.MakeMessageFromRequest(testGetByteCodesRequest)
+
+	testGetBlocksWithReceiptsNumbers    = []uint64{98, 99, 100, 101, 102}
+	testGetBlocksWithReceiptsRequest    = syncpb.MakeGetBlocksWithReceiptsByNumRequest(testGetBlocksWithReceiptsNumbers)
+	testGetBlocksWithReceiptsRequestMsg = syncpb.MakeMessageFromRequest(testGetBlocksWithReceiptsRequest)
 )
 
 func TestSyncStream_HandleGetBlocksByRequest(t *testing.T) {
@@ -188,6 +204,176 @@ func TestSyncStream_HandleGetNodeData(t *testing.T) {
 	}
 }
 
+func TestSyncStream_HandleGetByteCodes(t *testing.T) {
+	st, remoteSt := makeTestSyncStream()
+
+	go st.run()
+	defer close(st.closeC)
+
+	req := testGetByteCodesRequestMsg
+	b, _ := protobuf.Marshal(req)
+	err := remoteSt.WriteBytes(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	receivedBytes, _ := remoteSt.ReadBytes()
+
+	if err := checkGetByteCodesResult(receivedBytes, testGetByteCodes); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSyncStream_HandleGetBlocksWithReceiptsByNum(t *testing.T) {
+	st, remoteSt := makeTestSyncStream()
+
+	go st.run()
+	defer close(st.closeC)
+
+	req := testGetBlocksWithReceiptsRequestMsg
+	b, _ := protobuf.Marshal(req)
+	err := remoteSt.WriteBytes(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	receivedBytes, _ := remoteSt.ReadBytes()
+
+	// blocks above the current block number 100 are not delivered
+	if err := checkBlocksWithReceiptsResult(receivedBytes, []uint64{98, 99, 100}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSyncStream_Compressed(t *testing.T) {
+	st, remoteSt := makeTestSyncStream()
+	st.compress = true
+
+	go st.run()
+	defer close(st.closeC)
+
+	req := testGetBlockRequestMsg
+	b, _ := protobuf.Marshal(req)
+	err := remoteSt.WriteBytes(encodeMsgBytes(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	receivedBytes, _ := remoteSt.ReadBytes()
+	if len(receivedBytes) == 0 || receivedBytes[0] != msgCodecSnappy {
+		t.Fatalf("response not compressed")
+	}
+	decoded, err := decodeMsgBytes(receivedBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := checkBlocksResult(testGetBlockNumbers, decoded); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProtocol_WrapStreamVersion(t *testing.T) {
+	ps, err := pstoremem.NewPeerstore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &Protocol{
+		config: Config{
+			Host:    &testPeerstoreHost{ps: ps},
+			Network: "unitest",
+			ShardID: 0,
+		},
+	}
+	var (
+		pid100 = protocol.ID(p.protoIDByVersion(version100))
+		pid110 = protocol.ID(p.protoIDByVersion(version110))
+		pid120 = protocol.ID(p.protoIDByVersion(version120))
+	)
+	tests := []struct {
+		dir        libp2p_network.Direction
+		advertised []protocol.ID
+		expVersion *version.Version
+		expSnap    bool
+	}{
+		// a node of version 1.0.0 accepts the stream dialed at version 1.2.0
+		{libp2p_network.DirOutbound, []protocol.ID{pid100}, version100, false},
+		{libp2p_network.DirOutbound, []protocol.ID{pid100, pid110}, version110, true},
+		{libp2p_network.DirOutbound, []protocol.ID{pid100, pid110, pid120}, version120, true},
+		{libp2p_network.DirOutbound, []protocol.ID{"/ipfs/id/1.0.0"}, version120, true},
+		{libp2p_network.DirOutbound, nil, version120, true},
+		// the listening node knows the version of the dialer
+		{libp2p_network.DirInbound, []protocol.ID{pid100}, version120, true},
+	}
+	for i, test := range tests {
+		if err := ps.SetProtocols(testRemotePeer, test.advertised...); err != nil {
+			t.Fatal(err)
+		}
+		raw, _ := makePairP2PStreams()
+		st := p.wrapStream(&testDialedStream{testP2PStream: raw, pid: pid120, dir: test.dir})
+
+		spec, err := st.ProtoSpec()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !spec.Version.Equal(test.expVersion) {
+			t.Errorf("Test %v: unexpected version %v / %v", i, spec.Version, test.expVersion)
+		}
+		if st.compress != test.expVersion.GreaterThanOrEqual(version120) {
+			t.Errorf("Test %v: unexpected compression %v", i, st.compress)
+		}
+		req := newGetAccountRangeRequest(common.Hash{}, common.Hash{}, common.Hash{}, SoftResponseLimit)
+		if supported := req.IsSupportedByProto(spec); supported != test.expSnap {
+			t.Errorf("Test %v: unexpected snap support %v / %v", i, supported, test.expSnap)
+		}
+	}
+}
+
+func TestEncodeMsgBytes(t *testing.T) {
+	tests := []struct {
+		b        []byte
+		expCodec byte
+	}{
+		{
+			b:        []byte{1, 2, 3},
+			expCodec: msgCodecNone,
+		},
+		{
+			b:        []byte{},
+			expCodec: msgCodecNone,
+		},
+		{
+			b:        bytes.Repeat([]byte{1, 2, 3}, minCompressMsgBytes),
+			expCodec: msgCodecSnappy,
+		},
+	}
+	for i, test := range tests {
+		enc := encodeMsgBytes(test.b)
+		if enc[0] != test.expCodec {
+			t.Errorf("Test %v: unexpected codec %v / %v", i, enc[0], test.expCodec)
+		}
+		if test.expCodec == msgCodecSnappy && len(enc) >= len(test.b) {
+			t.Errorf("Test %v: message not compressed: %v / %v", i, len(enc), len(test.b))
+		}
+		dec, err := decodeMsgBytes(enc)
+		if err != nil {
+			t.Fatalf("Test %v: %v", i, err)
+		}
+		if !bytes.Equal(dec, test.b) {
+			t.Errorf("Test %v: unexpected decoded message", i)
+		}
+	}
+
+	if _, err := decodeMsgBytes([]byte{0xff, 1, 2}); err == nil {
+		t.Errorf("unknown codec decoded")
+	}
+	if _, err := decodeMsgBytes(nil); err == nil {
+		t.Errorf("empty message decoded")
+	}
+}
+
 func makeTestSyncStream() (*syncStream, *testRemoteBaseStream) {
 	localRaw, remoteRaw := makePairP2PStreams()
 	remote := newTestRemoteBaseStream(remoteRaw)
@@ -271,6 +457,36 @@ func (st *testP2PStream) Stat() libp2p_network.Stats        { return libp2p_netw
 func (st *testP2PStream) Conn() libp2p_network.Conn         { return &fakeConn{} }
 func (st *testP2PStream) Scope() libp2p_network.StreamScope { return nil }
 
+var testRemotePeer = peer.ID("remote")
+
+// testDialedStream is a stream of the given protocol and direction with testRemotePeer
+type testDialedStream struct {
+	*testP2PStream
+	pid protocol.ID
+	dir libp2p_network.Direction
+}
+
+func (st *testDialedStream) Protocol() protocol.ID { return st.pid }
+func (st *testDialedStream) Stat() libp2p_network.Stats {
+	return libp2p_network.Stats{Direction: st.dir}
+}
+func (st *testDialedStream) Conn() libp2p_network.Conn { return &testPeerConn{remote: testRemotePeer} }
+
+type testPeerConn struct {
+	fakeConn
+	remote peer.ID
+}
+
+func (conn *testPeerConn) RemotePeer() peer.ID { return conn.remote }
+
+// testPeerstoreHost is a host of which only the peer store is used
+type testPeerstoreHost struct {
+	libp2p_host.Host
+	ps peerstore.Peerstore
+}
+
+func (h *testPeerstoreHost) Peerstore() peerstore.Peerstore { return h.ps }
+
 type testRemoteBaseStream struct {
 	base *sttypes.BaseStream
 }
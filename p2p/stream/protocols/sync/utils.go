@@ -11,6 +11,10 @@ import (
 
 var (
 	errUnknownReqType = errors.New("unknown request")
+
+	// ErrStateUnavailable is returned when the remote node does not serve the
+	// state requested, mostly because the state is too old
+	ErrStateUnavailable = errors.New("state not available")
 )
 
 // syncResponse is the sync protocol response which implements sttypes.Response
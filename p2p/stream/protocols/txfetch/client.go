@@ -0,0 +1,117 @@
+package txfetch
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+	protobuf "github.com/golang/protobuf/proto"
+	"github.com/harmony-one/harmony/core/types"
+	txfetchpb "github.com/harmony-one/harmony/p2p/stream/protocols/txfetch/message"
+	sttypes "github.com/harmony-one/harmony/p2p/stream/types"
+	staking "github.com/harmony-one/harmony/staking/types"
+	"github.com/pkg/errors"
+)
+
+// GetPooledTransactions do getPooledTransactionsRequest through txfetch stream protocol.
+// Return the transactions the remote node has in its pool, which are a subset of
+// the requested ones, the target stream id, and error
+func (p *Protocol) GetPooledTransactions(ctx context.Context, hs []common.Hash, opts ...Option) (txs types.PoolTransactions, stid sttypes.StreamID, err error) {
+	timer := p.doMetricClientRequest("getPooledTransactions")
+	defer p.doMetricPostClientRequest("getPooledTransactions", err, timer)
+
+	if len(hs) == 0 {
+		err = fmt.Errorf("zero transaction hashes requested")
+		return
+	}
+	if len(hs) > GetPooledTransactionsCap {
+		err = fmt.Errorf("number of requested hashes exceed limit")
+		return
+	}
+	req := newGetPooledTransactionsRequest(hs)
+	resp, stid, err := p.rm.DoRequest(ctx, req, opts...)
+	if err != nil {
+		// At this point, error can be context canceled, context timed out, or waiting queue
+		// is already full.
+		return
+	}
+	txs, err = req.getTransactionsFromResponse(resp)
+	return
+}
+
+// getPooledTransactionsRequest is the request for get pooled transactions which implements
+// sttypes.Request interface
+type getPooledTransactionsRequest struct {
+	hashes []common.Hash
+	pbReq  *txfetchpb.Request
+}
+
+func newGetPooledTransactionsRequest(hashes []common.Hash) *getPooledTransactionsRequest {
+	pbReq := txfetchpb.MakeGetPooledTransactionsRequest(hashes)
+	return &getPooledTransactionsRequest{
+		hashes: hashes,
+		pbReq:  pbReq,
+	}
+}
+
+func (req *getPooledTransactionsRequest) ReqID() uint64 {
+	return req.pbReq.GetReqId()
+}
+
+func (req *getPooledTransactionsRequest) SetReqID(val uint64) {
+	req.pbReq.ReqId = val
+}
+
+func (req *getPooledTransactionsRequest) String() string {
+	hashStrs := make([]string, 0, len(req.hashes))
+	for _, h := range req.hashes {
+		hashStrs = append(hashStrs, fmt.Sprintf("%x", h[:]))
+	}
+	hStr := strings.Join(hashStrs, ", ")
+	return fmt.Sprintf("REQUEST [GetPooledTransactions: %s]", hStr)
+}
+
+func (req *getPooledTransactionsRequest) IsSupportedByProto(target sttypes.ProtoSpec) bool {
+	return target.Version.GreaterThanOrEqual(MinVersion)
+}
+
+func (req *getPooledTransactionsRequest) Encode() ([]byte, error) {
+	msg := txfetchpb.MakeMessageFromRequest(req.pbReq)
+	return protobuf.Marshal(msg)
+}
+
+func (req *getPooledTransactionsRequest) getTransactionsFromResponse(resp sttypes.Response) (types.PoolTransactions, error) {
+	tResp, ok := resp.(*txFetchResponse)
+	if !ok || tResp == nil {
+		return nil, errors.New("not txfetch response")
+	}
+	if errResp := tResp.pb.GetErrorResponse(); errResp != nil {
+		return nil, errors.New(errResp.Error)
+	}
+	ptResp := tResp.pb.GetGetPooledTransactionsResponse()
+	if ptResp == nil {
+		return nil, errors.New("response not GetPooledTransactions")
+	}
+	if len(ptResp.Transactions)+len(ptResp.StakingTransactions) > len(req.hashes) {
+		return nil, fmt.Errorf("more transactions delivered than requested: %v > %v",
+			len(ptResp.Transactions)+len(ptResp.StakingTransactions), len(req.hashes))
+	}
+	txs := make(types.PoolTransactions, 0, len(ptResp.Transactions)+len(ptResp.StakingTransactions))
+	for _, b := range ptResp.Transactions {
+		tx := new(types.Transaction)
+		if err := rlp.DecodeBytes(b, tx); err != nil {
+			return nil, errors.Wrap(err, "[GetPooledTransactionsResponse]")
+		}
+		txs = append(txs, tx)
+	}
+	for _, b := range ptResp.StakingTransactions {
+		tx := new(staking.StakingTransaction)
+		if err := rlp.DecodeBytes(b, tx); err != nil {
+			return nil, errors.Wrap(err, "[GetPooledTransactionsResponse]")
+		}
+		txs = append(txs, tx)
+	}
+	return txs, nil
+}
@@ -0,0 +1,65 @@
+package txfetch
+
+import "time"
+
+const (
+	// GetPooledTransactionsCap is the cap of hashes of a single GetPooledTransactions request
+	GetPooledTransactionsCap = 256
+
+	// SoftResponseLimit is the target size of a GetPooledTransactions response.
+	// The transactions are added to the response until this size is reached,
+	// so a response is at most SoftResponseLimit plus the size of a transaction.
+	// The remaining transactions are to be requested again by the client.
+	SoftResponseLimit = 2 * 1024 * 1024
+
+	// MaxStreamFailures is the maximum allowed failures before stream gets removed
+	MaxStreamFailures = 5
+
+	// FaultRecoveryThreshold is the minimum duration before it resets the previous failures
+	// So, if stream hasn't had any issue for a certain amount of time since last failure, we can still trust it
+	FaultRecoveryThreshold = 30 * time.Minute
+
+	// minAdvertiseInterval is the minimum advertise interval
+	minAdvertiseInterval = 1 * time.Minute
+
+	// rateLimiterGlobalRequestPerSecond is the request per second limit for all streams in the txfetch protocol.
+	rateLimiterGlobalRequestPerSecond = 50
+
+	// rateLimiterSingleRequestsPerSecond is the request per second limit for a single stream in the txfetch protocol.
+	rateLimiterSingleRequestsPerSecond = 10
+)
+
+const (
+	// maxPeerAnnounces is the maximum number of announced transactions waiting
+	// to be fetched for a single peer, the announcements above it are dropped
+	maxPeerAnnounces = 4096
+
+	// maxFetchAttempts is the number of requests for an announced transaction
+	// before it is dropped
+	maxFetchAttempts = 3
+
+	// maxInFlightRequests is the number of concurrent GetPooledTransactions requests
+	maxInFlightRequests = 16
+
+	// fetchRequestsPerSecond is the rate of the GetPooledTransactions requests
+	fetchRequestsPerSecond = 20
+
+	// fetchInterval is the interval at which the announced transactions are batched
+	// into requests
+	fetchInterval = 100 * time.Millisecond
+
+	// refetchDelay is the delay before a transaction which was not delivered is
+	// requested again, for the announcers to fetch it meanwhile
+	refetchDelay = 500 * time.Millisecond
+
+	// fetchTimeout is the timeout of a single GetPooledTransactions request
+	fetchTimeout = 5 * time.Second
+
+	// announceTimeout is the time after which an announced transaction which
+	// could not be fetched is dropped
+	announceTimeout = 1 * time.Minute
+
+	// knownTxsCacheSize is the number of recently fetched or dropped transaction
+	// hashes kept to ignore their further announcements
+	knownTxsCacheSize = 32768
+)
@@ -0,0 +1,390 @@
+package txfetch
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	proto_node "github.com/harmony-one/harmony/api/proto/node"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/internal/utils"
+	"github.com/harmony-one/harmony/internal/utils/lrucache"
+	sttypes "github.com/harmony-one/harmony/p2p/stream/types"
+	staking "github.com/harmony-one/harmony/staking/types"
+	"github.com/rs/zerolog"
+	"golang.org/x/time/rate"
+)
+
+// txRequester is the protocol the announced transactions are fetched with
+type txRequester interface {
+	GetPooledTransactions(ctx context.Context, hs []common.Hash, opts ...Option) (types.PoolTransactions, sttypes.StreamID, error)
+	HasStream(stID sttypes.StreamID) bool
+	StreamFailed(stID sttypes.StreamID, reason string)
+}
+
+// announcement is an announced transaction waiting to be fetched
+type announcement struct {
+	proto_node.TxAnnouncement
+	staking bool
+	// the announcers, in announce order
+	peers []sttypes.StreamID
+	// the streams which did not deliver the transaction
+	failed   []sttypes.StreamID
+	attempts int
+	fetching bool
+	time     time.Time
+	// the time before which the transaction is not requested again
+	next time.Time
+}
+
+// fetchBatch is the hashes fetched with a single request
+type fetchBatch struct {
+	// the stream of an announcer, empty to let the request manager pick one
+	stid      sttypes.StreamID
+	hashes    []common.Hash
+	blacklist []sttypes.StreamID
+}
+
+// Fetcher fetches the announced transactions which are not in the pool over
+// the txfetch protocol, and hands them to be added to the pool.
+//
+// The announcements are batched every fetchInterval into requests to one of
+// the announcers, or to any stream if no announcer can be reached. A transaction
+// not delivered is requested again after refetchDelay, up to maxFetchAttempts,
+// from another announcer if any. Else the announcers which failed are asked
+// again, as a peer relays an announcement before having fetched the transaction.
+// The delivery of a relayed announcement thus depends on the retry: when the
+// relaying peer is the only announcer reached, the transaction is only fetched
+// if the peer has it within maxFetchAttempts requests, refetchDelay apart.
+// The transactions already in the pool, being fetched or recently fetched are
+// not requested again, and the number of pending announcements of a single peer
+// as well as the rate and the concurrency of the requests are capped.
+type Fetcher struct {
+	requester txRequester
+	pool      TxPool
+	addTxs    func(types.PoolTransactions)
+
+	anns     map[common.Hash]*announcement
+	peerAnns map[sttypes.StreamID]int
+	known    *lrucache.Cache[common.Hash, struct{}]
+	limiter  *rate.Limiter
+	inFlight chan struct{}
+	lock     sync.Mutex
+
+	ctx       context.Context
+	cancel    func()
+	closeC    chan struct{}
+	closeOnce sync.Once
+	logger    zerolog.Logger
+}
+
+// NewFetcher creates a new fetcher of the announced transactions with the
+// protocol, the fetched transactions are given to addTxs
+func NewFetcher(protocol *Protocol, addTxs func(types.PoolTransactions)) *Fetcher {
+	return newFetcher(protocol, protocol.pool, addTxs)
+}
+
+func newFetcher(requester txRequester, pool TxPool, addTxs func(types.PoolTransactions)) *Fetcher {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Fetcher{
+		requester: requester,
+		pool:      pool,
+		addTxs:    addTxs,
+		anns:      make(map[common.Hash]*announcement),
+		peerAnns:  make(map[sttypes.StreamID]int),
+		known:     lrucache.NewCache[common.Hash, struct{}](knownTxsCacheSize),
+		limiter:   rate.NewLimiter(fetchRequestsPerSecond, fetchRequestsPerSecond),
+		inFlight:  make(chan struct{}, maxInFlightRequests),
+		ctx:       ctx,
+		cancel:    cancel,
+		closeC:    make(chan struct{}),
+		logger:    utils.Logger().With().Str("module", "txfetcher").Logger(),
+	}
+}
+
+// Start starts the fetcher
+func (f *Fetcher) Start() error {
+	go f.loop()
+	return nil
+}
+
+// Stop stops the fetcher
+func (f *Fetcher) Stop() error {
+	f.closeOnce.Do(func() {
+		f.cancel()
+		close(f.closeC)
+	})
+	return nil
+}
+
+// Notify schedules the fetch of the transactions announced by the peer
+func (f *Fetcher) Notify(peer sttypes.StreamID, isStaking bool, anns []proto_node.TxAnnouncement) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for _, a := range anns {
+		if ann, ok := f.anns[a.Hash]; ok {
+			txFetcherCounterVec.WithLabelValues("duplicate").Inc()
+			if containsStream(ann.peers, peer) {
+				// the announcer announced the transaction again, it may have
+				// fetched it since it failed to deliver it
+				ann.failed = removeStream(ann.failed, peer)
+			} else if f.peerAnns[peer] < maxPeerAnnounces {
+				ann.peers = append(ann.peers, peer)
+				f.peerAnns[peer]++
+			}
+			continue
+		}
+		if f.isKnown(a.Hash) {
+			txFetcherCounterVec.WithLabelValues("duplicate").Inc()
+			continue
+		}
+		if f.peerAnns[peer] >= maxPeerAnnounces {
+			txFetcherCounterVec.WithLabelValues("overflow").Inc()
+			continue
+		}
+		txFetcherCounterVec.WithLabelValues("announced").Inc()
+		f.anns[a.Hash] = &announcement{
+			TxAnnouncement: a,
+			staking:        isStaking,
+			peers:          []sttypes.StreamID{peer},
+			time:           time.Now(),
+		}
+		f.peerAnns[peer]++
+	}
+}
+
+func (f *Fetcher) loop() {
+	ticker := time.NewTicker(fetchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			f.schedule()
+		case <-f.closeC:
+			return
+		}
+	}
+}
+
+// schedule sends the requests for the announced transactions, as far as the
+// rate and the concurrency of the requests allow it
+func (f *Fetcher) schedule() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for _, b := range f.batches() {
+		select {
+		case f.inFlight <- struct{}{}:
+		default:
+			return
+		}
+		if !f.limiter.Allow() {
+			<-f.inFlight
+			return
+		}
+		for _, h := range b.hashes {
+			ann := f.anns[h]
+			ann.fetching = true
+			ann.attempts++
+		}
+		go f.fetch(b)
+	}
+}
+
+// batches groups the announced transactions which are not being fetched by the
+// stream they are to be fetched from. The expired announcements and the ones
+// already in the pool are removed.
+func (f *Fetcher) batches() []*fetchBatch {
+	var (
+		batches []*fetchBatch
+		current = make(map[sttypes.StreamID]*fetchBatch)
+	)
+	now := time.Now()
+	for h, ann := range f.anns {
+		if ann.fetching || now.Before(ann.next) {
+			continue
+		}
+		if time.Since(ann.time) > announceTimeout {
+			txFetcherCounterVec.WithLabelValues("expired").Inc()
+			f.remove(h, ann)
+			continue
+		}
+		if f.pool.Get(h) != nil {
+			f.remove(h, ann)
+			continue
+		}
+		stid := f.pickStream(ann)
+		b, ok := current[stid]
+		if !ok || len(b.hashes) >= GetPooledTransactionsCap {
+			b = &fetchBatch{stid: stid}
+			current[stid] = b
+			batches = append(batches, b)
+		}
+		b.hashes = append(b.hashes, h)
+		if stid != "" {
+			continue
+		}
+		for _, failed := range ann.failed {
+			if !containsStream(b.blacklist, failed) {
+				b.blacklist = append(b.blacklist, failed)
+			}
+		}
+	}
+	return batches
+}
+
+// pickStream returns the stream of the first announcer which has not failed to
+// deliver the transaction yet, else of the first announcer which failed, or an
+// empty stream ID if no announcer can be reached
+func (f *Fetcher) pickStream(ann *announcement) sttypes.StreamID {
+	var retry sttypes.StreamID
+	for _, peer := range ann.peers {
+		if !f.requester.HasStream(peer) {
+			continue
+		}
+		if !containsStream(ann.failed, peer) {
+			return peer
+		}
+		if retry == "" {
+			retry = peer
+		}
+	}
+	return retry
+}
+
+func (f *Fetcher) fetch(b *fetchBatch) {
+	defer func() { <-f.inFlight }()
+
+	ctx, cancel := context.WithTimeout(f.ctx, fetchTimeout)
+	defer cancel()
+
+	var opts []Option
+	if b.stid != "" {
+		opts = append(opts, WithWhitelist([]sttypes.StreamID{b.stid}))
+	}
+	if len(b.blacklist) > 0 {
+		opts = append(opts, WithBlacklist(b.blacklist))
+	}
+	txs, stid, err := f.requester.GetPooledTransactions(ctx, b.hashes, opts...)
+	if err != nil {
+		f.logger.Debug().Err(err).Str("stream", string(stid)).Int("hashes", len(b.hashes)).
+			Msg("failed to fetch the announced transactions")
+		if stid != "" && ctx.Err() == nil {
+			f.requester.StreamFailed(stid, "getPooledTransactions failed")
+		}
+		txs = nil
+	}
+	if stid == "" {
+		// the request was not served, the announcer is not to be asked again
+		stid = b.stid
+	}
+	if fetched := f.deliver(b.hashes, stid, txs); len(fetched) > 0 {
+		f.addTxs(fetched)
+	}
+}
+
+// deliver processes the transactions delivered by the stream for the requested
+// hashes, and returns the ones matching their announcement. The transactions
+// not delivered are requested again after refetchDelay.
+func (f *Fetcher) deliver(requested []common.Hash, stid sttypes.StreamID, txs types.PoolTransactions) types.PoolTransactions {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	missing := make(map[common.Hash]struct{}, len(requested))
+	for _, h := range requested {
+		missing[h] = struct{}{}
+	}
+	var (
+		fetched    = make(types.PoolTransactions, 0, len(txs))
+		misbehaved bool
+	)
+	for _, tx := range txs {
+		h := tx.Hash()
+		ann, ok := f.anns[h]
+		if _, isRequested := missing[h]; !isRequested || !ok {
+			txFetcherCounterVec.WithLabelValues("unrequested").Inc()
+			misbehaved = true
+			continue
+		}
+		if !ann.matches(tx) {
+			txFetcherCounterVec.WithLabelValues("mismatch").Inc()
+			misbehaved = true
+			continue
+		}
+		txFetcherCounterVec.WithLabelValues("fetched").Inc()
+		delete(missing, h)
+		f.remove(h, ann)
+		fetched = append(fetched, tx)
+	}
+	for h := range missing {
+		ann, ok := f.anns[h]
+		if !ok {
+			continue
+		}
+		ann.fetching = false
+		ann.next = time.Now().Add(refetchDelay)
+		if stid != "" && !containsStream(ann.failed, stid) {
+			ann.failed = append(ann.failed, stid)
+		}
+		if ann.attempts >= maxFetchAttempts {
+			txFetcherCounterVec.WithLabelValues("dropped").Inc()
+			f.remove(h, ann)
+		}
+	}
+	if misbehaved && stid != "" {
+		f.requester.StreamFailed(stid, "delivered unexpected transactions")
+	}
+	return fetched
+}
+
+// remove removes the announcement, and ignores the further announcements
+// of the transaction
+func (f *Fetcher) remove(h common.Hash, ann *announcement) {
+	delete(f.anns, h)
+	f.known.Set(h, struct{}{})
+	for _, peer := range ann.peers {
+		f.peerAnns[peer]--
+		if f.peerAnns[peer] <= 0 {
+			delete(f.peerAnns, peer)
+		}
+	}
+}
+
+// isKnown returns whether the transaction was recently fetched or dropped, or
+// is in the pool
+func (f *Fetcher) isKnown(h common.Hash) bool {
+	if _, ok := f.known.Get(h); ok {
+		return true
+	}
+	return f.pool.Get(h) != nil
+}
+
+// matches returns whether the transaction is the announced one
+func (ann *announcement) matches(tx types.PoolTransaction) bool {
+	_, isStaking := tx.(*staking.StakingTransaction)
+	if isStaking != ann.staking {
+		return false
+	}
+	return proto_node.NewTxAnnouncement(tx) == ann.TxAnnouncement
+}
+
+func containsStream(stids []sttypes.StreamID, target sttypes.StreamID) bool {
+	for _, stid := range stids {
+		if stid == target {
+			return true
+		}
+	}
+	return false
+}
+
+func removeStream(stids []sttypes.StreamID, target sttypes.StreamID) []sttypes.StreamID {
+	for i, stid := range stids {
+		if stid == target {
+			return append(stids[:i], stids[i+1:]...)
+		}
+	}
+	return stids
+}
@@ -0,0 +1,421 @@
+package txfetch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	proto_node "github.com/harmony-one/harmony/api/proto/node"
+	"github.com/harmony-one/harmony/core/types"
+	sttypes "github.com/harmony-one/harmony/p2p/stream/types"
+)
+
+func TestFetcher_Notify(t *testing.T) {
+	inPool := makeTestTx(0)
+	f := newFetcher(newTestRequester(), newTestPool(inPool), func(types.PoolTransactions) {})
+
+	anns := []proto_node.TxAnnouncement{
+		proto_node.NewTxAnnouncement(inPool),
+		proto_node.NewTxAnnouncement(makeTestTx(1)),
+		proto_node.NewTxAnnouncement(makeTestTx(2)),
+	}
+	f.Notify(makeTestStreamID(0), false, anns)
+	f.Notify(makeTestStreamID(1), false, anns[2:])
+
+	if len(f.anns) != 2 {
+		t.Fatalf("unexpected number of announcements %v / %v", len(f.anns), 2)
+	}
+	if _, ok := f.anns[inPool.Hash()]; ok {
+		t.Errorf("transaction in the pool announced")
+	}
+	if peers := f.anns[anns[2].Hash].peers; len(peers) != 2 {
+		t.Errorf("unexpected number of announcers %v / %v", len(peers), 2)
+	}
+	if f.peerAnns[makeTestStreamID(0)] != 2 || f.peerAnns[makeTestStreamID(1)] != 1 {
+		t.Errorf("unexpected announcements per peer %v", f.peerAnns)
+	}
+
+	// announcements above the cap of a peer are dropped
+	overflow := make([]proto_node.TxAnnouncement, 0, maxPeerAnnounces)
+	for i := 0; i < maxPeerAnnounces; i++ {
+		overflow = append(overflow, proto_node.TxAnnouncement{Hash: common.BigToHash(big.NewInt(int64(i + 100)))})
+	}
+	f.Notify(makeTestStreamID(1), false, overflow)
+	if n := f.peerAnns[makeTestStreamID(1)]; n != maxPeerAnnounces {
+		t.Errorf("unexpected announcements of the peer %v / %v", n, maxPeerAnnounces)
+	}
+}
+
+func TestFetcher_Fetch(t *testing.T) {
+	var (
+		tx0 = makeTestTx(0)
+		tx1 = makeTestTx(1)
+
+		requester = newTestRequester(makeTestStreamID(0))
+		fetchedC  = make(chan types.PoolTransactions, 10)
+	)
+	// the announcer only delivers the second transaction once asked again
+	requester.respond = func(hs []common.Hash, call int) (types.PoolTransactions, sttypes.StreamID, error) {
+		if call == 0 {
+			return types.PoolTransactions{tx0}, makeTestStreamID(0), nil
+		}
+		return types.PoolTransactions{tx1}, makeTestStreamID(0), nil
+	}
+	f := newFetcher(requester, newTestPool(), func(txs types.PoolTransactions) {
+		fetchedC <- txs
+	})
+	f.Notify(makeTestStreamID(0), false, []proto_node.TxAnnouncement{
+		proto_node.NewTxAnnouncement(tx0),
+		proto_node.NewTxAnnouncement(tx1),
+	})
+
+	f.schedule()
+	if txs := waitFetched(t, fetchedC); len(txs) != 1 || txs[0].Hash() != tx0.Hash() {
+		t.Fatalf("unexpected fetched transactions %v", txs)
+	}
+	// the missing transaction is not requested again before the delay
+	f.schedule()
+	requester.lock.Lock()
+	requests := len(requester.requests)
+	requester.lock.Unlock()
+	if requests != 1 {
+		t.Fatalf("transaction requested again before the delay")
+	}
+	time.Sleep(refetchDelay)
+	f.schedule()
+	if txs := waitFetched(t, fetchedC); len(txs) != 1 || txs[0].Hash() != tx1.Hash() {
+		t.Fatalf("unexpected fetched transactions %v", txs)
+	}
+
+	requester.lock.Lock()
+	defer requester.lock.Unlock()
+	if len(requester.requests) != 2 {
+		t.Fatalf("unexpected number of requests %v / %v", len(requester.requests), 2)
+	}
+	if len(requester.requests[0]) != 2 || len(requester.requests[1]) != 1 {
+		t.Errorf("unexpected requested hashes %v", requester.requests)
+	}
+	if len(requester.failed) != 0 {
+		t.Errorf("unexpected failed streams %v", requester.failed)
+	}
+
+	// fetched transactions are not announced again
+	f.Notify(makeTestStreamID(0), false, []proto_node.TxAnnouncement{proto_node.NewTxAnnouncement(tx0)})
+	if len(f.anns) != 0 {
+		t.Errorf("fetched transaction announced again")
+	}
+}
+
+func TestFetcher_deliver(t *testing.T) {
+	var (
+		tx0 = makeTestTx(0)
+		tx1 = makeTestTx(1)
+		tx2 = makeTestTx(2)
+	)
+	tests := []struct {
+		delivered  types.PoolTransactions
+		mismatch   bool
+		expFetched int
+		expFailed  bool
+	}{
+		{
+			delivered:  types.PoolTransactions{tx0, tx1},
+			expFetched: 2,
+			expFailed:  false,
+		},
+		{
+			delivered:  types.PoolTransactions{tx0},
+			expFetched: 1,
+			expFailed:  false,
+		},
+		{
+			// not requested
+			delivered:  types.PoolTransactions{tx0, tx2},
+			expFetched: 1,
+			expFailed:  true,
+		},
+		{
+			// not the announced size
+			delivered:  types.PoolTransactions{tx0, tx1},
+			mismatch:   true,
+			expFetched: 1,
+			expFailed:  true,
+		},
+	}
+
+	for i, test := range tests {
+		requester := newTestRequester(makeTestStreamID(0))
+		f := newFetcher(requester, newTestPool(), func(types.PoolTransactions) {})
+		f.Notify(makeTestStreamID(0), false, []proto_node.TxAnnouncement{
+			proto_node.NewTxAnnouncement(tx0),
+			proto_node.NewTxAnnouncement(tx1),
+		})
+		if test.mismatch {
+			f.anns[tx1.Hash()].Size++
+		}
+
+		fetched := f.deliver([]common.Hash{tx0.Hash(), tx1.Hash()}, makeTestStreamID(0), test.delivered)
+		if len(fetched) != test.expFetched {
+			t.Errorf("Test %v: unexpected fetched transactions %v / %v", i, len(fetched), test.expFetched)
+		}
+		if failed := len(requester.failed) != 0; failed != test.expFailed {
+			t.Errorf("Test %v: unexpected stream failure %v / %v", i, failed, test.expFailed)
+		}
+		if missing, ok := f.anns[tx1.Hash()]; ok && !containsStream(missing.failed, makeTestStreamID(0)) {
+			t.Errorf("Test %v: stream not recorded as failed for the missing transaction", i)
+		}
+	}
+}
+
+func TestFetcher_drop(t *testing.T) {
+	tx := makeTestTx(0)
+	requester := newTestRequester()
+	requester.respond = func(hs []common.Hash, call int) (types.PoolTransactions, sttypes.StreamID, error) {
+		return nil, "", errors.New("no stream")
+	}
+	f := newFetcher(requester, newTestPool(), func(types.PoolTransactions) {})
+	f.Notify(makeTestStreamID(0), false, []proto_node.TxAnnouncement{proto_node.NewTxAnnouncement(tx)})
+
+	for i := 0; i < maxFetchAttempts; i++ {
+		ann, ok := f.anns[tx.Hash()]
+		if !ok {
+			t.Fatalf("announcement dropped after %v attempts", i)
+		}
+		ann.attempts++
+		f.deliver([]common.Hash{tx.Hash()}, "", nil)
+	}
+	if _, ok := f.anns[tx.Hash()]; ok {
+		t.Errorf("announcement not dropped after %v attempts", maxFetchAttempts)
+	}
+	if len(f.peerAnns) != 0 {
+		t.Errorf("unexpected announcements per peer %v", f.peerAnns)
+	}
+}
+
+func TestFetcher_Relay(t *testing.T) {
+	// A announces the transaction to B, which relays the announcement to C.
+	// C has no stream to A and asks B again after the delay, once B has it.
+	var (
+		tx = makeTestTx(0)
+
+		idA, idB = makeTestStreamID(0), makeTestStreamID(1)
+		poolA    = newTestPool(tx)
+		poolB    = newTestPool()
+		poolC    = newTestPool()
+
+		requesterB = newTestRequester(idA)
+		requesterC = newTestRequester(idB)
+		fetchedB   = make(chan types.PoolTransactions, 10)
+		fetchedC   = make(chan types.PoolTransactions, 10)
+	)
+	requesterB.respond = makeTestPoolResponder(poolA, idA)
+	requesterC.respond = makeTestPoolResponder(poolB, idB)
+	fC := newFetcher(requesterC, poolC, func(txs types.PoolTransactions) {
+		for _, tx := range txs {
+			poolC.txs[tx.Hash()] = tx
+		}
+		fetchedC <- txs
+	})
+	fB := newFetcher(requesterB, poolB, func(txs types.PoolTransactions) {
+		for _, tx := range txs {
+			poolB.txs[tx.Hash()] = tx
+		}
+		fetchedB <- txs
+	})
+
+	anns := []proto_node.TxAnnouncement{proto_node.NewTxAnnouncement(tx)}
+	fB.Notify(idA, false, anns)
+	// the announcement relayed by B reaches C before B fetched the transaction
+	fC.Notify(idB, false, anns)
+
+	fC.schedule()
+	waitDelivered(t, fC, tx.Hash())
+	fC.lock.Lock()
+	ann, ok := fC.anns[tx.Hash()]
+	failed := ok && containsStream(ann.failed, idB)
+	fC.lock.Unlock()
+	if !failed {
+		t.Fatalf("stream not recorded as failed for the transaction not in its pool")
+	}
+
+	fB.schedule()
+	if txs := waitFetched(t, fetchedB); len(txs) != 1 || txs[0].Hash() != tx.Hash() {
+		t.Fatalf("unexpected transactions fetched by B %v", txs)
+	}
+	time.Sleep(refetchDelay)
+	fC.schedule()
+	if txs := waitFetched(t, fetchedC); len(txs) != 1 || txs[0].Hash() != tx.Hash() {
+		t.Fatalf("unexpected transactions fetched by C %v", txs)
+	}
+
+	requesterC.lock.Lock()
+	defer requesterC.lock.Unlock()
+	if len(requesterC.requests) != 2 {
+		t.Errorf("unexpected number of requests of C %v / %v", len(requesterC.requests), 2)
+	}
+	if len(fC.anns) != 0 {
+		t.Errorf("fetched transaction still announced")
+	}
+}
+
+func TestFetcher_RelayBeforeFetch(t *testing.T) {
+	// A announces the transaction to B, which relays the announcement before
+	// fetching it. There is no stream to A, so B is asked, and asked again.
+	var (
+		tx       = makeTestTx(0)
+		idA, idB = makeTestStreamID(0), makeTestStreamID(1)
+
+		requester = newTestRequester(idB)
+		fetchedC  = make(chan types.PoolTransactions, 10)
+	)
+	requester.respond = func(hs []common.Hash, call int) (types.PoolTransactions, sttypes.StreamID, error) {
+		if call == 0 {
+			return nil, idB, nil
+		}
+		return types.PoolTransactions{tx}, idB, nil
+	}
+	f := newFetcher(requester, newTestPool(), func(txs types.PoolTransactions) {
+		fetchedC <- txs
+	})
+	anns := []proto_node.TxAnnouncement{proto_node.NewTxAnnouncement(tx)}
+	f.Notify(idA, false, anns)
+	f.Notify(idB, false, anns)
+
+	f.schedule()
+	waitDelivered(t, f, tx.Hash())
+	f.lock.Lock()
+	ann, ok := f.anns[tx.Hash()]
+	if !ok {
+		f.lock.Unlock()
+		t.Fatalf("announcement dropped after the first attempt")
+	}
+	failed := containsStream(ann.failed, idB)
+	retry := f.pickStream(ann)
+	f.lock.Unlock()
+	if !failed {
+		t.Errorf("stream not recorded as failed for the transaction not in its pool")
+	}
+	// the relaying peer is asked again, as no other announcer is reachable
+	if retry != idB {
+		t.Errorf("unexpected stream picked for the retry %v / %v", retry, idB)
+	}
+
+	time.Sleep(refetchDelay)
+	f.schedule()
+	if txs := waitFetched(t, fetchedC); len(txs) != 1 || txs[0].Hash() != tx.Hash() {
+		t.Fatalf("unexpected fetched transactions %v", txs)
+	}
+
+	requester.lock.Lock()
+	defer requester.lock.Unlock()
+	if len(requester.requests) != 2 {
+		t.Errorf("unexpected number of requests %v / %v", len(requester.requests), 2)
+	}
+	// an empty response is not a failure of the stream
+	if len(requester.failed) != 0 {
+		t.Errorf("unexpected failed streams %v", requester.failed)
+	}
+	if len(f.anns) != 0 {
+		t.Errorf("fetched transaction still announced")
+	}
+}
+
+func TestFetcher_Stop(t *testing.T) {
+	f := newFetcher(newTestRequester(), newTestPool(), func(types.PoolTransactions) {})
+	if err := f.Start(); err != nil {
+		t.Fatal(err)
+	}
+	// stopping twice does not panic
+	for i := 0; i != 2; i++ {
+		if err := f.Stop(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if f.ctx.Err() == nil {
+		t.Errorf("fetcher context not canceled")
+	}
+}
+
+// waitDelivered waits for the response to the request of the transaction
+func waitDelivered(t *testing.T, f *Fetcher, h common.Hash) {
+	deadline := time.Now().Add(time.Second)
+	for {
+		f.lock.Lock()
+		ann, ok := f.anns[h]
+		delivered := !ok || !ann.fetching
+		f.lock.Unlock()
+		if delivered {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("transaction not delivered")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func waitFetched(t *testing.T, fetchedC chan types.PoolTransactions) types.PoolTransactions {
+	select {
+	case txs := <-fetchedC:
+		// wait for the in flight request to be released
+		time.Sleep(10 * time.Millisecond)
+		return txs
+	case <-time.After(time.Second):
+		t.Fatal("transactions not fetched")
+	}
+	return nil
+}
+
+func makeTestStreamID(index int) sttypes.StreamID {
+	id := fmt.Sprintf("[test stream %v]", index)
+	return sttypes.StreamID(id)
+}
+
+// makeTestPoolResponder returns the responses of the stream serving the
+// requested transactions in the pool
+func makeTestPoolResponder(pool *testPool, stid sttypes.StreamID) func(hs []common.Hash, call int) (types.PoolTransactions, sttypes.StreamID, error) {
+	return func(hs []common.Hash, call int) (types.PoolTransactions, sttypes.StreamID, error) {
+		var txs types.PoolTransactions
+		for _, h := range hs {
+			if tx := pool.Get(h); tx != nil {
+				txs = append(txs, tx)
+			}
+		}
+		return txs, stid, nil
+	}
+}
+
+type testRequester struct {
+	streams  []sttypes.StreamID
+	respond  func(hs []common.Hash, call int) (types.PoolTransactions, sttypes.StreamID, error)
+	requests [][]common.Hash
+	failed   []sttypes.StreamID
+	lock     sync.Mutex
+}
+
+func newTestRequester(streams ...sttypes.StreamID) *testRequester {
+	return &testRequester{streams: streams}
+}
+
+func (r *testRequester) GetPooledTransactions(ctx context.Context, hs []common.Hash, opts ...Option) (types.PoolTransactions, sttypes.StreamID, error) {
+	r.lock.Lock()
+	call := len(r.requests)
+	r.requests = append(r.requests, hs)
+	r.lock.Unlock()
+	return r.respond(hs, call)
+}
+
+func (r *testRequester) HasStream(stID sttypes.StreamID) bool {
+	return containsStream(r.streams, stID)
+}
+
+func (r *testRequester) StreamFailed(stID sttypes.StreamID, reason string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.failed = append(r.failed, stID)
+}
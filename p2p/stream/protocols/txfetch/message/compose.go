@@ -0,0 +1,81 @@
+package message
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// MakeGetPooledTransactionsRequest makes the GetPooledTransactions request
+func MakeGetPooledTransactionsRequest(hashes []common.Hash) *Request {
+	return &Request{
+		Request: &Request_GetPooledTransactionsRequest{
+			GetPooledTransactionsRequest: &GetPooledTransactionsRequest{
+				TxHashes: hashesToBytes(hashes),
+			},
+		},
+	}
+}
+
+// MakeErrorResponseMessage makes the error response as a message
+func MakeErrorResponseMessage(rid uint64, err error) *Message {
+	resp := MakeErrorResponse(rid, err)
+	return makeMessageFromResponse(resp)
+}
+
+// MakeErrorResponse makes the error response as a response
+func MakeErrorResponse(rid uint64, err error) *Response {
+	return &Response{
+		ReqId: rid,
+		Response: &Response_ErrorResponse{
+			&ErrorResponse{
+				Error: err.Error(),
+			},
+		},
+	}
+}
+
+// MakeGetPooledTransactionsResponseMessage makes the GetPooledTransactionsResponse of Message type
+func MakeGetPooledTransactionsResponseMessage(rid uint64, txs, stakingTxs [][]byte) *Message {
+	resp := MakeGetPooledTransactionsResponse(rid, txs, stakingTxs)
+	return makeMessageFromResponse(resp)
+}
+
+// MakeGetPooledTransactionsResponse makes the GetPooledTransactionsResponse of Response type
+func MakeGetPooledTransactionsResponse(rid uint64, txs, stakingTxs [][]byte) *Response {
+	return &Response{
+		ReqId: rid,
+		Response: &Response_GetPooledTransactionsResponse{
+			GetPooledTransactionsResponse: &GetPooledTransactionsResponse{
+				Transactions:        txs,
+				StakingTransactions: stakingTxs,
+			},
+		},
+	}
+}
+
+// MakeMessageFromRequest makes a message from the request
+func MakeMessageFromRequest(req *Request) *Message {
+	return &Message{
+		ReqOrResp: &Message_Req{
+			Req: req,
+		},
+	}
+}
+
+func makeMessageFromResponse(resp *Response) *Message {
+	return &Message{
+		ReqOrResp: &Message_Resp{
+			Resp: resp,
+		},
+	}
+}
+
+func hashesToBytes(hashes []common.Hash) [][]byte {
+	res := make([][]byte, 0, len(hashes))
+
+	for _, h := range hashes {
+		b := make([]byte, common.HashLength)
+		copy(b, h[:])
+		res = append(res, b)
+	}
+	return res
+}
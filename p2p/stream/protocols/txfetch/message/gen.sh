@@ -0,0 +1,3 @@
+#!/bin/bash
+
+docker run --platform linux/amd64 -v ${PWD}:/tmp ${PROTOC_IMAGE} /tmp/msg.proto
\ No newline at end of file
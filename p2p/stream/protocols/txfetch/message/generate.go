@@ -0,0 +1,3 @@
+package message
+
+//go:generate ./gen.sh
@@ -0,0 +1,270 @@
+package txfetch
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/core/types"
+	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
+	"github.com/harmony-one/harmony/internal/utils"
+	"github.com/harmony-one/harmony/p2p/discovery"
+	"github.com/harmony-one/harmony/p2p/stream/common/ratelimiter"
+	"github.com/harmony-one/harmony/p2p/stream/common/requestmanager"
+	"github.com/harmony-one/harmony/p2p/stream/common/streammanager"
+	sttypes "github.com/harmony-one/harmony/p2p/stream/types"
+	"github.com/hashicorp/go-version"
+	libp2p_host "github.com/libp2p/go-libp2p/core/host"
+	libp2p_network "github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// serviceSpecifier is the specifier for the service.
+	serviceSpecifier = "txfetch"
+)
+
+var (
+	version100, _ = version.NewVersion("1.0.0")
+
+	// MyVersion is the version of txfetch protocol of the local node
+	MyVersion = version100
+
+	// MinVersion is the minimum version for matching function
+	MinVersion = version100
+)
+
+type (
+	// TxPool is the transaction pool the pooled transactions are served from
+	TxPool interface {
+		Get(hash common.Hash) types.PoolTransaction
+	}
+
+	// Protocol is the protocol to fetch the announced transactions from the
+	// transaction pool of the remote nodes
+	Protocol struct {
+		pool TxPool                        // provide the pooled transactions
+		rl   ratelimiter.RateLimiter       // limit the incoming request rate
+		sm   streammanager.StreamManager   // stream management
+		rm   requestmanager.RequestManager // deliver the response from stream
+		disc discovery.Discovery
+
+		config Config
+		logger zerolog.Logger
+
+		ctx    context.Context
+		cancel func()
+		closeC chan struct{}
+	}
+
+	// Config is the txfetch protocol config
+	Config struct {
+		Pool                 TxPool
+		Host                 libp2p_host.Host
+		Discovery            discovery.Discovery
+		ShardID              nodeconfig.ShardID
+		Network              nodeconfig.NetworkType
+		MaxAdvertiseWaitTime int
+		// stream manager config
+		SmSoftLowCap int
+		SmHardLowCap int
+		SmHiCap      int
+		DiscBatch    int
+	}
+)
+
+// NewProtocol creates a new txfetch protocol
+func NewProtocol(config Config) *Protocol {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	tp := &Protocol{
+		pool:   config.Pool,
+		disc:   config.Discovery,
+		config: config,
+		ctx:    ctx,
+		cancel: cancel,
+		closeC: make(chan struct{}),
+	}
+	smConfig := streammanager.Config{
+		SoftLoCap: config.SmSoftLowCap,
+		HardLoCap: config.SmHardLowCap,
+		HiCap:     config.SmHiCap,
+		DiscBatch: config.DiscBatch,
+	}
+	tp.sm = streammanager.NewStreamManager(tp.ProtoID(), config.Host, config.Discovery,
+		tp.HandleStream, smConfig)
+
+	tp.rl = ratelimiter.NewRateLimiter(tp.sm, rateLimiterGlobalRequestPerSecond, rateLimiterSingleRequestsPerSecond)
+
+	tp.rm = requestmanager.NewRequestManager(tp.sm)
+
+	tp.logger = utils.Logger().With().Str("Protocol", string(tp.ProtoID())).Logger()
+	return tp
+}
+
+// Start starts the txfetch protocol
+func (p *Protocol) Start() {
+	p.sm.Start()
+	p.rm.Start()
+	p.rl.Start()
+	go p.advertiseLoop()
+}
+
+// Close close the protocol
+func (p *Protocol) Close() {
+	p.rl.Close()
+	p.rm.Close()
+	p.sm.Close()
+	p.cancel()
+	close(p.closeC)
+}
+
+// Specifier return the specifier for the protocol
+func (p *Protocol) Specifier() string {
+	return serviceSpecifier + "/" + strconv.Itoa(int(p.config.ShardID))
+}
+
+// ProtoID return the ProtoID of the txfetch protocol
+func (p *Protocol) ProtoID() sttypes.ProtoID {
+	return p.protoIDByVersion(MyVersion)
+}
+
+// Version returns the txfetch protocol version
+func (p *Protocol) Version() *version.Version {
+	return MyVersion
+}
+
+// IsBeaconNode returns false, the transactions are fetched within a shard
+func (p *Protocol) IsBeaconNode() bool {
+	return false
+}
+
+// Match checks the compatibility to the target protocol ID.
+func (p *Protocol) Match(targetID protocol.ID) bool {
+	target, err := sttypes.ProtoIDToProtoSpec(sttypes.ProtoID(targetID))
+	if err != nil {
+		return false
+	}
+	if target.Service != serviceSpecifier {
+		return false
+	}
+	if target.NetworkType != p.config.Network {
+		return false
+	}
+	if target.ShardID != p.config.ShardID {
+		return false
+	}
+	if target.Version.LessThan(MinVersion) {
+		return false
+	}
+	return true
+}
+
+// HandleStream is the stream handle function being registered to libp2p.
+func (p *Protocol) HandleStream(raw libp2p_network.Stream) {
+	p.logger.Info().Str("stream", raw.ID()).Msg("handle new txfetch stream")
+	st := p.wrapStream(raw)
+	if err := p.sm.NewStream(st); err != nil {
+		// Possibly we have reach the hard limit of the stream
+		p.logger.Warn().Err(err).Str("stream ID", string(st.ID())).
+			Msg("failed to add new stream")
+		return
+	}
+	st.run()
+}
+
+func (p *Protocol) advertiseLoop() {
+	for {
+		sleep := p.advertise()
+		maxSleepTime := time.Duration(p.config.MaxAdvertiseWaitTime) * time.Minute
+		if maxSleepTime > 0 && sleep > maxSleepTime {
+			sleep = maxSleepTime
+		}
+		select {
+		case <-p.closeC:
+			return
+		case <-time.After(sleep):
+		}
+	}
+}
+
+// advertise will advertise all compatible protocol versions for helping nodes running low
+// version
+func (p *Protocol) advertise() time.Duration {
+	var nextWait time.Duration
+
+	for _, v := range p.supportedVersions() {
+		pid := p.protoIDByVersion(v)
+		w, e := p.disc.Advertise(p.ctx, string(pid))
+		if e != nil {
+			p.logger.Warn().Err(e).Str("protocol", string(pid)).
+				Msg("cannot advertise txfetch protocol")
+			continue
+		}
+		if nextWait == 0 || nextWait > w {
+			nextWait = w
+		}
+	}
+	if nextWait < minAdvertiseInterval {
+		nextWait = minAdvertiseInterval
+	}
+	return nextWait
+}
+
+func (p *Protocol) supportedVersions() []*version.Version {
+	return []*version.Version{version100}
+}
+
+func (p *Protocol) protoIDByVersion(v *version.Version) sttypes.ProtoID {
+	spec := sttypes.ProtoSpec{
+		Service:     serviceSpecifier,
+		NetworkType: p.config.Network,
+		ShardID:     p.config.ShardID,
+		Version:     v,
+	}
+	return spec.ToProtoID()
+}
+
+// HasStream returns whether the protocol has a stream with the given ID
+func (p *Protocol) HasStream(stID sttypes.StreamID) bool {
+	st, exist := p.sm.GetStreamByID(stID)
+	return exist && st != nil
+}
+
+// StreamFailed records a failure of the stream, which is removed once it
+// reaches MaxStreamFailures
+func (p *Protocol) StreamFailed(stID sttypes.StreamID, reason string) {
+	st, exist := p.sm.GetStreamByID(stID)
+	if exist && st != nil {
+		st.AddFailedTimes(FaultRecoveryThreshold)
+		p.logger.Info().
+			Str("stream ID", string(st.ID())).
+			Int("num failures", st.FailedTimes()).
+			Str("reason", reason).
+			Msg("stream failed")
+		if st.FailedTimes() >= MaxStreamFailures {
+			st.Close()
+			// stream manager removes this stream from the list and triggers discovery if number of streams are not enough
+			p.sm.RemoveStream(stID)
+			p.logger.Warn().
+				Str("stream ID", string(st.ID())).
+				Msg("stream removed")
+		}
+	}
+}
+
+// NumStreams return the streams with minimum version.
+func (p *Protocol) NumStreams() int {
+	res := 0
+	sts := p.sm.GetStreams()
+
+	for _, st := range sts {
+		ps, _ := st.ProtoSpec()
+		if ps.Version.GreaterThanOrEqual(MinVersion) {
+			res++
+		}
+	}
+	return res
+}
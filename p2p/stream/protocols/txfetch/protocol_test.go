@@ -0,0 +1,37 @@
+package txfetch
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/protocol"
+)
+
+func TestProtocol_Match(t *testing.T) {
+	tests := []struct {
+		targetID protocol.ID
+		exp      bool
+	}{
+		{"harmony/txfetch/unitest/0/1.0.0/0", true},
+		{"harmony/txfetch/unitest/0/1.0.1/1", true},
+		{"h123456", false},
+		{"harmony/txfetch/unitest/0/0.9.9/0", false},
+		{"harmony/sync/unitest/0/1.0.0/0", false},
+		{"harmony/txfetch/mainnet/0/1.0.0/0", false},
+		{"harmony/txfetch/unitest/1/1.0.0/0", false},
+	}
+
+	for i, test := range tests {
+		p := &Protocol{
+			config: Config{
+				Network: "unitest",
+				ShardID: 0,
+			},
+		}
+
+		res := p.Match(test.targetID)
+
+		if res != test.exp {
+			t.Errorf("Test %v: unexpected result %v / %v", i, res, test.exp)
+		}
+	}
+}
@@ -0,0 +1,275 @@
+package txfetch
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+	protobuf "github.com/golang/protobuf/proto"
+	"github.com/harmony-one/harmony/core/types"
+	txfetchpb "github.com/harmony-one/harmony/p2p/stream/protocols/txfetch/message"
+	sttypes "github.com/harmony-one/harmony/p2p/stream/types"
+	staking "github.com/harmony-one/harmony/staking/types"
+	libp2p_network "github.com/libp2p/go-libp2p/core/network"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+)
+
+// txFetchStream is the structure for a stream running txfetch protocol.
+type txFetchStream struct {
+	// Basic stream
+	*sttypes.BaseStream
+
+	protocol *Protocol
+	pool     TxPool
+
+	// pipeline channels
+	reqC  chan *txfetchpb.Request
+	respC chan *txfetchpb.Response
+
+	// close related fields. Concurrent call of close is possible.
+	closeC    chan struct{}
+	closeStat uint32
+
+	logger zerolog.Logger
+}
+
+// wrapStream wraps the raw libp2p stream to txFetchStream
+func (p *Protocol) wrapStream(raw libp2p_network.Stream) *txFetchStream {
+	bs := sttypes.NewBaseStream(raw)
+	logger := p.logger.With().
+		Str("ID", string(bs.ID())).
+		Str("Remote Protocol", string(bs.ProtoID())).
+		Logger()
+
+	return &txFetchStream{
+		BaseStream: bs,
+		protocol:   p,
+		pool:       p.pool,
+		reqC:       make(chan *txfetchpb.Request, 100),
+		respC:      make(chan *txfetchpb.Response, 100),
+		closeC:     make(chan struct{}),
+		closeStat:  0,
+		logger:     logger,
+	}
+}
+
+func (st *txFetchStream) run() {
+	st.logger.Info().Str("StreamID", string(st.ID())).Msg("running txfetch protocol on stream")
+	defer st.logger.Info().Str("StreamID", string(st.ID())).Msg("end running txfetch protocol on stream")
+
+	go st.handleReqLoop()
+	go st.handleRespLoop()
+	st.readMsgLoop()
+}
+
+// readMsgLoop is the loop
+func (st *txFetchStream) readMsgLoop() {
+	for {
+		msg, err := st.readMsg()
+		if err != nil {
+			if err := st.Close(); err != nil {
+				st.logger.Err(err).Msg("failed to close txfetch stream")
+			}
+			return
+		}
+		st.deliverMsg(msg)
+	}
+}
+
+// deliverMsg process the delivered message and forward to the corresponding channel
+func (st *txFetchStream) deliverMsg(msg *txfetchpb.Message) {
+	if req := msg.GetReq(); req != nil {
+		go func() {
+			select {
+			case st.reqC <- req:
+			case <-time.After(1 * time.Minute):
+				st.logger.Warn().Str("request", req.String()).
+					Msg("request handler severely jammed, message dropped")
+			}
+		}()
+	}
+	if resp := msg.GetResp(); resp != nil {
+		go func() {
+			select {
+			case st.respC <- resp:
+			case <-time.After(1 * time.Minute):
+				st.logger.Warn().Str("response", resp.String()).
+					Msg("response handler severely jammed, message dropped")
+			}
+		}()
+	}
+}
+
+func (st *txFetchStream) handleReqLoop() {
+	for {
+		select {
+		case req := <-st.reqC:
+			st.protocol.rl.LimitRequest(st.ID())
+			err := st.handleReq(req)
+
+			if err != nil {
+				st.logger.Info().Err(err).Str("request", req.String()).
+					Msg("handle request error. Closing stream")
+				if err := st.Close(); err != nil {
+					st.logger.Err(err).Msg("failed to close txfetch stream")
+				}
+				return
+			}
+
+		case <-st.closeC:
+			return
+		}
+	}
+}
+
+func (st *txFetchStream) handleRespLoop() {
+	for {
+		select {
+		case resp := <-st.respC:
+			st.handleResp(resp)
+
+		case <-st.closeC:
+			return
+		}
+	}
+}
+
+// Close stops the stream handling and closes the underlying stream
+func (st *txFetchStream) Close() error {
+	notClosed := atomic.CompareAndSwapUint32(&st.closeStat, 0, 1)
+	if !notClosed {
+		// Already closed by another goroutine. Directly return
+		return nil
+	}
+	if err := st.protocol.sm.RemoveStream(st.ID()); err != nil {
+		st.logger.Err(err).Str("stream ID", string(st.ID())).
+			Msg("failed to remove txfetch stream on close")
+	}
+	close(st.closeC)
+	return st.BaseStream.Close()
+}
+
+// CloseOnExit reset the stream on exiting node
+func (st *txFetchStream) CloseOnExit() error {
+	notClosed := atomic.CompareAndSwapUint32(&st.closeStat, 0, 1)
+	if !notClosed {
+		// Already closed by another goroutine. Directly return
+		return nil
+	}
+	close(st.closeC)
+	return st.BaseStream.CloseOnExit()
+}
+
+func (st *txFetchStream) handleReq(req *txfetchpb.Request) error {
+	if ptReq := req.GetGetPooledTransactionsRequest(); ptReq != nil {
+		return st.handleGetPooledTransactionsRequest(req.ReqId, ptReq)
+	}
+	// unsupported request type
+	return st.handleUnknownRequest(req.ReqId)
+}
+
+func (st *txFetchStream) handleGetPooledTransactionsRequest(rid uint64, req *txfetchpb.GetPooledTransactionsRequest) error {
+	serverRequestCounterVec.With(prometheus.Labels{
+		"topic":        string(st.ProtoID()),
+		"request_type": "getPooledTransactions",
+	}).Inc()
+
+	hashes := bytesToHashes(req.TxHashes)
+	resp, err := st.computeGetPooledTransactions(rid, hashes)
+	if resp == nil && err != nil {
+		resp = txfetchpb.MakeErrorResponseMessage(rid, err)
+	}
+	if writeErr := st.writeMsg(resp); writeErr != nil {
+		if err == nil {
+			err = writeErr
+		} else {
+			err = fmt.Errorf("%v; [writeMsg] %v", err.Error(), writeErr)
+		}
+	}
+	return errors.Wrap(err, "[GetPooledTransactions]")
+}
+
+func (st *txFetchStream) handleUnknownRequest(rid uint64) error {
+	serverRequestCounterVec.With(prometheus.Labels{
+		"topic":        string(st.ProtoID()),
+		"request_type": "unknown",
+	}).Inc()
+	resp := txfetchpb.MakeErrorResponseMessage(rid, errUnknownReqType)
+	return st.writeMsg(resp)
+}
+
+func (st *txFetchStream) handleResp(resp *txfetchpb.Response) {
+	st.protocol.rm.DeliverResponse(st.ID(), &txFetchResponse{resp})
+}
+
+func (st *txFetchStream) readMsg() (*txfetchpb.Message, error) {
+	b, err := st.ReadBytes()
+	if err != nil {
+		return nil, err
+	}
+	var msg = &txfetchpb.Message{}
+	if err := protobuf.Unmarshal(b, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+func (st *txFetchStream) writeMsg(msg *txfetchpb.Message) error {
+	b, err := protobuf.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return st.WriteBytes(b)
+}
+
+// computeGetPooledTransactions returns the requested transactions found in the
+// pool, the unknown ones are skipped. The transactions are added until the
+// response reaches SoftResponseLimit.
+func (st *txFetchStream) computeGetPooledTransactions(rid uint64, hs []common.Hash) (*txfetchpb.Message, error) {
+	if len(hs) > GetPooledTransactionsCap {
+		err := fmt.Errorf("GetPooledTransactions amount exceed cap: %v > %v", len(hs), GetPooledTransactionsCap)
+		return nil, err
+	}
+	var (
+		txs        = make([][]byte, 0, len(hs))
+		stakingTxs = make([][]byte, 0)
+		size       int
+	)
+	for _, h := range hs {
+		if size >= SoftResponseLimit {
+			break
+		}
+		tx := st.pool.Get(h)
+		if tx == nil {
+			continue
+		}
+		b, err := rlp.EncodeToBytes(tx)
+		if err != nil {
+			return nil, err
+		}
+		switch tx.(type) {
+		case *types.Transaction:
+			txs = append(txs, b)
+		case *staking.StakingTransaction:
+			stakingTxs = append(stakingTxs, b)
+		default:
+			continue
+		}
+		size += len(b)
+	}
+	return txfetchpb.MakeGetPooledTransactionsResponseMessage(rid, txs, stakingTxs), nil
+}
+
+func bytesToHashes(bs [][]byte) []common.Hash {
+	hs := make([]common.Hash, 0, len(bs))
+	for _, b := range bs {
+		var h common.Hash
+		copy(h[:], b)
+		hs = append(hs, h)
+	}
+	return hs
+}
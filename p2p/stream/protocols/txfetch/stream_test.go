@@ -0,0 +1,67 @@
+package txfetch
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/core/types"
+	txfetchpb "github.com/harmony-one/harmony/p2p/stream/protocols/txfetch/message"
+	sttypes "github.com/harmony-one/harmony/p2p/stream/types"
+)
+
+var (
+	_ sttypes.Protocol = &Protocol{}
+	_ sttypes.Request  = &getPooledTransactionsRequest{}
+	_ sttypes.Response = &txFetchResponse{&txfetchpb.Response{}}
+)
+
+func TestTxFetchStream_computeGetPooledTransactions(t *testing.T) {
+	pool := newTestPool(makeTestTx(0), makeTestTx(1), makeTestTx(2))
+	st := &txFetchStream{pool: pool}
+
+	hashes := []common.Hash{makeTestTx(0).Hash(), makeTestTx(5).Hash(), makeTestTx(2).Hash()}
+	msg, err := st.computeGetPooledTransactions(1, hashes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp := msg.GetResp()
+	if resp == nil || resp.ReqId != 1 {
+		t.Fatalf("unexpected response %v", msg)
+	}
+	txs, err := newGetPooledTransactionsRequest(hashes).getTransactionsFromResponse(&txFetchResponse{resp})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(txs) != 2 {
+		t.Fatalf("unexpected number of transactions %v / %v", len(txs), 2)
+	}
+	if txs[0].Hash() != hashes[0] || txs[1].Hash() != hashes[2] {
+		t.Errorf("unexpected transactions delivered")
+	}
+
+	hashes = make([]common.Hash, GetPooledTransactionsCap+1)
+	if _, err := st.computeGetPooledTransactions(1, hashes); err == nil {
+		t.Errorf("expected error for the number of hashes above the cap")
+	}
+}
+
+type testPool struct {
+	txs map[common.Hash]types.PoolTransaction
+}
+
+func newTestPool(txs ...types.PoolTransaction) *testPool {
+	pool := &testPool{txs: make(map[common.Hash]types.PoolTransaction)}
+	for _, tx := range txs {
+		pool.txs[tx.Hash()] = tx
+	}
+	return pool
+}
+
+func (pool *testPool) Get(hash common.Hash) types.PoolTransaction {
+	return pool.txs[hash]
+}
+
+func makeTestTx(nonce uint64) *types.Transaction {
+	return types.NewTransaction(nonce, common.HexToAddress("0x1337"), 0, big.NewInt(1), 21000, big.NewInt(1), nil)
+}
@@ -0,0 +1,52 @@
+package txfetch
+
+import (
+	"fmt"
+
+	protobuf "github.com/golang/protobuf/proto"
+	"github.com/harmony-one/harmony/p2p/stream/common/requestmanager"
+	txfetchpb "github.com/harmony-one/harmony/p2p/stream/protocols/txfetch/message"
+	"github.com/pkg/errors"
+)
+
+var (
+	errUnknownReqType = errors.New("unknown request")
+)
+
+// txFetchResponse is the txfetch protocol response which implements sttypes.Response
+type txFetchResponse struct {
+	pb *txfetchpb.Response
+}
+
+// ReqID return the request ID of the response
+func (resp *txFetchResponse) ReqID() uint64 {
+	return resp.pb.ReqId
+}
+
+// GetProtobufMsg return the raw protobuf message
+func (resp *txFetchResponse) GetProtobufMsg() protobuf.Message {
+	return resp.pb
+}
+
+func (resp *txFetchResponse) String() string {
+	return fmt.Sprintf("[TxFetchResponse %v]", resp.pb.String())
+}
+
+// Option is the additional option to do requests.
+// Currently, three options are supported:
+//  1. WithHighPriority - do the request in high priority.
+//  2. WithBlacklist - do the request without the given stream ids as blacklist
+//  3. WithWhitelist - do the request only with the given stream ids
+type Option = requestmanager.RequestOption
+
+var (
+	// WithHighPriority instruct the request manager to do the request with high
+	// priority
+	WithHighPriority = requestmanager.WithHighPriority
+	// WithBlacklist instruct the request manager not to assign the request to the
+	// given streamID
+	WithBlacklist = requestmanager.WithBlacklist
+	// WithWhitelist instruct the request manager only to assign the request to the
+	// given streamID
+	WithWhitelist = requestmanager.WithWhitelist
+)
@@ -37,6 +37,12 @@ const (
 	// UndelegateOperation is an operation that only affects the native currency.
 	UndelegateOperation = "Undelegate"
 
+	// RedelegateOperation is an operation that only affects the native currency.
+	RedelegateOperation = "Redelegate"
+
+	// EditDelegationOperation is an operation that only affects the native currency.
+	EditDelegationOperation = "EditDelegation"
+
 	// CollectRewardsOperation is an operation that only affects the native currency.
 	CollectRewardsOperation = "CollectRewards"
 
@@ -72,6 +78,8 @@ var (
 		staking.DirectiveDelegate.String(),
 		staking.DirectiveUndelegate.String(),
 		staking.DirectiveCollectRewards.String(),
+		staking.DirectiveRedelegate.String(),
+		staking.DirectiveEditDelegation.String(),
 	}
 
 	// MutuallyExclusiveOperations for invariant: A transaction can only contain 1 type of 'native' operation.
@@ -114,6 +122,12 @@ type DelegateOperationMetadata rpcV2.DelegateMsg
 // UndelegateOperationMetadata ..
 type UndelegateOperationMetadata rpcV2.UndelegateMsg
 
+// RedelegateOperationMetadata ..
+type RedelegateOperationMetadata rpcV2.RedelegateMsg
+
+// EditDelegationOperationMetadata ..
+type EditDelegationOperationMetadata rpcV2.EditDelegationMsg
+
 // CollectRewardsMetadata ..
 type CollectRewardsMetadata rpcV2.CollectRewardsMsg
 
@@ -209,6 +223,51 @@ func (s *UndelegateOperationMetadata) UnmarshalFromInterface(data interface{}) e
 	return nil
 }
 
+func (s *RedelegateOperationMetadata) UnmarshalFromInterface(data interface{}) error {
+	var T RedelegateOperationMetadata
+	dat, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(dat, &T); err != nil {
+		return err
+	}
+
+	if T.Amount == nil || T.FromValidatorAddress == "" || T.ToValidatorAddress == "" || T.DelegatorAddress == "" {
+		return fmt.Errorf("expected validator addresses & delegator address & amount be present for RedelegateOperationMetadata")
+	}
+
+	if !common.IsBech32Address(T.FromValidatorAddress) || !common.IsBech32Address(T.ToValidatorAddress) ||
+		!common.IsBech32Address(T.DelegatorAddress) {
+		return fmt.Errorf("expected validator addresses & delegator address to be bech32 format for RedelegateOperationMetadata")
+	}
+
+	*s = T
+	return nil
+}
+
+func (s *EditDelegationOperationMetadata) UnmarshalFromInterface(data interface{}) error {
+	var T EditDelegationOperationMetadata
+	dat, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(dat, &T); err != nil {
+		return err
+	}
+
+	if T.ValidatorAddress == "" || T.DelegatorAddress == "" {
+		return fmt.Errorf("expected validator address & delegator address be present for EditDelegationOperationMetadata")
+	}
+
+	if !common.IsBech32Address(T.ValidatorAddress) || !common.IsBech32Address(T.DelegatorAddress) {
+		return fmt.Errorf("expected validator address & delegator address to be bech32 format for EditDelegationOperationMetadata")
+	}
+
+	*s = T
+	return nil
+}
+
 func (s *CollectRewardsMetadata) UnmarshalFromInterface(data interface{}) error {
 	var T CollectRewardsMetadata
 	dat, err := json.Marshal(data)
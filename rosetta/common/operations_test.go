@@ -74,6 +74,8 @@ func TestStakingOperationTypes(t *testing.T) {
 		staking.DirectiveDelegate.String(),
 		staking.DirectiveUndelegate.String(),
 		staking.DirectiveCollectRewards.String(),
+		staking.DirectiveRedelegate.String(),
+		staking.DirectiveEditDelegation.String(),
 	}
 	sort.Strings(referenceOperationTypes)
 	sort.Strings(stakingOperationTypes)
@@ -230,3 +232,56 @@ func TestUndelegateOperationMetadata_UnmarshalFromInterface(t *testing.T) {
 		t.Fatal("wrong amount")
 	}
 }
+
+func TestRedelegateOperationMetadata_UnmarshalFromInterface(t *testing.T) {
+	data := map[string]interface{}{
+		"fromValidatorAddress": "one1a0x3d6xpmr6f8wsyaxd9v36pytvp48zckswvv9",
+		"toValidatorAddress":   "one1pdv9lrdwl0rg5vglh4xtyrv3wjk3wsqket7zxy",
+		"delegatorAddress":     "one1a0x3d6xpmr6f8wsyaxd9v36pytvp48zckswvv9",
+		"amount":               20000,
+	}
+	s := RedelegateOperationMetadata{}
+	err := s.UnmarshalFromInterface(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.FromValidatorAddress != "one1a0x3d6xpmr6f8wsyaxd9v36pytvp48zckswvv9" {
+		t.Fatal("wrong from validator address")
+	}
+	if s.ToValidatorAddress != "one1pdv9lrdwl0rg5vglh4xtyrv3wjk3wsqket7zxy" {
+		t.Fatal("wrong to validator address")
+	}
+	if s.DelegatorAddress != "one1a0x3d6xpmr6f8wsyaxd9v36pytvp48zckswvv9" {
+		t.Fatal("wrong delegator address")
+	}
+	if s.Amount.Cmp(new(big.Int).SetInt64(20000)) != 0 {
+		t.Fatal("wrong amount")
+	}
+
+	delete(data, "toValidatorAddress")
+	if err := s.UnmarshalFromInterface(data); err == nil {
+		t.Fatal("expected error for missing to validator address")
+	}
+}
+
+func TestEditDelegationOperationMetadata_UnmarshalFromInterface(t *testing.T) {
+	data := map[string]interface{}{
+		"validatorAddress": "one1pdv9lrdwl0rg5vglh4xtyrv3wjk3wsqket7zxy",
+		"delegatorAddress": "one1a0x3d6xpmr6f8wsyaxd9v36pytvp48zckswvv9",
+		"autoCompound":     true,
+	}
+	s := EditDelegationOperationMetadata{}
+	err := s.UnmarshalFromInterface(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.ValidatorAddress != "one1pdv9lrdwl0rg5vglh4xtyrv3wjk3wsqket7zxy" {
+		t.Fatal("wrong validator address")
+	}
+	if s.DelegatorAddress != "one1a0x3d6xpmr6f8wsyaxd9v36pytvp48zckswvv9" {
+		t.Fatal("wrong delegator address")
+	}
+	if !s.AutoCompound {
+		t.Fatal("wrong auto compound")
+	}
+}
@@ -221,6 +221,69 @@ func unpackWrappedTransactionFromString(
 				}
 			}
 			stakingTransaction, _ = stakingTypes.NewStakingTransaction(stakingTx.Nonce(), stakingTx.GasLimit(), stakingTx.GasPrice(), stakePayloadMaker)
+		case stakingTypes.DirectiveRedelegate:
+			var redelegateMsg common.RedelegateOperationMetadata
+			err := redelegateMsg.UnmarshalFromInterface(formattedTx.Operations[index].Metadata)
+			if err != nil {
+				return nil, nil, common.NewError(common.InvalidTransactionConstructionError, map[string]interface{}{
+					"message": err,
+				})
+			}
+			fromValidatorAddr, err := common2.Bech32ToAddress(redelegateMsg.FromValidatorAddress)
+			if err != nil {
+				return nil, nil, common.NewError(common.InvalidTransactionConstructionError, map[string]interface{}{
+					"message": err,
+				})
+			}
+			toValidatorAddr, err := common2.Bech32ToAddress(redelegateMsg.ToValidatorAddress)
+			if err != nil {
+				return nil, nil, common.NewError(common.InvalidTransactionConstructionError, map[string]interface{}{
+					"message": err,
+				})
+			}
+			delegatorAddr, err := common2.Bech32ToAddress(redelegateMsg.DelegatorAddress)
+			if err != nil {
+				return nil, nil, common.NewError(common.InvalidTransactionConstructionError, map[string]interface{}{
+					"message": err,
+				})
+			}
+			stakePayloadMaker := func() (stakingTypes.Directive, interface{}) {
+				return stakingTypes.DirectiveRedelegate, stakingTypes.Redelegate{
+					DelegatorAddress:     delegatorAddr,
+					FromValidatorAddress: fromValidatorAddr,
+					ToValidatorAddress:   toValidatorAddr,
+					Amount:               redelegateMsg.Amount,
+				}
+			}
+			stakingTransaction, _ = stakingTypes.NewStakingTransaction(stakingTx.Nonce(), stakingTx.GasLimit(), stakingTx.GasPrice(), stakePayloadMaker)
+		case stakingTypes.DirectiveEditDelegation:
+			var editDelegationMsg common.EditDelegationOperationMetadata
+			err := editDelegationMsg.UnmarshalFromInterface(formattedTx.Operations[index].Metadata)
+			if err != nil {
+				return nil, nil, common.NewError(common.InvalidTransactionConstructionError, map[string]interface{}{
+					"message": err,
+				})
+			}
+			validatorAddr, err := common2.Bech32ToAddress(editDelegationMsg.ValidatorAddress)
+			if err != nil {
+				return nil, nil, common.NewError(common.InvalidTransactionConstructionError, map[string]interface{}{
+					"message": err,
+				})
+			}
+			delegatorAddr, err := common2.Bech32ToAddress(editDelegationMsg.DelegatorAddress)
+			if err != nil {
+				return nil, nil, common.NewError(common.InvalidTransactionConstructionError, map[string]interface{}{
+					"message": err,
+				})
+			}
+			stakePayloadMaker := func() (stakingTypes.Directive, interface{}) {
+				return stakingTypes.DirectiveEditDelegation, stakingTypes.EditDelegation{
+					DelegatorAddress: delegatorAddr,
+					ValidatorAddress: validatorAddr,
+					AutoCompound:     editDelegationMsg.AutoCompound,
+				}
+			}
+			stakingTransaction, _ = stakingTypes.NewStakingTransaction(stakingTx.Nonce(), stakingTx.GasLimit(), stakingTx.GasPrice(), stakePayloadMaker)
 		case stakingTypes.DirectiveCollectRewards:
 			var collectRewardsMsg common.CollectRewardsMetadata
 			err := collectRewardsMsg.UnmarshalFromInterface(formattedTx.Operations[index].Metadata)
@@ -95,6 +95,14 @@ func ConstructTransaction(
 		if tx, rosettaError = constructUndelegateTransaction(components, metadata); rosettaError != nil {
 			return nil, rosettaError
 		}
+	case common.RedelegateOperation:
+		if tx, rosettaError = constructRedelegateTransaction(components, metadata); rosettaError != nil {
+			return nil, rosettaError
+		}
+	case common.EditDelegationOperation:
+		if tx, rosettaError = constructEditDelegationTransaction(components, metadata); rosettaError != nil {
+			return nil, rosettaError
+		}
 	case common.CollectRewardsOperation:
 		if tx, rosettaError = constructCollectRewardsTransaction(components, metadata); rosettaError != nil {
 			return nil, rosettaError
@@ -375,6 +383,83 @@ func constructUndelegateTransaction(
 	return stakingTransaction, nil
 }
 
+func constructRedelegateTransaction(
+	components *OperationComponents, metadata *ConstructMetadata,
+) (hmyTypes.PoolTransaction, *types.Error) {
+	redelegateMsg := components.StakingMessage.(common.RedelegateOperationMetadata)
+	delegatorAddr, err := common2.Bech32ToAddress(redelegateMsg.DelegatorAddress)
+	if err != nil {
+		return nil, common.NewError(common.InvalidTransactionConstructionError, map[string]interface{}{
+			"message": errors.WithMessage(err, "convert delegator address error").Error(),
+		})
+	}
+	fromValidatorAddr, err := common2.Bech32ToAddress(redelegateMsg.FromValidatorAddress)
+	if err != nil {
+		return nil, common.NewError(common.InvalidTransactionConstructionError, map[string]interface{}{
+			"message": errors.WithMessage(err, "convert from validator address error").Error(),
+		})
+	}
+	toValidatorAddr, err := common2.Bech32ToAddress(redelegateMsg.ToValidatorAddress)
+	if err != nil {
+		return nil, common.NewError(common.InvalidTransactionConstructionError, map[string]interface{}{
+			"message": errors.WithMessage(err, "convert to validator address error").Error(),
+		})
+	}
+
+	stakePayloadMaker := func() (types2.Directive, interface{}) {
+		return types2.DirectiveRedelegate, types2.Redelegate{
+			DelegatorAddress:     delegatorAddr,
+			FromValidatorAddress: fromValidatorAddr,
+			ToValidatorAddress:   toValidatorAddr,
+			Amount:               new(big.Int).Mul(redelegateMsg.Amount, big.NewInt(1e18)),
+		}
+	}
+
+	stakingTransaction, err := types2.NewStakingTransaction(metadata.Nonce, metadata.GasLimit, metadata.GasPrice, stakePayloadMaker)
+	if err != nil {
+		return nil, common.NewError(common.InvalidTransactionConstructionError, map[string]interface{}{
+			"message": errors.WithMessage(err, "new staking transaction error").Error(),
+		})
+	}
+
+	return stakingTransaction, nil
+}
+
+func constructEditDelegationTransaction(
+	components *OperationComponents, metadata *ConstructMetadata,
+) (hmyTypes.PoolTransaction, *types.Error) {
+	editDelegationMsg := components.StakingMessage.(common.EditDelegationOperationMetadata)
+	delegatorAddr, err := common2.Bech32ToAddress(editDelegationMsg.DelegatorAddress)
+	if err != nil {
+		return nil, common.NewError(common.InvalidTransactionConstructionError, map[string]interface{}{
+			"message": errors.WithMessage(err, "convert delegator address error").Error(),
+		})
+	}
+	validatorAddr, err := common2.Bech32ToAddress(editDelegationMsg.ValidatorAddress)
+	if err != nil {
+		return nil, common.NewError(common.InvalidTransactionConstructionError, map[string]interface{}{
+			"message": errors.WithMessage(err, "convert validator address error").Error(),
+		})
+	}
+
+	stakePayloadMaker := func() (types2.Directive, interface{}) {
+		return types2.DirectiveEditDelegation, types2.EditDelegation{
+			DelegatorAddress: delegatorAddr,
+			ValidatorAddress: validatorAddr,
+			AutoCompound:     editDelegationMsg.AutoCompound,
+		}
+	}
+
+	stakingTransaction, err := types2.NewStakingTransaction(metadata.Nonce, metadata.GasLimit, metadata.GasPrice, stakePayloadMaker)
+	if err != nil {
+		return nil, common.NewError(common.InvalidTransactionConstructionError, map[string]interface{}{
+			"message": errors.WithMessage(err, "new staking transaction error").Error(),
+		})
+	}
+
+	return stakingTransaction, nil
+}
+
 func constructCollectRewardsTransaction(
 	components *OperationComponents, metadata *ConstructMetadata,
 ) (hmyTypes.PoolTransaction, *types.Error) {
@@ -172,6 +172,12 @@ func GetNativeOperationsFromStakingTransaction(
 			op2 := getUndelegateOperationForSubAccount(tx, operations[1], receipt)
 			return append(operations, op2), nil
 		}
+
+		// expose the stake moved between the delegation sub accounts
+		if tx.StakingType() == stakingTypes.DirectiveRedelegate {
+			ops := getRedelegateOperationsForSubAccount(tx, operations[1])
+			return append(operations, ops...), nil
+		}
 	}
 
 	return operations, nil
@@ -219,6 +225,63 @@ func getUndelegateOperationForSubAccount(
 	return undelegateion
 }
 
+func getRedelegateOperationsForSubAccount(
+	tx *stakingTypes.StakingTransaction, redelegateOperation *types.Operation,
+) []*types.Operation {
+	msg, err := stakingTypes.RLPDecodeStakeMsg(tx.Data(), stakingTypes.DirectiveRedelegate)
+	if err != nil {
+		return nil
+	}
+	stkMsg, ok := msg.(*stakingTypes.Redelegate)
+	if !ok {
+		return nil
+	}
+	fromValidatorAddress, err := internalCommon.AddressToBech32(stkMsg.FromValidatorAddress)
+	if err != nil {
+		return nil
+	}
+	toValidatorAddress, err := internalCommon.AddressToBech32(stkMsg.ToValidatorAddress)
+	if err != nil {
+		return nil
+	}
+
+	newOperation := func(idx int64, validatorAddress string, amount string) *types.Operation {
+		return &types.Operation{
+			OperationIdentifier: &types.OperationIdentifier{
+				Index: redelegateOperation.OperationIdentifier.Index + idx,
+			},
+			RelatedOperations: []*types.OperationIdentifier{
+				{
+					Index: redelegateOperation.OperationIdentifier.Index,
+				},
+			},
+			Type:   tx.StakingType().String(),
+			Status: redelegateOperation.Status,
+			Account: &types.AccountIdentifier{
+				Address: redelegateOperation.Account.Address,
+				SubAccount: &types.SubAccountIdentifier{
+					Address: validatorAddress,
+					Metadata: map[string]interface{}{
+						SubAccountMetadataKey: Delegation,
+					},
+				},
+				Metadata: redelegateOperation.Account.Metadata,
+			},
+			Amount: &types.Amount{
+				Value:    amount,
+				Currency: redelegateOperation.Amount.Currency,
+				Metadata: redelegateOperation.Amount.Metadata,
+			},
+			Metadata: redelegateOperation.Metadata,
+		}
+	}
+
+	return []*types.Operation{
+		newOperation(1, fromValidatorAddress, negativeBigValue(stkMsg.Amount)),
+		newOperation(2, toValidatorAddress, stkMsg.Amount.String()),
+	}
+}
+
 func getDelegateOperationForSubAccount(tx *stakingTypes.StakingTransaction, receipt *hmytypes.Receipt, delegateOperation *types.Operation) (ops []*types.Operation) {
 	msg, err := stakingTypes.RLPDecodeStakeMsg(tx.Data(), stakingTypes.DirectiveDelegate)
 	if err != nil {
@@ -70,6 +70,10 @@ func GetOperationComponents(
 		return getDelegateOperationComponents(operations[0])
 	case common.UndelegateOperation:
 		return getUndelegateOperationComponents(operations[0])
+	case common.RedelegateOperation:
+		return getRedelegateOperationComponents(operations[0])
+	case common.EditDelegationOperation:
+		return getEditDelegationOperationComponents(operations[0])
 	case common.CollectRewardsOperation:
 		return getCollectRewardsOperationComponents(operations[0])
 	default:
@@ -417,6 +421,70 @@ func getUndelegateOperationComponents(
 
 }
 
+func getRedelegateOperationComponents(
+	operation *types.Operation,
+) (*OperationComponents, *types.Error) {
+	if operation == nil {
+		return nil, common.NewError(common.CatchAllError, map[string]interface{}{
+			"message": "nil operation",
+		})
+	}
+	metadata := common.RedelegateOperationMetadata{}
+	if err := metadata.UnmarshalFromInterface(operation.Metadata); err != nil {
+		return nil, common.NewError(common.InvalidStakingConstructionError, map[string]interface{}{
+			"message": errors.WithMessage(err, "invalid metadata").Error(),
+		})
+	}
+
+	// validators and delegator and amount already got checked inside UnmarshalFromInterface
+	components := &OperationComponents{
+		Type:           operation.Type,
+		From:           operation.Account,
+		StakingMessage: metadata,
+	}
+
+	if components.From == nil {
+		return nil, common.NewError(common.InvalidTransactionConstructionError, map[string]interface{}{
+			"message": "operation must have account sender/from identifier for redelegating",
+		})
+	}
+
+	return components, nil
+
+}
+
+func getEditDelegationOperationComponents(
+	operation *types.Operation,
+) (*OperationComponents, *types.Error) {
+	if operation == nil {
+		return nil, common.NewError(common.CatchAllError, map[string]interface{}{
+			"message": "nil operation",
+		})
+	}
+	metadata := common.EditDelegationOperationMetadata{}
+	if err := metadata.UnmarshalFromInterface(operation.Metadata); err != nil {
+		return nil, common.NewError(common.InvalidStakingConstructionError, map[string]interface{}{
+			"message": errors.WithMessage(err, "invalid metadata").Error(),
+		})
+	}
+
+	// validator and delegator already got checked inside UnmarshalFromInterface
+	components := &OperationComponents{
+		Type:           operation.Type,
+		From:           operation.Account,
+		StakingMessage: metadata,
+	}
+
+	if components.From == nil {
+		return nil, common.NewError(common.InvalidTransactionConstructionError, map[string]interface{}{
+			"message": "operation must have account sender/from identifier for editing delegation",
+		})
+	}
+
+	return components, nil
+
+}
+
 func getCollectRewardsOperationComponents(
 	operation *types.Operation,
 ) (*OperationComponents, *types.Error) {
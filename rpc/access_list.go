@@ -0,0 +1,133 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/core/vm"
+	"github.com/harmony-one/harmony/eth/rpc"
+	"github.com/harmony-one/harmony/hmy"
+	"github.com/harmony-one/harmony/hmy/tracers"
+)
+
+// AccessListResult is the result of the access list creation of a call
+type AccessListResult struct {
+	AccessList *types.AccessList `json:"accessList"`
+	Error      string            `json:"error,omitempty"`
+	GasUsed    hexutil.Uint64    `json:"gasUsed"`
+}
+
+// CreateAccessList returns the EIP-2930 access list of the addresses and
+// storage slots the call accesses on the state for the given block number,
+// pending if not set, along with the gas used by the call carrying that list.
+// Like Call, it doesn't make any changes in the state/blockchain.
+func (s *PublicContractService) CreateAccessList(
+	ctx context.Context, args CallArgs, blockNrOrHash *rpc.BlockNumberOrHash,
+) (*AccessListResult, error) {
+	timer := DoMetricRPCRequest(CreateAccessList)
+	defer DoRPCRequestDuration(CreateAccessList, timer)
+
+	err := s.wait(s.limiterCall, ctx)
+	if err != nil {
+		DoMetricRPCQueryInfo(CreateAccessList, RateLimitedNumber)
+		return nil, err
+	}
+
+	bNrOrHash := rpc.BlockNumberOrHashWithNumber(rpc.PendingBlockNumber)
+	if blockNrOrHash != nil {
+		bNrOrHash = *blockNrOrHash
+	}
+	accessList, gasUsed, vmErr, err := DoAccessListCall(ctx, s.hmy, args, bNrOrHash, s.evmCallTimeout)
+	if err != nil {
+		DoMetricRPCQueryInfo(CreateAccessList, FailedNumber)
+		return nil, err
+	}
+	result := &AccessListResult{AccessList: &accessList, GasUsed: hexutil.Uint64(gasUsed)}
+	if vmErr != nil {
+		result.Error = vmErr.Error()
+	}
+	return result, nil
+}
+
+// DoAccessListCall runs the call with an access list tracer, on a copy of the
+// state, until the access list it carries is the one it accesses: the list
+// found by each run is given to the next one. It returns the access list, the
+// gas used by the last run and its EVM error.
+func DoAccessListCall(
+	ctx context.Context, hmy *hmy.Harmony, args CallArgs, blockNrOrHash rpc.BlockNumberOrHash,
+	timeout time.Duration,
+) (types.AccessList, uint64, error, error) {
+	// Fetch state
+	state, header, err := hmy.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if state == nil || err != nil {
+		return nil, 0, nil, err
+	}
+
+	// The timeout applies to all the runs
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	// The sender and the recipient, or the created contract, and the precompiled
+	// contracts are always accessed, they are left out of the list
+	precompiles := vm.ActivePrecompiles(hmy.BlockChain.Config().Rules(header.Epoch()))
+	var from, to common.Address
+	if args.From != nil {
+		from = *args.From
+	}
+	if args.To != nil {
+		to = *args.To
+	} else {
+		to = crypto.CreateAddress(from, state.GetNonce(from))
+	}
+	var prevTracer *tracers.AccessListTracer
+	if args.AccessList != nil {
+		prevTracer = tracers.NewAccessListTracer(*args.AccessList, from, to, precompiles)
+	} else {
+		prevTracer = tracers.NewAccessListTracer(nil, from, to, precompiles)
+	}
+	for {
+		accessList := prevTracer.AccessList()
+		args.AccessList = &accessList
+		msg := args.ToMessage(hmy.RPCGasCap)
+
+		callState := state.Copy()
+		callState.SetBalance(msg.From(), math.MaxBig256)
+		tracer := tracers.NewAccessListTracer(accessList, from, to, precompiles)
+		vmConfig := vm.Config{Debug: true, Tracer: tracer, NoBaseFee: true}
+		evm := vm.NewEVM(
+			core.NewEVMContext(msg, header, hmy.BlockChain, nil), callState, hmy.BlockChain.Config(), vmConfig,
+		)
+		done := make(chan struct{})
+		go func() {
+			select {
+			case <-ctx.Done():
+				evm.Cancel()
+			case <-done:
+			}
+		}()
+		result, err := core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(msg.Gas()))
+		close(done)
+		if err != nil {
+			return nil, 0, nil, fmt.Errorf("failed to apply transaction: %v", err)
+		}
+		if evm.Cancelled() || ctx.Err() != nil {
+			return nil, 0, nil, fmt.Errorf("execution aborted (timeout = %v)", timeout)
+		}
+		if tracer.Equal(prevTracer) {
+			return accessList, result.UsedGas, result.VMErr, nil
+		}
+		prevTracer = tracer
+	}
+}
@@ -431,6 +431,13 @@ func (s *PublicBlockchainService) GetBlockReceipts(
 		return nil, err
 	}
 
+	return newBlockReceipts(s.version, block, receipts)
+}
+
+// newBlockReceipts returns the receipts of the transactions of the block, in the
+// format of the version
+func newBlockReceipts(version Version, block *types.Block, receipts types.Receipts) ([]StructuredResponse, error) {
+	blockHash := block.Hash()
 	rmap := make(map[common.Hash]*types.Receipt, len(receipts))
 	for _, r := range receipts {
 		rmap[r.TxHash] = r
@@ -457,7 +464,7 @@ func (s *PublicBlockchainService) GetBlockReceipts(
 		index := uint64(i)
 
 		r, err := interface{}(nil), error(nil)
-		switch s.version {
+		switch version {
 		case V1:
 			r, err = v1.NewReceipt(tx, blockHash, block.NumberU64(), index, rmap[tx.Hash()])
 		case V2:
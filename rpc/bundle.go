@@ -0,0 +1,211 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/harmony-one/harmony/accounts/abi"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/core/vm"
+	"github.com/harmony-one/harmony/eth/rpc"
+	"github.com/harmony-one/harmony/hmy"
+)
+
+// maxBundleCalls is the maximum number of calls of a bundle simulation
+const maxBundleCalls = 100
+
+// BundleOptions are the options of a bundle simulation
+type BundleOptions struct {
+	// Trace traces each call of the bundle with the given config if set
+	Trace *hmy.TraceConfig `json:"trace"`
+}
+
+// BundleCallResult is the result of a call of a bundle simulation
+type BundleCallResult struct {
+	ReturnData   hexutil.Bytes  `json:"returnData"`
+	Logs         []*types.Log   `json:"logs"`
+	GasUsed      hexutil.Uint64 `json:"gasUsed"`
+	Error        string         `json:"error,omitempty"`
+	RevertReason string         `json:"revertReason,omitempty"`
+	Trace        interface{}    `json:"trace,omitempty"`
+}
+
+// CallBundle executes the calls in order on top of the state for the given
+// block number, each call seeing the state left by the previous ones. The
+// optional overrides are applied to the state and block before the first call.
+// Like Call, it doesn't make any changes in the state/blockchain.
+func (s *PublicContractService) CallBundle(
+	ctx context.Context, calls []CallArgs, blockNrOrHash rpc.BlockNumberOrHash,
+	overrides *StateOverride, blockOverrides *BlockOverrides, opts *BundleOptions,
+) ([]*BundleCallResult, error) {
+	timer := DoMetricRPCRequest(CallBundle)
+	defer DoRPCRequestDuration(CallBundle, timer)
+
+	err := s.wait(s.limiterCall, ctx)
+	if err != nil {
+		DoMetricRPCQueryInfo(CallBundle, RateLimitedNumber)
+		return nil, err
+	}
+	if len(calls) == 0 {
+		return nil, fmt.Errorf("empty bundle")
+	}
+	if len(calls) > maxBundleCalls {
+		return nil, fmt.Errorf("too many calls in bundle: %d > %d", len(calls), maxBundleCalls)
+	}
+
+	results, err := DoEVMCallBundle(
+		ctx, s.hmy, calls, blockNrOrHash, overrides, blockOverrides, opts, s.evmCallTimeout,
+	)
+	if err != nil {
+		DoMetricRPCQueryInfo(CallBundle, FailedNumber)
+		return nil, err
+	}
+	return results, nil
+}
+
+// DoEVMCallBundle executes the calls in order on a copy of the state, the
+// canonical state is never modified. A call that can't be applied, for
+// instance with too little gas, fails the whole bundle, while a reverted
+// call only fails its own result.
+func DoEVMCallBundle(
+	ctx context.Context, hmy *hmy.Harmony, calls []CallArgs, blockNrOrHash rpc.BlockNumberOrHash,
+	overrides *StateOverride, blockOverrides *BlockOverrides, opts *BundleOptions, timeout time.Duration,
+) ([]*BundleCallResult, error) {
+	// Fetch state
+	state, header, err := hmy.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	state = state.Copy()
+
+	// The timeout applies to the whole bundle
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	blockNum := header.Number().Uint64()
+	if blockOverrides != nil && blockOverrides.Number != nil {
+		blockNum = blockOverrides.Number.ToInt().Uint64()
+	}
+	msgs := make([]core.Message, 0, len(calls))
+	for _, args := range calls {
+		msgs = append(msgs, args.ToMessage(hmy.RPCGasCap))
+	}
+	fundBundleSenders(state, msgs, overrides)
+	newEVM := func(i int, msg core.Message) (*vm.EVM, error) {
+		// The state overrides are applied once, before the first call
+		stateOverrides := overrides
+		if i > 0 {
+			stateOverrides = nil
+		}
+		evm, err := hmy.GetEVMOnState(ctx, msg, state, header)
+		if err != nil {
+			return nil, err
+		}
+		if err := setupCallEVM(ctx, evm, state, stateOverrides, blockOverrides); err != nil {
+			return nil, err
+		}
+		return evm, nil
+	}
+	var trace traceBundleCallFn
+	if opts != nil && opts.Trace != nil {
+		trace = func(ctx context.Context, msg core.Message, vmctx vm.Context) (interface{}, *core.ExecutionResult, error) {
+			return hmy.TraceMessage(ctx, msg, vmctx, state, opts.Trace, true)
+		}
+	}
+	return applyBundle(ctx, msgs, state, header.Hash(), blockNum, newEVM, trace, timeout)
+}
+
+// fundBundleSenders gives the senders of the messages the max balance, as a
+// single call gets, unless their balance is overridden. It is done once
+// before the first call, so that the balance changes made by a call are
+// seen by the next ones.
+func fundBundleSenders(state *state.DB, msgs []core.Message, overrides *StateOverride) {
+	for _, msg := range msgs {
+		if overrides != nil {
+			if account, ok := (*overrides)[msg.From()]; ok && account.Balance != nil {
+				continue
+			}
+		}
+		state.SetBalance(msg.From(), math.MaxBig256)
+	}
+}
+
+// traceBundleCallFn traces a call of a bundle on the EVM context given
+type traceBundleCallFn func(ctx context.Context, msg core.Message, vmctx vm.Context) (interface{}, *core.ExecutionResult, error)
+
+// applyBundle executes the messages in order on the state, with the EVM of
+// each one returned by newEVM. The calls are traced if trace is set. The
+// execution is aborted once ctx is done.
+func applyBundle(
+	ctx context.Context, msgs []core.Message, state *state.DB, blockHash common.Hash, blockNum uint64,
+	newEVM func(i int, msg core.Message) (*vm.EVM, error), trace traceBundleCallFn, timeout time.Duration,
+) ([]*BundleCallResult, error) {
+	results := make([]*BundleCallResult, 0, len(msgs))
+	for i, msg := range msgs {
+		evm, err := newEVM(i, msg)
+		if err != nil {
+			return nil, fmt.Errorf("call %d: %v", i, err)
+		}
+		// Each call gets its own hash so that its logs can be told apart
+		callHash := common.BigToHash(big.NewInt(int64(i)))
+		state.Prepare(callHash, blockHash, i)
+
+		var (
+			result    *core.ExecutionResult
+			callTrace interface{}
+		)
+		if trace != nil {
+			// The traced EVM is cancelled with ctx as well
+			callTrace, result, err = trace(ctx, msg, evm.Context)
+		} else {
+			var res core.ExecutionResult
+			res, err = core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(math.MaxUint64))
+			result = &res
+		}
+		if evm.Cancelled() || ctx.Err() != nil {
+			return nil, fmt.Errorf("execution aborted (timeout = %v)", timeout)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("call %d: %v", i, err)
+		}
+		// Make the changes of the call visible to the next one, as between
+		// the transactions of a block
+		state.Finalise(true)
+
+		logs := state.GetLogs(callHash, blockNum, blockHash)
+		for _, log := range logs {
+			log.TxHash = common.Hash{}
+		}
+		if logs == nil {
+			logs = []*types.Log{}
+		}
+		callResult := &BundleCallResult{
+			ReturnData: result.ReturnData,
+			Logs:       logs,
+			GasUsed:    hexutil.Uint64(result.UsedGas),
+			Trace:      callTrace,
+		}
+		if result.VMErr != nil {
+			callResult.Error = result.VMErr.Error()
+			if revert := result.Revert(); len(revert) > 0 {
+				if reason, errUnpack := abi.UnpackRevert(revert); errUnpack == nil {
+					callResult.RevertReason = reason
+				}
+			}
+		}
+		results = append(results, callResult)
+	}
+	return results, nil
+}
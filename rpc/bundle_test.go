@@ -0,0 +1,144 @@
+package rpc
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/core/vm"
+	"github.com/harmony-one/harmony/internal/params"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	// bundleTestCounter increments the slot 0, emits a log with the new value
+	// as topic and returns it
+	bundleTestCounter     = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	bundleTestCounterCode = common.FromHex("60005460010180600055806000528060206000a15060206000f3")
+	// bundleTestReverter reverts with the reason "no"
+	bundleTestReverter     = common.HexToAddress("0x2000000000000000000000000000000000000002")
+	bundleTestReverterCode = common.FromHex("7f08c379a000000000000000000000000000000000000000000000000000000000" +
+		"600052602060045260026024527f6e6f000000000000000000000000000000000000000000000000000000000000" +
+		"60445260646000fd")
+	// bundleTestLooper loops forever
+	bundleTestLooper     = common.HexToAddress("0x3000000000000000000000000000000000000003")
+	bundleTestLooperCode = common.FromHex("5b600056")
+)
+
+func TestApplyBundle(t *testing.T) {
+	db := makeBundleTestState(t)
+	msgs := []core.Message{
+		makeBundleTestMessage(bundleTestCounter, 100000),
+		makeBundleTestMessage(bundleTestReverter, 100000),
+		makeBundleTestMessage(bundleTestCounter, 100000),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	results, err := applyBundle(ctx, msgs, db, common.Hash{}, 1, makeBundleTestEVMFn(ctx, db), nil, 0)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	// the second increment sees the state left by the first one
+	require.Equal(t, common.BigToHash(big.NewInt(1)).Bytes(), []byte(results[0].ReturnData))
+	require.Equal(t, common.BigToHash(big.NewInt(2)).Bytes(), []byte(results[2].ReturnData))
+
+	// the logs are of their own call
+	require.Len(t, results[0].Logs, 1)
+	require.Equal(t, common.BigToHash(big.NewInt(1)), results[0].Logs[0].Topics[0])
+	require.Len(t, results[1].Logs, 0)
+	require.Len(t, results[2].Logs, 1)
+	require.Equal(t, common.BigToHash(big.NewInt(2)), results[2].Logs[0].Topics[0])
+
+	// the reverted call fails its own result only
+	require.Empty(t, results[0].Error)
+	require.NotEmpty(t, results[1].Error)
+	require.Equal(t, "no", results[1].RevertReason)
+	require.Empty(t, results[2].Error)
+}
+
+func TestApplyBundle_Timeout(t *testing.T) {
+	db := makeBundleTestState(t)
+	msgs := []core.Message{
+		makeBundleTestMessage(bundleTestCounter, 100000),
+		makeBundleTestMessage(bundleTestLooper, 1<<60),
+	}
+	timeout := 100 * time.Millisecond
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	start := time.Now()
+	_, err := applyBundle(ctx, msgs, db, common.Hash{}, 1, makeBundleTestEVMFn(ctx, db), nil, timeout)
+	require.Error(t, err)
+	require.Less(t, time.Since(start), 10*time.Second)
+}
+
+func TestApplyBundle_BalanceCarriesOver(t *testing.T) {
+	db := makeBundleTestState(t)
+	recipient := common.HexToAddress("0x4000000000000000000000000000000000000004")
+	payee := common.HexToAddress("0x5000000000000000000000000000000000000005")
+	msgs := []core.Message{
+		types.NewMessage(testAddr1, &recipient, 0, big.NewInt(10), 100000, new(big.Int), nil, false),
+		types.NewMessage(recipient, &payee, 0, big.NewInt(13), 100000, new(big.Int), nil, false),
+	}
+	balance := (*hexutil.Big)(big.NewInt(3))
+	overrides := &StateOverride{recipient: OverrideAccount{Balance: &balance}}
+
+	// the overridden balance of the second sender is kept, and the funds it
+	// received from the first call are still there when it sends them on
+	fundBundleSenders(db, msgs, overrides)
+	require.NoError(t, overrides.Apply(db))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	results, err := applyBundle(ctx, msgs, db, common.Hash{}, 1, makeBundleTestEVMFn(ctx, db), nil, 0)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.Equal(t, uint64(0), db.GetBalance(recipient).Uint64())
+	require.Equal(t, uint64(13), db.GetBalance(payee).Uint64())
+}
+
+func makeBundleTestState(t *testing.T) *state.DB {
+	db, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	db.SetCode(bundleTestCounter, bundleTestCounterCode, false)
+	db.SetCode(bundleTestReverter, bundleTestReverterCode, false)
+	db.SetCode(bundleTestLooper, bundleTestLooperCode, false)
+	return db
+}
+
+func makeBundleTestMessage(to common.Address, gas uint64) core.Message {
+	return types.NewMessage(testAddr1, &to, 0, new(big.Int), gas, new(big.Int), nil, false)
+}
+
+// makeBundleTestEVMFn returns EVMs on the state, cancelled once ctx is done as
+// those of the RPC calls
+func makeBundleTestEVMFn(ctx context.Context, db *state.DB) func(int, core.Message) (*vm.EVM, error) {
+	return func(i int, msg core.Message) (*vm.EVM, error) {
+		vmctx := vm.Context{
+			CanTransfer: core.CanTransfer,
+			Transfer:    core.Transfer,
+			IsValidator: core.IsValidator,
+			GetHash:     func(uint64) common.Hash { return common.Hash{} },
+			GetVRF:      func(uint64) common.Hash { return common.Hash{} },
+			Origin:      msg.From(),
+			GasPrice:    new(big.Int),
+			GasLimit:    math.MaxUint64,
+			BlockNumber: big.NewInt(1),
+			EpochNumber: big.NewInt(0),
+			Time:        big.NewInt(0),
+		}
+		evm := vm.NewEVM(vmctx, db, params.TestChainConfig, vm.Config{})
+		go func() {
+			<-ctx.Done()
+			evm.Cancel()
+		}()
+		return evm, nil
+	}
+}
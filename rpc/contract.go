@@ -12,8 +12,11 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/harmony-one/harmony/block"
 	"github.com/harmony-one/harmony/common/denominations"
 	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/vm"
 	"github.com/harmony-one/harmony/eth/rpc"
 	"github.com/harmony-one/harmony/hmy"
 	hmyCommon "github.com/harmony-one/harmony/internal/common"
@@ -79,8 +82,10 @@ func (s *PublicContractService) wait(limiter *rate.Limiter, ctx context.Context)
 
 // Call executes the given transaction on the state for the given block number.
 // It doesn't make and changes in the state/blockchain and is useful to execute and retrieve values.
+// The optional overrides are applied to the state and block the call runs on.
 func (s *PublicContractService) Call(
 	ctx context.Context, args CallArgs, blockNrOrHash rpc.BlockNumberOrHash,
+	overrides *StateOverride, blockOverrides *BlockOverrides,
 ) (hexutil.Bytes, error) {
 	timer := DoMetricRPCRequest(Call)
 	defer DoRPCRequestDuration(Call, timer)
@@ -92,7 +97,7 @@ func (s *PublicContractService) Call(
 	}
 
 	// Execute call
-	result, err := DoEVMCall(ctx, s.hmy, args, blockNrOrHash, s.evmCallTimeout)
+	result, err := DoEVMCall(ctx, s.hmy, args, blockNrOrHash, overrides, blockOverrides, s.evmCallTimeout)
 	if err != nil {
 		return nil, err
 	}
@@ -155,10 +160,11 @@ func (s *PublicContractService) GetStorageAt(
 	return res[:], state.Error()
 }
 
-// DoEVMCall executes an EVM call
+// DoEVMCall executes an EVM call. The overrides are applied on a copy of the
+// state, the canonical state is never modified.
 func DoEVMCall(
 	ctx context.Context, hmy *hmy.Harmony, args CallArgs, blockNrOrHash rpc.BlockNumberOrHash,
-	timeout time.Duration,
+	overrides *StateOverride, blockOverrides *BlockOverrides, timeout time.Duration,
 ) (core.ExecutionResult, error) {
 	defer func(start time.Time) {
 		utils.Logger().Debug().
@@ -172,6 +178,7 @@ func DoEVMCall(
 		DoMetricRPCQueryInfo(DoEvmCall, FailedNumber)
 		return core.ExecutionResult{}, err
 	}
+	state = state.Copy()
 
 	// Create new call message
 	msg := args.ToMessage(hmy.RPCGasCap)
@@ -190,19 +197,12 @@ func DoEVMCall(
 	defer cancel()
 
 	// Get a new instance of the EVM.
-	evm, err := hmy.GetEVM(ctx, msg, state, header)
+	evm, err := newCallEVM(ctx, hmy, msg, state, header, overrides, blockOverrides)
 	if err != nil {
 		DoMetricRPCQueryInfo(DoEvmCall, FailedNumber)
 		return core.ExecutionResult{}, err
 	}
 
-	// Wait for the context to be done and cancel the evm. Even if the
-	// EVM has finished, cancelling may be done (repeatedly)
-	go func() {
-		<-ctx.Done()
-		evm.Cancel()
-	}()
-
 	// Setup the gas pool (also for unmetered requests)
 	// and apply the message.
 	gp := new(core.GasPool).AddGas(math.MaxUint64)
@@ -221,3 +221,39 @@ func DoEVMCall(
 	// Response output is the same for all versions
 	return result, nil
 }
+
+// newCallEVM returns the EVM to apply the call message on the state, which must
+// be a copy of the canonical state. The overrides are applied after the EVM setup,
+// so that they take precedence. The EVM is cancelled once ctx is done.
+func newCallEVM(
+	ctx context.Context, hmy *hmy.Harmony, msg core.Message, state *state.DB, header *block.Header,
+	overrides *StateOverride, blockOverrides *BlockOverrides,
+) (*vm.EVM, error) {
+	evm, err := hmy.GetEVM(ctx, msg, state, header)
+	if err != nil {
+		return nil, err
+	}
+	if err := setupCallEVM(ctx, evm, state, overrides, blockOverrides); err != nil {
+		return nil, err
+	}
+	return evm, nil
+}
+
+// setupCallEVM applies the overrides to the state and block context of the
+// EVM, and cancels the EVM once ctx is done.
+func setupCallEVM(
+	ctx context.Context, evm *vm.EVM, state *state.DB, overrides *StateOverride, blockOverrides *BlockOverrides,
+) error {
+	if err := overrides.Apply(state); err != nil {
+		return err
+	}
+	blockOverrides.Apply(&evm.Context)
+
+	// Wait for the context to be done and cancel the evm. Even if the
+	// EVM has finished, cancelling may be done (repeatedly)
+	go func() {
+		<-ctx.Done()
+		evm.Cancel()
+	}()
+	return nil
+}
@@ -19,4 +19,6 @@ var (
 	ErrUnknownRPCVersion = errors.New("API service has an unknown version")
 	// ErrTransactionNotFound when attempting to get a transaction that does not exist or has not been finalized
 	ErrTransactionNotFound = errors.New("transaction not found")
+	// ErrUnknownLightBlock when the light node has not verified the header of the block hash
+	ErrUnknownLightBlock = errors.New("block header not verified by the light node, query it by number first")
 )
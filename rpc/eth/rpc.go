@@ -2,6 +2,7 @@ package eth
 
 import (
 	"context"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/harmony-one/harmony/eth/rpc"
@@ -44,3 +45,22 @@ func (s *PublicEthService) GetBalance(
 	}
 	return (*hexutil.Big)(balance), nil
 }
+
+// MaxPriorityFeePerGas returns a suggestion for the priority fee per gas of the
+// dynamic fee transactions: the part of the suggested gas price above the base
+// fee of the current block.
+func (s *PublicEthService) MaxPriorityFeePerGas(ctx context.Context) (*hexutil.Big, error) {
+	price, err := s.hmy.SuggestPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	baseFee := s.hmy.CurrentBlock().Header().BaseFee()
+	if baseFee == nil {
+		return (*hexutil.Big)(price), nil
+	}
+	tip := new(big.Int)
+	if price.Cmp(baseFee) > 0 {
+		tip.Sub(price, baseFee)
+	}
+	return (*hexutil.Big)(tip), nil
+}
@@ -33,6 +33,7 @@ type Block struct {
 	TransactionsRoot common.Hash         `json:"transactionsRoot"`
 	ReceiptsRoot     common.Hash         `json:"receiptsRoot"`
 	Uncles           []common.Hash       `json:"uncles"`
+	BaseFee          *hexutil.Big        `json:"baseFeePerGas,omitempty"`
 }
 
 // BlockWithTxHash represents a block that will serialize to the RPC representation of a block
@@ -53,29 +54,36 @@ type BlockWithFullTx struct {
 
 // Transaction represents a transaction that will serialize to the RPC representation of a transaction
 type Transaction struct {
-	BlockHash        *common.Hash    `json:"blockHash"`
-	BlockNumber      *hexutil.Big    `json:"blockNumber"`
-	From             common.Address  `json:"from"`
-	Timestamp        hexutil.Uint64  `json:"timestamp"` // Not exposed by Ethereum anymore
-	Gas              hexutil.Uint64  `json:"gas"`
-	GasPrice         *hexutil.Big    `json:"gasPrice"`
-	Hash             common.Hash     `json:"hash"`
-	Input            hexutil.Bytes   `json:"input"`
-	Nonce            hexutil.Uint64  `json:"nonce"`
-	To               *common.Address `json:"to"`
-	TransactionIndex *hexutil.Uint64 `json:"transactionIndex"`
-	Value            *hexutil.Big    `json:"value"`
-	V                *hexutil.Big    `json:"v"`
-	R                *hexutil.Big    `json:"r"`
-	S                *hexutil.Big    `json:"s"`
+	BlockHash        *common.Hash      `json:"blockHash"`
+	BlockNumber      *hexutil.Big      `json:"blockNumber"`
+	From             common.Address    `json:"from"`
+	Timestamp        hexutil.Uint64    `json:"timestamp"` // Not exposed by Ethereum anymore
+	Gas              hexutil.Uint64    `json:"gas"`
+	GasPrice         *hexutil.Big      `json:"gasPrice"`
+	GasFeeCap        *hexutil.Big      `json:"maxFeePerGas,omitempty"`
+	GasTipCap        *hexutil.Big      `json:"maxPriorityFeePerGas,omitempty"`
+	Hash             common.Hash       `json:"hash"`
+	Input            hexutil.Bytes     `json:"input"`
+	Nonce            hexutil.Uint64    `json:"nonce"`
+	To               *common.Address   `json:"to"`
+	TransactionIndex *hexutil.Uint64   `json:"transactionIndex"`
+	Value            *hexutil.Big      `json:"value"`
+	V                *hexutil.Big      `json:"v"`
+	R                *hexutil.Big      `json:"r"`
+	S                *hexutil.Big      `json:"s"`
+	Type             hexutil.Uint64    `json:"type"`
+	ChainID          *hexutil.Big      `json:"chainId,omitempty"`
+	Accesses         *types.AccessList `json:"accessList,omitempty"`
 }
 
 // NewTransaction returns a transaction that will serialize to the RPC
 // representation, with the given location metadata set (if available).
 // Note that all txs on Harmony are replay protected (post EIP155 epoch).
+// The base fee of the block, nil if pending or before EIP-1559, sets the gas
+// price actually paid by the dynamic fee transactions.
 func NewTransaction(
 	from common.Address, tx *types.EthTransaction, blockHash common.Hash,
-	blockNumber uint64, timestamp uint64, index uint64,
+	blockNumber uint64, timestamp uint64, index uint64, baseFee *big.Int,
 ) (*Transaction, error) {
 	v, r, s := tx.RawSignatureValues()
 
@@ -92,6 +100,24 @@ func NewTransaction(
 		V:         (*hexutil.Big)(v),
 		R:         (*hexutil.Big)(r),
 		S:         (*hexutil.Big)(s),
+		Type:      hexutil.Uint64(tx.Type()),
+	}
+	if tx.Type() != types.LegacyTxType {
+		al := tx.AccessList()
+		result.Accesses = &al
+		result.ChainID = (*hexutil.Big)(tx.ChainID())
+	}
+	if tx.Type() == types.DynamicFeeTxType {
+		result.GasFeeCap = (*hexutil.Big)(tx.GasFeeCap())
+		result.GasTipCap = (*hexutil.Big)(tx.GasTipCap())
+		// The mined transactions paid the base fee plus the tip, up to the fee cap
+		if blockHash != (common.Hash{}) && baseFee != nil {
+			price := new(big.Int).Add(tx.GasTipCap(), baseFee)
+			if price.Cmp(tx.GasFeeCap()) > 0 {
+				price = tx.GasFeeCap()
+			}
+			result.GasPrice = (*hexutil.Big)(price)
+		}
 	}
 	if blockHash != (common.Hash{}) {
 		result.BlockHash = &blockHash
@@ -121,6 +147,7 @@ func NewReceipt(senderAddr common.Address, tx *types.EthTransaction, blockHash c
 		"contractAddress":   nil,
 		"logs":              receipt.Logs,
 		"logsBloom":         receipt.Bloom,
+		"type":              hexutil.Uint(tx.Type()),
 	}
 
 	// Assign receipt status or post state.
@@ -170,6 +197,7 @@ func newBlock(b *types.Block) *Block {
 		TransactionsRoot: head.TxHash(),
 		ReceiptsRoot:     head.ReceiptHash(),
 		Uncles:           []common.Hash{},
+		BaseFee:          (*hexutil.Big)(head.BaseFee()),
 	}
 }
 
@@ -198,7 +226,7 @@ func blockWithFullTxFromBlock(b *types.Block) (*BlockWithFullTx, error) {
 		if err != nil {
 			return nil, err
 		}
-		fmtTx, err := NewTransaction(from, tx.ConvertToEth(), b.Hash(), b.NumberU64(), b.Time().Uint64(), uint64(idx))
+		fmtTx, err := NewTransaction(from, tx.ConvertToEth(), b.Hash(), b.NumberU64(), b.Time().Uint64(), uint64(idx), b.Header().BaseFee())
 		if err != nil {
 			return nil, err
 		}
@@ -220,5 +248,5 @@ func NewTransactionFromBlockIndex(b *types.Block, index uint64) (*Transaction, e
 	if err != nil {
 		return nil, err
 	}
-	return NewTransaction(from, tx, b.Hash(), b.NumberU64(), b.Time().Uint64(), index)
+	return NewTransaction(from, tx, b.Hash(), b.NumberU64(), b.Time().Uint64(), index, b.Header().BaseFee())
 }
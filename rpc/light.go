@@ -0,0 +1,206 @@
+package rpc
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+
+	"github.com/harmony-one/harmony/api/service/lightnode"
+	"github.com/harmony-one/harmony/block"
+	"github.com/harmony-one/harmony/eth/rpc"
+	internal_common "github.com/harmony-one/harmony/internal/common"
+	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
+)
+
+// PublicLightService provides the RPC methods of a light node. The headers are
+// verified against their commit signatures, and the bodies, receipts and proofs
+// fetched on demand are verified against the headers.
+type PublicLightService struct {
+	backend *lightnode.Backend
+	version Version
+	limiter *rate.Limiter
+}
+
+// NewPublicLightAPI creates a new API for the RPC interface of a light node
+func NewPublicLightAPI(backend *lightnode.Backend, version Version, limiterEnable bool, limit int) rpc.API {
+	var limiter *rate.Limiter
+	if limiterEnable {
+		limiter = rate.NewLimiter(rate.Limit(limit), limit)
+	}
+	return rpc.API{
+		Namespace: version.Namespace(),
+		Version:   APIVersion,
+		Service:   &PublicLightService{backend, version, limiter},
+		Public:    true,
+	}
+}
+
+func (s *PublicLightService) wait(limiter *rate.Limiter, ctx context.Context) error {
+	if limiter != nil {
+		deadlineCtx, cancel := context.WithTimeout(ctx, DefaultRateLimiterWaitTimeout)
+		defer cancel()
+		if !limiter.Allow() {
+			name := reflect.TypeOf(limiter).Elem().Name()
+			rpcRateLimitCounterVec.With(prometheus.Labels{
+				"limiter_name": name,
+			}).Inc()
+		}
+
+		return limiter.Wait(deadlineCtx)
+	}
+	return nil
+}
+
+// BlockNumber returns the block number of the verified chain head.
+func (s *PublicLightService) BlockNumber(ctx context.Context) (interface{}, error) {
+	header := s.backend.CurrentHeader()
+
+	// Format return base on version
+	switch s.version {
+	case V1, Eth:
+		return hexutil.Uint64(header.Number().Uint64()), nil
+	case V2:
+		return header.Number().Uint64(), nil
+	default:
+		return nil, ErrUnknownRPCVersion
+	}
+}
+
+// GetHeaderByNumber returns the verified block header at given number
+func (s *PublicLightService) GetHeaderByNumber(
+	ctx context.Context, blockNumber BlockNumber,
+) (StructuredResponse, error) {
+	timer := DoMetricRPCRequest(GetHeaderByNumber)
+	defer DoRPCRequestDuration(GetHeaderByNumber, timer)
+
+	err := s.wait(s.limiter, ctx)
+	if err != nil {
+		DoMetricRPCQueryInfo(GetHeaderByNumber, RateLimitedNumber)
+		return nil, err
+	}
+
+	header, err := s.headerByNumber(ctx, blockNumber.EthBlockNumber())
+	if err != nil {
+		DoMetricRPCQueryInfo(GetHeaderByNumber, FailedNumber)
+		return nil, err
+	}
+	// Response output is the same for all versions
+	var leader string
+	if addr, err := s.backend.Leader(header); err == nil {
+		leader, _ = internal_common.AddressToBech32(addr)
+	}
+	return NewStructuredResponse(NewHeaderInformation(header, leader))
+}
+
+// GetBlockReceipts returns all transaction receipts for a particular block, verified
+// against its header. The header of the block hash is to be verified already.
+func (s *PublicLightService) GetBlockReceipts(
+	ctx context.Context, blockHash common.Hash,
+) ([]StructuredResponse, error) {
+	timer := DoMetricRPCRequest(GetBlockReceipts)
+	defer DoRPCRequestDuration(GetBlockReceipts, timer)
+
+	err := s.wait(s.limiter, ctx)
+	if err != nil {
+		DoMetricRPCQueryInfo(GetBlockReceipts, RateLimitedNumber)
+		return nil, err
+	}
+
+	header := s.backend.HeaderByHash(blockHash)
+	if header == nil {
+		return nil, ErrUnknownLightBlock
+	}
+	blk, err := s.backend.BlockByHeader(ctx, header)
+	if err != nil {
+		return nil, err
+	}
+	receipts, err := s.backend.GetReceipts(ctx, blk)
+	if err != nil {
+		return nil, err
+	}
+	return newBlockReceipts(s.version, blk, receipts)
+}
+
+// GetProof returns the account and storage values of the specified account including
+// the merkle proofs, verified against the state root of the verified block header.
+func (s *PublicLightService) GetProof(
+	ctx context.Context, address common.Address, storageKeys []string, blockNrOrHash rpc.BlockNumberOrHash) (ret *AccountResult, err error) {
+	timer := DoMetricRPCRequest(GetProof)
+	defer DoRPCRequestDuration(GetProof, timer)
+
+	defer func() {
+		if ret == nil || err != nil {
+			DoMetricRPCQueryInfo(GetProof, FailedNumber)
+		}
+	}()
+
+	err = s.wait(s.limiter, ctx)
+	if err != nil {
+		DoMetricRPCQueryInfo(GetProof, RateLimitedNumber)
+		return
+	}
+
+	var header *block.Header
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		if header = s.backend.HeaderByHash(hash); header == nil {
+			return nil, ErrUnknownLightBlock
+		}
+	} else {
+		number, _ := blockNrOrHash.Number()
+		if header, err = s.headerByNumber(ctx, number); err != nil {
+			return nil, err
+		}
+	}
+
+	keys := make([]common.Hash, 0, len(storageKeys))
+	for _, key := range storageKeys {
+		keys = append(keys, common.HexToHash(key))
+	}
+	ap, err := s.backend.GetProof(ctx, header, address, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	storageProof := make([]StorageResult, 0, len(ap.StorageProofs))
+	for i, sp := range ap.StorageProofs {
+		storageProof = append(storageProof, StorageResult{storageKeys[i], (*hexutil.Big)(sp.Value), toHexSlice(sp.Proof)})
+	}
+	return &AccountResult{
+		Address:      address,
+		AccountProof: toHexSlice(ap.Proof),
+		Balance:      (*hexutil.Big)(ap.Balance),
+		CodeHash:     ap.CodeHash,
+		Nonce:        hexutil.Uint64(ap.Nonce),
+		StorageHash:  ap.StorageHash,
+		StorageProof: storageProof,
+	}, nil
+}
+
+// headerByNumber returns the verified header of the block number, the latest
+// and pending numbers being the verified chain head
+func (s *PublicLightService) headerByNumber(ctx context.Context, blockNum rpc.BlockNumber) (*block.Header, error) {
+	current := s.backend.CurrentHeader()
+	if blockNum < 0 {
+		return current, nil
+	}
+	if uint64(blockNum) > current.Number().Uint64() {
+		return nil, ErrRequestedBlockTooHigh
+	}
+	return s.backend.HeaderByNumber(ctx, uint64(blockNum))
+}
+
+func getLightAPIs(backend *lightnode.Backend, config nodeconfig.RPCServerConfig) []rpc.API {
+	apis := []rpc.API{
+		NewPublicLightAPI(backend, V1, config.RateLimiterEnabled, config.RequestsPerSecond),
+		NewPublicLightAPI(backend, V2, config.RateLimiterEnabled, config.RequestsPerSecond),
+		NewPublicWeb3API(),
+	}
+	if config.EthRPCsEnabled {
+		apis = append(apis, NewPublicLightAPI(backend, Eth, config.RateLimiterEnabled, config.RequestsPerSecond))
+	}
+	return apis
+}
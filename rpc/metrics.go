@@ -41,10 +41,12 @@ const (
 	SetNodeToBackupMode      = "SetNodeToBackupMode"
 
 	// contract
-	GetCode      = "GetCode"
-	GetStorageAt = "GetStorageAt"
-	Call         = "Call"
-	DoEvmCall    = "DoEVMCall"
+	GetCode          = "GetCode"
+	GetStorageAt     = "GetStorageAt"
+	Call             = "Call"
+	CallBundle       = "CallBundle"
+	CreateAccessList = "CreateAccessList"
+	DoEvmCall        = "DoEVMCall"
 
 	// net
 	PeerCount  = "PeerCount"
@@ -60,6 +62,13 @@ const (
 	GetCurrentStakingErrorSink     = "GetCurrentStakingErrorSink"
 	GetPendingCXReceipts           = "GetPendingCXReceipts"
 
+	// txpool
+	TxPoolContent     = "TxPoolContent"
+	TxPoolContentFrom = "TxPoolContentFrom"
+	TxPoolInspect     = "TxPoolInspect"
+	TxPoolStatus      = "TxPoolStatus"
+	TxPoolNonceGaps   = "TxPoolNonceGaps"
+
 	// staking
 	GetTotalStaking                         = "GetTotalStaking"
 	GetMedianRawStakeSnapshot               = "GetMedianRawStakeSnapshot"
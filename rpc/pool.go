@@ -85,7 +85,7 @@ func (s *PublicPoolService) SendRawTransaction(
 
 	if s.version == Eth {
 		ethTx := new(types.EthTransaction)
-		if err := rlp.DecodeBytes(encodedTx, ethTx); err != nil {
+		if err := ethTx.UnmarshalBinary(encodedTx); err != nil {
 			return common.Hash{}, err
 		}
 		txHash = ethTx.Hash()
@@ -260,7 +260,7 @@ func (s *PublicPoolService) PendingTransactions(
 						Msgf("%v error at %v", LogTag, "PendingTransactions")
 					continue // Legacy behavior is to not return error here
 				}
-				tx, err = eth.NewTransaction(from, plainTx.ConvertToEth(), common.Hash{}, 0, 0, 0)
+				tx, err = eth.NewTransaction(from, plainTx.ConvertToEth(), common.Hash{}, 0, 0, 0, nil)
 				if err != nil {
 					utils.Logger().Debug().
 						Err(err).
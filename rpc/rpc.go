@@ -5,6 +5,7 @@ import (
 	"net"
 	"strings"
 
+	"github.com/harmony-one/harmony/api/service/lightnode"
 	"github.com/harmony-one/harmony/eth/rpc"
 	"github.com/harmony-one/harmony/hmy"
 	"github.com/harmony-one/harmony/internal/configs/harmony"
@@ -34,26 +35,30 @@ const (
 	// WSPortOffset ..
 	WSPortOffset = 800
 
-	netNamespace   = "net"
-	netV1Namespace = "netv1"
-	netV2Namespace = "netv2"
-	web3Namespace  = "web3"
+	netNamespace    = "net"
+	netV1Namespace  = "netv1"
+	netV2Namespace  = "netv2"
+	web3Namespace   = "web3"
+	txPoolNamespace = "txpool"
 )
 
 var (
 	// HTTPModules ..
-	HTTPModules = []string{"hmy", "hmyv2", "eth", "debug", "trace", netNamespace, netV1Namespace, netV2Namespace, web3Namespace, "explorer", "preimages"}
+	HTTPModules = []string{"hmy", "hmyv2", "eth", "debug", "trace", netNamespace, netV1Namespace, netV2Namespace, web3Namespace, txPoolNamespace, "explorer", "preimages"}
 	// WSModules ..
-	WSModules = []string{"hmy", "hmyv2", "eth", "debug", "trace", netNamespace, netV1Namespace, netV2Namespace, web3Namespace, "web3"}
+	WSModules = []string{"hmy", "hmyv2", "eth", "debug", "trace", netNamespace, netV1Namespace, netV2Namespace, web3Namespace, txPoolNamespace, "web3"}
 
 	httpListener     net.Listener
 	httpHandler      *rpc.Server
 	wsListener       net.Listener
 	wsHandler        *rpc.Server
+	ipcListener      net.Listener
+	ipcHandler       *rpc.Server
 	httpEndpoint     = ""
 	httpAuthEndpoint = ""
 	wsEndpoint       = ""
 	wsAuthEndpoint   = ""
+	ipcEndpoint      = ""
 	httpVirtualHosts = []string{"*"}
 	httpOrigins      = []string{"*"}
 	wsOrigins        = []string{"*"}
@@ -67,13 +72,24 @@ func (n Version) Namespace() string {
 	return HTTPModules[n]
 }
 
-// StartServers starts the http & ws servers
+// StartServers starts the http, ws & ipc servers
 func StartServers(hmy *hmy.Harmony, apis []rpc.API, config nodeconfig.RPCServerConfig, rpcOpt harmony.RpcOptConfig) error {
 	apis = append(apis, getAPIs(hmy, config)...)
 	authApis := append(apis, getAuthAPIs(hmy, config.DebugEnabled, config.RateLimiterEnabled, config.RequestsPerSecond)...)
 	if rpcOpt.PreimagesEnabled {
 		authApis = append(authApis, NewPreimagesAPI(hmy, "preimages"))
 	}
+	return startServers(apis, authApis, config, rpcOpt)
+}
+
+// StartLightServers starts the http, ws & ipc servers of a light node, serving
+// only the data verified by the light node
+func StartLightServers(backend *lightnode.Backend, config nodeconfig.RPCServerConfig, rpcOpt harmony.RpcOptConfig) error {
+	apis := getLightAPIs(backend, config)
+	return startServers(apis, apis, config, rpcOpt)
+}
+
+func startServers(apis, authApis []rpc.API, config nodeconfig.RPCServerConfig, rpcOpt harmony.RpcOptConfig) error {
 	// load method filter from file (if exist)
 	var rmf rpc.RpcMethodFilter
 	rpcFilterFilePath := strings.TrimSpace(rpcOpt.RpcFilterFile)
@@ -84,6 +100,7 @@ func StartServers(hmy *hmy.Harmony, apis []rpc.API, config nodeconfig.RPCServerC
 	} else {
 		rmf.ExposeAll()
 	}
+	limiter := newClientLimiter(rpcOpt)
 	if config.HTTPEnabled {
 		timeouts := rpc.HTTPTimeouts{
 			ReadTimeout:  config.HTTPTimeoutRead,
@@ -91,24 +108,39 @@ func StartServers(hmy *hmy.Harmony, apis []rpc.API, config nodeconfig.RPCServerC
 			IdleTimeout:  config.HTTPTimeoutIdle,
 		}
 		httpEndpoint = fmt.Sprintf("%v:%v", config.HTTPIp, config.HTTPPort)
-		if err := startHTTP(apis, &rmf, timeouts); err != nil {
+		if err := startHTTP(apis, &rmf, timeouts, limiter); err != nil {
 			return err
 		}
 
 		httpAuthEndpoint = fmt.Sprintf("%v:%v", config.HTTPIp, config.HTTPAuthPort)
-		if err := startAuthHTTP(authApis, &rmf, timeouts); err != nil {
+		jwtSecret, err := loadJWTSecret(config.HTTPAuthJWTSecret)
+		if err != nil {
+			return err
+		}
+		if err := startAuthHTTP(authApis, &rmf, timeouts, jwtSecret, limiter); err != nil {
 			return err
 		}
 	}
 
 	if config.WSEnabled {
 		wsEndpoint = fmt.Sprintf("%v:%v", config.WSIp, config.WSPort)
-		if err := startWS(apis, &rmf); err != nil {
+		if err := startWS(apis, &rmf, limiter); err != nil {
 			return err
 		}
 
 		wsAuthEndpoint = fmt.Sprintf("%v:%v", config.WSIp, config.WSAuthPort)
-		if err := startAuthWS(authApis, &rmf); err != nil {
+		jwtSecret, err := loadJWTSecret(config.WSAuthJWTSecret)
+		if err != nil {
+			return err
+		}
+		if err := startAuthWS(authApis, &rmf, jwtSecret, limiter); err != nil {
+			return err
+		}
+	}
+
+	if config.IPCEnabled {
+		ipcEndpoint = config.IPCPath
+		if err := startIPC(authApis, &rmf); err != nil {
 			return err
 		}
 	}
@@ -116,7 +148,7 @@ func StartServers(hmy *hmy.Harmony, apis []rpc.API, config nodeconfig.RPCServerC
 	return nil
 }
 
-// StopServers stops the http & ws servers
+// StopServers stops the http, ws & ipc servers
 func StopServers() error {
 	if httpListener != nil {
 		if err := httpListener.Close(); err != nil {
@@ -144,9 +176,54 @@ func StopServers() error {
 		wsHandler.Stop()
 		wsHandler = nil
 	}
+	if ipcListener != nil {
+		if err := ipcListener.Close(); err != nil {
+			return err
+		}
+		ipcListener = nil
+		utils.Logger().Info().
+			Str("path", ipcEndpoint).
+			Msg("IPC endpoint closed")
+	}
+	if ipcHandler != nil {
+		ipcHandler.Stop()
+		ipcHandler = nil
+	}
 	return nil
 }
 
+// loadJWTSecret loads the jwt secret of an auth endpoint, if a secret file is set
+func loadJWTSecret(file string) ([]byte, error) {
+	if file == "" {
+		return nil, nil
+	}
+	secret, err := rpc.ObtainJWTSecret(file)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load jwt secret: %v", err)
+	}
+	return secret, nil
+}
+
+// newClientLimiter returns the per-client rate limiter of the RPC endpoints,
+// or nil if disabled
+func newClientLimiter(rpcOpt harmony.RpcOptConfig) *rpc.ClientLimiter {
+	if !rpcOpt.ClientRateLimiterEnabled {
+		return nil
+	}
+	rps := rpcOpt.ClientRequestsPerSecond
+	if rps <= 0 {
+		rps = nodeconfig.DefaultRPCClientRateLimit
+	}
+	return rpc.NewClientLimiter(rpc.ClientLimiterConfig{
+		RequestsPerSecond: rps,
+		Burst:             rpcOpt.ClientBurst,
+		KeyHeader:         rpcOpt.ClientKeyHeader,
+		Keys:              rpcOpt.ClientKeys,
+		Whitelist:         rpcOpt.ClientWhitelist,
+		MethodCosts:       rpcOpt.MethodCosts,
+	})
+}
+
 func getAuthAPIs(hmy *hmy.Harmony, debugEnable bool, rateLimiterEnable bool, ratelimit int) []rpc.API {
 	return []rpc.API{
 		NewPublicTraceAPI(hmy, Debug), // Debug version means geth trace rpc
@@ -168,6 +245,7 @@ func getAPIs(hmy *hmy.Harmony, config nodeconfig.RPCServerConfig) []rpc.API {
 		NewPublicTransactionAPI(hmy, V2),
 		NewPublicPoolAPI(hmy, V1, config.RateLimiterEnabled, config.RequestsPerSecond),
 		NewPublicPoolAPI(hmy, V2, config.RateLimiterEnabled, config.RequestsPerSecond),
+		NewPublicTxPoolAPI(hmy, config.RateLimiterEnabled, config.RequestsPerSecond),
 	}
 
 	// Legacy methods (subject to removal)
@@ -214,9 +292,9 @@ func getAPIs(hmy *hmy.Harmony, config nodeconfig.RPCServerConfig) []rpc.API {
 	return publicAPIs
 }
 
-func startHTTP(apis []rpc.API, rmf *rpc.RpcMethodFilter, httpTimeouts rpc.HTTPTimeouts) (err error) {
+func startHTTP(apis []rpc.API, rmf *rpc.RpcMethodFilter, httpTimeouts rpc.HTTPTimeouts, limiter *rpc.ClientLimiter) (err error) {
 	httpListener, httpHandler, err = rpc.StartHTTPEndpoint(
-		httpEndpoint, apis, HTTPModules, rmf, httpOrigins, httpVirtualHosts, httpTimeouts,
+		httpEndpoint, apis, HTTPModules, rmf, httpOrigins, httpVirtualHosts, httpTimeouts, nil, limiter,
 	)
 	if err != nil {
 		return err
@@ -231,9 +309,9 @@ func startHTTP(apis []rpc.API, rmf *rpc.RpcMethodFilter, httpTimeouts rpc.HTTPTi
 	return nil
 }
 
-func startAuthHTTP(apis []rpc.API, rmf *rpc.RpcMethodFilter, httpTimeouts rpc.HTTPTimeouts) (err error) {
+func startAuthHTTP(apis []rpc.API, rmf *rpc.RpcMethodFilter, httpTimeouts rpc.HTTPTimeouts, jwtSecret []byte, limiter *rpc.ClientLimiter) (err error) {
 	httpListener, httpHandler, err = rpc.StartHTTPEndpoint(
-		httpAuthEndpoint, apis, HTTPModules, rmf, httpOrigins, httpVirtualHosts, httpTimeouts,
+		httpAuthEndpoint, apis, HTTPModules, rmf, httpOrigins, httpVirtualHosts, httpTimeouts, jwtSecret, limiter,
 	)
 	if err != nil {
 		return err
@@ -243,13 +321,14 @@ func startAuthHTTP(apis []rpc.API, rmf *rpc.RpcMethodFilter, httpTimeouts rpc.HT
 		Str("url", fmt.Sprintf("http://%s", httpAuthEndpoint)).
 		Str("cors", strings.Join(httpOrigins, ",")).
 		Str("vhosts", strings.Join(httpVirtualHosts, ",")).
+		Bool("jwt", len(jwtSecret) != 0).
 		Msg("HTTP endpoint opened")
 	fmt.Printf("Started Auth-RPC server at: %v\n", httpAuthEndpoint)
 	return nil
 }
 
-func startWS(apis []rpc.API, rmf *rpc.RpcMethodFilter) (err error) {
-	wsListener, wsHandler, err = rpc.StartWSEndpoint(wsEndpoint, apis, WSModules, rmf, wsOrigins, true)
+func startWS(apis []rpc.API, rmf *rpc.RpcMethodFilter, limiter *rpc.ClientLimiter) (err error) {
+	wsListener, wsHandler, err = rpc.StartWSEndpoint(wsEndpoint, apis, WSModules, rmf, wsOrigins, true, nil, limiter)
 	if err != nil {
 		return err
 	}
@@ -261,15 +340,31 @@ func startWS(apis []rpc.API, rmf *rpc.RpcMethodFilter) (err error) {
 	return nil
 }
 
-func startAuthWS(apis []rpc.API, rmf *rpc.RpcMethodFilter) (err error) {
-	wsListener, wsHandler, err = rpc.StartWSEndpoint(wsAuthEndpoint, apis, WSModules, rmf, wsOrigins, true)
+func startAuthWS(apis []rpc.API, rmf *rpc.RpcMethodFilter, jwtSecret []byte, limiter *rpc.ClientLimiter) (err error) {
+	wsListener, wsHandler, err = rpc.StartWSEndpoint(wsAuthEndpoint, apis, WSModules, rmf, wsOrigins, true, jwtSecret, limiter)
 	if err != nil {
 		return err
 	}
 
 	utils.Logger().Info().
 		Str("url", fmt.Sprintf("ws://%s", wsListener.Addr())).
+		Bool("jwt", len(jwtSecret) != 0).
 		Msg("WebSocket endpoint opened")
 	fmt.Printf("Started Auth-WS server at: %v\n", wsAuthEndpoint)
 	return nil
 }
+
+// startIPC starts the IPC endpoint, with no auth other than the permissions
+// of the unix socket
+func startIPC(apis []rpc.API, rmf *rpc.RpcMethodFilter) (err error) {
+	ipcListener, ipcHandler, err = rpc.StartIPCEndpoint(ipcEndpoint, apis, rmf)
+	if err != nil {
+		return err
+	}
+
+	utils.Logger().Info().
+		Str("path", ipcEndpoint).
+		Msg("IPC endpoint opened")
+	fmt.Printf("Started IPC server at: %v\n", ipcEndpoint)
+	return nil
+}
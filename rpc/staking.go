@@ -651,6 +651,8 @@ func (s *PublicStakingService) GetDelegationsByDelegator(
 			Amount:           delegation.Amount,
 			Reward:           delegation.Reward,
 			Undelegations:    undelegations,
+			Redelegations:    NewRedelegations(delegation.Redelegations),
+			AutoCompound:     delegation.AutoCompound,
 		})
 		if err != nil {
 			DoMetricRPCQueryInfo(GetDelegationsByDelegator, FailedNumber)
@@ -725,6 +727,8 @@ func (s *PublicStakingService) parseGetDelegationsByDelegatorResp(
 			Amount:           delegation.Amount,
 			Reward:           delegation.Reward,
 			Undelegations:    undelegations,
+			Redelegations:    NewRedelegations(delegation.Redelegations),
+			AutoCompound:     delegation.AutoCompound,
 		})
 		if err != nil {
 			return nil, err
@@ -788,6 +792,8 @@ func (s *PublicStakingService) getDelegationByValidatorHelper(address string) ([
 			Amount:           delegation.Amount,
 			Reward:           delegation.Reward,
 			Undelegations:    undelegations,
+			Redelegations:    NewRedelegations(delegation.Redelegations),
+			AutoCompound:     delegation.AutoCompound,
 		}.IntoStructuredResponse()
 		result = append(result, del)
 	}
@@ -843,6 +849,8 @@ func (s *PublicStakingService) GetDelegationByDelegatorAndValidator(
 			Amount:           delegation.Amount,
 			Reward:           delegation.Reward,
 			Undelegations:    undelegations,
+			Redelegations:    NewRedelegations(delegation.Redelegations),
+			AutoCompound:     delegation.AutoCompound,
 		})
 	}
 	return nil, nil
@@ -48,6 +48,14 @@ var (
 	ErrNotAvailable = errors.New("RPC not available yet")
 )
 
+// TraceCallConfig is the config for traceCall API. It holds the overrides of
+// the state and block the call is traced on, on top of the trace config.
+type TraceCallConfig struct {
+	hmy.TraceConfig
+	StateOverrides *StateOverride
+	BlockOverrides *BlockOverrides
+}
+
 // PublicTracerService provides an API to access Harmony's staking services.
 // It offers only methods that operate on public data that is freely available to anyone.
 type PublicTracerService struct {
@@ -176,8 +184,9 @@ func (s *PublicTracerService) TraceTransaction(ctx context.Context, hash common.
 // TraceCall lets you trace a given eth_call. It collects the structured logs created during the execution of EVM
 // if the given transaction was added on top of the provided block and returns them as a JSON object.
 // You can provide -2 as a block number to trace on top of the pending block.
+// The state and block overrides of the config are applied on a copy of the state.
 // NOTE: Our version only supports block number as an input
-func (s *PublicTracerService) TraceCall(ctx context.Context, args CallArgs, blockNr rpc.BlockNumber, config *hmy.TraceConfig) (interface{}, error) {
+func (s *PublicTracerService) TraceCall(ctx context.Context, args CallArgs, blockNr rpc.BlockNumber, config *TraceCallConfig) (interface{}, error) {
 	timer := DoMetricRPCRequest(TraceCall)
 	defer DoRPCRequestDuration(TraceCall, timer)
 
@@ -205,6 +214,19 @@ func (s *PublicTracerService) TraceCall(ctx context.Context, args CallArgs, bloc
 	// Execute the trace
 	msg := args.ToMessage(s.hmy.RPCGasCap)
 	vmctx := core.NewEVMContext(msg, header, s.hmy.BlockChain, nil)
-	// Trace the transaction and return
-	return s.hmy.TraceTx(ctx, msg, vmctx, statedb, config)
+
+	var traceConfig *hmy.TraceConfig
+	if config != nil {
+		statedb = statedb.Copy()
+		if err := config.StateOverrides.Apply(statedb); err != nil {
+			DoMetricRPCQueryInfo(TraceCall, FailedNumber)
+			return nil, err
+		}
+		config.BlockOverrides.Apply(&vmctx)
+		traceConfig = &config.TraceConfig
+	}
+	// Trace the call and return, the calls with no gas price skip the base fee
+	// check as eth_call does
+	trace, _, err := s.hmy.TraceMessage(ctx, msg, vmctx, statedb, traceConfig, true)
+	return trace, err
 }
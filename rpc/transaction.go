@@ -161,9 +161,11 @@ func (s *PublicTransactionService) GetStakingTransactionsCount(
 }
 
 // EstimateGas returns an estimate of the amount of gas needed to execute the
-// given transaction against the current pending block.
+// given transaction against the current pending block. The optional overrides
+// are applied to the state and block the estimation runs on.
 func (s *PublicTransactionService) EstimateGas(
 	ctx context.Context, args CallArgs, blockNrOrHash *rpc.BlockNumberOrHash,
+	overrides *StateOverride, blockOverrides *BlockOverrides,
 ) (hexutil.Uint64, error) {
 	timer := DoMetricRPCRequest(RpcEstimateGas)
 	defer DoRPCRequestDuration(RpcEstimateGas, timer)
@@ -171,7 +173,7 @@ func (s *PublicTransactionService) EstimateGas(
 	if blockNrOrHash != nil {
 		bNrOrHash = *blockNrOrHash
 	}
-	gas, err := EstimateGas(ctx, s.hmy, args, bNrOrHash, nil)
+	gas, err := EstimateGas(ctx, s.hmy, args, bNrOrHash, overrides, blockOverrides, nil)
 	if err != nil {
 		return 0, err
 	}
@@ -192,7 +194,7 @@ func (s *PublicTransactionService) GetTransactionByHash(
 		// Try to return a pending transaction
 		if tx := s.hmy.TxPool.Get(hash); tx != nil {
 			if plainTx, ok := tx.(*types.Transaction); ok {
-				return s.newRPCTransaction(plainTx, common.Hash{}, 0, 0, 0)
+				return s.newRPCTransaction(plainTx, common.Hash{}, 0, 0, 0, nil)
 			}
 		}
 
@@ -213,11 +215,11 @@ func (s *PublicTransactionService) GetTransactionByHash(
 		return nil, nil
 	}
 
-	return s.newRPCTransaction(tx, blockHash, blockNumber, block.Time().Uint64(), index)
+	return s.newRPCTransaction(tx, blockHash, blockNumber, block.Time().Uint64(), index, block.BaseFee())
 }
 
 func (s *PublicTransactionService) newRPCTransaction(tx *types.Transaction, blockHash common.Hash,
-	blockNumber uint64, timestamp uint64, index uint64) (StructuredResponse, error) {
+	blockNumber uint64, timestamp uint64, index uint64, baseFee *big.Int) (StructuredResponse, error) {
 
 	// Format the response according to the version
 	switch s.version {
@@ -242,7 +244,7 @@ func (s *PublicTransactionService) newRPCTransaction(tx *types.Transaction, bloc
 			DoMetricRPCQueryInfo(GetTransactionByHash, FailedNumber)
 			return nil, err
 		}
-		tx, err := eth.NewTransaction(senderAddr, tx.ConvertToEth(), blockHash, blockNumber, timestamp, index)
+		tx, err := eth.NewTransaction(senderAddr, tx.ConvertToEth(), blockHash, blockNumber, timestamp, index, baseFee)
 		if err != nil {
 			DoMetricRPCQueryInfo(GetTransactionByHash, FailedNumber)
 			return nil, err
@@ -848,7 +850,10 @@ func returnHashesWithPagination(hashes []common.Hash, pageIndex uint32, pageSize
 }
 
 // EstimateGas - estimate gas cost for a given operation
-func EstimateGas(ctx context.Context, hmy *hmy.Harmony, args CallArgs, blockNrOrHash rpc.BlockNumberOrHash, gasCap *big.Int) (uint64, error) {
+func EstimateGas(
+	ctx context.Context, hmy *hmy.Harmony, args CallArgs, blockNrOrHash rpc.BlockNumberOrHash,
+	overrides *StateOverride, blockOverrides *BlockOverrides, gasCap *big.Int,
+) (uint64, error) {
 	// Binary search the gas requirement, as it may be higher than the amount used
 	var (
 		lo  uint64 = params.TxGas - 1
@@ -862,6 +867,8 @@ func EstimateGas(ctx context.Context, hmy *hmy.Harmony, args CallArgs, blockNrOr
 	// Determine the highest gas limit can be used during the estimation.
 	if args.Gas != nil && uint64(*args.Gas) >= params.TxGas {
 		hi = uint64(*args.Gas)
+	} else if blockOverrides != nil && blockOverrides.GasLimit != nil {
+		hi = uint64(*blockOverrides.GasLimit)
 	} else {
 
 		// Retrieve the block to act as the gas ceiling
@@ -877,6 +884,10 @@ func EstimateGas(ctx context.Context, hmy *hmy.Harmony, args CallArgs, blockNrOr
 		if err != nil {
 			return 0, err
 		}
+		state = state.Copy()
+		if err := overrides.Apply(state); err != nil {
+			return 0, err
+		}
 		balance := state.GetBalance(*args.From) // from can't be nil
 		available := new(big.Int).Set(balance)
 		if args.Value != nil {
@@ -909,7 +920,7 @@ func EstimateGas(ctx context.Context, hmy *hmy.Harmony, args CallArgs, blockNrOr
 	executable := func(gas uint64) (bool, *core.ExecutionResult, error) {
 		args.Gas = (*hexutil.Uint64)(&gas)
 
-		result, err := DoEVMCall(ctx, hmy, args, blockNrOrHash, 0)
+		result, err := DoEVMCall(ctx, hmy, args, blockNrOrHash, overrides, blockOverrides, 0)
 		if err != nil {
 			if errors.Is(err, core.ErrIntrinsicGas) {
 				return true, nil, nil // Special case, raise gas limit
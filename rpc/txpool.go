@@ -0,0 +1,292 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/eth/rpc"
+	"github.com/harmony-one/harmony/hmy"
+	eth "github.com/harmony-one/harmony/rpc/eth"
+	v2 "github.com/harmony-one/harmony/rpc/v2"
+	staking "github.com/harmony-one/harmony/staking/types"
+)
+
+// PublicTxPoolService offers the geth compatible txpool RPC methods, to inspect
+// the plain and staking transactions of the Harmony node's transaction pool.
+// The plain transactions are in the eth format, the staking ones in the hmyv2 format.
+type PublicTxPoolService struct {
+	hmy *hmy.Harmony
+
+	// the content methods format many transactions
+	limiterContent *rate.Limiter
+}
+
+// NewPublicTxPoolAPI creates a new txpool API for the RPC interface
+func NewPublicTxPoolAPI(hmy *hmy.Harmony, limiterEnable bool, limit int) rpc.API {
+	var limiter *rate.Limiter
+	if limiterEnable {
+		limiter = rate.NewLimiter(rate.Limit(limit), limit)
+	}
+	return rpc.API{
+		Namespace: txPoolNamespace,
+		Version:   APIVersion,
+		Service:   &PublicTxPoolService{hmy, limiter},
+		Public:    true,
+	}
+}
+
+func (s *PublicTxPoolService) wait(limiter *rate.Limiter, ctx context.Context) error {
+	if limiter != nil {
+		deadlineCtx, cancel := context.WithTimeout(ctx, DefaultRateLimiterWaitTimeout)
+		defer cancel()
+		if !limiter.Allow() {
+			name := reflect.TypeOf(limiter).Elem().Name()
+			rpcRateLimitCounterVec.With(prometheus.Labels{
+				"limiter_name": name,
+			}).Inc()
+		}
+
+		return limiter.Wait(deadlineCtx)
+	}
+	return nil
+}
+
+// TxPoolNonceRange is a range of nonces, both ends included
+type TxPoolNonceRange struct {
+	From hexutil.Uint64 `json:"from"`
+	To   hexutil.Uint64 `json:"to"`
+}
+
+// TxPoolNonceGap tells why the queued transactions of a sender are not
+// executable. The transactions of a sender are executed in nonce order starting
+// at its next nonce, so every missing nonce blocks the queued transactions after it.
+// No missing nonce means the transactions are queued for another reason, such
+// as the balance of the sender or the gas limit of the block.
+type TxPoolNonceGap struct {
+	NextNonce hexutil.Uint64     `json:"nextNonce"`
+	Missing   []TxPoolNonceRange `json:"missing"`
+}
+
+// Content returns the pending and queued transactions of the pool, grouped by
+// sender and keyed by nonce.
+func (s *PublicTxPoolService) Content(
+	ctx context.Context,
+) (map[string]map[string]map[string]interface{}, error) {
+	timer := DoMetricRPCRequest(TxPoolContent)
+	defer DoRPCRequestDuration(TxPoolContent, timer)
+
+	err := s.wait(s.limiterContent, ctx)
+	if err != nil {
+		DoMetricRPCQueryInfo(TxPoolContent, RateLimitedNumber)
+		return nil, err
+	}
+
+	pending, queued := s.hmy.GetPoolContent()
+	content := map[string]map[string]map[string]interface{}{
+		"pending": make(map[string]map[string]interface{}, len(pending)),
+		"queued":  make(map[string]map[string]interface{}, len(queued)),
+	}
+	for addr, txs := range pending {
+		if content["pending"][addr.Hex()], err = newTxPoolTransactions(txs); err != nil {
+			DoMetricRPCQueryInfo(TxPoolContent, FailedNumber)
+			return nil, err
+		}
+	}
+	for addr, txs := range queued {
+		if content["queued"][addr.Hex()], err = newTxPoolTransactions(txs); err != nil {
+			DoMetricRPCQueryInfo(TxPoolContent, FailedNumber)
+			return nil, err
+		}
+	}
+	return content, nil
+}
+
+// ContentFrom returns the pending and queued transactions of the sender, keyed
+// by nonce, along with the nonce gap of its queued transactions.
+func (s *PublicTxPoolService) ContentFrom(
+	ctx context.Context, address common.Address,
+) (map[string]interface{}, error) {
+	timer := DoMetricRPCRequest(TxPoolContentFrom)
+	defer DoRPCRequestDuration(TxPoolContentFrom, timer)
+
+	err := s.wait(s.limiterContent, ctx)
+	if err != nil {
+		DoMetricRPCQueryInfo(TxPoolContentFrom, RateLimitedNumber)
+		return nil, err
+	}
+
+	pending, queued := s.hmy.GetPoolContentFrom(address)
+	pendingTxs, err := newTxPoolTransactions(pending)
+	if err != nil {
+		DoMetricRPCQueryInfo(TxPoolContentFrom, FailedNumber)
+		return nil, err
+	}
+	queuedTxs, err := newTxPoolTransactions(queued)
+	if err != nil {
+		DoMetricRPCQueryInfo(TxPoolContentFrom, FailedNumber)
+		return nil, err
+	}
+	content := map[string]interface{}{
+		"pending": pendingTxs,
+		"queued":  queuedTxs,
+	}
+	if len(queued) > 0 {
+		nonce, err := s.hmy.GetPoolNonce(ctx, address)
+		if err != nil {
+			DoMetricRPCQueryInfo(TxPoolContentFrom, FailedNumber)
+			return nil, err
+		}
+		content["nonceGap"] = newTxPoolNonceGap(nonce, queued)
+	}
+	return content, nil
+}
+
+// Inspect returns a textual summary of the pending and queued transactions of
+// the pool, grouped by sender and keyed by nonce.
+func (s *PublicTxPoolService) Inspect(
+	ctx context.Context,
+) (map[string]map[string]map[string]string, error) {
+	timer := DoMetricRPCRequest(TxPoolInspect)
+	defer DoRPCRequestDuration(TxPoolInspect, timer)
+
+	err := s.wait(s.limiterContent, ctx)
+	if err != nil {
+		DoMetricRPCQueryInfo(TxPoolInspect, RateLimitedNumber)
+		return nil, err
+	}
+
+	pending, queued := s.hmy.GetPoolContent()
+	content := map[string]map[string]map[string]string{
+		"pending": make(map[string]map[string]string, len(pending)),
+		"queued":  make(map[string]map[string]string, len(queued)),
+	}
+	for addr, txs := range pending {
+		content["pending"][addr.Hex()] = inspectTxPoolTransactions(txs)
+	}
+	for addr, txs := range queued {
+		content["queued"][addr.Hex()] = inspectTxPoolTransactions(txs)
+	}
+	return content, nil
+}
+
+// Status returns the number of pending and queued transactions of the pool
+func (s *PublicTxPoolService) Status(ctx context.Context) map[string]hexutil.Uint {
+	timer := DoMetricRPCRequest(TxPoolStatus)
+	defer DoRPCRequestDuration(TxPoolStatus, timer)
+
+	pendingCount, queuedCount := s.hmy.GetPoolStats()
+	return map[string]hexutil.Uint{
+		"pending": hexutil.Uint(pendingCount),
+		"queued":  hexutil.Uint(queuedCount),
+	}
+}
+
+// NonceGaps returns the nonce gap of every sender with queued transactions,
+// that is why they are not executable.
+func (s *PublicTxPoolService) NonceGaps(
+	ctx context.Context,
+) (map[string]*TxPoolNonceGap, error) {
+	timer := DoMetricRPCRequest(TxPoolNonceGaps)
+	defer DoRPCRequestDuration(TxPoolNonceGaps, timer)
+
+	err := s.wait(s.limiterContent, ctx)
+	if err != nil {
+		DoMetricRPCQueryInfo(TxPoolNonceGaps, RateLimitedNumber)
+		return nil, err
+	}
+
+	_, queued := s.hmy.GetPoolContent()
+	gaps := make(map[string]*TxPoolNonceGap, len(queued))
+	for addr, txs := range queued {
+		nonce, err := s.hmy.GetPoolNonce(ctx, addr)
+		if err != nil {
+			DoMetricRPCQueryInfo(TxPoolNonceGaps, FailedNumber)
+			return nil, err
+		}
+		gaps[addr.Hex()] = newTxPoolNonceGap(nonce, txs)
+	}
+	return gaps, nil
+}
+
+// newTxPoolTransactions formats the transactions of a sender, keyed by nonce
+func newTxPoolTransactions(txs types.PoolTransactions) (map[string]interface{}, error) {
+	result := make(map[string]interface{}, len(txs))
+	for _, tx := range txs {
+		rpcTx, err := newTxPoolTransaction(tx)
+		if err != nil {
+			return nil, err
+		}
+		result[fmt.Sprintf("%d", tx.Nonce())] = rpcTx
+	}
+	return result, nil
+}
+
+// newTxPoolTransaction formats a plain transaction in the eth format and a
+// staking transaction in the hmyv2 format
+func newTxPoolTransaction(tx types.PoolTransaction) (interface{}, error) {
+	switch tx := tx.(type) {
+	case *types.Transaction:
+		from, err := tx.SenderAddress()
+		if err != nil {
+			return nil, err
+		}
+		return eth.NewTransaction(from, tx.ConvertToEth(), common.Hash{}, 0, 0, 0, nil)
+	case *staking.StakingTransaction:
+		return v2.NewStakingTransaction(tx, common.Hash{}, 0, 0, 0, true)
+	default:
+		return nil, types.ErrUnknownPoolTxType
+	}
+}
+
+// inspectTxPoolTransactions summarizes the transactions of a sender, keyed by nonce
+func inspectTxPoolTransactions(txs types.PoolTransactions) map[string]string {
+	result := make(map[string]string, len(txs))
+	for _, tx := range txs {
+		var summary string
+		switch tx := tx.(type) {
+		case *types.Transaction:
+			if to := tx.To(); to != nil {
+				summary = fmt.Sprintf("%s: %v wei + %v gas × %v wei", to.Hex(), tx.Value(), tx.GasLimit(), tx.GasPrice())
+			} else {
+				summary = fmt.Sprintf("contract creation: %v wei + %v gas × %v wei", tx.Value(), tx.GasLimit(), tx.GasPrice())
+			}
+		case *staking.StakingTransaction:
+			summary = fmt.Sprintf("%s: %v gas × %v wei", tx.StakingType(), tx.GasLimit(), tx.GasPrice())
+		default:
+			summary = types.ErrUnknownPoolTxType.Error()
+		}
+		result[fmt.Sprintf("%d", tx.Nonce())] = summary
+	}
+	return result
+}
+
+// newTxPoolNonceGap returns the nonces missing from the queued transactions of
+// a sender, sorted by nonce, given the next nonce of the sender in the pool
+func newTxPoolNonceGap(nextNonce uint64, queued types.PoolTransactions) *TxPoolNonceGap {
+	gap := &TxPoolNonceGap{
+		NextNonce: hexutil.Uint64(nextNonce),
+		Missing:   []TxPoolNonceRange{},
+	}
+	expected := nextNonce
+	for _, tx := range queued {
+		nonce := tx.Nonce()
+		if nonce < expected {
+			continue
+		}
+		if nonce > expected {
+			gap.Missing = append(gap.Missing, TxPoolNonceRange{
+				From: hexutil.Uint64(expected),
+				To:   hexutil.Uint64(nonce - 1),
+			})
+		}
+		expected = nonce + 1
+	}
+	return gap
+}
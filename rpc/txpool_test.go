@@ -0,0 +1,176 @@
+package rpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/hmy"
+	"github.com/harmony-one/harmony/internal/params"
+	rpc_eth "github.com/harmony-one/harmony/rpc/eth"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxPoolService_Content(t *testing.T) {
+	s, sender := makeTxPoolTestService(t)
+
+	content, err := s.Content(context.Background())
+	require.NoError(t, err)
+	require.Len(t, content["pending"], 1)
+	require.Len(t, content["queued"], 1)
+
+	pending := content["pending"][sender.Hex()]
+	require.Len(t, pending, 2)
+	tx, ok := pending["1"].(*rpc_eth.Transaction)
+	require.True(t, ok)
+	require.Equal(t, hexutil.Uint64(1), tx.Nonce)
+	require.Equal(t, sender, tx.From)
+
+	queued := content["queued"][sender.Hex()]
+	require.Len(t, queued, 1)
+	require.Contains(t, queued, "4")
+}
+
+func TestTxPoolService_ContentFrom(t *testing.T) {
+	s, sender := makeTxPoolTestService(t)
+
+	content, err := s.ContentFrom(context.Background(), sender)
+	require.NoError(t, err)
+	require.Len(t, content["pending"], 2)
+	require.Len(t, content["queued"], 1)
+	// the nonce 3 is missing, so that the nonce 4 is queued
+	require.Equal(t, &TxPoolNonceGap{
+		NextNonce: 3,
+		Missing:   []TxPoolNonceRange{{From: 3, To: 3}},
+	}, content["nonceGap"])
+
+	// no transaction and no gap of an unknown sender
+	content, err = s.ContentFrom(context.Background(), testAddr1)
+	require.NoError(t, err)
+	require.Empty(t, content["pending"])
+	require.Empty(t, content["queued"])
+	require.NotContains(t, content, "nonceGap")
+}
+
+func TestTxPoolService_Inspect(t *testing.T) {
+	s, sender := makeTxPoolTestService(t)
+
+	content, err := s.Inspect(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"1": testAddr1.Hex() + ": 1 wei + 21000 gas × 100000000000 wei",
+		"2": "contract creation: 0 wei + 100000 gas × 100000000000 wei",
+	}, content["pending"][sender.Hex()])
+	require.Equal(t, map[string]string{
+		"4": testAddr1.Hex() + ": 1 wei + 21000 gas × 100000000000 wei",
+	}, content["queued"][sender.Hex()])
+}
+
+// makeTxPoolTestService returns the txpool service of a pool with the pending
+// nonces 1 and 2 and the queued nonce 4 of the returned sender
+func makeTxPoolTestService(t *testing.T) (*PublicTxPoolService, common.Address) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	statedb.SetNonce(sender, 1)
+	statedb.AddBalance(sender, new(big.Int).Exp(big.NewInt(10), big.NewInt(20), nil))
+
+	config := core.DefaultTxPoolConfig
+	config.Journal = ""
+	pool := core.NewTxPool(config, params.TestChainConfig, &txPoolTestChain{statedb, new(event.Feed)}, types.NewTransactionErrorSink())
+	t.Cleanup(pool.Stop)
+
+	txs := types.PoolTransactions{
+		signTxPoolTestTx(t, types.NewTransaction(1, testAddr1, 0, big.NewInt(1), 21000, big.NewInt(100e9), nil), key),
+		signTxPoolTestTx(t, types.NewContractCreation(2, 0, new(big.Int), 100000, big.NewInt(100e9), nil), key),
+		signTxPoolTestTx(t, types.NewTransaction(4, testAddr1, 0, big.NewInt(1), 21000, big.NewInt(100e9), nil), key),
+	}
+	for _, err := range pool.AddRemotes(txs) {
+		require.NoError(t, err)
+	}
+	return &PublicTxPoolService{hmy: &hmy.Harmony{TxPool: pool}}, sender
+}
+
+func signTxPoolTestTx(t *testing.T, tx *types.Transaction, key *ecdsa.PrivateKey) *types.Transaction {
+	signed, err := types.SignTx(tx, types.HomesteadSigner{}, key)
+	require.NoError(t, err)
+	return signed
+}
+
+// txPoolTestChain is the chain of the pool, at a block of the shard 0
+type txPoolTestChain struct {
+	statedb       *state.DB
+	chainHeadFeed *event.Feed
+}
+
+func (bc *txPoolTestChain) CurrentBlock() *types.Block {
+	return types.NewBlock(blockfactory.NewTestHeader().With().GasLimit(1e18).Header(), nil, nil, nil, nil, nil)
+}
+
+func (bc *txPoolTestChain) GetBlock(hash common.Hash, number uint64) *types.Block {
+	return bc.CurrentBlock()
+}
+
+func (bc *txPoolTestChain) StateAt(common.Hash) (*state.DB, error) {
+	return bc.statedb, nil
+}
+
+func (bc *txPoolTestChain) SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription {
+	return bc.chainHeadFeed.Subscribe(ch)
+}
+
+func TestNewTxPoolNonceGap(t *testing.T) {
+	queued := func(nonces ...uint64) types.PoolTransactions {
+		txs := types.PoolTransactions{}
+		for _, nonce := range nonces {
+			txs = append(txs, types.NewTransaction(nonce, testAddr1, 0, big.NewInt(1), 21000, big.NewInt(1), nil))
+		}
+		return txs
+	}
+	tests := []struct {
+		nextNonce uint64
+		queued    types.PoolTransactions
+		exp       []TxPoolNonceRange
+	}{
+		{
+			// no gap, queued for another reason
+			nextNonce: 3,
+			queued:    queued(3, 4),
+			exp:       []TxPoolNonceRange{},
+		},
+		{
+			nextNonce: 3,
+			queued:    queued(4),
+			exp:       []TxPoolNonceRange{{From: 3, To: 3}},
+		},
+		{
+			nextNonce: 0,
+			queued:    queued(2, 3, 7),
+			exp:       []TxPoolNonceRange{{From: 0, To: 1}, {From: 4, To: 6}},
+		},
+		{
+			// stale nonces are skipped
+			nextNonce: 5,
+			queued:    queued(2, 6),
+			exp:       []TxPoolNonceRange{{From: 5, To: 5}},
+		},
+	}
+	for i, test := range tests {
+		gap := newTxPoolNonceGap(test.nextNonce, test.queued)
+		require.Equal(t, hexutil.Uint64(test.nextNonce), gap.NextNonce, "test %d", i)
+		require.Equal(t, test.exp, gap.Missing, "test %d", i)
+	}
+}
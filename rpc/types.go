@@ -18,22 +18,28 @@ import (
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/harmony-one/harmony/block"
+	"github.com/harmony-one/harmony/core/state"
 	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/core/vm"
 	"github.com/harmony-one/harmony/eth/rpc"
 	"github.com/harmony-one/harmony/internal/utils"
 	"github.com/harmony-one/harmony/numeric"
 	"github.com/harmony-one/harmony/shard"
+	staking "github.com/harmony-one/harmony/staking/types"
 	jsoniter "github.com/json-iterator/go"
 )
 
 // CallArgs represents the arguments for a call.
 type CallArgs struct {
-	From     *common.Address `json:"from"`
-	To       *common.Address `json:"to"`
-	Gas      *hexutil.Uint64 `json:"gas"`
-	GasPrice *hexutil.Big    `json:"gasPrice"`
-	Value    *hexutil.Big    `json:"value"`
-	Data     *hexutil.Bytes  `json:"data"`
+	From                 *common.Address   `json:"from"`
+	To                   *common.Address   `json:"to"`
+	Gas                  *hexutil.Uint64   `json:"gas"`
+	GasPrice             *hexutil.Big      `json:"gasPrice"`
+	MaxFeePerGas         *hexutil.Big      `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big      `json:"maxPriorityFeePerGas"`
+	Value                *hexutil.Big      `json:"value"`
+	Data                 *hexutil.Bytes    `json:"data"`
+	AccessList           *types.AccessList `json:"accessList"`
 }
 
 // ToMessage converts CallArgs to the Message type used by the core evm
@@ -72,10 +78,110 @@ func (args *CallArgs) ToMessage(globalGasCap *big.Int) types.Message {
 		data = []byte(*args.Data)
 	}
 
-	msg := types.NewMessage(addr, args.To, 0, value, gas, gasPrice, data, false)
+	// The fee caps of the dynamic fee transactions take precedence over the gas price
+	var msg types.Message
+	if args.MaxFeePerGas != nil || args.MaxPriorityFeePerGas != nil {
+		gasFeeCap, gasTipCap := new(big.Int), new(big.Int)
+		if args.MaxFeePerGas != nil {
+			gasFeeCap = args.MaxFeePerGas.ToInt()
+		}
+		if args.MaxPriorityFeePerGas != nil {
+			gasTipCap = args.MaxPriorityFeePerGas.ToInt()
+		}
+		msg = types.NewDynamicFeeMessage(addr, args.To, 0, value, gas, gasFeeCap, gasTipCap, data, false)
+	} else {
+		msg = types.NewMessage(addr, args.To, 0, value, gas, gasPrice, data, false)
+	}
+	if args.AccessList != nil {
+		msg.SetAccessList(*args.AccessList)
+	}
 	return msg
 }
 
+// OverrideAccount indicates the overriding fields of account during the execution
+// of a message call.
+// Note, state and stateDiff can't be specified at the same time. If state is
+// set, message execution will only use the data in the given state. Otherwise
+// if stateDiff is set, all diff will be applied first and then execute the call
+// message.
+type OverrideAccount struct {
+	Nonce     *hexutil.Uint64              `json:"nonce"`
+	Code      *hexutil.Bytes               `json:"code"`
+	Balance   **hexutil.Big                `json:"balance"`
+	State     *map[common.Hash]common.Hash `json:"state"`
+	StateDiff *map[common.Hash]common.Hash `json:"stateDiff"`
+}
+
+// StateOverride is the collection of overridden accounts.
+type StateOverride map[common.Address]OverrideAccount
+
+// Apply overrides the fields of specified accounts into the given state.
+// The state must be a copy, so that the overrides never reach the canonical state.
+func (diff *StateOverride) Apply(state *state.DB) error {
+	if diff == nil {
+		return nil
+	}
+	for addr, account := range *diff {
+		// Override account nonce.
+		if account.Nonce != nil {
+			state.SetNonce(addr, uint64(*account.Nonce))
+		}
+		// Override account(contract) code.
+		if account.Code != nil {
+			state.SetCode(addr, *account.Code, false)
+		}
+		// Override account balance.
+		if account.Balance != nil {
+			state.SetBalance(addr, (*big.Int)(*account.Balance))
+		}
+		if account.State != nil && account.StateDiff != nil {
+			return fmt.Errorf("account %s has both 'state' and 'stateDiff'", addr.Hex())
+		}
+		// Replace entire state if caller requires.
+		if account.State != nil {
+			state.SetStorage(addr, *account.State)
+		}
+		// Apply state diff into specified accounts.
+		if account.StateDiff != nil {
+			for key, value := range *account.StateDiff {
+				state.SetState(addr, key, value)
+			}
+		}
+	}
+	// Now finalize the changes. Finalize is normally performed between transactions.
+	// By using finalize, the overrides are semantically behaving as
+	// if they were created in a transaction just before the tracing occur.
+	state.Finalise(false)
+	return nil
+}
+
+// BlockOverrides is a set of header fields to override.
+type BlockOverrides struct {
+	Number   *hexutil.Big    `json:"number"`
+	Time     *hexutil.Uint64 `json:"time"`
+	GasLimit *hexutil.Uint64 `json:"gasLimit"`
+	Coinbase *common.Address `json:"coinbase"`
+}
+
+// Apply overrides the given header fields into the given block context.
+func (diff *BlockOverrides) Apply(blockCtx *vm.Context) {
+	if diff == nil {
+		return
+	}
+	if diff.Number != nil {
+		blockCtx.BlockNumber = new(big.Int).Set(diff.Number.ToInt())
+	}
+	if diff.Time != nil {
+		blockCtx.Time = new(big.Int).SetUint64(uint64(*diff.Time))
+	}
+	if diff.GasLimit != nil {
+		blockCtx.GasLimit = uint64(*diff.GasLimit)
+	}
+	if diff.Coinbase != nil {
+		blockCtx.Coinbase = *diff.Coinbase
+	}
+}
+
 // StakingNetworkInfo returns global staking info.
 type StakingNetworkInfo struct {
 	TotalSupply       numeric.Dec `json:"total-supply"`
@@ -92,16 +198,23 @@ type Delegation struct {
 	Amount           *big.Int       `json:"amount"`
 	Reward           *big.Int       `json:"reward"`
 	Undelegations    []Undelegation `json:"Undelegations"`
+	Redelegations    []Redelegation `json:"Redelegations,omitempty"`
+	AutoCompound     bool           `json:"auto_compound"`
 }
 
 func (d Delegation) IntoStructuredResponse() StructuredResponse {
-	return StructuredResponse{
+	response := StructuredResponse{
 		"validator_address": d.ValidatorAddress,
 		"delegator_address": d.DelegatorAddress,
 		"amount":            d.Amount,
 		"reward":            d.Reward,
 		"Undelegations":     d.Undelegations,
+		"auto_compound":     d.AutoCompound,
 	}
+	if len(d.Redelegations) > 0 {
+		response["Redelegations"] = d.Redelegations
+	}
+	return response
 }
 
 // Undelegation represents one undelegation entry
@@ -110,6 +223,31 @@ type Undelegation struct {
 	Epoch  *big.Int
 }
 
+// Redelegation represents one redelegation entry, the tokens moved to
+// another validator which are still slashable
+type Redelegation struct {
+	ValidatorAddress string
+	Amount           *big.Int
+	Epoch            *big.Int
+}
+
+// NewRedelegations returns the RPC representation of the redelegations
+func NewRedelegations(redelegations staking.Redelegations) []Redelegation {
+	if len(redelegations) == 0 {
+		return nil
+	}
+	result := make([]Redelegation, 0, len(redelegations))
+	for _, redelegation := range redelegations {
+		valAddr, _ := internal_common.AddressToBech32(redelegation.ValidatorAddress)
+		result = append(result, Redelegation{
+			ValidatorAddress: valAddr,
+			Amount:           redelegation.Amount,
+			Epoch:            redelegation.Epoch,
+		})
+	}
+	return result
+}
+
 // StructuredResponse type of RPCs
 type StructuredResponse = map[string]interface{}
 
@@ -7,6 +7,8 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/state"
 	internal_common "github.com/harmony-one/harmony/internal/common"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
@@ -94,3 +96,30 @@ func TestDelegation_IntoStructuredResponse(t *testing.T) {
 
 	require.JSONEq(t, string(js1), string(js2))
 }
+
+func TestStateOverride_Apply(t *testing.T) {
+	var overrides StateOverride
+	slot, val := common.HexToHash("0x01"), common.HexToHash("0x02")
+	input := fmt.Sprintf(`{
+		"%v": {"nonce": "0x5", "balance": "0x64", "code": "0x6000", "stateDiff": {"%v": "%v"}},
+		"%v": {"state": {"%v": "%v"}, "stateDiff": {"%v": "%v"}}
+	}`, testAddr1.Hex(), slot.Hex(), val.Hex(), testAddr2.Hex(), slot.Hex(), val.Hex(), slot.Hex(), val.Hex())
+	require.NoError(t, json.Unmarshal([]byte(input), &overrides))
+
+	db, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	// state and stateDiff of the same account are rejected
+	require.Error(t, overrides.Apply(db.Copy()))
+
+	delete(overrides, testAddr2)
+	cpy := db.Copy()
+	require.NoError(t, overrides.Apply(cpy))
+	require.Equal(t, uint64(5), cpy.GetNonce(testAddr1))
+	require.Equal(t, big.NewInt(100), cpy.GetBalance(testAddr1))
+	require.Equal(t, []byte{0x60, 0x00}, cpy.GetCode(testAddr1))
+	require.Equal(t, val, cpy.GetState(testAddr1, slot))
+
+	// the original state is untouched
+	require.Equal(t, uint64(0), db.GetNonce(testAddr1))
+	require.Equal(t, common.Hash{}, db.GetState(testAddr1, slot))
+}
@@ -202,6 +202,23 @@ type UndelegateMsg struct {
 	Amount           *big.Int `json:"amount"`
 }
 
+// RedelegateMsg represents a staking transaction's redelegate directive that
+// will serialize to the RPC representation
+type RedelegateMsg struct {
+	DelegatorAddress     string   `json:"delegatorAddress"`
+	FromValidatorAddress string   `json:"fromValidatorAddress"`
+	ToValidatorAddress   string   `json:"toValidatorAddress"`
+	Amount               *big.Int `json:"amount"`
+}
+
+// EditDelegationMsg represents a staking transaction's edit delegation
+// directive that will serialize to the RPC representation
+type EditDelegationMsg struct {
+	DelegatorAddress string `json:"delegatorAddress"`
+	ValidatorAddress string `json:"validatorAddress"`
+	AutoCompound     bool   `json:"autoCompound"`
+}
+
 // TxReceipt represents a transaction receipt that will serialize to the RPC representation.
 type TxReceipt struct {
 	BlockHash         common.Hash    `json:"blockHash"`
@@ -581,6 +598,55 @@ func NewStakingTransaction(
 			ValidatorAddress: validatorAddress,
 			Amount:           msg.Amount,
 		}
+	case staking.DirectiveRedelegate:
+		rawMsg, err := staking.RLPDecodeStakeMsg(tx.Data(), staking.DirectiveRedelegate)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("RLP decode error: %s", err.Error()))
+		}
+		msg, ok := rawMsg.(*staking.Redelegate)
+		if !ok {
+			return nil, fmt.Errorf("could not decode staking message")
+		}
+		delegatorAddress, err := internal_common.AddressToBech32(msg.DelegatorAddress)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("convert delegator address error: %s", err.Error()))
+		}
+		fromValidatorAddress, err := internal_common.AddressToBech32(msg.FromValidatorAddress)
+		if err != nil {
+			return nil, err
+		}
+		toValidatorAddress, err := internal_common.AddressToBech32(msg.ToValidatorAddress)
+		if err != nil {
+			return nil, err
+		}
+		rpcMsg = &RedelegateMsg{
+			DelegatorAddress:     delegatorAddress,
+			FromValidatorAddress: fromValidatorAddress,
+			ToValidatorAddress:   toValidatorAddress,
+			Amount:               msg.Amount,
+		}
+	case staking.DirectiveEditDelegation:
+		rawMsg, err := staking.RLPDecodeStakeMsg(tx.Data(), staking.DirectiveEditDelegation)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("RLP decode error: %s", err.Error()))
+		}
+		msg, ok := rawMsg.(*staking.EditDelegation)
+		if !ok {
+			return nil, fmt.Errorf("could not decode staking message")
+		}
+		delegatorAddress, err := internal_common.AddressToBech32(msg.DelegatorAddress)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("convert delegator address error: %s", err.Error()))
+		}
+		validatorAddress, err := internal_common.AddressToBech32(msg.ValidatorAddress)
+		if err != nil {
+			return nil, err
+		}
+		rpcMsg = &EditDelegationMsg{
+			DelegatorAddress: delegatorAddress,
+			ValidatorAddress: validatorAddress,
+			AutoCompound:     msg.AutoCompound,
+		}
 	}
 
 	result := &StakingTransaction{
@@ -1,6 +1,10 @@
 package staking
 
 import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
@@ -11,6 +15,9 @@ const (
 	delegateStr           = "Harmony/Delegate"
 	unDelegateStr         = "Harmony/UnDelegate"
 	firstElectionEpochStr = "Harmony/FirstElectionEpoch/Key/v1"
+
+	isContractValidatorKeyStr      = "Harmony/IsContractValidator/Key/v1"
+	contractValidatorWrapperKeyStr = "Harmony/ContractValidatorWrapper/Key/v1"
 )
 
 // keys used to retrieve staking related informatio
@@ -21,4 +28,32 @@ var (
 	DelegateTopic         = crypto.Keccak256Hash([]byte(delegateStr))
 	UnDelegateTopic       = crypto.Keccak256Hash([]byte(unDelegateStr))
 	FirstElectionEpochKey = crypto.Keccak256Hash([]byte(firstElectionEpochStr))
+
+	// the wrapper of a contract validator is kept in its storage, the size at
+	// the key and the 32 bytes chunks at the keys sharing its prefix
+	IsContractValidatorKey      = crypto.Keccak256Hash([]byte(isContractValidatorKeyStr))
+	ContractValidatorWrapperKey = crypto.Keccak256Hash([]byte(contractValidatorWrapperKeyStr))
 )
+
+// MaxContractValidatorWrapperSize is the maximum size of the encoded wrapper
+// of a contract validator kept in its storage
+const MaxContractValidatorWrapperSize = 4 * 1024 * 1024
+
+// contractValidatorWrapperPrefix is the length of the prefix of the wrapper key
+// shared by the keys of the wrapper chunks
+const contractValidatorWrapperPrefix = common.HashLength - 8
+
+// ContractValidatorWrapperChunkKey returns the storage key of the i-th 32 bytes
+// chunk of the wrapper of a contract validator
+func ContractValidatorWrapperChunkKey(i uint64) common.Hash {
+	key := ContractValidatorWrapperKey
+	binary.BigEndian.PutUint64(key[contractValidatorWrapperPrefix:], i)
+	return key
+}
+
+// IsReservedStorageKey returns whether the storage key holds the staking data
+// of a validator, which the code of a contract can't write
+func IsReservedStorageKey(key common.Hash) bool {
+	return key == IsValidatorKey || key == IsContractValidatorKey || key == FirstElectionEpochKey ||
+		bytes.Equal(key[:contractValidatorWrapperPrefix], ContractValidatorWrapperKey[:contractValidatorWrapperPrefix])
+}
@@ -0,0 +1,33 @@
+package staking
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestIsReservedStorageKey(t *testing.T) {
+	maxChunks := uint64(MaxContractValidatorWrapperSize / common.HashLength)
+	// no chunk is at the size key
+	if binary.BigEndian.Uint64(ContractValidatorWrapperKey[contractValidatorWrapperPrefix:]) <= maxChunks {
+		t.Fatal("the size key is the key of a chunk")
+	}
+	for _, i := range []uint64{0, 1, maxChunks} {
+		if key := ContractValidatorWrapperChunkKey(i); !IsReservedStorageKey(key) {
+			t.Errorf("chunk %v not reserved", i)
+		}
+	}
+	for _, key := range []common.Hash{
+		IsValidatorKey, IsContractValidatorKey, FirstElectionEpochKey, ContractValidatorWrapperKey,
+	} {
+		if !IsReservedStorageKey(key) {
+			t.Errorf("key %x not reserved", key)
+		}
+	}
+	for _, key := range []common.Hash{{}, common.BigToHash(common.Big1), IsValidator} {
+		if IsReservedStorageKey(key) {
+			t.Errorf("key %x reserved", key)
+		}
+	}
+}
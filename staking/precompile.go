@@ -6,6 +6,9 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/harmony-one/harmony/accounts/abi"
+	"github.com/harmony-one/harmony/crypto/bls"
+	"github.com/harmony-one/harmony/numeric"
+	"github.com/harmony-one/harmony/staking/effective"
 	stakingTypes "github.com/harmony-one/harmony/staking/types"
 	"github.com/pkg/errors"
 )
@@ -75,6 +78,208 @@ func init() {
 	    "outputs": [],
 	    "stateMutability": "nonpayable",
 	    "type": "function"
+	  },
+	  {
+	    "inputs": [
+	      {
+	        "internalType": "address",
+	        "name": "delegatorAddress",
+	        "type": "address"
+	      },
+	      {
+	        "internalType": "address",
+	        "name": "fromValidatorAddress",
+	        "type": "address"
+	      },
+	      {
+	        "internalType": "address",
+	        "name": "toValidatorAddress",
+	        "type": "address"
+	      },
+	      {
+	        "internalType": "uint256",
+	        "name": "amount",
+	        "type": "uint256"
+	      }
+	    ],
+	    "name": "Redelegate",
+	    "outputs": [],
+	    "stateMutability": "nonpayable",
+	    "type": "function"
+	  },
+	  {
+	    "inputs": [
+	      {
+	        "internalType": "address",
+	        "name": "delegatorAddress",
+	        "type": "address"
+	      },
+	      {
+	        "internalType": "address",
+	        "name": "validatorAddress",
+	        "type": "address"
+	      },
+	      {
+	        "internalType": "bool",
+	        "name": "autoCompound",
+	        "type": "bool"
+	      }
+	    ],
+	    "name": "EditDelegation",
+	    "outputs": [],
+	    "stateMutability": "nonpayable",
+	    "type": "function"
+	  },
+	  {
+	    "inputs": [
+	      {
+	        "internalType": "address",
+	        "name": "validatorAddress",
+	        "type": "address"
+	      },
+	      {
+	        "internalType": "string",
+	        "name": "name",
+	        "type": "string"
+	      },
+	      {
+	        "internalType": "string",
+	        "name": "identity",
+	        "type": "string"
+	      },
+	      {
+	        "internalType": "string",
+	        "name": "website",
+	        "type": "string"
+	      },
+	      {
+	        "internalType": "string",
+	        "name": "securityContact",
+	        "type": "string"
+	      },
+	      {
+	        "internalType": "string",
+	        "name": "details",
+	        "type": "string"
+	      },
+	      {
+	        "internalType": "string",
+	        "name": "rate",
+	        "type": "string"
+	      },
+	      {
+	        "internalType": "string",
+	        "name": "maxRate",
+	        "type": "string"
+	      },
+	      {
+	        "internalType": "string",
+	        "name": "maxChangeRate",
+	        "type": "string"
+	      },
+	      {
+	        "internalType": "uint256",
+	        "name": "minSelfDelegation",
+	        "type": "uint256"
+	      },
+	      {
+	        "internalType": "uint256",
+	        "name": "maxTotalDelegation",
+	        "type": "uint256"
+	      },
+	      {
+	        "internalType": "bytes[]",
+	        "name": "slotPubKeys",
+	        "type": "bytes[]"
+	      },
+	      {
+	        "internalType": "bytes[]",
+	        "name": "slotKeySigs",
+	        "type": "bytes[]"
+	      },
+	      {
+	        "internalType": "uint256",
+	        "name": "amount",
+	        "type": "uint256"
+	      }
+	    ],
+	    "name": "CreateValidator",
+	    "outputs": [],
+	    "stateMutability": "nonpayable",
+	    "type": "function"
+	  },
+	  {
+	    "inputs": [
+	      {
+	        "internalType": "address",
+	        "name": "validatorAddress",
+	        "type": "address"
+	      },
+	      {
+	        "internalType": "string",
+	        "name": "name",
+	        "type": "string"
+	      },
+	      {
+	        "internalType": "string",
+	        "name": "identity",
+	        "type": "string"
+	      },
+	      {
+	        "internalType": "string",
+	        "name": "website",
+	        "type": "string"
+	      },
+	      {
+	        "internalType": "string",
+	        "name": "securityContact",
+	        "type": "string"
+	      },
+	      {
+	        "internalType": "string",
+	        "name": "details",
+	        "type": "string"
+	      },
+	      {
+	        "internalType": "string",
+	        "name": "commissionRate",
+	        "type": "string"
+	      },
+	      {
+	        "internalType": "uint256",
+	        "name": "minSelfDelegation",
+	        "type": "uint256"
+	      },
+	      {
+	        "internalType": "uint256",
+	        "name": "maxTotalDelegation",
+	        "type": "uint256"
+	      },
+	      {
+	        "internalType": "bytes",
+	        "name": "slotKeyToRemove",
+	        "type": "bytes"
+	      },
+	      {
+	        "internalType": "bytes",
+	        "name": "slotKeyToAdd",
+	        "type": "bytes"
+	      },
+	      {
+	        "internalType": "bytes",
+	        "name": "slotKeyToAddSig",
+	        "type": "bytes"
+	      },
+	      {
+	        "internalType": "uint8",
+	        "name": "eposStatus",
+	        "type": "uint8"
+	      }
+	    ],
+	    "name": "EditValidator",
+	    "outputs": [],
+	    "stateMutability": "nonpayable",
+	    "type": "function"
 	  }
 	]
 	`
@@ -163,6 +368,55 @@ func ParseStakeMsg(contractCaller common.Address, input []byte) (interface{}, er
 			}
 			return stakeMsg, nil
 		}
+	case "Redelegate":
+		{
+			// same validation as above
+			address, err := ValidateContractAddress(contractCaller, args, "delegatorAddress")
+			if err != nil {
+				return nil, err
+			}
+			fromValidatorAddress, err := abi.ParseAddressFromKey(args, "fromValidatorAddress")
+			if err != nil {
+				return nil, err
+			}
+			toValidatorAddress, err := abi.ParseAddressFromKey(args, "toValidatorAddress")
+			if err != nil {
+				return nil, err
+			}
+			amount, err := abi.ParseBigIntFromKey(args, "amount")
+			if err != nil {
+				return nil, err
+			}
+			stakeMsg := &stakingTypes.Redelegate{
+				DelegatorAddress:     address,
+				FromValidatorAddress: fromValidatorAddress,
+				ToValidatorAddress:   toValidatorAddress,
+				Amount:               amount,
+			}
+			return stakeMsg, nil
+		}
+	case "EditDelegation":
+		{
+			// same validation as above
+			address, err := ValidateContractAddress(contractCaller, args, "delegatorAddress")
+			if err != nil {
+				return nil, err
+			}
+			validatorAddress, err := abi.ParseAddressFromKey(args, "validatorAddress")
+			if err != nil {
+				return nil, err
+			}
+			autoCompound, err := abi.ParseBoolFromKey(args, "autoCompound")
+			if err != nil {
+				return nil, err
+			}
+			stakeMsg := &stakingTypes.EditDelegation{
+				DelegatorAddress: address,
+				ValidatorAddress: validatorAddress,
+				AutoCompound:     autoCompound,
+			}
+			return stakeMsg, nil
+		}
 	case "CollectRewards":
 		{
 			// same validation as above
@@ -175,6 +429,141 @@ func ParseStakeMsg(contractCaller common.Address, input []byte) (interface{}, er
 			}
 			return stakeMsg, nil
 		}
+	case "CreateValidator":
+		{
+			// same validation as above, the validator is operated by the caller
+			address, err := ValidateContractAddress(contractCaller, args, "validatorAddress")
+			if err != nil {
+				return nil, err
+			}
+			description, err := parseDescription(args)
+			if err != nil {
+				return nil, err
+			}
+			rate, err := parseDecFromKey(args, "rate")
+			if err != nil {
+				return nil, err
+			}
+			maxRate, err := parseDecFromKey(args, "maxRate")
+			if err != nil {
+				return nil, err
+			}
+			maxChangeRate, err := parseDecFromKey(args, "maxChangeRate")
+			if err != nil {
+				return nil, err
+			}
+			minSelfDelegation, err := abi.ParseBigIntFromKey(args, "minSelfDelegation")
+			if err != nil {
+				return nil, err
+			}
+			maxTotalDelegation, err := abi.ParseBigIntFromKey(args, "maxTotalDelegation")
+			if err != nil {
+				return nil, err
+			}
+			rawPubKeys, err := abi.ParseBytesSliceFromKey(args, "slotPubKeys")
+			if err != nil {
+				return nil, err
+			}
+			slotPubKeys := make([]bls.SerializedPublicKey, len(rawPubKeys))
+			for i, rawPubKey := range rawPubKeys {
+				if err := setFixedBytes(slotPubKeys[i][:], rawPubKey, "slotPubKeys"); err != nil {
+					return nil, err
+				}
+			}
+			rawKeySigs, err := abi.ParseBytesSliceFromKey(args, "slotKeySigs")
+			if err != nil {
+				return nil, err
+			}
+			slotKeySigs := make([]bls.SerializedSignature, len(rawKeySigs))
+			for i, rawKeySig := range rawKeySigs {
+				if err := setFixedBytes(slotKeySigs[i][:], rawKeySig, "slotKeySigs"); err != nil {
+					return nil, err
+				}
+			}
+			amount, err := abi.ParseBigIntFromKey(args, "amount")
+			if err != nil {
+				return nil, err
+			}
+			// the BLS key signatures are verified with the other
+			// checks when the validator is created
+			stakeMsg := &stakingTypes.CreateValidator{
+				ValidatorAddress: address,
+				Description:      description,
+				CommissionRates: stakingTypes.CommissionRates{
+					Rate:          rate,
+					MaxRate:       maxRate,
+					MaxChangeRate: maxChangeRate,
+				},
+				MinSelfDelegation:  minSelfDelegation,
+				MaxTotalDelegation: maxTotalDelegation,
+				SlotPubKeys:        slotPubKeys,
+				SlotKeySigs:        slotKeySigs,
+				Amount:             amount,
+			}
+			return stakeMsg, nil
+		}
+	case "EditValidator":
+		{
+			// same validation as above
+			// the empty strings, bytes and zero values are left unchanged
+			address, err := ValidateContractAddress(contractCaller, args, "validatorAddress")
+			if err != nil {
+				return nil, err
+			}
+			description, err := parseDescription(args)
+			if err != nil {
+				return nil, err
+			}
+			stakeMsg := &stakingTypes.EditValidator{
+				ValidatorAddress: address,
+				Description:      description,
+			}
+			if rate, err := abi.ParseStringFromKey(args, "commissionRate"); err != nil {
+				return nil, err
+			} else if rate != "" {
+				commissionRate, err := parseDecFromKey(args, "commissionRate")
+				if err != nil {
+					return nil, err
+				}
+				stakeMsg.CommissionRate = &commissionRate
+			}
+			if stakeMsg.MinSelfDelegation, err = abi.ParseBigIntFromKey(args, "minSelfDelegation"); err != nil {
+				return nil, err
+			}
+			if stakeMsg.MaxTotalDelegation, err = abi.ParseBigIntFromKey(args, "maxTotalDelegation"); err != nil {
+				return nil, err
+			}
+			if rawKey, err := abi.ParseBytesFromKey(args, "slotKeyToRemove"); err != nil {
+				return nil, err
+			} else if len(rawKey) > 0 {
+				stakeMsg.SlotKeyToRemove = &bls.SerializedPublicKey{}
+				if err := setFixedBytes(stakeMsg.SlotKeyToRemove[:], rawKey, "slotKeyToRemove"); err != nil {
+					return nil, err
+				}
+			}
+			if rawKey, err := abi.ParseBytesFromKey(args, "slotKeyToAdd"); err != nil {
+				return nil, err
+			} else if len(rawKey) > 0 {
+				stakeMsg.SlotKeyToAdd = &bls.SerializedPublicKey{}
+				if err := setFixedBytes(stakeMsg.SlotKeyToAdd[:], rawKey, "slotKeyToAdd"); err != nil {
+					return nil, err
+				}
+				rawSig, err := abi.ParseBytesFromKey(args, "slotKeyToAddSig")
+				if err != nil {
+					return nil, err
+				}
+				stakeMsg.SlotKeyToAddSig = &bls.SerializedSignature{}
+				if err := setFixedBytes(stakeMsg.SlotKeyToAddSig[:], rawSig, "slotKeyToAddSig"); err != nil {
+					return nil, err
+				}
+			}
+			status, err := abi.ParseUint8FromKey(args, "eposStatus")
+			if err != nil {
+				return nil, err
+			}
+			stakeMsg.EPOSStatus = effective.Eligibility(status)
+			return stakeMsg, nil
+		}
 	//case "Migrate":
 	//	{
 	//		from, err := ValidateContractAddress(contractCaller, args, "from")
@@ -213,3 +602,53 @@ func ValidateContractAddress(contractCaller common.Address, args map[string]inte
 		return address, nil
 	}
 }
+
+// parseDescription pulls out the validator description from the arguments
+func parseDescription(args map[string]interface{}) (stakingTypes.Description, error) {
+	description := stakingTypes.Description{}
+	fields := []struct {
+		key   string
+		value *string
+	}{
+		{"name", &description.Name},
+		{"identity", &description.Identity},
+		{"website", &description.Website},
+		{"securityContact", &description.SecurityContact},
+		{"details", &description.Details},
+	}
+	for _, field := range fields {
+		value, err := abi.ParseStringFromKey(args, field.key)
+		if err != nil {
+			return stakingTypes.Description{}, err
+		}
+		*field.value = value
+	}
+	return description, nil
+}
+
+// parseDecFromKey pulls out the decimal sent as a string, since solidity
+// does not support floats, from a map with provided key
+func parseDecFromKey(args map[string]interface{}, key string) (numeric.Dec, error) {
+	value, err := abi.ParseStringFromKey(args, key)
+	if err != nil {
+		return numeric.Dec{}, err
+	}
+	dec, err := numeric.NewDecFromStr(value)
+	if err != nil {
+		return numeric.Dec{}, errors.Wrapf(err, "[StakingPrecompile] Invalid %s", key)
+	}
+	return dec, nil
+}
+
+// setFixedBytes copies the bytes of a BLS key or signature to its fixed size
+// array, the sizes must match
+func setFixedBytes(dst, src []byte, key string) error {
+	if len(src) != len(dst) {
+		return errors.Errorf(
+			"[StakingPrecompile] Invalid %s length, expected %d have %d",
+			key, len(dst), len(src),
+		)
+	}
+	copy(dst, src)
+	return nil
+}
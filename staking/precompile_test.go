@@ -1,13 +1,18 @@
 package staking
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"math/big"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/harmony-one/harmony/common/denominations"
+	"github.com/harmony-one/harmony/crypto/bls"
+	"github.com/harmony-one/harmony/numeric"
+	"github.com/harmony-one/harmony/staking/effective"
 	stakingTypes "github.com/harmony-one/harmony/staking/types"
 )
 
@@ -174,6 +179,24 @@ func testParseStakeMsg(test parseTest, t *testing.T) {
 					} else if !converted.Equals(*convertedExp) {
 						t.Errorf("Expected %+v but got %+v", test.expected, converted)
 					}
+				} else if converted, ok := res.(*stakingTypes.Redelegate); ok {
+					convertedExp, ok := test.expected.(*stakingTypes.Redelegate)
+					if !ok {
+						t.Errorf("Could not converted test.expected to *stakingTypes.Redelegate")
+					} else if !converted.Equals(*convertedExp) {
+						t.Errorf("Expected %+v but got %+v", test.expected, converted)
+					}
+				} else if converted, ok := res.(*stakingTypes.EditDelegation); ok {
+					convertedExp, ok := test.expected.(*stakingTypes.EditDelegation)
+					if !ok {
+						t.Errorf("Could not converted test.expected to *stakingTypes.EditDelegation")
+					} else if !converted.Equals(*convertedExp) {
+						t.Errorf("Expected %+v but got %+v", test.expected, converted)
+					}
+				} else if _, ok := res.(*stakingTypes.CreateValidator); ok {
+					checkStakeMsgEncoding(test.expected, res, t)
+				} else if _, ok := res.(*stakingTypes.EditValidator); ok {
+					checkStakeMsgEncoding(test.expected, res, t)
 				} else {
 					panic("Received unexpected result from ParseStakeMsg")
 				}
@@ -184,8 +207,213 @@ func testParseStakeMsg(test parseTest, t *testing.T) {
 	})
 }
 
+// checkStakeMsgEncoding compares the messages without an Equals method
+// through their encoding
+func checkStakeMsgEncoding(expected, res interface{}, t *testing.T) {
+	expectedEncoded, err := rlp.EncodeToBytes(expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resEncoded, err := rlp.EncodeToBytes(res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(expectedEncoded, resEncoded) {
+		t.Errorf("Expected %+v but got %+v", expected, res)
+	}
+}
+
 func TestParseStakeMsgs(t *testing.T) {
 	for _, test := range ParseStakeMsgTests {
 		testParseStakeMsg(test, t)
 	}
+	// built here since the ABI is only parsed by init
+	for _, test := range validatorParseStakeMsgTests() {
+		testParseStakeMsg(test, t)
+	}
+	for _, test := range redelegateParseStakeMsgTests() {
+		testParseStakeMsg(test, t)
+	}
+	for _, test := range editDelegationParseStakeMsgTests() {
+		testParseStakeMsg(test, t)
+	}
+}
+
+func redelegateParseStakeMsgTests() []parseTest {
+	amount := new(big.Int).Mul(big.NewInt(denominations.One), big.NewInt(100))
+	return []parseTest{
+		{
+			input: mustPackStaking("Redelegate", common.HexToAddress("0x1337"),
+				common.HexToAddress("0x1338"), common.HexToAddress("0x1339"), amount),
+			expected: &stakingTypes.Redelegate{
+				DelegatorAddress:     common.HexToAddress("0x1337"),
+				FromValidatorAddress: common.HexToAddress("0x1338"),
+				ToValidatorAddress:   common.HexToAddress("0x1339"),
+				Amount:               amount,
+			},
+			name: "redelegateSuccess",
+		},
+		{
+			input: mustPackStaking("Redelegate", common.HexToAddress("0x1338"),
+				common.HexToAddress("0x1338"), common.HexToAddress("0x1339"), amount),
+			expectedError: errors.New("[StakingPrecompile] Address mismatch, expected 0x0000000000000000000000000000000000001337 have 0x0000000000000000000000000000000000001338"),
+			name:          "redelegateAddressMismatch",
+		},
+		{
+			input: mustPackStaking("Redelegate", common.HexToAddress("0x1337"),
+				common.HexToAddress("0x1338"), common.HexToAddress("0x1339"), amount)[:131],
+			expectedError: errors.New("abi: cannot marshal in to go type: length insufficient 127 require 128"),
+			name:          "redelegateInvalidABI",
+		},
+	}
+}
+
+func editDelegationParseStakeMsgTests() []parseTest {
+	return []parseTest{
+		{
+			input: mustPackStaking("EditDelegation", common.HexToAddress("0x1337"),
+				common.HexToAddress("0x1338"), true),
+			expected: &stakingTypes.EditDelegation{
+				DelegatorAddress: common.HexToAddress("0x1337"),
+				ValidatorAddress: common.HexToAddress("0x1338"),
+				AutoCompound:     true,
+			},
+			name: "editDelegationSuccess",
+		},
+		{
+			input: mustPackStaking("EditDelegation", common.HexToAddress("0x1338"),
+				common.HexToAddress("0x1338"), false),
+			expectedError: errors.New("[StakingPrecompile] Address mismatch, expected 0x0000000000000000000000000000000000001337 have 0x0000000000000000000000000000000000001338"),
+			name:          "editDelegationAddressMismatch",
+		},
+	}
+}
+
+func mustPackStaking(name string, args ...interface{}) []byte {
+	input, err := abiStaking.Pack(name, args...)
+	if err != nil {
+		panic(err)
+	}
+	return input
+}
+
+func validatorParseStakeMsgTests() []parseTest {
+	validatorAddress := common.HexToAddress("0x1337")
+	pubKey, sig := bls.SerializedPublicKey{1}, bls.SerializedSignature{2}
+	amount := new(big.Int).Mul(big.NewInt(denominations.One), big.NewInt(10000))
+	maxTotalDelegation := new(big.Int).Mul(big.NewInt(denominations.One), big.NewInt(100000))
+	description := stakingTypes.Description{
+		Name:            "Alice",
+		Identity:        "alice",
+		Website:         "alice.harmony.one",
+		SecurityContact: "Bob",
+		Details:         "Don't mess with me!!!",
+	}
+	commissionRate := numeric.MustNewDecFromStr("0.15")
+	return []parseTest{
+		{
+			input: mustPackStaking("CreateValidator", validatorAddress,
+				description.Name, description.Identity, description.Website,
+				description.SecurityContact, description.Details,
+				"0.1", "0.9", "0.05", amount, maxTotalDelegation,
+				[][]byte{pubKey[:]}, [][]byte{sig[:]}, amount,
+			),
+			expected: &stakingTypes.CreateValidator{
+				ValidatorAddress: validatorAddress,
+				Description:      description,
+				CommissionRates: stakingTypes.CommissionRates{
+					Rate:          numeric.MustNewDecFromStr("0.1"),
+					MaxRate:       numeric.MustNewDecFromStr("0.9"),
+					MaxChangeRate: numeric.MustNewDecFromStr("0.05"),
+				},
+				MinSelfDelegation:  amount,
+				MaxTotalDelegation: maxTotalDelegation,
+				SlotPubKeys:        []bls.SerializedPublicKey{pubKey},
+				SlotKeySigs:        []bls.SerializedSignature{sig},
+				Amount:             amount,
+			},
+			name: "createValidatorSuccess",
+		},
+		{
+			input: mustPackStaking("CreateValidator", common.HexToAddress("0x1338"),
+				description.Name, description.Identity, description.Website,
+				description.SecurityContact, description.Details,
+				"0.1", "0.9", "0.05", amount, maxTotalDelegation,
+				[][]byte{pubKey[:]}, [][]byte{sig[:]}, amount,
+			),
+			expectedError: errors.New("[StakingPrecompile] Address mismatch, expected 0x0000000000000000000000000000000000001337 have 0x0000000000000000000000000000000000001338"),
+			name:          "createValidatorAddressMismatch",
+		},
+		{
+			input: mustPackStaking("CreateValidator", validatorAddress,
+				description.Name, description.Identity, description.Website,
+				description.SecurityContact, description.Details,
+				"0.1.0", "0.9", "0.05", amount, maxTotalDelegation,
+				[][]byte{pubKey[:]}, [][]byte{sig[:]}, amount,
+			),
+			expectedError: errors.New("[StakingPrecompile] Invalid rate: too many periods to be a decimal string"),
+			name:          "createValidatorInvalidRate",
+		},
+		{
+			input: mustPackStaking("CreateValidator", validatorAddress,
+				description.Name, description.Identity, description.Website,
+				description.SecurityContact, description.Details,
+				"0.1", "0.9", "0.05", amount, maxTotalDelegation,
+				[][]byte{pubKey[:47]}, [][]byte{sig[:]}, amount,
+			),
+			expectedError: errors.New("[StakingPrecompile] Invalid slotPubKeys length, expected 48 have 47"),
+			name:          "createValidatorInvalidPubKey",
+		},
+		{
+			input: mustPackStaking("EditValidator", validatorAddress,
+				description.Name, description.Identity, description.Website,
+				description.SecurityContact, description.Details,
+				"0.15", amount, maxTotalDelegation,
+				[]byte{}, pubKey[:], sig[:], uint8(effective.Active),
+			),
+			expected: &stakingTypes.EditValidator{
+				ValidatorAddress:   validatorAddress,
+				Description:        description,
+				CommissionRate:     &commissionRate,
+				MinSelfDelegation:  amount,
+				MaxTotalDelegation: maxTotalDelegation,
+				SlotKeyToAdd:       &pubKey,
+				SlotKeyToAddSig:    &sig,
+				EPOSStatus:         effective.Active,
+			},
+			name: "editValidatorSuccess",
+		},
+		{
+			input: mustPackStaking("EditValidator", validatorAddress,
+				"", "", "", "", "",
+				"", big.NewInt(0), big.NewInt(0),
+				pubKey[:], []byte{}, []byte{}, uint8(0),
+			),
+			expected: &stakingTypes.EditValidator{
+				ValidatorAddress:   validatorAddress,
+				MinSelfDelegation:  big.NewInt(0),
+				MaxTotalDelegation: big.NewInt(0),
+				SlotKeyToRemove:    &pubKey,
+			},
+			name: "editValidatorUnchanged",
+		},
+		{
+			input: mustPackStaking("EditValidator", common.HexToAddress("0x1338"),
+				"", "", "", "", "",
+				"", big.NewInt(0), big.NewInt(0),
+				[]byte{}, []byte{}, []byte{}, uint8(0),
+			),
+			expectedError: errors.New("[StakingPrecompile] Address mismatch, expected 0x0000000000000000000000000000000000001337 have 0x0000000000000000000000000000000000001338"),
+			name:          "editValidatorAddressMismatch",
+		},
+		{
+			input: mustPackStaking("EditValidator", validatorAddress,
+				"", "", "", "", "",
+				"", big.NewInt(0), big.NewInt(0),
+				[]byte{}, pubKey[:], sig[:95], uint8(0),
+			),
+			expectedError: errors.New("[StakingPrecompile] Invalid slotKeyToAddSig length, expected 96 have 95"),
+			name:          "editValidatorInvalidSig",
+		},
+	}
 }
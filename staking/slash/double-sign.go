@@ -435,12 +435,88 @@ func applySlashingToDelegation(delegation *staking.Delegation, state *state.DB,
 			payDownByUndelegation(undelegation, debtCopy, slashed)
 		}
 	}
+	// the stake redelegated away since the double sign is still liable,
+	// it is paid down from the delegations with the new validators
+	for i := range delegation.Redelegations {
+		if debtCopy.Sign() == 0 {
+			break
+		}
+		redelegation := &delegation.Redelegations[i]
+		if redelegation.Epoch.Cmp(doubleSignEpoch) >= 0 {
+			payDownByRedelegation(
+				delegation.DelegatorAddress, redelegation, state, debtCopy, slashed,
+			)
+		}
+	}
 	if debtCopy.Sign() == 1 {
 		payDownByReward(delegation, debtCopy, slashed)
 	}
 	return slashed
 }
 
+// payDownByRedelegation pays down the debt from the delegation of the
+// delegator with the validator the stake was redelegated to, then from the
+// undelegations of that delegation made since the redelegation, up to the
+// amount redelegated
+func payDownByRedelegation(
+	delegatorAddress common.Address,
+	redelegation *staking.Redelegation,
+	state *state.DB,
+	slashDebt, totalSlashed *big.Int,
+) {
+	wrapper, err := state.ValidatorWrapper(redelegation.ValidatorAddress, false, true)
+	if err != nil {
+		utils.Logger().Warn().Err(err).
+			Str("validator", redelegation.ValidatorAddress.Hex()).
+			Msg("could not find the redelegation validator during slash")
+		return
+	}
+	var delegation *staking.Delegation
+	for i := range wrapper.Delegations {
+		if wrapper.Delegations[i].DelegatorAddress == delegatorAddress {
+			delegation = &wrapper.Delegations[i]
+			break
+		}
+	}
+	if delegation == nil {
+		return
+	}
+	// only what is left of the redelegated stake can be slashed
+	debt := new(big.Int).Set(slashDebt)
+	if debt.Cmp(redelegation.Amount) > 0 {
+		debt.Set(redelegation.Amount)
+	}
+	slashable := new(big.Int).Set(debt)
+	payDown(delegation.Amount, debt, totalSlashed)
+
+	// the redelegated stake may since have been undelegated from the
+	// new validator, it is still liable while it is locked
+	for i := range delegation.Undelegations {
+		if debt.Sign() == 0 {
+			break
+		}
+		undelegation := &delegation.Undelegations[i]
+		if undelegation.Epoch.Cmp(redelegation.Epoch) >= 0 {
+			payDownByUndelegation(undelegation, debt, totalSlashed)
+		}
+	}
+	slashAmount := slashable.Sub(slashable, debt)
+	redelegation.Amount.Sub(redelegation.Amount, slashAmount)
+	slashDebt.Sub(slashDebt, slashAmount)
+
+	// same as undelegate, the self delegation can go below
+	// the min self delegation, the validator becomes inactive
+	if err := wrapper.SanityCheck(); err != nil &&
+		errors.Cause(err) == staking.ErrInvalidSelfDelegation {
+		wrapper.Status = effective.Inactive
+	}
+	if err := state.UpdateValidatorWrapper(wrapper.Address, wrapper); err != nil {
+		utils.Logger().Warn().Err(err).
+			Str("validator", redelegation.ValidatorAddress.Hex()).
+			Msg("could not update the redelegation validator during slash")
+	}
+}
+
 // Apply ..
 func Apply(
 	chain staking.ValidatorSnapshotReader, state *state.DB,
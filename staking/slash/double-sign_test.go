@@ -29,6 +29,7 @@ var (
 	bigOne          = big.NewInt(1e18)
 	fiveKOnes       = new(big.Int).Mul(big.NewInt(5000), bigOne)
 	tenKOnes        = new(big.Int).Mul(big.NewInt(10000), bigOne)
+	fifteenKOnes    = new(big.Int).Mul(big.NewInt(15000), bigOne)
 	twentyKOnes     = new(big.Int).Mul(big.NewInt(20000), bigOne)
 	twentyFiveKOnes = new(big.Int).Mul(big.NewInt(25000), bigOne)
 	thirtyKOnes     = new(big.Int).Mul(big.NewInt(30000), bigOne)
@@ -72,8 +73,9 @@ var (
 	offKey   = keyPairs[offIndex]
 	offPub   = offKey.Pub()
 
-	leaderAddr   = makeTestAddress("leader")
-	reporterAddr = makeTestAddress("reporter")
+	leaderAddr      = makeTestAddress("leader")
+	reporterAddr    = makeTestAddress("reporter")
+	redelegatedAddr = makeTestAddress("redelegated")
 )
 
 func TestVerify(t *testing.T) {
@@ -747,6 +749,337 @@ func (tc *slashApplyTestCase) checkResult() error {
 	return nil
 }
 
+func TestApplySlashingToDelegationRedelegated(t *testing.T) {
+	tests := []redelegationSlashTestCase{
+		{
+			// the debt is paid down from the stake redelegated after the double sign
+			amount:        big.NewInt(0),
+			redelegations: []staking.Redelegation{makeRedelegation(tenKOnes, doubleSignEpoch)},
+			destAmount:    twentyKOnes,
+			debt:          fiveKOnes,
+
+			expSlashed:     fiveKOnes,
+			expAmount:      big.NewInt(0),
+			expReward:      tenKOnes,
+			expRedelAmount: []*big.Int{fiveKOnes},
+			expDestAmount:  new(big.Int).Sub(twentyKOnes, fiveKOnes),
+			expStatus:      effective.Active,
+		},
+		{
+			// the debit is capped by the amount redelegated, the rest is carried
+			// over to the reward
+			amount:        fiveKOnes,
+			redelegations: []staking.Redelegation{makeRedelegation(tenKOnes, doubleSignEpoch+1)},
+			destAmount:    thirtyKOnes,
+			debt:          twentyKOnes,
+
+			expSlashed:     twentyKOnes,
+			expAmount:      big.NewInt(0),
+			expReward:      fiveKOnes,
+			expRedelAmount: []*big.Int{big.NewInt(0)},
+			expDestAmount:  twentyKOnes,
+			expStatus:      effective.Active,
+		},
+		{
+			// the stake redelegated before the double sign is exempt
+			amount:        big.NewInt(0),
+			redelegations: []staking.Redelegation{makeRedelegation(tenKOnes, doubleSignEpoch-1)},
+			destAmount:    twentyKOnes,
+			debt:          fiveKOnes,
+
+			expSlashed:     fiveKOnes,
+			expAmount:      big.NewInt(0),
+			expReward:      fiveKOnes,
+			expRedelAmount: []*big.Int{tenKOnes},
+			expDestAmount:  twentyKOnes,
+			expStatus:      effective.Active,
+		},
+		{
+			// only the stake redelegated after the double sign is debited
+			amount: big.NewInt(0),
+			redelegations: []staking.Redelegation{
+				makeRedelegation(tenKOnes, doubleSignEpoch-1),
+				makeRedelegation(fiveKOnes, doubleSignEpoch+1),
+			},
+			destAmount: twentyKOnes,
+			debt:       tenKOnes,
+
+			expSlashed:     tenKOnes,
+			expAmount:      big.NewInt(0),
+			expReward:      fiveKOnes,
+			expRedelAmount: []*big.Int{tenKOnes, big.NewInt(0)},
+			expDestAmount:  new(big.Int).Sub(twentyKOnes, fiveKOnes),
+			expStatus:      effective.Active,
+		},
+		{
+			// the debit is capped by what is left of the delegation with the
+			// destination validator
+			amount:        big.NewInt(0),
+			redelegations: []staking.Redelegation{makeRedelegation(tenKOnes, doubleSignEpoch)},
+			destAmount:    fiveKOnes,
+			debt:          twentyKOnes,
+
+			expSlashed:     fifteenKOnes,
+			expAmount:      big.NewInt(0),
+			expReward:      big.NewInt(0),
+			expRedelAmount: []*big.Int{fiveKOnes},
+			expDestAmount:  big.NewInt(0),
+			expStatus:      effective.Active,
+		},
+		{
+			// the destination validator goes inactive under the min self delegation
+			selfDelegated: true,
+			amount:        big.NewInt(0),
+			redelegations: []staking.Redelegation{makeRedelegation(tenKOnes, doubleSignEpoch)},
+			destAmount:    fifteenKOnes,
+			debt:          tenKOnes,
+
+			expSlashed:     tenKOnes,
+			expAmount:      big.NewInt(0),
+			expReward:      tenKOnes,
+			expRedelAmount: []*big.Int{big.NewInt(0)},
+			expDestAmount:  fiveKOnes,
+			expStatus:      effective.Inactive,
+		},
+		{
+			// the destination validator stays active above the min self delegation
+			selfDelegated: true,
+			amount:        big.NewInt(0),
+			redelegations: []staking.Redelegation{makeRedelegation(tenKOnes, doubleSignEpoch)},
+			destAmount:    thirtyKOnes,
+			debt:          tenKOnes,
+
+			expSlashed:     tenKOnes,
+			expAmount:      big.NewInt(0),
+			expReward:      tenKOnes,
+			expRedelAmount: []*big.Int{big.NewInt(0)},
+			expDestAmount:  twentyKOnes,
+			expStatus:      effective.Active,
+		},
+		{
+			// the debit goes on to the stake undelegated from the destination
+			// since the redelegation, up to the amount redelegated
+			amount:            big.NewInt(0),
+			redelegations:     []staking.Redelegation{makeRedelegation(tenKOnes, doubleSignEpoch)},
+			destAmount:        fiveKOnes,
+			destUndelegations: []staking.Undelegation{makeUndelegation(tenKOnes, doubleSignEpoch+1)},
+			debt:              twentyKOnes,
+
+			expSlashed:          twentyKOnes,
+			expAmount:           big.NewInt(0),
+			expReward:           big.NewInt(0),
+			expRedelAmount:      []*big.Int{big.NewInt(0)},
+			expDestAmount:       big.NewInt(0),
+			expDestUndelAmounts: []*big.Int{fiveKOnes},
+			expStatus:           effective.Active,
+		},
+		{
+			// the stake undelegated from the destination before the redelegation
+			// is exempt
+			amount:            big.NewInt(0),
+			redelegations:     []staking.Redelegation{makeRedelegation(tenKOnes, doubleSignEpoch+1)},
+			destAmount:        fiveKOnes,
+			destUndelegations: []staking.Undelegation{makeUndelegation(tenKOnes, doubleSignEpoch)},
+			debt:              twentyKOnes,
+
+			expSlashed:          fifteenKOnes,
+			expAmount:           big.NewInt(0),
+			expReward:           big.NewInt(0),
+			expRedelAmount:      []*big.Int{fiveKOnes},
+			expDestAmount:       big.NewInt(0),
+			expDestUndelAmounts: []*big.Int{tenKOnes},
+			expStatus:           effective.Active,
+		},
+	}
+	for i, tc := range tests {
+		if err := tc.makeData(); err != nil {
+			t.Fatalf("Test %v: %v", i, err)
+		}
+		tc.apply()
+
+		if err := tc.checkResult(); err != nil {
+			t.Errorf("Test %v: %v", i, err)
+		}
+	}
+}
+
+// redelegationSlashTestCase slashes a delegation with the offender which
+// redelegated stake to redelegatedAddr
+type redelegationSlashTestCase struct {
+	selfDelegated     bool
+	amount            *big.Int
+	redelegations     []staking.Redelegation
+	destAmount        *big.Int
+	destUndelegations []staking.Undelegation
+	debt              *big.Int
+
+	state      *state.DB
+	delegation staking.Delegation
+	gotSlashed *big.Int
+
+	expSlashed, expAmount, expReward *big.Int
+	expRedelAmount                   []*big.Int
+	expDestAmount                    *big.Int
+	expDestUndelAmounts              []*big.Int
+	expStatus                        effective.Eligibility
+}
+
+func (tc *redelegationSlashTestCase) delegator() common.Address {
+	if tc.selfDelegated {
+		return redelegatedAddr
+	}
+	return makeTestAddress("del1")
+}
+
+func (tc *redelegationSlashTestCase) makeData() error {
+	tc.state = makeTestStateDB()
+
+	v := defaultTestValidator([]bls.SerializedPublicKey{keyPairs[offIndex+1].Pub()})
+	v.Address = redelegatedAddr
+	w := &staking.ValidatorWrapper{Validator: v}
+	if tc.selfDelegated {
+		w.Delegations = staking.Delegations{
+			makeDelegation(redelegatedAddr, new(big.Int).Set(tc.destAmount)),
+		}
+	} else {
+		w.Delegations = staking.Delegations{
+			makeDelegation(redelegatedAddr, new(big.Int).Set(twentyKOnes)),
+			makeDelegation(tc.delegator(), new(big.Int).Set(tc.destAmount)),
+		}
+	}
+	dest := &w.Delegations[len(w.Delegations)-1]
+	for _, undelegation := range tc.destUndelegations {
+		dest.Undelegations = append(dest.Undelegations,
+			makeUndelegation(undelegation.Amount, undelegation.Epoch.Int64()))
+	}
+	if err := tc.state.UpdateValidatorWrapper(redelegatedAddr, w); err != nil {
+		return err
+	}
+
+	tc.delegation = makeDelegation(tc.delegator(), new(big.Int).Set(tc.amount))
+	tc.delegation.Redelegations = tc.redelegations
+	return nil
+}
+
+func (tc *redelegationSlashTestCase) apply() {
+	tc.gotSlashed = applySlashingToDelegation(&tc.delegation, tc.state, leaderAddr,
+		big.NewInt(doubleSignEpoch), new(big.Int).Set(tc.debt))
+}
+
+func (tc *redelegationSlashTestCase) checkResult() error {
+	if tc.gotSlashed.Cmp(tc.expSlashed) != 0 {
+		return fmt.Errorf("unexpected slashed %v / %v", tc.gotSlashed, tc.expSlashed)
+	}
+	if tc.delegation.Amount.Cmp(tc.expAmount) != 0 {
+		return fmt.Errorf("unexpected amount %v / %v", tc.delegation.Amount, tc.expAmount)
+	}
+	if tc.delegation.Reward.Cmp(tc.expReward) != 0 {
+		return fmt.Errorf("unexpected reward %v / %v", tc.delegation.Reward, tc.expReward)
+	}
+	for i, redelegation := range tc.delegation.Redelegations {
+		if redelegation.Amount.Cmp(tc.expRedelAmount[i]) != 0 {
+			return fmt.Errorf("[%v]th redelegation unexpected amount %v / %v", i,
+				redelegation.Amount, tc.expRedelAmount[i])
+		}
+	}
+
+	w, err := tc.state.ValidatorWrapper(redelegatedAddr, true, false)
+	if err != nil {
+		return err
+	}
+	var dest *staking.Delegation
+	for i := range w.Delegations {
+		if w.Delegations[i].DelegatorAddress == tc.delegator() {
+			dest = &w.Delegations[i]
+		}
+	}
+	if dest == nil || dest.Amount.Cmp(tc.expDestAmount) != 0 {
+		return fmt.Errorf("unexpected amount with the destination %v / %v", dest,
+			tc.expDestAmount)
+	}
+	if len(dest.Undelegations) != len(tc.expDestUndelAmounts) {
+		return fmt.Errorf("unexpected undelegations with the destination %v / %v",
+			len(dest.Undelegations), len(tc.expDestUndelAmounts))
+	}
+	for i, undelegation := range dest.Undelegations {
+		if undelegation.Amount.Cmp(tc.expDestUndelAmounts[i]) != 0 {
+			return fmt.Errorf("[%v]th undelegation with the destination unexpected amount %v / %v",
+				i, undelegation.Amount, tc.expDestUndelAmounts[i])
+		}
+	}
+	if w.Status != tc.expStatus {
+		return fmt.Errorf("unexpected status of the destination %v / %v", w.Status, tc.expStatus)
+	}
+	return nil
+}
+
+func makeRedelegation(amount *big.Int, epoch int64) staking.Redelegation {
+	return staking.Redelegation{
+		ValidatorAddress: redelegatedAddr,
+		Amount:           new(big.Int).Set(amount),
+		Epoch:            big.NewInt(epoch),
+	}
+}
+
+func makeUndelegation(amount *big.Int, epoch int64) staking.Undelegation {
+	return staking.Undelegation{
+		Amount: new(big.Int).Set(amount),
+		Epoch:  big.NewInt(epoch),
+	}
+}
+
+// TestApplySlashingRedelegatedThenUndelegated redelegates stake after the
+// double sign, undelegates it from the new validator, then slashes
+func TestApplySlashingRedelegatedThenUndelegated(t *testing.T) {
+	st := makeTestStateDB()
+	delegator := makeTestAddress("del1")
+
+	delegation := makeDelegation(delegator, new(big.Int).Set(tenKOnes))
+	if err := delegation.Redelegate(big.NewInt(doubleSignEpoch+1), redelegatedAddr, tenKOnes); err != nil {
+		t.Fatal(err)
+	}
+
+	v := defaultTestValidator([]bls.SerializedPublicKey{keyPairs[offIndex+1].Pub()})
+	v.Address = redelegatedAddr
+	w := &staking.ValidatorWrapper{
+		Validator: v,
+		Delegations: staking.Delegations{
+			makeDelegation(redelegatedAddr, new(big.Int).Set(twentyKOnes)),
+			makeDelegation(delegator, new(big.Int).Set(tenKOnes)),
+		},
+	}
+	if err := w.Delegations[1].Undelegate(big.NewInt(doubleSignEpoch+2), new(big.Int).Set(tenKOnes)); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.UpdateValidatorWrapper(redelegatedAddr, w); err != nil {
+		t.Fatal(err)
+	}
+
+	slashed := applySlashingToDelegation(&delegation, st, leaderAddr,
+		big.NewInt(doubleSignEpoch), new(big.Int).Set(fifteenKOnes))
+	if slashed.Cmp(fifteenKOnes) != 0 {
+		t.Errorf("unexpected slashed %v / %v", slashed, fifteenKOnes)
+	}
+	if delegation.Reward.Cmp(fiveKOnes) != 0 {
+		t.Errorf("unexpected reward %v / %v", delegation.Reward, fiveKOnes)
+	}
+	if amount := delegation.Redelegations[0].Amount; amount.Sign() != 0 {
+		t.Errorf("unexpected redelegation amount %v / 0", amount)
+	}
+
+	w, err := st.ValidatorWrapper(redelegatedAddr, true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dest := w.Delegations[1]
+	if dest.Amount.Sign() != 0 {
+		t.Errorf("unexpected amount with the destination %v / 0", dest.Amount)
+	}
+	if amount := dest.Undelegations[0].Amount; amount.Sign() != 0 {
+		t.Errorf("unexpected undelegation with the destination %v / 0", amount)
+	}
+}
+
 type expDelegation struct {
 	expAmt, expReward *big.Int
 	expUndelAmt       []*big.Int
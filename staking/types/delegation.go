@@ -12,8 +12,9 @@ import (
 )
 
 var (
-	errInsufficientBalance = errors.New("insufficient balance to undelegate")
-	errInvalidAmount       = errors.New("invalid amount, must be positive")
+	errInsufficientBalance           = errors.New("insufficient balance to undelegate")
+	errInvalidAmount                 = errors.New("invalid amount, must be positive")
+	errInsufficientBalanceRedelegate = errors.New("insufficient balance to redelegate")
 )
 
 const (
@@ -21,6 +22,9 @@ const (
 	LockPeriodInEpoch = 7
 	// LockPeriodInEpochV2 there is no extended locking time besides the current epoch time.
 	LockPeriodInEpochV2 = 0
+	// RedelegationCooldownInEpoch is the number of epochs a redelegated token needs to be before it can be
+	// redelegated again, it stays slashable by the validator it was redelegated from for as long
+	RedelegationCooldownInEpoch = 7
 )
 
 // Delegation represents the bond with tokens held by an account. It is
@@ -31,6 +35,11 @@ type Delegation struct {
 	Amount           *big.Int
 	Reward           *big.Int
 	Undelegations    Undelegations
+	// the tokens moved to other validators, which are still slashable
+	// optional so that the delegations without any keep their encoding
+	Redelegations Redelegations `rlp:"optional"`
+	// whether the rewards are added to the amount instead of being collected
+	AutoCompound bool `rlp:"optional"`
 }
 
 // Delegations ..
@@ -49,8 +58,10 @@ func (d Delegation) MarshalJSON() ([]byte, error) {
 		Amount           *big.Int      `json:"amount"`
 		Reward           *big.Int      `json:"reward"`
 		Undelegations    Undelegations `json:"undelegations"`
+		Redelegations    Redelegations `json:"redelegations,omitempty"`
+		AutoCompound     bool          `json:"auto-compound,omitempty"`
 	}{common2.MustAddressToBech32(d.DelegatorAddress), d.Amount,
-		d.Reward, d.Undelegations,
+		d.Reward, d.Undelegations, d.Redelegations, d.AutoCompound,
 	})
 }
 
@@ -99,6 +110,23 @@ func (u Undelegations) String() string {
 	return string(s)
 }
 
+// Redelegation represents one redelegation entry, the tokens moved
+// to another validator in the epoch
+type Redelegation struct {
+	ValidatorAddress common.Address `json:"validator-address"`
+	Amount           *big.Int       `json:"amount"`
+	Epoch            *big.Int       `json:"epoch"`
+}
+
+// Redelegations ..
+type Redelegations []Redelegation
+
+// String ..
+func (r Redelegations) String() string {
+	s, _ := json.Marshal(r)
+	return string(s)
+}
+
 // DelegationIndexes is a slice of DelegationIndex
 type DelegationIndexes []DelegationIndex
 
@@ -202,3 +230,79 @@ func (d *Delegation) RemoveUnlockedUndelegations(
 	d.Undelegations = d.Undelegations[count:]
 	return totalWithdraw
 }
+
+// Redelegate - move the amount out of the delegation, and append an
+// entry to the redelegations
+func (d *Delegation) Redelegate(epoch *big.Int, validatorAddress common.Address, amt *big.Int) error {
+	if amt.Sign() <= 0 {
+		return errInvalidAmount
+	}
+	if d.Amount.Cmp(amt) < 0 {
+		return errInsufficientBalanceRedelegate
+	}
+	d.Amount.Sub(d.Amount, amt)
+
+	for _, entry := range d.Redelegations {
+		if entry.Epoch.Cmp(epoch) == 0 && entry.ValidatorAddress == validatorAddress {
+			entry.Amount.Add(entry.Amount, amt)
+			return nil
+		}
+	}
+	// the entries are appended in the increasing order of epoch
+	d.Redelegations = append(d.Redelegations, Redelegation{
+		ValidatorAddress: validatorAddress,
+		Amount:           new(big.Int).Set(amt),
+		Epoch:            new(big.Int).Set(epoch),
+	})
+	return nil
+}
+
+// TotalInRedelegationCooldown - return the total amount of token redelegated
+// to the validator which is still in the cooldown period
+func (d *Delegation) TotalInRedelegationCooldown(
+	curEpoch *big.Int, validatorAddress common.Address, cooldown int,
+) *big.Int {
+	total := big.NewInt(0)
+	for _, entry := range d.Redelegations {
+		if entry.ValidatorAddress != validatorAddress {
+			continue
+		}
+		if new(big.Int).Sub(curEpoch, entry.Epoch).Int64() < int64(cooldown) {
+			total.Add(total, entry.Amount)
+		}
+	}
+	return total
+}
+
+// RemoveExpiredRedelegations removes all the redelegations past the
+// cooldown period, which are no longer slashable
+func (d *Delegation) RemoveExpiredRedelegations(curEpoch *big.Int, cooldown int) {
+	count := 0
+	for _, entry := range d.Redelegations {
+		if new(big.Int).Sub(curEpoch, entry.Epoch).Int64() < int64(cooldown) {
+			break
+		}
+		count++
+	}
+	if count == 0 {
+		return
+	}
+	if count == len(d.Redelegations) {
+		// back to nil, as for the delegations which never redelegated
+		d.Redelegations = nil
+		return
+	}
+	d.Redelegations = d.Redelegations[count:]
+}
+
+// SetAutoCompound sets whether the rewards of the delegation are added to
+// its amount instead of being collected
+func (d *Delegation) SetAutoCompound(autoCompound bool) {
+	d.AutoCompound = autoCompound
+	// the redelegations are encoded ahead of the flag, an empty list left
+	// from the decoding is dropped for the encoding to be the same as the
+	// one of a delegation which never had the flag
+	if len(d.Redelegations) == 0 {
+		d.Redelegations = nil
+	}
+}
@@ -1,10 +1,12 @@
 package types
 
 import (
+	"bytes"
 	"math/big"
 	"testing"
 
 	common "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
 	common2 "github.com/harmony-one/harmony/internal/common"
 )
 
@@ -286,3 +288,138 @@ func TestNoMaxRateAtExcess(t *testing.T) {
 		t.Errorf("should remove undelegations at 8")
 	}
 }
+
+func TestRedelegate(t *testing.T) {
+	d := NewDelegation(delegatorAddr, big.NewInt(10000))
+	toAddr := common.BigToAddress(common.Big1)
+	if err := d.Redelegate(big.NewInt(10), toAddr, big.NewInt(1000)); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Redelegate(big.NewInt(10), toAddr, big.NewInt(500)); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Redelegate(big.NewInt(12), toAddr, big.NewInt(2000)); err != nil {
+		t.Fatal(err)
+	}
+	if d.Amount.Cmp(big.NewInt(6500)) != 0 {
+		t.Errorf("redelegate failed, amount %v does not match", d.Amount)
+	}
+	if len(d.Redelegations) != 2 {
+		t.Fatalf("total number of redelegations should have been two")
+	}
+	if d.Redelegations[0].Amount.Cmp(big.NewInt(1500)) != 0 {
+		t.Errorf("redelegate failed, entries of the same epoch are not merged")
+	}
+	if err := d.Redelegate(big.NewInt(12), toAddr, big.NewInt(10000)); err != errInsufficientBalanceRedelegate {
+		t.Errorf("expected %v, got %v", errInsufficientBalanceRedelegate, err)
+	}
+	if err := d.Redelegate(big.NewInt(12), toAddr, big.NewInt(0)); err != errInvalidAmount {
+		t.Errorf("expected %v, got %v", errInvalidAmount, err)
+	}
+}
+
+func TestTotalInRedelegationCooldown(t *testing.T) {
+	d := NewDelegation(delegatorAddr, big.NewInt(10000))
+	toAddr := common.BigToAddress(common.Big1)
+	d.Redelegate(big.NewInt(10), toAddr, big.NewInt(1000))
+	d.Redelegate(big.NewInt(12), toAddr, big.NewInt(2000))
+	d.Redelegate(big.NewInt(12), common.BigToAddress(common.Big2), big.NewInt(4000))
+
+	total := d.TotalInRedelegationCooldown(big.NewInt(17), toAddr, RedelegationCooldownInEpoch)
+	if total.Cmp(big.NewInt(2000)) != 0 {
+		t.Errorf("expected 2000 in cooldown, got %v", total)
+	}
+	total = d.TotalInRedelegationCooldown(big.NewInt(16), toAddr, RedelegationCooldownInEpoch)
+	if total.Cmp(big.NewInt(3000)) != 0 {
+		t.Errorf("expected 3000 in cooldown, got %v", total)
+	}
+}
+
+func TestRemoveExpiredRedelegations(t *testing.T) {
+	d := NewDelegation(delegatorAddr, big.NewInt(10000))
+	toAddr := common.BigToAddress(common.Big1)
+	d.Redelegate(big.NewInt(10), toAddr, big.NewInt(1000))
+	d.Redelegate(big.NewInt(12), toAddr, big.NewInt(2000))
+
+	d.RemoveExpiredRedelegations(big.NewInt(16), RedelegationCooldownInEpoch)
+	if len(d.Redelegations) != 2 {
+		t.Errorf("redelegations removed before the end of the cooldown")
+	}
+	d.RemoveExpiredRedelegations(big.NewInt(17), RedelegationCooldownInEpoch)
+	if len(d.Redelegations) != 1 || d.Redelegations[0].Epoch.Cmp(big.NewInt(12)) != 0 {
+		t.Errorf("expired redelegation not removed")
+	}
+	d.RemoveExpiredRedelegations(big.NewInt(19), RedelegationCooldownInEpoch)
+	if d.Redelegations != nil {
+		t.Errorf("expected no redelegations, got %v", d.Redelegations)
+	}
+}
+
+func TestDelegationEncodingWithoutRedelegations(t *testing.T) {
+	legacy := struct {
+		DelegatorAddress common.Address
+		Amount           *big.Int
+		Reward           *big.Int
+		Undelegations    Undelegations
+	}{delegatorAddr, big.NewInt(100), big.NewInt(1), Undelegations{}}
+	d := Delegation{
+		DelegatorAddress: delegatorAddr,
+		Amount:           big.NewInt(100),
+		Reward:           big.NewInt(1),
+		Undelegations:    Undelegations{},
+	}
+	legacyEncoded, err := rlp.EncodeToBytes(legacy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := rlp.EncodeToBytes(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(legacyEncoded, encoded) {
+		t.Errorf("encoding changed for the delegations without redelegations")
+	}
+	decoded := Delegation{}
+	if err := rlp.DecodeBytes(legacyEncoded, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Redelegations != nil {
+		t.Errorf("expected no redelegations, got %v", decoded.Redelegations)
+	}
+}
+
+func TestSetAutoCompound(t *testing.T) {
+	d := Delegation{
+		DelegatorAddress: delegatorAddr,
+		Amount:           big.NewInt(100),
+		Reward:           big.NewInt(1),
+		Undelegations:    Undelegations{},
+	}
+	legacyEncoded, err := rlp.EncodeToBytes(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d.SetAutoCompound(true)
+	encoded, err := rlp.EncodeToBytes(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := Delegation{}
+	if err := rlp.DecodeBytes(encoded, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.AutoCompound {
+		t.Errorf("expected the flag to be decoded")
+	}
+
+	// back to the encoding of a delegation which never had the flag
+	decoded.SetAutoCompound(false)
+	encoded, err = rlp.EncodeToBytes(decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(legacyEncoded, encoded) {
+		t.Errorf("encoding changed after the flag is unset")
+	}
+}
@@ -27,6 +27,10 @@ const (
 	DirectiveUndelegate
 	// DirectiveCollectRewards ...
 	DirectiveCollectRewards
+	// DirectiveRedelegate ...
+	DirectiveRedelegate
+	// DirectiveEditDelegation ...
+	DirectiveEditDelegation
 )
 
 var (
@@ -36,6 +40,8 @@ var (
 		DirectiveDelegate:        "Delegate",
 		DirectiveUndelegate:      "Undelegate",
 		DirectiveCollectRewards:  "CollectRewards",
+		DirectiveRedelegate:      "Redelegate",
+		DirectiveEditDelegation:  "EditDelegation",
 	}
 	// ErrInvalidStakingKind given when caller gives bad staking message kind
 	ErrInvalidStakingKind = errors.New("bad staking kind")
@@ -247,6 +253,81 @@ func (v CollectRewards) Equals(s CollectRewards) bool {
 	return bytes.Equal(v.DelegatorAddress.Bytes(), s.DelegatorAddress.Bytes())
 }
 
+// Redelegate - type for moving a delegation from one validator to another
+type Redelegate struct {
+	DelegatorAddress     common.Address `json:"delegator_address"`
+	FromValidatorAddress common.Address `json:"from_validator_address"`
+	ToValidatorAddress   common.Address `json:"to_validator_address"`
+	Amount               *big.Int       `json:"amount"`
+}
+
+// Type of Redelegate
+func (v Redelegate) Type() Directive {
+	return DirectiveRedelegate
+}
+
+// Copy returns a deep copy of the Redelegate as a StakeMsg interface
+func (v Redelegate) Copy() StakeMsg {
+	cp := Redelegate{
+		DelegatorAddress:     v.DelegatorAddress,
+		FromValidatorAddress: v.FromValidatorAddress,
+		ToValidatorAddress:   v.ToValidatorAddress,
+	}
+	if v.Amount != nil {
+		cp.Amount = new(big.Int).Set(v.Amount)
+	}
+	return cp
+}
+
+// Equals returns if v and s are equal
+func (v Redelegate) Equals(s Redelegate) bool {
+	if !bytes.Equal(v.DelegatorAddress.Bytes(), s.DelegatorAddress.Bytes()) {
+		return false
+	}
+	if !bytes.Equal(v.FromValidatorAddress.Bytes(), s.FromValidatorAddress.Bytes()) {
+		return false
+	}
+	if !bytes.Equal(v.ToValidatorAddress.Bytes(), s.ToValidatorAddress.Bytes()) {
+		return false
+	}
+	if v.Amount == nil {
+		return s.Amount == nil
+	}
+	return s.Amount != nil && v.Amount.Cmp(s.Amount) == 0 // pointer
+}
+
+// EditDelegation - type for editing the settings of a delegation
+type EditDelegation struct {
+	DelegatorAddress common.Address `json:"delegator_address"`
+	ValidatorAddress common.Address `json:"validator_address"`
+	AutoCompound     bool           `json:"auto_compound"`
+}
+
+// Type of EditDelegation
+func (v EditDelegation) Type() Directive {
+	return DirectiveEditDelegation
+}
+
+// Copy returns a deep copy of the EditDelegation as a StakeMsg interface
+func (v EditDelegation) Copy() StakeMsg {
+	return EditDelegation{
+		DelegatorAddress: v.DelegatorAddress,
+		ValidatorAddress: v.ValidatorAddress,
+		AutoCompound:     v.AutoCompound,
+	}
+}
+
+// Equals returns if v and s are equal
+func (v EditDelegation) Equals(s EditDelegation) bool {
+	if !bytes.Equal(v.DelegatorAddress.Bytes(), s.DelegatorAddress.Bytes()) {
+		return false
+	}
+	if !bytes.Equal(v.ValidatorAddress.Bytes(), s.ValidatorAddress.Bytes()) {
+		return false
+	}
+	return v.AutoCompound == s.AutoCompound
+}
+
 // Migration Msg - type for switching delegation from one user to next
 type MigrationMsg struct {
 	From common.Address `json:"from" rlp:"nil"`
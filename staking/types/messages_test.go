@@ -19,6 +19,8 @@ var (
 	testDelegate, zeroDelegate               Delegate
 	testUndelegate, zeroUndelegate           Undelegate
 	testCollectReward, zeroCollectReward     CollectRewards
+	testRedelegate, zeroRedelegate           Redelegate
+	testEditDelegation                       EditDelegation
 )
 
 func init() {
@@ -35,6 +37,8 @@ func TestDirective_String(t *testing.T) {
 		{DirectiveDelegate, "Delegate"},
 		{DirectiveUndelegate, "Undelegate"},
 		{DirectiveCollectRewards, "CollectRewards"},
+		{DirectiveRedelegate, "Redelegate"},
+		{DirectiveEditDelegation, "EditDelegation"},
 		{0xff, "Directive 255"},
 	}
 	for i, test := range tests {
@@ -56,6 +60,8 @@ func TestStakeMsg_Type(t *testing.T) {
 		{testDelegate, DirectiveDelegate},
 		{testUndelegate, DirectiveUndelegate},
 		{testCollectReward, DirectiveCollectRewards},
+		{testRedelegate, DirectiveRedelegate},
+		{testEditDelegation, DirectiveEditDelegation},
 	}
 	for i, test := range tests {
 		dir := test.msg.Type()
@@ -182,6 +188,61 @@ func TestCollectRewards_Copy(t *testing.T) {
 	}
 }
 
+func TestRedelegate_Copy(t *testing.T) {
+	tests := []struct {
+		r Redelegate
+	}{
+		{testRedelegate}, // non-zero values
+		{zeroRedelegate}, // zero values
+		{Redelegate{}},   // empty values
+	}
+	for i, test := range tests {
+		cp := test.r.Copy().(Redelegate)
+
+		if err := assertRedelegateDeepCopy(cp, test.r); err != nil {
+			t.Errorf("Test %v: %v", i, err)
+		}
+		if !cp.Equals(test.r) {
+			t.Errorf("Test %v: copy not equal", i)
+		}
+	}
+}
+
+func TestEditDelegation_Copy(t *testing.T) {
+	tests := []struct {
+		e EditDelegation
+	}{
+		{testEditDelegation}, // non-zero values
+		{EditDelegation{}},   // empty values
+	}
+	for i, test := range tests {
+		cp := test.e.Copy().(EditDelegation)
+
+		if !reflect.DeepEqual(cp, test.e) {
+			t.Errorf("Test %v: not deep equal", i)
+		}
+		if !cp.Equals(test.e) {
+			t.Errorf("Test %v: copy not equal", i)
+		}
+	}
+}
+
+func assertRedelegateDeepCopy(r1, r2 Redelegate) error {
+	if !reflect.DeepEqual(r1, r2) {
+		return fmt.Errorf("not deep equal")
+	}
+	if &r1.FromValidatorAddress == &r2.FromValidatorAddress {
+		return fmt.Errorf("FromValidatorAddress same pointer")
+	}
+	if &r1.ToValidatorAddress == &r2.ToValidatorAddress {
+		return fmt.Errorf("ToValidatorAddress same pointer")
+	}
+	if r1.Amount != nil && r1.Amount == r2.Amount {
+		return fmt.Errorf("amount same pointer")
+	}
+	return nil
+}
+
 func assertCreateValidatorDeepCopy(cv1, cv2 CreateValidator) error {
 	if !reflect.DeepEqual(cv1, cv2) {
 		return fmt.Errorf("not deep equal")
@@ -363,5 +424,21 @@ func cpTestDataSetup() {
 	testCollectReward = CollectRewards{
 		DelegatorAddress: common.BigToAddress(common.Big1),
 	}
+
+	testRedelegate = Redelegate{
+		DelegatorAddress:     common.BigToAddress(common.Big1),
+		FromValidatorAddress: validatorAddr,
+		ToValidatorAddress:   common.BigToAddress(common.Big2),
+		Amount:               twelveK,
+	}
+	zeroRedelegate = Redelegate{
+		Amount: common.Big0,
+	}
+
+	testEditDelegation = EditDelegation{
+		DelegatorAddress: common.BigToAddress(common.Big1),
+		ValidatorAddress: validatorAddr,
+		AutoCompound:     true,
+	}
 	zeroCollectReward = CollectRewards{}
 }
@@ -97,6 +97,8 @@ func CopyDelegation(d staking.Delegation) staking.Delegation {
 	cp := staking.Delegation{
 		DelegatorAddress: d.DelegatorAddress,
 		Undelegations:    CopyUndelegations(d.Undelegations),
+		Redelegations:    CopyRedelegations(d.Redelegations),
+		AutoCompound:     d.AutoCompound,
 	}
 	if d.Amount != nil {
 		cp.Amount = new(big.Int).Set(d.Amount)
@@ -130,3 +132,29 @@ func CopyUndelegation(ud staking.Undelegation) staking.Undelegation {
 	}
 	return cp
 }
+
+// CopyRedelegations deep copies staking.Redelegations
+func CopyRedelegations(rds staking.Redelegations) staking.Redelegations {
+	if rds == nil {
+		return nil
+	}
+	cp := make(staking.Redelegations, 0, len(rds))
+	for _, rd := range rds {
+		cp = append(cp, CopyRedelegation(rd))
+	}
+	return cp
+}
+
+// CopyRedelegation deep copies staking.Redelegation
+func CopyRedelegation(rd staking.Redelegation) staking.Redelegation {
+	cp := staking.Redelegation{
+		ValidatorAddress: rd.ValidatorAddress,
+	}
+	if rd.Amount != nil {
+		cp.Amount = new(big.Int).Set(rd.Amount)
+	}
+	if rd.Epoch != nil {
+		cp.Epoch = new(big.Int).Set(rd.Epoch)
+	}
+	return cp
+}
@@ -104,6 +104,13 @@ func checkDelegationEqual(d1, d2 staking.Delegation) error {
 	if err := checkUndelegationsEqual(d1.Undelegations, d2.Undelegations); err != nil {
 		return fmt.Errorf(".Undelegations%v", err)
 	}
+	if err := checkRedelegationsEqual(d1.Redelegations, d2.Redelegations); err != nil {
+		return fmt.Errorf(".Redelegations%v", err)
+	}
+	if d1.AutoCompound != d2.AutoCompound {
+		return fmt.Errorf(".AutoCompound not equal: %v / %v",
+			d1.AutoCompound, d2.AutoCompound)
+	}
 	return nil
 }
 
@@ -129,6 +136,32 @@ func checkUndelegationEqual(ud1, ud2 staking.Undelegation) error {
 	return nil
 }
 
+func checkRedelegationsEqual(rds1, rds2 staking.Redelegations) error {
+	if len(rds1) != len(rds2) {
+		return fmt.Errorf(".len not equal: %v / %v", len(rds1), len(rds2))
+	}
+	for i := range rds1 {
+		if err := checkRedelegationEqual(rds1[i], rds2[i]); err != nil {
+			return fmt.Errorf("[%v]%v", i, err)
+		}
+	}
+	return nil
+}
+
+func checkRedelegationEqual(rd1, rd2 staking.Redelegation) error {
+	if rd1.ValidatorAddress != rd2.ValidatorAddress {
+		return fmt.Errorf(".ValidatorAddress not equal: %x / %x",
+			rd1.ValidatorAddress, rd2.ValidatorAddress)
+	}
+	if err := checkBigIntEqual(rd1.Amount, rd2.Amount); err != nil {
+		return fmt.Errorf(".Amount %v", err)
+	}
+	if err := checkBigIntEqual(rd1.Epoch, rd2.Epoch); err != nil {
+		return fmt.Errorf(".Epoch %v", err)
+	}
+	return nil
+}
+
 func checkPubKeysEqual(pubs1, pubs2 []bls.SerializedPublicKey) error {
 	if len(pubs1) != len(pubs2) {
 		return fmt.Errorf(".len not equal: %v / %v", len(pubs1), len(pubs2))
@@ -290,6 +290,10 @@ func RLPDecodeStakeMsg(payload []byte, d Directive) (interface{}, error) {
 			ds = &Undelegate{}
 		case DirectiveCollectRewards:
 			ds = &CollectRewards{}
+		case DirectiveRedelegate:
+			ds = &Redelegate{}
+		case DirectiveEditDelegation:
+			ds = &EditDelegation{}
 		default:
 			return nil, nil
 		}
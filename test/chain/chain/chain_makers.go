@@ -27,6 +27,7 @@ import (
 	"github.com/harmony-one/harmony/block"
 	blockfactory "github.com/harmony-one/harmony/block/factory"
 	consensus_engine "github.com/harmony-one/harmony/consensus/engine"
+	"github.com/harmony-one/harmony/consensus/misc"
 	"github.com/harmony-one/harmony/core/state"
 	"github.com/harmony-one/harmony/core/types"
 	"github.com/harmony-one/harmony/core/vm"
@@ -223,7 +224,7 @@ func makeHeader(chain consensus_engine.ChainReader, parent *block.Header, state
 		time = new(big.Int).Add(parent.Time(), big.NewInt(10)) // block time is fixed at 10 seconds
 	}
 
-	return factory.NewHeader(parent.Epoch()).With().
+	header := factory.NewHeader(parent.Epoch()).With().
 		Root(state.IntermediateRoot(chain.Config().IsS3(parent.Epoch()))).
 		ParentHash(parent.Hash()).
 		Coinbase(parent.Coinbase()).
@@ -231,6 +232,10 @@ func makeHeader(chain consensus_engine.ChainReader, parent *block.Header, state
 		Number(new(big.Int).Add(parent.Number(), common.Big1)).
 		Time(time).
 		Header()
+	if chain.Config().IsEIP1559(parent.Epoch()) {
+		header.SetBaseFee(misc.CalcBaseFee(chain.Config(), parent))
+	}
+	return header
 }
 
 type fakeChainReader struct {
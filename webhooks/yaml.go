@@ -23,6 +23,7 @@ type AvailabilityHooks struct {
 // DoubleSignWebHooks ..
 type DoubleSignWebHooks struct {
 	OnNoticeDoubleSign string `yaml:"on-notice-double-sign"`
+	OnDoppelganger     string `yaml:"on-doppelganger-detected"`
 }
 
 // BadBlockHooks ..